@@ -0,0 +1,332 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// URLScheme is the URL scheme of external signers.
+const URLScheme = "extapi"
+
+var (
+	ErrChainIdNil     = errors.New("chain id shouldn't be nil")
+	ErrNoSignature    = errors.New("external signer returned no signature")
+	ErrInvalidSigLen  = errors.New("external signer returned a signature of invalid length")
+	ErrSignerMismatch = errors.New("external signer signed with a key not of the account")
+)
+
+var logger = log.NewModuleLogger(log.AccountsExternal)
+
+// SignTxArgs is the argument of account_signTransaction.
+type SignTxArgs struct {
+	From    common.Address      `json:"from"`
+	Role    accountkey.RoleType `json:"role"`
+	ChainID *hexutil.Big        `json:"chainId"`
+	Raw     hexutil.Bytes       `json:"raw"`
+}
+
+// SignTxResult is the result of account_signTransaction.
+type SignTxResult struct {
+	Signatures types.TxSignaturesJSON `json:"signatures"`
+}
+
+// ExternalBackend is an accounts.Backend providing a wallet of an external signer.
+type ExternalBackend struct {
+	signers []accounts.Wallet
+}
+
+// NewExternalBackend connects to the external signer at the endpoint, which is an HTTP URL or an IPC path.
+func NewExternalBackend(endpoint string) (*ExternalBackend, error) {
+	signer, err := NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalBackend{signers: []accounts.Wallet{signer}}, nil
+}
+
+// Wallets implements accounts.Backend, returning the wallet of the external signer.
+func (eb *ExternalBackend) Wallets() []accounts.Wallet {
+	return eb.signers
+}
+
+// Close closes the connections to the external signers.
+// It is called by accounts.Manager when the manager is closed.
+func (eb *ExternalBackend) Close() error {
+	for _, signer := range eb.signers {
+		if err := signer.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribe implements accounts.Backend. No event is sent since the wallet never arrives or departs.
+func (eb *ExternalBackend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// ExternalSigner implements accounts.Wallet with an external signer.
+// The signer guards the keys and their passphrases, so the passphrase variants of
+// the signing methods are not supported.
+type ExternalSigner struct {
+	client   *rpc.Client
+	endpoint string
+	status   string
+
+	cacheMu sync.RWMutex
+	cache   []accounts.Account
+}
+
+// NewExternalSigner connects to the external signer at the endpoint, and checks its version.
+func NewExternalSigner(endpoint string) (*ExternalSigner, error) {
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return newExternalSignerWithClient(client, endpoint)
+}
+
+func newExternalSignerWithClient(client *rpc.Client, endpoint string) (*ExternalSigner, error) {
+	signer := &ExternalSigner{
+		client:   client,
+		endpoint: endpoint,
+	}
+	var version string
+	if err := client.Call(&version, "account_version"); err != nil {
+		return nil, fmt.Errorf("failed to connect to the external signer: %v", err)
+	}
+	signer.status = fmt.Sprintf("ok [version=%v]", version)
+	return signer, nil
+}
+
+// URL implements accounts.Wallet, returning the endpoint of the external signer.
+func (api *ExternalSigner) URL() accounts.URL {
+	return accounts.URL{
+		Scheme: URLScheme,
+		Path:   api.endpoint,
+	}
+}
+
+// Status implements accounts.Wallet, returning the version of the external signer.
+func (api *ExternalSigner) Status() (string, error) {
+	return api.status, nil
+}
+
+// Open implements accounts.Wallet, but is a noop since the connection is established on creation.
+func (api *ExternalSigner) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, closing the connection to the external signer.
+func (api *ExternalSigner) Close() error {
+	api.client.Close()
+	return nil
+}
+
+// Accounts implements accounts.Wallet, retrieving the accounts from the external signer.
+// If the signer is not reachable, the accounts retrieved last time are returned.
+func (api *ExternalSigner) Accounts() []accounts.Account {
+	var addrs []common.Address
+	if err := api.client.Call(&addrs, "account_list"); err != nil {
+		logger.Error("Failed to retrieve accounts from the external signer", "endpoint", api.endpoint, "err", err)
+		api.cacheMu.RLock()
+		defer api.cacheMu.RUnlock()
+		return api.cache
+	}
+
+	accs := make([]accounts.Account, len(addrs))
+	for i, addr := range addrs {
+		accs[i] = accounts.Account{
+			Address: addr,
+			URL:     accounts.URL{Scheme: URLScheme, Path: api.endpoint},
+		}
+	}
+	api.cacheMu.Lock()
+	api.cache = accs
+	api.cacheMu.Unlock()
+	return accs
+}
+
+// Contains implements accounts.Wallet, returning whether the external signer has the account.
+// The accounts are retrieved again if it is not found in the cache.
+func (api *ExternalSigner) Contains(account accounts.Account) bool {
+	api.cacheMu.RLock()
+	cache := api.cache
+	api.cacheMu.RUnlock()
+
+	if containsAccount(cache, account) {
+		return true
+	}
+	return containsAccount(api.Accounts(), account)
+}
+
+func containsAccount(accs []accounts.Account, account accounts.Account) bool {
+	for _, a := range accs {
+		if a.Address == account.Address && (account.URL == (accounts.URL{}) || account.URL == a.URL) {
+			return true
+		}
+	}
+	return false
+}
+
+// Derive implements accounts.Wallet, but is not supported since the external signer manages its keys.
+func (api *ExternalSigner) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop since the external signer manages its keys.
+func (api *ExternalSigner) SelfDerive(base accounts.DerivationPath, chain klaytn.ChainReader) {
+	logger.Error("Operation not supported on external signers")
+}
+
+// SignHash implements accounts.Wallet, requesting the external signer to sign the hash.
+// The signature is rejected if it is not recovered to the address of the account.
+func (api *ExternalSigner) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	if !api.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	var sig hexutil.Bytes
+	if err := api.client.Call(&sig, "account_signData", account.Address, hexutil.Bytes(hash)); err != nil {
+		return nil, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, ErrInvalidSigLen
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(*pub) != account.Address {
+		return nil, ErrSignerMismatch
+	}
+	return sig, nil
+}
+
+// SignTx implements accounts.Wallet, requesting the external signer to sign the transaction
+// with the keys of the role for the tx type.
+// The sender of an ethereum transaction is recovered from the signature, and the transaction
+// is rejected if the sender is not the account. The other tx types have the field `from`,
+// which should be the account. Their signatures are validated against the account key
+// when the transaction is submitted, since the key may be decoupled from the address.
+func (api *ExternalSigner) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, sigs, err := api.signTx(account, tx, chainID, tx.GetRoleTypeForValidation())
+	if err != nil {
+		return nil, err
+	}
+	signed.SetSignature(sigs)
+
+	var sender common.Address
+	if signed.IsEthereumTransaction() {
+		sender, err = types.SenderFrom(types.LatestSignerForChainID(chainID), signed)
+	} else {
+		sender, err = signed.From()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sender != account.Address {
+		return nil, ErrSignerMismatch
+	}
+	return signed, nil
+}
+
+// SignTxAsFeePayer implements accounts.Wallet, requesting the external signer to sign the transaction
+// with the keys of RoleFeePayer. The fee payer of the transaction should be the account.
+func (api *ExternalSigner) SignTxAsFeePayer(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	feePayer, err := tx.FeePayer()
+	if err != nil {
+		return nil, err
+	}
+	if feePayer != account.Address {
+		return nil, ErrSignerMismatch
+	}
+	signed, sigs, err := api.signTx(account, tx, chainID, accountkey.RoleFeePayer)
+	if err != nil {
+		return nil, err
+	}
+	if err := signed.SetFeePayerSignatures(sigs); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// signTx requests the signatures of the transaction, and returns them with a copy of the transaction.
+func (api *ExternalSigner) signTx(account accounts.Account, tx *types.Transaction, chainID *big.Int, role accountkey.RoleType) (*types.Transaction, types.TxSignatures, error) {
+	if chainID == nil {
+		return nil, nil, ErrChainIdNil
+	}
+	if !api.Contains(account) {
+		return nil, nil, accounts.ErrUnknownAccount
+	}
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := &SignTxArgs{
+		From:    account.Address,
+		Role:    role,
+		ChainID: (*hexutil.Big)(chainID),
+		Raw:     raw,
+	}
+	var res SignTxResult
+	if err := api.client.Call(&res, "account_signTransaction", args); err != nil {
+		return nil, nil, err
+	}
+	if len(res.Signatures) == 0 {
+		return nil, nil, ErrNoSignature
+	}
+
+	// Decode the transaction again not to modify the given one.
+	signed, err := types.DecodeUnsignedTx(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signed, res.Signatures.ToTxSignatures(), nil
+}
+
+// SignHashWithPassphrase implements accounts.Wallet, but is not supported.
+func (api *ExternalSigner) SignHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, but is not supported.
+func (api *ExternalSigner) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignTxAsFeePayerWithPassphrase implements accounts.Wallet, but is not supported.
+func (api *ExternalSigner) SignTxAsFeePayerWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, accounts.ErrNotSupported
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) (*MockSigner, *ExternalSigner) {
+	mock := NewMockSigner()
+	server, err := mock.Server()
+	require.NoError(t, err)
+
+	signer, err := newExternalSignerWithClient(rpc.DialInProc(server), "inproc")
+	require.NoError(t, err)
+	return mock, signer
+}
+
+func genKeys(t *testing.T, n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	return keys
+}
+
+func pubkeysOf(keys []*ecdsa.PrivateKey) []*ecdsa.PublicKey {
+	pubs := make([]*ecdsa.PublicKey, len(keys))
+	for i, key := range keys {
+		pubs[i] = &key.PublicKey
+	}
+	return pubs
+}
+
+func TestExternalSigner_Accounts(t *testing.T) {
+	mock, signer := newTestSigner(t)
+	defer signer.Close()
+
+	status, err := signer.Status()
+	assert.NoError(t, err)
+	assert.Contains(t, status, MockSignerVersion)
+	assert.Empty(t, signer.Accounts())
+
+	// Accounts added after the connection are found
+	addr := mock.AddKey(genKeys(t, 1)[0])
+	assert.True(t, signer.Contains(accounts.Account{Address: addr}))
+	assert.True(t, signer.Contains(accounts.Account{Address: addr, URL: signer.URL()}))
+	assert.False(t, signer.Contains(accounts.Account{Address: common.HexToAddress("0x1")}))
+	assert.Equal(t, []accounts.Account{{Address: addr, URL: accounts.URL{Scheme: URLScheme, Path: "inproc"}}}, signer.Accounts())
+
+	// Accounts are found through accounts.Manager
+	am := accounts.NewManager(&ExternalBackend{signers: []accounts.Wallet{signer}})
+	defer am.Close()
+	wallet, err := am.Find(accounts.Account{Address: addr})
+	assert.NoError(t, err)
+	assert.Equal(t, signer, wallet)
+}
+
+func TestExternalSigner_SignTx(t *testing.T) {
+	mock, signer := newTestSigner(t)
+	defer signer.Close()
+
+	chainID := big.NewInt(1001)
+	txSigner := types.LatestSignerForChainID(chainID)
+
+	// An account having a role-based key of which RoleFeePayer is a multi-sig key
+	keys := genKeys(t, 4)
+	roleKeys := [][]*ecdsa.PrivateKey{{keys[0]}, {keys[1]}, {keys[2], keys[3]}}
+	from := common.HexToAddress("0x1111")
+	mock.AddRoleBasedKeys(from, roleKeys)
+	legacyKey := genKeys(t, 1)[0]
+	legacy := mock.AddKey(legacyKey)
+
+	genTx := func(txType types.TxType, from common.Address) *types.Transaction {
+		values := map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    uint64(0),
+			types.TxValueKeyFrom:     from,
+			types.TxValueKeyGasLimit: uint64(100000),
+			types.TxValueKeyGasPrice: big.NewInt(25000000000),
+		}
+		switch txType {
+		case types.TxTypeAccountUpdate:
+			values[types.TxValueKeyAccountKey] = accountkey.NewAccountKeyLegacy()
+		case types.TxTypeFeeDelegatedValueTransfer:
+			values[types.TxValueKeyFeePayer] = from
+			fallthrough
+		default:
+			values[types.TxValueKeyTo] = common.HexToAddress("0x2222")
+			values[types.TxValueKeyAmount] = big.NewInt(1)
+		}
+		tx, err := types.NewTransactionWithMap(txType, values)
+		require.NoError(t, err)
+		return tx
+	}
+
+	// A value transfer is signed by the key of RoleTransaction
+	tx := genTx(types.TxTypeValueTransfer, from)
+	signed, err := signer.SignTx(accounts.Account{Address: from}, tx, chainID)
+	require.NoError(t, err)
+	pubs, err := types.SenderPubkey(txSigner, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, pubkeysOf(roleKeys[accountkey.RoleTransaction]), pubs)
+	assert.NotEqual(t, tx.RawSignatureValues(), signed.RawSignatureValues(), "the given tx should not be modified")
+
+	// An account update is signed by the key of RoleAccountUpdate
+	signed, err = signer.SignTx(accounts.Account{Address: from}, genTx(types.TxTypeAccountUpdate, from), chainID)
+	require.NoError(t, err)
+	pubs, err = types.SenderPubkey(txSigner, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, pubkeysOf(roleKeys[accountkey.RoleAccountUpdate]), pubs)
+
+	// A fee-delegated tx signed by the sender is signed by all the keys of RoleFeePayer
+	signed, err = signer.SignTx(accounts.Account{Address: from}, genTx(types.TxTypeFeeDelegatedValueTransfer, from), chainID)
+	require.NoError(t, err)
+	signed, err = signer.SignTxAsFeePayer(accounts.Account{Address: from}, signed, chainID)
+	require.NoError(t, err)
+	pubs, err = types.SenderFeePayerPubkey(txSigner, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, pubkeysOf(roleKeys[accountkey.RoleFeePayer]), pubs)
+
+	// A legacy tx is signed by the key of the address
+	legacyTx := types.NewTransaction(0, common.HexToAddress("0x2222"), big.NewInt(1), 100000, big.NewInt(25000000000), nil)
+	signed, err = signer.SignTx(accounts.Account{Address: legacy}, legacyTx, chainID)
+	require.NoError(t, err)
+	sender, err := types.Sender(txSigner, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, sender)
+
+	// Errors
+	_, err = signer.SignTx(accounts.Account{Address: common.HexToAddress("0x3333")}, tx, chainID)
+	assert.Equal(t, accounts.ErrUnknownAccount, err)
+	_, err = signer.SignTx(accounts.Account{Address: from}, tx, nil)
+	assert.Equal(t, ErrChainIdNil, err)
+	_, err = signer.SignTxWithPassphrase(accounts.Account{Address: from}, "", tx, chainID)
+	assert.Equal(t, accounts.ErrNotSupported, err)
+	_, err = signer.SignTxAsFeePayerWithPassphrase(accounts.Account{Address: from}, "", tx, chainID)
+	assert.Equal(t, accounts.ErrNotSupported, err)
+
+	// The sender recovered from the signature of a legacy tx should be the account
+	_, err = signer.SignTx(accounts.Account{Address: from}, legacyTx, chainID)
+	assert.Equal(t, ErrSignerMismatch, err)
+	// The sender and the fee payer of the other tx types should be the account
+	_, err = signer.SignTx(accounts.Account{Address: legacy}, tx, chainID)
+	assert.Equal(t, ErrSignerMismatch, err)
+	_, err = signer.SignTxAsFeePayer(accounts.Account{Address: legacy}, genTx(types.TxTypeFeeDelegatedValueTransfer, from), chainID)
+	assert.Equal(t, ErrSignerMismatch, err)
+}
+
+func TestExternalSigner_SignHash(t *testing.T) {
+	mock, signer := newTestSigner(t)
+	defer signer.Close()
+
+	key := genKeys(t, 1)[0]
+	addr := mock.AddKey(key)
+	hash := crypto.Keccak256([]byte("klaytn"))
+
+	sig, err := signer.SignHash(accounts.Account{Address: addr}, hash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, addr, crypto.PubkeyToAddress(*pub))
+
+	_, err = signer.SignHashWithPassphrase(accounts.Account{Address: addr}, "", hash)
+	assert.Equal(t, accounts.ErrNotSupported, err)
+
+	// The signature should be recovered to the address of the account
+	decoupled := common.HexToAddress("0x1111")
+	mock.AddRoleBasedKeys(decoupled, [][]*ecdsa.PrivateKey{{key}})
+	_, err = signer.SignHash(accounts.Account{Address: decoupled}, hash)
+	assert.Equal(t, ErrSignerMismatch, err)
+}
+
+func TestExternalBackend_Close(t *testing.T) {
+	mock, signer := newTestSigner(t)
+	addr := mock.AddKey(genKeys(t, 1)[0])
+	hash := crypto.Keccak256([]byte("klaytn"))
+
+	am := accounts.NewManager(&ExternalBackend{signers: []accounts.Wallet{signer}})
+	wallet, err := am.Find(accounts.Account{Address: addr})
+	require.NoError(t, err)
+	_, err = wallet.SignHash(accounts.Account{Address: addr}, hash)
+	assert.NoError(t, err)
+
+	// Closing the manager closes the connection to the external signer
+	assert.NoError(t, am.Close())
+	_, err = wallet.SignHash(accounts.Account{Address: addr}, hash)
+	assert.Equal(t, rpc.ErrClientQuit, err)
+}
+
+func TestExternalBackend_HTTP(t *testing.T) {
+	mock := NewMockSigner()
+	addr := mock.AddKey(genKeys(t, 1)[0])
+	server, err := mock.Server()
+	require.NoError(t, err)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	backend, err := NewExternalBackend(httpServer.URL)
+	require.NoError(t, err)
+	require.Len(t, backend.Wallets(), 1)
+	assert.True(t, backend.Wallets()[0].Contains(accounts.Account{Address: addr}))
+
+	_, err = NewExternalBackend("http://127.0.0.1:1")
+	assert.Error(t, err)
+}
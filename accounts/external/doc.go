@@ -0,0 +1,56 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package external implements an account backend that delegates signing to an external signer,
+such as a process guarding a hardware wallet or a remote key management service.
+With the backend, a node can sign transactions for klay_sendTransaction and the fee-payer APIs
+without holding the private keys of the operators.
+
+Protocol
+
+The node talks to the external signer with JSON-RPC 2.0 over HTTP or IPC.
+The external signer should serve the following methods in the "account" namespace.
+
+  - account_version() string
+    Returns the version of the signer. It is used to check the connection.
+
+  - account_list() []address
+    Returns the addresses of the accounts the signer can sign with.
+
+  - account_signTransaction(args) {"signatures": [{"V", "R", "S"}, ...]}
+    Signs a transaction and returns the signatures of the account for the given role.
+    The args is an object of the following fields.
+      - from: the address of the account to sign with, the sender or the fee payer of the transaction.
+      - role: the role of the key to sign with, 0 (RoleTransaction), 1 (RoleAccountUpdate) or 2 (RoleFeePayer).
+        The signer returns the signatures of all the keys of the role if the account has a role-based key
+        or a multi-sig key, and falls back to the keys of RoleTransaction if the role is not set.
+      - chainId: the chain ID used to derive the signature hash.
+      - raw: the RLP-encoded transaction of any Klaytn tx type. The signatures already included are ignored.
+    If the role is RoleFeePayer, the signatures are for the fee payer of a fee-delegated transaction.
+
+  - account_signData(address, hash) bytes
+    Signs a 32-byte hash, and returns a 65-byte signature in [R || S || V] format where V is 0 or 1.
+
+The signer may reject a request, for example if an operator does not confirm it, by returning a JSON-RPC error.
+MockSigner is an in-memory implementation of the protocol for tests.
+
+Source Files
+
+  - backend.go     : Defines ExternalBackend implementing accounts.Backend and ExternalSigner implementing accounts.Wallet
+  - mock_signer.go : Defines MockSigner, an in-memory external signer for tests
+*/
+package external
@@ -0,0 +1,161 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+// MockSignerVersion is the version returned by account_version of MockSigner.
+const MockSignerVersion = "mock/1.0.0"
+
+var (
+	errMockUnknownAccount = errors.New("unknown account")
+	errMockInvalidRole    = errors.New("invalid role")
+	errMockChainIdNil     = errors.New("chain id shouldn't be nil")
+)
+
+// MockSigner is an in-memory external signer serving the protocol described in the package document.
+// It signs every request without confirmation, so it should be used for tests only.
+type MockSigner struct {
+	mu    sync.RWMutex
+	addrs []common.Address
+	keys  map[common.Address][][]*ecdsa.PrivateKey // keys of each role
+}
+
+// NewMockSigner creates a mock signer without accounts.
+func NewMockSigner() *MockSigner {
+	return &MockSigner{keys: make(map[common.Address][][]*ecdsa.PrivateKey)}
+}
+
+// AddKey adds an account having the address derived from the key.
+func (m *MockSigner) AddKey(prv *ecdsa.PrivateKey) common.Address {
+	addr := crypto.PubkeyToAddress(prv.PublicKey)
+	m.AddRoleBasedKeys(addr, [][]*ecdsa.PrivateKey{{prv}})
+	return addr
+}
+
+// AddRoleBasedKeys adds an account with the keys of each role.
+// If a role has multiple keys, all of them sign the transactions of the role.
+func (m *MockSigner) AddRoleBasedKeys(addr common.Address, keys [][]*ecdsa.PrivateKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[addr]; !ok {
+		m.addrs = append(m.addrs, addr)
+	}
+	m.keys[addr] = keys
+}
+
+// Server returns an RPC server serving the mock signer in the "account" namespace.
+// It can be served over HTTP or IPC, or be dialed in process with rpc.DialInProc.
+func (m *MockSigner) Server() (*rpc.Server, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &mockSignerAPI{m}); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// keysWithRole returns the keys of the role, or the keys of RoleTransaction if the role is not set.
+func (m *MockSigner) keysWithRole(addr common.Address, role accountkey.RoleType) ([]*ecdsa.PrivateKey, error) {
+	if role < accountkey.RoleTransaction || role >= accountkey.RoleLast {
+		return nil, errMockInvalidRole
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys, ok := m.keys[addr]
+	if !ok || len(keys) == 0 {
+		return nil, errMockUnknownAccount
+	}
+	if int(role) < len(keys) && len(keys[role]) > 0 {
+		return keys[role], nil
+	}
+	return keys[accountkey.RoleTransaction], nil
+}
+
+// mockSignerAPI serves the RPC methods of MockSigner.
+type mockSignerAPI struct {
+	m *MockSigner
+}
+
+// Version returns the version of the mock signer.
+func (api *mockSignerAPI) Version() string {
+	return MockSignerVersion
+}
+
+// List returns the addresses of the accounts.
+func (api *mockSignerAPI) List() []common.Address {
+	api.m.mu.RLock()
+	defer api.m.mu.RUnlock()
+
+	addrs := make([]common.Address, len(api.m.addrs))
+	copy(addrs, api.m.addrs)
+	return addrs
+}
+
+// SignTransaction signs the transaction with the keys of the role.
+func (api *mockSignerAPI) SignTransaction(args SignTxArgs) (*SignTxResult, error) {
+	if args.ChainID == nil {
+		return nil, errMockChainIdNil
+	}
+	keys, err := api.m.keysWithRole(args.From, args.Role)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := types.DecodeUnsignedTx(args.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := types.LatestSignerForChainID(args.ChainID.ToInt())
+	var sigs types.TxSignatures
+	if args.Role == accountkey.RoleFeePayer {
+		if err := tx.SignFeePayerWithKeys(signer, keys); err != nil {
+			return nil, err
+		}
+		if sigs, err = tx.GetFeePayerSignatures(); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := tx.SignWithKeys(signer, keys); err != nil {
+			return nil, err
+		}
+		sigs = tx.RawSignatureValues()
+	}
+	return &SignTxResult{Signatures: sigs.ToJSON()}, nil
+}
+
+// SignData signs the hash with the key of RoleTransaction.
+func (api *mockSignerAPI) SignData(addr common.Address, hash hexutil.Bytes) (hexutil.Bytes, error) {
+	keys, err := api.m.keysWithRole(addr, accountkey.RoleTransaction)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, keys[0])
+}
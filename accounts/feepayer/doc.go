@@ -0,0 +1,55 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package feepayer implements a fee payer service which pays the fees of fee-delegated transactions.
+
+A dApp sends a fee-delegated transaction signed by its user to the service (see cmd/kfeepayer).
+The service checks the transaction against its policy, signs it as the fee payer with an account
+of the keystore, and submits it to a Klaytn node.
+
+Protocol
+
+The service exposes a JSON-RPC service in the "feepayer" namespace.
+
+- feepayer_sendRawTransaction : takes an RLP-encoded sender-signed transaction and returns the transaction hash.
+
+- feepayer_address            : returns the address of the fee payer.
+
+- feepayer_policy             : returns the policy of the fee payer.
+
+- feepayer_getGasUsage        : returns the gas paid for a sender today.
+
+- feepayer_getAuditLogs       : takes a sequence number and a count, and returns the audit records.
+
+Policy
+
+The policy is read from a JSON file. A transaction should
+  - be a fee-delegated value transfer, smart contract deployment or execution, or batch naming the service as the fee payer,
+  - call one of "contracts" with one of its "methods" (4-byte selectors) if they are given, in every call of a batch,
+  - not exceed "maxGasLimit" and "maxFeeRatio" (the percentage paid by the fee payer),
+  - not exceed "dailyGasBudget" of the sender together with the transactions paid today (UTC),
+  - not revert when it is executed by klay_call if "simulate" is true. Each call of a batch is executed separately.
+
+Files
+
+- policy.go  : Policy, the rules that a transaction should satisfy
+
+- store.go   : the daily gas usages of senders and the audit log kept in a database
+
+- service.go : Service and the feepayer JSON-RPC API
+*/
+package feepayer
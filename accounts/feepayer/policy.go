@@ -0,0 +1,180 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package feepayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+)
+
+const selectorLength = 4
+
+var (
+	errNotFeeDelegated      = errors.New("not a fee-delegated transaction")
+	errTxTypeNotAllowed     = errors.New("transaction type is not allowed by the fee payer")
+	errContractNotAllowed   = errors.New("recipient is not allowed by the fee payer")
+	errMethodNotAllowed     = errors.New("method is not allowed by the fee payer")
+	errFeeRatioTooHigh      = errors.New("fee ratio exceeds the limit of the fee payer")
+	errGasLimitTooHigh      = errors.New("gas limit exceeds the limit of the fee payer")
+	errDailyBudgetExceeded  = errors.New("daily gas budget of the sender is exceeded")
+	errInvalidSelector      = errors.New("method selector should be 4 bytes")
+	errInvalidMaxFeeRatio   = errors.New("maxFeeRatio should be in the range of [1, 100]")
+	errDuplicatedContract   = errors.New("contract is duplicated in the policy")
+	errWrongFeePayerAddress = errors.New("fee payer of the transaction is not this service")
+)
+
+// ContractPolicy allows calls to a contract. If Methods is empty, any method of the contract is allowed.
+type ContractPolicy struct {
+	Address common.Address  `json:"address"`
+	Methods []hexutil.Bytes `json:"methods"`
+}
+
+// Policy is the set of rules that a transaction should satisfy to be paid by the fee payer.
+type Policy struct {
+	// Contracts lists the recipients allowed to be called. If it is empty, any recipient is allowed
+	// including contract deployments.
+	Contracts []ContractPolicy `json:"contracts"`
+
+	// DailyGasBudget is the total gas limit of the transactions paid for a sender in a day (UTC).
+	// Zero means no limit.
+	DailyGasBudget uint64 `json:"dailyGasBudget"`
+
+	// MaxGasLimit is the maximum gas limit of a transaction. Zero means no limit.
+	MaxGasLimit uint64 `json:"maxGasLimit"`
+
+	// MaxFeeRatio is the maximum percentage of the transaction fee that the fee payer pays.
+	// If it is lower than 100, only the fee-delegated transactions with a ratio are accepted.
+	MaxFeeRatio types.FeeRatio `json:"maxFeeRatio"`
+
+	// Simulate executes the transaction by klay_call before signing, and rejects it if it reverts.
+	// The calls of a batch transaction are executed one by one on the latest state, so a call
+	// depending on the state changed by the preceding calls may be rejected.
+	Simulate bool `json:"simulate"`
+
+	contracts map[common.Address]map[string]struct{}
+}
+
+// DefaultPolicy allows any fee-delegated transaction checking that it does not revert.
+func DefaultPolicy() *Policy {
+	p := &Policy{MaxFeeRatio: types.MaxFeeRatio, Simulate: true}
+	p.init()
+	return p
+}
+
+// LoadPolicy reads the policy from the given JSON file.
+func LoadPolicy(file string) (*Policy, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p := DefaultPolicy()
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("failed to parse the policy file %s: %v", file, err)
+	}
+	if err := p.init(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// init validates the policy and builds the lookup table of the allowed contracts.
+func (p *Policy) init() error {
+	if p.MaxFeeRatio == 0 || p.MaxFeeRatio > types.MaxFeeRatio {
+		return errInvalidMaxFeeRatio
+	}
+	p.contracts = make(map[common.Address]map[string]struct{}, len(p.Contracts))
+	for _, c := range p.Contracts {
+		if _, ok := p.contracts[c.Address]; ok {
+			return errDuplicatedContract
+		}
+		methods := make(map[string]struct{}, len(c.Methods))
+		for _, m := range c.Methods {
+			if len(m) != selectorLength {
+				return errInvalidSelector
+			}
+			methods[string(m)] = struct{}{}
+		}
+		p.contracts[c.Address] = methods
+	}
+	return nil
+}
+
+// Check returns an error if the transaction violates the static rules of the policy.
+// The daily gas budget and the simulation are checked by the Service.
+func (p *Policy) Check(tx *types.Transaction, feePayer common.Address) error {
+	if !tx.IsFeeDelegatedTransaction() {
+		return errNotFeeDelegated
+	}
+	txType := tx.Type()
+	if txType.IsAccountUpdate() || txType.IsCancelTransaction() || txType.IsChainDataAnchoring() {
+		return errTxTypeNotAllowed
+	}
+	if addr, err := tx.FeePayer(); err != nil || addr != feePayer {
+		return errWrongFeePayerAddress
+	}
+	if p.MaxGasLimit != 0 && tx.Gas() > p.MaxGasLimit {
+		return errGasLimitTooHigh
+	}
+	if ratio, _ := tx.FeeRatio(); ratio > p.MaxFeeRatio {
+		return errFeeRatioTooHigh
+	}
+	if len(p.contracts) == 0 {
+		return nil
+	}
+
+	// Every call of a batch transaction should be allowed
+	if txType.IsBatch() {
+		for _, call := range tx.BatchCalls() {
+			to := call.To
+			if err := p.checkCall(&to, call.Data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if txType.IsContractDeploy() {
+		return errContractNotAllowed
+	}
+	return p.checkCall(tx.To(), tx.Data())
+}
+
+// checkCall returns an error if the call to the recipient with the input data is not allowed.
+func (p *Policy) checkCall(to *common.Address, data []byte) error {
+	if to == nil {
+		return errContractNotAllowed
+	}
+	methods, ok := p.contracts[*to]
+	if !ok {
+		return errContractNotAllowed
+	}
+	if len(methods) == 0 {
+		return nil
+	}
+	if len(data) < selectorLength {
+		return errMethodNotAllowed
+	}
+	if _, ok := methods[string(data[:selectorLength])]; !ok {
+		return errMethodNotAllowed
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package feepayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+const (
+	namespace = "feepayer"
+
+	secondsPerDay = 24 * 60 * 60
+
+	// maxAuditLogs is the maximum number of the audit records returned at once.
+	maxAuditLogs = 1000
+)
+
+var logger = log.NewModuleLogger(log.AccountsFeePayer)
+
+// Backend is the connection to a Klaytn node used to simulate and submit the transactions.
+// It is implemented by client.Client.
+type Backend interface {
+	CallContract(ctx context.Context, msg klaytn.CallMsg, blockNumber *big.Int) ([]byte, error)
+	SendRawTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error)
+}
+
+// Signer signs transactions as a fee payer with an unlocked account.
+// It is implemented by keystore.KeyStore.
+type Signer interface {
+	SignTxAsFeePayer(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Service pays the fees of the fee-delegated transactions satisfying its policy.
+type Service struct {
+	backend  Backend
+	signer   Signer
+	feePayer accounts.Account
+	chainID  *big.Int
+	policy   *Policy
+	store    *store
+
+	now func() time.Time // overridden in tests
+}
+
+// NewService creates a fee payer service which signs with the feePayer account of the signer.
+// The daily gas usages and the audit log are kept in the given database.
+func NewService(backend Backend, signer Signer, feePayer common.Address, chainID *big.Int, policy *Policy, db database.Database) (*Service, error) {
+	if chainID == nil {
+		return nil, errors.New("chain ID is not given")
+	}
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	return &Service{
+		backend:  backend,
+		signer:   signer,
+		feePayer: accounts.Account{Address: feePayer},
+		chainID:  chainID,
+		policy:   policy,
+		store:    newStore(db),
+		now:      time.Now,
+	}, nil
+}
+
+// APIs returns the RPC APIs of the fee payer service.
+func (s *Service) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: namespace,
+			Version:   "1.0",
+			Service:   &PublicFeePayerAPI{s},
+			Public:    true,
+		},
+	}
+}
+
+func (s *Service) today() uint64 {
+	return uint64(s.now().Unix()) / secondsPerDay
+}
+
+// sendTransaction checks the sender-signed transaction against the policy,
+// signs it as the fee payer and submits it. The result is recorded in the audit log.
+func (s *Service) sendTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error) {
+	sender, err := tx.From()
+	if err != nil {
+		return common.Hash{}, errNotFeeDelegated
+	}
+	ratio, _ := tx.FeeRatio()
+	record := &AuditRecord{
+		Time:     uint64(s.now().Unix()),
+		TxHash:   tx.Hash(),
+		Type:     tx.Type().String(),
+		Sender:   sender,
+		To:       tx.To(),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		FeeRatio: ratio,
+	}
+
+	hash, err := s.payFee(ctx, tx, sender)
+	if err != nil {
+		record.Reason = err.Error()
+		logger.Info("Rejected a transaction", "sender", sender, "tx", record.TxHash, "reason", err)
+	} else {
+		record.TxHash, record.Accepted = hash, true
+		logger.Info("Paid the fee of a transaction", "sender", sender, "tx", hash, "gas", tx.Gas())
+	}
+	if logErr := s.store.appendAuditLog(record); logErr != nil {
+		logger.Error("Failed to write the audit log", "tx", record.TxHash, "err", logErr)
+	}
+	return hash, err
+}
+
+func (s *Service) payFee(ctx context.Context, tx *types.Transaction, sender common.Address) (common.Hash, error) {
+	if err := s.policy.Check(tx, s.feePayer.Address); err != nil {
+		return common.Hash{}, err
+	}
+
+	// Reserve the gas first not to exceed the budget with concurrent requests.
+	day := s.today()
+	if err := s.store.reserveGas(day, sender, tx.Gas(), s.policy.DailyGasBudget); err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := s.signAndSubmit(ctx, tx, sender)
+	if err != nil {
+		if releaseErr := s.store.releaseGas(day, sender, tx.Gas()); releaseErr != nil {
+			logger.Error("Failed to release the reserved gas", "sender", sender, "err", releaseErr)
+		}
+	}
+	return hash, err
+}
+
+func (s *Service) signAndSubmit(ctx context.Context, tx *types.Transaction, sender common.Address) (common.Hash, error) {
+	if s.policy.Simulate {
+		for _, msg := range simulationMsgs(tx, sender) {
+			if _, err := s.backend.CallContract(ctx, msg, nil); err != nil {
+				return common.Hash{}, fmt.Errorf("simulation failed: %v", err)
+			}
+		}
+	}
+
+	signed, err := s.signer.SignTxAsFeePayer(s.feePayer, tx, s.chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return s.backend.SendRawTransaction(ctx, signed)
+}
+
+// simulationMsgs returns the messages executing the transaction, one for each call of a batch transaction.
+func simulationMsgs(tx *types.Transaction, sender common.Address) []klaytn.CallMsg {
+	if !tx.Type().IsBatch() {
+		return []klaytn.CallMsg{{
+			From:     sender,
+			To:       tx.To(),
+			Gas:      tx.Gas(),
+			GasPrice: tx.GasPrice(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}}
+	}
+	calls := tx.BatchCalls()
+	msgs := make([]klaytn.CallMsg, len(calls))
+	for i, call := range calls {
+		to := call.To
+		msgs[i] = klaytn.CallMsg{
+			From:     sender,
+			To:       &to,
+			Gas:      tx.Gas(),
+			GasPrice: tx.GasPrice(),
+			Value:    call.Value,
+			Data:     call.Data,
+		}
+	}
+	return msgs
+}
+
+// GasUsage represents the gas paid for a sender today.
+type GasUsage struct {
+	Used      hexutil.Uint64 `json:"used"`
+	Budget    hexutil.Uint64 `json:"budget"`
+	Remaining hexutil.Uint64 `json:"remaining"`
+}
+
+// PublicFeePayerAPI is the API served by the fee payer service.
+type PublicFeePayerAPI struct {
+	s *Service
+}
+
+// Address returns the address of the fee payer.
+func (api *PublicFeePayerAPI) Address() common.Address {
+	return api.s.feePayer.Address
+}
+
+// Policy returns the policy of the fee payer.
+func (api *PublicFeePayerAPI) Policy() *Policy {
+	return api.s.policy
+}
+
+// SendRawTransaction signs the RLP-encoded transaction signed by the sender as the fee payer
+// and submits it, if the transaction satisfies the policy of the fee payer.
+func (api *PublicFeePayerAPI) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return api.s.sendTransaction(ctx, tx)
+}
+
+// GetGasUsage returns the gas paid for the sender today (UTC).
+// Remaining is zero if the daily gas budget is not limited.
+func (api *PublicFeePayerAPI) GetGasUsage(sender common.Address) *GasUsage {
+	used := api.s.store.gasUsage(api.s.today(), sender)
+	budget := api.s.policy.DailyGasBudget
+	usage := &GasUsage{Used: hexutil.Uint64(used), Budget: hexutil.Uint64(budget)}
+	if budget > used {
+		usage.Remaining = hexutil.Uint64(budget - used)
+	}
+	return usage
+}
+
+// GetAuditLogs returns at most count audit records starting from the given sequence number.
+func (api *PublicFeePayerAPI) GetAuditLogs(from hexutil.Uint64, count hexutil.Uint64) ([]*AuditRecord, error) {
+	if count > maxAuditLogs {
+		return nil, fmt.Errorf("count should not exceed %d", maxAuditLogs)
+	}
+	return api.s.store.auditLogs(uint64(from), uint64(count))
+}
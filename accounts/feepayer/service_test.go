@@ -0,0 +1,301 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package feepayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testChainID  = big.NewInt(1001)
+	testContract = common.HexToAddress("0x1234567890123456789012345678901234567890")
+	transferSel  = hexutil.Bytes{0xa9, 0x05, 0x9c, 0xbb}
+)
+
+type testBackend struct {
+	callErr error
+	called  []klaytn.CallMsg
+	sent    []*types.Transaction
+}
+
+func (b *testBackend) CallContract(ctx context.Context, msg klaytn.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.called = append(b.called, msg)
+	return nil, b.callErr
+}
+
+func (b *testBackend) SendRawTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error) {
+	b.sent = append(b.sent, tx)
+	return tx.Hash(), nil
+}
+
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSigner) SignTxAsFeePayer(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if a.Address != crypto.PubkeyToAddress(s.key.PublicKey) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	return types.SignTxAsFeePayer(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+func newTestTx(t *testing.T, sender *ecdsa.PrivateKey, txType types.TxType, feePayer common.Address, to common.Address, gas uint64, data []byte) *types.Transaction {
+	values := map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyFrom:     crypto.PubkeyToAddress(sender.PublicKey),
+		types.TxValueKeyTo:       to,
+		types.TxValueKeyAmount:   big.NewInt(0),
+		types.TxValueKeyGasLimit: gas,
+		types.TxValueKeyGasPrice: big.NewInt(25000000000),
+		types.TxValueKeyFeePayer: feePayer,
+	}
+	switch txType {
+	case types.TxTypeFeeDelegatedSmartContractExecution:
+		values[types.TxValueKeyData] = data
+	case types.TxTypeFeeDelegatedSmartContractExecutionWithRatio:
+		values[types.TxValueKeyData] = data
+		values[types.TxValueKeyFeeRatioOfFeePayer] = types.FeeRatio(30)
+	case types.TxTypeValueTransfer:
+		delete(values, types.TxValueKeyFeePayer)
+	}
+	tx, err := types.NewTransactionWithMap(txType, values)
+	require.NoError(t, err)
+	require.NoError(t, tx.Sign(types.LatestSignerForChainID(testChainID), sender))
+	return tx
+}
+
+func newTestBatchTx(t *testing.T, sender *ecdsa.PrivateKey, feePayer common.Address, gas uint64, calls []*types.BatchCall) *types.Transaction {
+	tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedBatch, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyFrom:     crypto.PubkeyToAddress(sender.PublicKey),
+		types.TxValueKeyGasLimit: gas,
+		types.TxValueKeyGasPrice: big.NewInt(25000000000),
+		types.TxValueKeyCalls:    calls,
+		types.TxValueKeyFeePayer: feePayer,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.Sign(types.LatestSignerForChainID(testChainID), sender))
+	return tx
+}
+
+func TestPolicyCheck(t *testing.T) {
+	sender, _ := crypto.GenerateKey()
+	feePayer := common.HexToAddress("0xfee")
+	other := common.HexToAddress("0xabc")
+
+	policy := &Policy{
+		Contracts:   []ContractPolicy{{Address: testContract, Methods: []hexutil.Bytes{transferSel}}, {Address: other}},
+		MaxGasLimit: 100000,
+		MaxFeeRatio: 50,
+	}
+	require.NoError(t, policy.init())
+
+	exec := types.TxTypeFeeDelegatedSmartContractExecutionWithRatio
+	transfer := append(common.CopyBytes(transferSel), make([]byte, 64)...)
+	testcases := []struct {
+		tx  *types.Transaction
+		err error
+	}{
+		{newTestTx(t, sender, exec, feePayer, testContract, 50000, transfer), nil},
+		{newTestTx(t, sender, exec, feePayer, other, 50000, []byte{1, 2, 3, 4}), nil},
+		{newTestTx(t, sender, exec, feePayer, testContract, 50000, []byte{1, 2, 3, 4}), errMethodNotAllowed},
+		{newTestTx(t, sender, exec, feePayer, testContract, 50000, transferSel[:3]), errMethodNotAllowed},
+		{newTestTx(t, sender, exec, feePayer, common.HexToAddress("0xdef"), 50000, transfer), errContractNotAllowed},
+		{newTestTx(t, sender, exec, other, testContract, 50000, transfer), errWrongFeePayerAddress},
+		{newTestTx(t, sender, exec, feePayer, testContract, 100001, transfer), errGasLimitTooHigh},
+		// The fee payer pays 100% without a ratio
+		{newTestTx(t, sender, types.TxTypeFeeDelegatedSmartContractExecution, feePayer, testContract, 50000, transfer), errFeeRatioTooHigh},
+		{newTestTx(t, sender, types.TxTypeValueTransfer, feePayer, testContract, 50000, nil), errNotFeeDelegated},
+	}
+	for i, tc := range testcases {
+		assert.Equal(t, tc.err, policy.Check(tc.tx, feePayer), "testcases[%d] failed", i)
+	}
+
+	// Every call of a batch transaction is checked
+	policy.MaxFeeRatio = types.MaxFeeRatio
+	allowed := &types.BatchCall{To: testContract, Value: big.NewInt(0), Data: transfer}
+	batchcases := []struct {
+		calls []*types.BatchCall
+		err   error
+	}{
+		{[]*types.BatchCall{allowed, {To: other, Value: big.NewInt(0)}}, nil},
+		{[]*types.BatchCall{allowed, {To: testContract, Value: big.NewInt(0), Data: []byte{1, 2, 3, 4}}}, errMethodNotAllowed},
+		{[]*types.BatchCall{allowed, {To: common.HexToAddress("0xdef"), Value: big.NewInt(0)}}, errContractNotAllowed},
+	}
+	for i, tc := range batchcases {
+		assert.Equal(t, tc.err, policy.Check(newTestBatchTx(t, sender, feePayer, 50000, tc.calls), feePayer), "batchcases[%d] failed", i)
+	}
+
+	// Invalid policies
+	assert.Equal(t, errInvalidMaxFeeRatio, (&Policy{MaxFeeRatio: 101}).init())
+	assert.Equal(t, errInvalidSelector, (&Policy{MaxFeeRatio: 100, Contracts: []ContractPolicy{{Address: other, Methods: []hexutil.Bytes{{1}}}}}).init())
+	assert.Equal(t, errDuplicatedContract, (&Policy{MaxFeeRatio: 100, Contracts: []ContractPolicy{{Address: other}, {Address: other}}}).init())
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feepayer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "policy.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{
+		"contracts": [{"address": "0x1234567890123456789012345678901234567890", "methods": ["0xa9059cbb"]}],
+		"dailyGasBudget": 1000000
+	}`), 0o600))
+
+	policy, err := LoadPolicy(file)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000000), policy.DailyGasBudget)
+	assert.Equal(t, types.MaxFeeRatio, policy.MaxFeeRatio)
+	assert.True(t, policy.Simulate)
+	assert.Contains(t, policy.contracts[testContract], string(transferSel))
+}
+
+func TestService(t *testing.T) {
+	sender, _ := crypto.GenerateKey()
+	senderAddr := crypto.PubkeyToAddress(sender.PublicKey)
+	feePayerKey, _ := crypto.GenerateKey()
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey)
+
+	policy := DefaultPolicy()
+	policy.DailyGasBudget = 100000
+
+	backend := &testBackend{}
+	service, err := NewService(backend, &testSigner{feePayerKey}, feePayer, testChainID, policy, database.NewMemDB())
+	require.NoError(t, err)
+	now := time.Unix(1660000000, 0)
+	service.now = func() time.Time { return now }
+	api := &PublicFeePayerAPI{service}
+
+	send := func(gas uint64) (common.Hash, error) {
+		tx := newTestTx(t, sender, types.TxTypeFeeDelegatedSmartContractExecution, feePayer, testContract, gas, transferSel)
+		b, err := rlp.EncodeToBytes(tx)
+		require.NoError(t, err)
+		return api.SendRawTransaction(context.Background(), b)
+	}
+
+	// The transaction is signed by the fee payer and submitted
+	hash, err := send(60000)
+	require.NoError(t, err)
+	require.Len(t, backend.sent, 1)
+	assert.Equal(t, hash, backend.sent[0].Hash())
+	_, err = backend.sent[0].ValidateFeePayer(types.LatestSignerForChainID(testChainID), &testKeyPicker{}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(60000), api.GetGasUsage(senderAddr).Used)
+	assert.Equal(t, hexutil.Uint64(40000), api.GetGasUsage(senderAddr).Remaining)
+
+	// The daily budget is exceeded
+	_, err = send(50000)
+	assert.Equal(t, errDailyBudgetExceeded, err)
+
+	// The reverted transaction does not consume the budget
+	backend.callErr = errors.New("execution reverted")
+	_, err = send(40000)
+	assert.Error(t, err)
+	assert.Equal(t, hexutil.Uint64(60000), api.GetGasUsage(senderAddr).Used)
+	backend.callErr = nil
+
+	// The budget is reset on the next day
+	now = now.Add(24 * time.Hour)
+	_, err = send(50000)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Uint64(50000), api.GetGasUsage(senderAddr).Used)
+	assert.Len(t, backend.sent, 2)
+
+	// All requests are recorded in the audit log
+	records, err := api.GetAuditLogs(0, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	accepted := []bool{true, false, false, true}
+	for i, record := range records {
+		assert.Equal(t, uint64(i), record.Seq)
+		assert.Equal(t, senderAddr, record.Sender)
+		assert.Equal(t, accepted[i], record.Accepted)
+	}
+	assert.Equal(t, errDailyBudgetExceeded.Error(), records[1].Reason)
+	assert.Equal(t, hash, records[0].TxHash)
+
+	records, err = api.GetAuditLogs(3, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(3), records[0].Seq)
+
+	_, err = api.GetAuditLogs(0, maxAuditLogs+1)
+	assert.Error(t, err)
+}
+
+func TestServiceSimulateBatch(t *testing.T) {
+	sender, _ := crypto.GenerateKey()
+	feePayerKey, _ := crypto.GenerateKey()
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey)
+
+	backend := &testBackend{}
+	service, err := NewService(backend, &testSigner{feePayerKey}, feePayer, testChainID, DefaultPolicy(), database.NewMemDB())
+	require.NoError(t, err)
+
+	// Each call of the batch transaction is simulated
+	other := common.HexToAddress("0xabc")
+	calls := []*types.BatchCall{
+		{To: testContract, Value: big.NewInt(0), Data: transferSel},
+		{To: other, Value: big.NewInt(1)},
+	}
+	_, err = service.sendTransaction(context.Background(), newTestBatchTx(t, sender, feePayer, 100000, calls))
+	require.NoError(t, err)
+	require.Len(t, backend.called, 2)
+	assert.Equal(t, testContract, *backend.called[0].To)
+	assert.Equal(t, []byte(transferSel), backend.called[0].Data)
+	assert.Equal(t, other, *backend.called[1].To)
+	assert.Equal(t, big.NewInt(1), backend.called[1].Value)
+	assert.Len(t, backend.sent, 1)
+
+	// The batch transaction is rejected if any of its calls reverts
+	backend.callErr = errors.New("execution reverted")
+	_, err = service.sendTransaction(context.Background(), newTestBatchTx(t, sender, feePayer, 100000, calls))
+	assert.Error(t, err)
+	assert.Len(t, backend.sent, 1)
+}
+
+// testKeyPicker returns the legacy account key for any address.
+type testKeyPicker struct{}
+
+func (p *testKeyPicker) GetKey(addr common.Address) accountkey.AccountKey {
+	return accountkey.NewAccountKeyLegacy()
+}
+
+func (p *testKeyPicker) Exist(addr common.Address) bool {
+	return true
+}
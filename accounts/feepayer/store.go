@@ -0,0 +1,145 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package feepayer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+var (
+	gasUsagePrefix = []byte("feepayer-gas-") // gasUsagePrefix + day (uint64 big endian) + sender -> gas (uint64 big endian)
+	auditLogPrefix = []byte("feepayer-log-") // auditLogPrefix + seq (uint64 big endian) -> AuditRecord in JSON
+	auditSeqKey    = []byte("feepayer-seq")  // auditSeqKey -> the next seq (uint64 big endian)
+)
+
+// AuditRecord is an entry of the audit log kept for every transaction requested to the fee payer.
+type AuditRecord struct {
+	Seq      uint64          `json:"seq"`
+	Time     uint64          `json:"time"`
+	TxHash   common.Hash     `json:"txHash"`
+	Type     string          `json:"type"`
+	Sender   common.Address  `json:"sender"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	FeeRatio types.FeeRatio  `json:"feeRatio"`
+	Accepted bool            `json:"accepted"`
+	Reason   string          `json:"reason,omitempty"`
+}
+
+// store keeps the daily gas usages of senders and the audit log in the database.
+type store struct {
+	db database.Database
+	mu sync.Mutex
+}
+
+func newStore(db database.Database) *store {
+	return &store{db: db}
+}
+
+func gasUsageKey(day uint64, sender common.Address) []byte {
+	key := append(append([]byte{}, gasUsagePrefix...), common.Int64ToByteBigEndian(day)...)
+	return append(key, sender.Bytes()...)
+}
+
+func auditLogKey(seq uint64) []byte {
+	return append(append([]byte{}, auditLogPrefix...), common.Int64ToByteBigEndian(seq)...)
+}
+
+// gasUsage returns the gas paid for the sender in the day.
+func (s *store) gasUsage(day uint64, sender common.Address) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readUint64(gasUsageKey(day, sender))
+}
+
+// reserveGas adds the gas to the usage of the sender in the day if it does not exceed the budget.
+// A zero budget means no limit.
+func (s *store) reserveGas(day uint64, sender common.Address, gas, budget uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := gasUsageKey(day, sender)
+	used := s.readUint64(key)
+	if budget != 0 && (used+gas < used || used+gas > budget) {
+		return errDailyBudgetExceeded
+	}
+	return s.db.Put(key, common.Int64ToByteBigEndian(used+gas))
+}
+
+// releaseGas subtracts the gas reserved by reserveGas, e.g. when the transaction is not submitted.
+func (s *store) releaseGas(day uint64, sender common.Address, gas uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := gasUsageKey(day, sender)
+	used := s.readUint64(key)
+	if gas > used {
+		gas = used
+	}
+	return s.db.Put(key, common.Int64ToByteBigEndian(used-gas))
+}
+
+// appendAuditLog assigns the next sequence number to the record and writes it.
+func (s *store) appendAuditLog(record *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.Seq = s.readUint64(auditSeqKey)
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	batch := s.db.NewBatch()
+	if err := batch.Put(auditLogKey(record.Seq), b); err != nil {
+		return err
+	}
+	if err := batch.Put(auditSeqKey, common.Int64ToByteBigEndian(record.Seq+1)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// auditLogs returns at most count records starting from the given sequence number.
+func (s *store) auditLogs(from, count uint64) ([]*AuditRecord, error) {
+	it := s.db.NewIterator(auditLogPrefix, common.Int64ToByteBigEndian(from))
+	defer it.Release()
+
+	records := []*AuditRecord{}
+	for uint64(len(records)) < count && it.Next() {
+		record := new(AuditRecord)
+		if err := json.Unmarshal(it.Value(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, it.Error()
+}
+
+func (s *store) readUint64(key []byte) uint64 {
+	b, err := s.db.Get(key)
+	if err != nil || len(b) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
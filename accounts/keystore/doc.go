@@ -29,12 +29,17 @@ Source Files
 Each file contains following contents
  - account_cache.go 	: Provides `accountCache` which contains a live index of all accounts in keystore folder
  - file_cache.go 	: Provides `fileCache` which contains information of all files in keystore folder
+ - hdkey.go 		: Provides `DeriveKey` which derives a private key from an HD seed as specified in BIP-32
  - key.go 		: Defines `KeyV3` struct, `keyStore` interface and related functions
  - keyv4.go 		: Defines `KeyV4` struct.
  - keystore.go 		: Defines `KeyStore` which manages a key storage directory on disk and related functions
- - keystore_passphrase.go: Provides functions to encrypt and decrypt `Key` with a passphrase
+ - keystore_hd.go 	: Provides functions to import a BIP-39 mnemonic and to derive accounts from its HD seed
+ - keystore_migrate.go 	: Provides `MigrateKeyDir` which re-encrypts all key files in a directory with a new passphrase and KDF parameters
+ - keystore_passphrase.go: Provides functions to encrypt and decrypt `Key` with a passphrase using scrypt or argon2id
  - keystore_plain.go 	: Deprecated
  - keystore_wallet.go 	: Defines `keystoreWallet` struct which implements accounts.Wallet interface. Wallet represents a software or hardware wallet that might contain one or more accounts
+ - mnemonic.go 		: Provides functions to generate and validate a BIP-39 mnemonic, and to derive a seed from it
+ - mnemonic_wordlist.go : Defines the English word list of BIP-39
  - presale.go 		: Deprecated
  - watch.go 		: Provides a watcher which monitors any changes on the keystore folder
  - watch_fallback.go 	: Provides an empty watcher for unsupported platforms
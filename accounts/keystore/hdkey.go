@@ -0,0 +1,109 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/common/math"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+var (
+	ErrInvalidSeedLen = errors.New("seed length should be within [16, 64] bytes")
+	errInvalidHDKey   = errors.New("derived key is invalid, use another derivation path")
+
+	masterHMACKey = []byte("Bitcoin seed")
+)
+
+// hdKey is an extended private key of BIP-32.
+type hdKey struct {
+	key       *big.Int
+	chainCode []byte
+}
+
+// newMasterHDKey generates the master key of BIP-32 from the seed.
+func newMasterHDKey(seed []byte) (*hdKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeedLen
+	}
+	mac := hmac.New(sha512.New, masterHMACKey)
+	mac.Write(seed)
+	return newHDKey(mac.Sum(nil), nil)
+}
+
+// newHDKey builds an extended key from the HMAC-SHA512 output, adding the parent key if any.
+func newHDKey(sum []byte, parent *big.Int) (*hdKey, error) {
+	n := crypto.S256().Params().N
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Cmp(n) >= 0 {
+		return nil, errInvalidHDKey
+	}
+	if parent != nil {
+		key.Add(key, parent)
+		key.Mod(key, n)
+	}
+	if key.Sign() == 0 {
+		return nil, errInvalidHDKey
+	}
+	return &hdKey{key: key, chainCode: sum[32:]}, nil
+}
+
+// child derives the child key of the given index. The index at or above 0x80000000 is hardened.
+func (k *hdKey) child(index uint32) (*hdKey, error) {
+	var data []byte
+	if index >= 0x80000000 {
+		data = append([]byte{0}, math.PaddedBigBytes(k.key, 32)...)
+	} else {
+		privateKey, err := k.privateKey()
+		if err != nil {
+			return nil, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	data = append(data, indexBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	return newHDKey(mac.Sum(nil), k.key)
+}
+
+func (k *hdKey) privateKey() (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(math.PaddedBigBytes(k.key, 32))
+}
+
+// DeriveKey derives the private key of the derivation path from the seed as specified in BIP-32.
+func DeriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, err := newMasterHDKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range path {
+		if key, err = key.child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key.privateKey()
+}
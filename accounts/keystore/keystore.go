@@ -62,6 +62,7 @@ type KeyStore struct {
 	cache    *accountCache                // In-memory account cache over the filesystem storage
 	changes  chan struct{}                // Channel receiving change notifications from the cache
 	unlocked map[common.Address]*unlocked // Currently unlocked account (decrypted private keys)
+	pinned   map[common.Address][]pinned  // Derived accounts pinned in each wallet, kept across wallet refreshes
 
 	wallets     []accounts.Wallet       // Wallet wrappers around the individual key files
 	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
@@ -100,6 +101,7 @@ func (ks *KeyStore) init(keydir string) {
 
 	// Initialize the set of unlocked keys and the account cache
 	ks.unlocked = make(map[common.Address]*unlocked)
+	ks.pinned = make(map[common.Address][]pinned)
 	ks.cache, ks.changes = newAccountCache(keydir)
 
 	// TODO: In order for this finalizer to work, there must be no references
@@ -112,7 +114,7 @@ func (ks *KeyStore) init(keydir string) {
 	accs := ks.cache.accounts()
 	ks.wallets = make([]accounts.Wallet, len(accs))
 	for i := 0; i < len(accs); i++ {
-		ks.wallets[i] = &keystoreWallet{account: accs[i], keystore: ks}
+		ks.wallets[i] = ks.newWallet(accs[i])
 	}
 }
 
@@ -151,7 +153,7 @@ func (ks *KeyStore) refreshWallets() {
 		}
 		// If there are no more wallets or the account is before the next, wrap new wallet
 		if len(ks.wallets) == 0 || ks.wallets[0].URL().Cmp(account.URL) > 0 {
-			wallet := &keystoreWallet{account: account, keystore: ks}
+			wallet := ks.newWallet(account)
 
 			events = append(events, accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletArrived})
 			wallets = append(wallets, wallet)
@@ -250,6 +252,9 @@ func (ks *KeyStore) Delete(a accounts.Account, passphrase string) error {
 	err = os.Remove(a.URL.Path)
 	if err == nil {
 		ks.cache.delete(a)
+		ks.mu.Lock()
+		delete(ks.pinned, a.Address)
+		ks.mu.Unlock()
 		ks.refreshWallets()
 	}
 	return err
@@ -385,6 +390,8 @@ func (ks *KeyStore) TimedUnlock(a accounts.Account, passphrase string, timeout t
 	if err != nil {
 		return err
 	}
+	// Discover the accounts derived from the HD seed, if any, after the unlock
+	defer ks.selfDerive(a.Address)
 
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
@@ -0,0 +1,242 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/pborman/uuid"
+)
+
+// MaxDeriveAccounts is the maximum number of accounts derived into key files at once.
+const MaxDeriveAccounts = maxSelfDerive
+
+var (
+	ErrNoHDSeed        = errors.New("account has no HD seed")
+	ErrInvalidRoleKeys = errors.New("derivation paths should be given for 1 to 3 roles, at least one for each role")
+	ErrDeriveCount     = fmt.Errorf("the number of accounts to derive should be within [0, %d]", MaxDeriveAccounts)
+)
+
+// hdSeedOf returns the HD seed of the key, or nil if the key is not derived from an HD wallet.
+func hdSeedOf(key Key) []byte {
+	if k, ok := key.(*KeyV4); ok {
+		return k.Seed
+	}
+	return nil
+}
+
+// ImportMnemonic imports the HD wallet of the BIP-39 mnemonic into the keystore. The key
+// at accounts.DefaultBaseDerivationPath becomes the account, and the seed is stored
+// together so that more accounts can be derived from it later on.
+func (ks *KeyStore) ImportMnemonic(mnemonic, mnemonicPassphrase, passphrase string) (accounts.Account, error) {
+	seed, err := NewSeedFromMnemonic(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	priv, err := DeriveKey(seed, accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	key := &KeyV4{
+		Id:          uuid.NewRandom(),
+		Address:     crypto.PubkeyToAddress(priv.PublicKey),
+		PrivateKeys: [][]*ecdsa.PrivateKey{{priv}},
+		Seed:        seed,
+	}
+	defer key.ResetPrivateKey()
+	if ks.cache.hasAddress(key.Address) {
+		return accounts.Account{}, fmt.Errorf("account already exists")
+	}
+	return ks.importKey(key, passphrase)
+}
+
+// DeriveAccounts derives count accounts from the HD seed of the account a, starting at
+// the base path and increasing its last component, and stores each of them as a
+// separate key file encrypted with the passphrase. The accounts already in the keystore
+// are returned without being stored again. At most MaxDeriveAccounts accounts are derived
+// at once, since each key file is encrypted with scrypt.
+func (ks *KeyStore) DeriveAccounts(a accounts.Account, passphrase string, base accounts.DerivationPath, count int) ([]accounts.Account, error) {
+	if len(base) == 0 {
+		return nil, errors.New("empty derivation path")
+	}
+	if count < 0 || count > MaxDeriveAccounts {
+		return nil, ErrDeriveCount
+	}
+	seed, err := ks.decryptHDSeed(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(seed)
+
+	path := make(accounts.DerivationPath, len(base))
+	copy(path, base)
+
+	derived := make([]accounts.Account, 0, count)
+	for i := 0; i < count; i++ {
+		priv, err := DeriveKey(seed, path)
+		if err != nil {
+			return derived, fmt.Errorf("failed to derive %s: %v", path, err)
+		}
+		key := newKeyFromECDSA(priv)
+		if found, err := ks.Find(accounts.Account{Address: key.GetAddress()}); err == nil {
+			derived = append(derived, found)
+		} else {
+			account, err := ks.importKey(key, passphrase)
+			if err != nil {
+				key.ResetPrivateKey()
+				return derived, err
+			}
+			derived = append(derived, account)
+		}
+		key.ResetPrivateKey()
+		path[len(path)-1]++
+	}
+	return derived, nil
+}
+
+// DeriveRoleBasedAccount derives the keys of the paths from the HD seed of the account a,
+// and stores them into a role-based key file encrypted with the passphrase, where
+// paths[i] are the paths of the keys of the i-th role. If address is nil, the address of
+// the first key of accountkey.RoleTransaction is used.
+func (ks *KeyStore) DeriveRoleBasedAccount(a accounts.Account, passphrase string, paths [][]accounts.DerivationPath, address *common.Address) (accounts.Account, error) {
+	if len(paths) == 0 || len(paths) > int(accountkey.RoleLast) {
+		return accounts.Account{}, ErrInvalidRoleKeys
+	}
+	for _, rolePaths := range paths {
+		if len(rolePaths) == 0 {
+			return accounts.Account{}, ErrInvalidRoleKeys
+		}
+	}
+	seed, err := ks.decryptHDSeed(a, passphrase)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	defer zeroBytes(seed)
+
+	key := &KeyV4{Id: uuid.NewRandom(), PrivateKeys: make([][]*ecdsa.PrivateKey, len(paths))}
+	defer key.ResetPrivateKey()
+	for i, rolePaths := range paths {
+		key.PrivateKeys[i] = make([]*ecdsa.PrivateKey, len(rolePaths))
+		for j, path := range rolePaths {
+			if key.PrivateKeys[i][j], err = DeriveKey(seed, path); err != nil {
+				return accounts.Account{}, fmt.Errorf("failed to derive %s: %v", path, err)
+			}
+		}
+	}
+	if address != nil {
+		key.Address = *address
+	} else {
+		key.Address = crypto.PubkeyToAddress(key.PrivateKeys[accountkey.RoleTransaction][0].PublicKey)
+	}
+	if ks.cache.hasAddress(key.Address) {
+		return accounts.Account{}, fmt.Errorf("account already exists")
+	}
+	return ks.importKey(key, passphrase)
+}
+
+// decryptHDSeed decrypts the key file of the account, returning a copy of its HD seed.
+func (ks *KeyStore) decryptHDSeed(a accounts.Account, passphrase string) ([]byte, error) {
+	_, key, err := ks.getDecryptedKey(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer key.ResetPrivateKey()
+
+	seed := hdSeedOf(key)
+	if len(seed) == 0 {
+		return nil, ErrNoHDSeed
+	}
+	return common.CopyBytes(seed), nil
+}
+
+// deriveUnlockedKey derives the key of the path from the HD seed of the unlocked account.
+func (ks *KeyStore) deriveUnlockedKey(addr common.Address, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	ks.mu.RLock()
+	u, found := ks.unlocked[addr]
+	var seed []byte
+	if found {
+		seed = common.CopyBytes(hdSeedOf(u.Key))
+	}
+	ks.mu.RUnlock()
+
+	if !found {
+		return nil, ErrLocked
+	}
+	if len(seed) == 0 {
+		return nil, ErrNoHDSeed
+	}
+	defer zeroBytes(seed)
+	return DeriveKey(seed, path)
+}
+
+// deriveKeyWithPassphrase derives the key of the path from the HD seed of the account,
+// decrypting its key file with the passphrase.
+func (ks *KeyStore) deriveKeyWithPassphrase(a accounts.Account, passphrase string, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	seed, err := ks.decryptHDSeed(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(seed)
+	return DeriveKey(seed, path)
+}
+
+// hasHDSeed returns whether the key file of the account has an HD seed, without decrypting it.
+func (ks *KeyStore) hasHDSeed(a accounts.Account) bool {
+	keyjson, err := ioutil.ReadFile(a.URL.Path)
+	if err != nil {
+		return false
+	}
+	var k struct {
+		HDSeed json.RawMessage `json:"hdseed"`
+		Seed   string          `json:"seed"`
+	}
+	if err := json.Unmarshal(keyjson, &k); err != nil {
+		return false
+	}
+	return len(k.HDSeed) > 0 || k.Seed != ""
+}
+
+// selfDerive starts the account discovery of the wallet of the address, if any.
+func (ks *KeyStore) selfDerive(addr common.Address) {
+	ks.mu.RLock()
+	var wallet *keystoreWallet
+	for _, w := range ks.wallets {
+		if kw, ok := w.(*keystoreWallet); ok && kw.account.Address == addr {
+			wallet = kw
+			break
+		}
+	}
+	ks.mu.RUnlock()
+
+	if wallet != nil {
+		go wallet.selfDerive()
+	}
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
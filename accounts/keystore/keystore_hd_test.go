@@ -0,0 +1,301 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klaytn/klaytn"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestDeriveKey(t *testing.T) {
+	// Test vector 1 of BIP-32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := DeriveKey(seed, accounts.DerivationPath{})
+	require.NoError(t, err)
+	assert.Equal(t, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", hex.EncodeToString(crypto.FromECDSA(master)))
+
+	testcases := []struct {
+		path, key string
+	}{
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, tc := range testcases {
+		path, err := accounts.ParseDerivationPath(tc.path)
+		require.NoError(t, err)
+		key, err := DeriveKey(seed, path)
+		require.NoError(t, err)
+		assert.Equal(t, tc.key, hex.EncodeToString(crypto.FromECDSA(key)), tc.path)
+	}
+
+	// The well-known accounts of the test mnemonic with the Ethereum coin type
+	seed, err = NewSeedFromMnemonic(testMnemonic, "")
+	require.NoError(t, err)
+	for i, addr := range []string{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"} {
+		key, err := DeriveKey(seed, accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000, 0, uint32(i)})
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToAddress(addr), crypto.PubkeyToAddress(key.PublicKey))
+	}
+
+	_, err = DeriveKey(seed[:15], accounts.DefaultBaseDerivationPath)
+	assert.Equal(t, ErrInvalidSeedLen, err)
+}
+
+// deriveTestAddress returns the address of the path derived from the test mnemonic.
+func deriveTestAddress(t *testing.T, path accounts.DerivationPath) common.Address {
+	seed, err := NewSeedFromMnemonic(testMnemonic, "")
+	require.NoError(t, err)
+	key, err := DeriveKey(seed, path)
+	require.NoError(t, err)
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestKeyStore_ImportMnemonic(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	a, err := ks.ImportMnemonic(testMnemonic, "", "foo")
+	require.NoError(t, err)
+	assert.Equal(t, deriveTestAddress(t, accounts.DefaultBaseDerivationPath), a.Address)
+
+	_, err = ks.ImportMnemonic(testMnemonic, "", "foo")
+	assert.Error(t, err, "account already exists")
+	_, err = ks.ImportMnemonic("test test test", "", "foo")
+	assert.Equal(t, ErrInvalidMnemonic, err)
+
+	// The seed is encrypted together, and kept through an export and import
+	keyjson, err := ks.Export(a, "foo", "bar")
+	require.NoError(t, err)
+	key, err := DecryptKey(keyjson, "bar")
+	require.NoError(t, err)
+	seed, _ := NewSeedFromMnemonic(testMnemonic, "")
+	assert.Equal(t, seed, hdSeedOf(key))
+	assert.True(t, ks.hasHDSeed(a))
+
+	// Accounts are derived into separate key files
+	base := accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 8217, 0x80000000, 0, 1}
+	derived, err := ks.DeriveAccounts(a, "foo", base, 3)
+	require.NoError(t, err)
+	require.Len(t, derived, 3)
+	for i, d := range derived {
+		path := append(accounts.DerivationPath{}, base...)
+		path[len(path)-1] += uint32(i)
+		assert.Equal(t, deriveTestAddress(t, path), d.Address)
+		assert.True(t, ks.HasAddress(d.Address))
+		assert.False(t, ks.hasHDSeed(d))
+	}
+	// Deriving again returns the existing accounts
+	again, err := ks.DeriveAccounts(a, "foo", base, 4)
+	require.NoError(t, err)
+	assert.Equal(t, derived, again[:3])
+	assert.Len(t, ks.Accounts(), 5)
+
+	_, err = ks.DeriveAccounts(derived[0], "foo", base, 1)
+	assert.Equal(t, ErrNoHDSeed, err)
+	_, err = ks.DeriveAccounts(a, "bar", base, 1)
+	assert.Equal(t, ErrDecrypt, err)
+	_, err = ks.DeriveAccounts(a, "foo", base, MaxDeriveAccounts+1)
+	assert.Equal(t, ErrDeriveCount, err)
+}
+
+func TestKeyStore_DeriveRoleBasedAccount(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	a, err := ks.ImportMnemonic(testMnemonic, "", "foo")
+	require.NoError(t, err)
+
+	pathOf := func(i uint32) accounts.DerivationPath {
+		return accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 8217, 0x80000000, 0, i}
+	}
+	paths := [][]accounts.DerivationPath{{pathOf(10)}, {pathOf(11)}, {pathOf(12), pathOf(13)}}
+	role, err := ks.DeriveRoleBasedAccount(a, "foo", paths, nil)
+	require.NoError(t, err)
+	assert.Equal(t, deriveTestAddress(t, pathOf(10)), role.Address)
+
+	_, key, err := ks.getDecryptedKey(role, "foo")
+	require.NoError(t, err)
+	pks := key.GetPrivateKeys()
+	require.Len(t, pks, 3)
+	for i, rolePaths := range paths {
+		require.Len(t, pks[i], len(rolePaths))
+		for j, path := range rolePaths {
+			assert.Equal(t, deriveTestAddress(t, path), crypto.PubkeyToAddress(pks[i][j].PublicKey))
+		}
+	}
+
+	// A role-based key of the given address
+	addr := common.HexToAddress("0x1111")
+	role, err = ks.DeriveRoleBasedAccount(a, "foo", paths[:1], &addr)
+	require.NoError(t, err)
+	assert.Equal(t, addr, role.Address)
+
+	_, err = ks.DeriveRoleBasedAccount(a, "foo", nil, nil)
+	assert.Equal(t, ErrInvalidRoleKeys, err)
+	_, err = ks.DeriveRoleBasedAccount(a, "foo", [][]accounts.DerivationPath{{pathOf(20)}, {}}, nil)
+	assert.Equal(t, ErrInvalidRoleKeys, err)
+}
+
+func TestKeystoreWallet_Derive(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	a, err := ks.ImportMnemonic(testMnemonic, "", "foo")
+	require.NoError(t, err)
+	plain, err := ks.NewAccount("foo")
+	require.NoError(t, err)
+
+	wallet := findWallet(t, ks, a)
+	path := accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 8217, 0x80000000, 0, 5}
+	expected := deriveTestAddress(t, path)
+
+	// The key file should be unlocked to derive
+	_, err = wallet.Derive(path, true)
+	assert.Equal(t, ErrLocked, err)
+	require.NoError(t, ks.Unlock(a, "foo"))
+	derived, err := wallet.Derive(path, true)
+	require.NoError(t, err)
+	assert.Equal(t, accounts.Account{Address: expected, URL: a.URL}, derived)
+	assert.Equal(t, []accounts.Account{a, derived}, wallet.Accounts())
+	assert.True(t, wallet.Contains(accounts.Account{Address: expected}))
+
+	// The pinned account is kept when the wallet is created again
+	ks.mu.Lock()
+	ks.wallets = nil
+	ks.mu.Unlock()
+	ks.refreshWallets()
+	refreshed := findWallet(t, ks, a)
+	assert.NotSame(t, wallet, refreshed)
+	assert.Equal(t, []accounts.Account{a, derived}, refreshed.Accounts())
+
+	// A plain key file has no HD seed
+	require.NoError(t, ks.Unlock(plain, "foo"))
+	_, err = findWallet(t, ks, plain).Derive(path, true)
+	assert.Equal(t, accounts.ErrNotSupported, err)
+
+	// The derived account signs with the derived key
+	chainID := big.NewInt(1001)
+	tx := types.NewTransaction(0, common.HexToAddress("0x2222"), big.NewInt(1), 100000, big.NewInt(25000000000), nil)
+	signed, err := wallet.SignTx(derived, tx, chainID)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sender)
+
+	hash := crypto.Keccak256([]byte("klaytn"))
+	sig, err := wallet.SignHash(derived, hash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, expected, crypto.PubkeyToAddress(*pub))
+
+	// The passphrase variants decrypt the key file
+	require.NoError(t, ks.Lock(a.Address))
+	_, err = wallet.SignTx(derived, tx, chainID)
+	assert.Equal(t, ErrLocked, err)
+	signed, err = wallet.SignTxWithPassphrase(derived, "foo", tx, chainID)
+	require.NoError(t, err)
+	sender, err = types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sender)
+	_, err = wallet.SignTxWithPassphrase(derived, "bar", tx, chainID)
+	assert.Equal(t, ErrDecrypt, err)
+
+	_, err = wallet.SignTx(accounts.Account{Address: common.HexToAddress("0x3333")}, tx, chainID)
+	assert.Equal(t, accounts.ErrUnknownAccount, err)
+}
+
+// testStateReader is a blockchain state reader of which the given addresses are used.
+type testStateReader struct {
+	klaytn.ChainReader
+	used map[common.Address]bool
+}
+
+func (r *testStateReader) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if r.used[account] {
+		return big.NewInt(1), nil
+	}
+	return new(big.Int), nil
+}
+
+func (r *testStateReader) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (r *testStateReader) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (r *testStateReader) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return 0, nil
+}
+
+func TestKeystoreWallet_SelfDerive(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	a, err := ks.ImportMnemonic(testMnemonic, "", "foo")
+	require.NoError(t, err)
+	wallet := findWallet(t, ks, a)
+
+	// The accounts at 0 to 2 are used, the one at 0 is of the key file
+	base := accounts.DefaultBaseDerivationPath
+	used := make(map[common.Address]bool)
+	var expected []accounts.Account
+	for i := uint32(0); i < 3; i++ {
+		path := append(accounts.DerivationPath{}, base...)
+		path[len(path)-1] = i
+		addr := deriveTestAddress(t, path)
+		used[addr] = true
+		if i > 0 {
+			expected = append(expected, accounts.Account{Address: addr, URL: a.URL})
+		}
+	}
+
+	// Nothing is discovered until the key file is unlocked
+	wallet.SelfDerive(base, &testStateReader{used: used})
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []accounts.Account{a}, wallet.Accounts())
+
+	require.NoError(t, ks.Unlock(a, "foo"))
+	assert.Eventually(t, func() bool { return len(wallet.Accounts()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, append([]accounts.Account{a}, expected...), wallet.Accounts())
+}
+
+func findWallet(t *testing.T, ks *KeyStore, a accounts.Account) accounts.Wallet {
+	for _, wallet := range ks.Wallets() {
+		if wallet.URL() == a.URL {
+			return wallet
+		}
+	}
+	t.Fatalf("wallet of %v not found", a.Address)
+	return nil
+}
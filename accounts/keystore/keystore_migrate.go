@@ -0,0 +1,278 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+var (
+	ErrNoBackupDir     = errors.New("backup directory is not given")
+	ErrBackupDirInside = errors.New("backup directory should be out of the keystore directory")
+	errKeyMismatch     = errors.New("re-encrypted key does not match the original key")
+)
+
+// MigrateConfig specifies how to re-encrypt the key files of a keystore directory.
+type MigrateConfig struct {
+	Passphrase    string    // Passphrase of the existing key files
+	NewPassphrase string    // Passphrase to re-encrypt the key files with
+	KDF           KDFParams // KDF parameters to re-encrypt the key files with
+	BackupDir     string    // Directory to copy the original key files to
+}
+
+// MigrateResult is the result of re-encrypting a key file.
+type MigrateResult struct {
+	Path    string
+	Address common.Address
+	Version int   // Keystore version of the original file
+	Err     error // Error while decrypting, re-encrypting or validating the file
+}
+
+// migration holds a key file to be replaced.
+type migration struct {
+	path     string
+	original []byte
+	migrated []byte
+	tmpName  string
+}
+
+// MigrateKeyDir re-encrypts all the key files in keydir with the new passphrase and
+// KDF parameters, converting them to the keystore v4 format.
+//
+// The migration is all or nothing. Every file is decrypted, re-encrypted and validated
+// in memory first, and no file is changed if any of them fails. Then the original files
+// are copied to the backup directory, and replaced with the re-encrypted ones. If a file
+// fails to be replaced, the files already replaced are restored from the originals.
+func MigrateKeyDir(keydir string, config MigrateConfig) ([]MigrateResult, error) {
+	if err := config.KDF.Validate(); err != nil {
+		return nil, err
+	}
+	if config.BackupDir == "" {
+		return nil, ErrNoBackupDir
+	}
+	if inside, err := isSubDir(keydir, config.BackupDir); err != nil {
+		return nil, err
+	} else if inside {
+		return nil, ErrBackupDirInside
+	}
+
+	files, err := ioutil.ReadDir(keydir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-encrypt and validate all the key files in memory
+	var (
+		results    []MigrateResult
+		migrations []*migration
+		numFailed  int
+	)
+	for _, fi := range files {
+		if skipKeyFile(fi) {
+			continue
+		}
+		path := filepath.Join(keydir, fi.Name())
+		m, result := migrateKeyFile(path, config)
+		if result.Err != nil {
+			numFailed++
+		}
+		results = append(results, result)
+		migrations = append(migrations, m)
+	}
+	if numFailed > 0 {
+		return results, fmt.Errorf("failed to migrate %d of %d key files, no file is changed", numFailed, len(results))
+	}
+	if len(migrations) == 0 {
+		return results, nil
+	}
+
+	if err := backupKeyFiles(config.BackupDir, migrations); err != nil {
+		return results, fmt.Errorf("failed to back up key files, no file is changed: %v", err)
+	}
+	if err := replaceKeyFiles(migrations); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// migrateKeyFile decrypts the key file and re-encrypts it with the given config.
+func migrateKeyFile(path string, config MigrateConfig) (*migration, MigrateResult) {
+	result := MigrateResult{Path: path}
+	keyjson, err := ioutil.ReadFile(path)
+	if err != nil {
+		result.Err = err
+		return nil, result
+	}
+	if result.Version, err = keyFileVersion(keyjson); err != nil {
+		result.Err = err
+		return nil, result
+	}
+
+	key, err := DecryptKey(keyjson, config.Passphrase)
+	if err != nil {
+		result.Err = err
+		return nil, result
+	}
+	defer key.ResetPrivateKey()
+	result.Address = key.GetAddress()
+
+	migrated, err := EncryptKeyWithKDF(key, config.NewPassphrase, config.KDF)
+	if err != nil {
+		result.Err = err
+		return nil, result
+	}
+
+	// Validate that the re-encrypted key is decrypted to the original key
+	newKey, err := DecryptKey(migrated, config.NewPassphrase)
+	if err != nil {
+		result.Err = err
+		return nil, result
+	}
+	defer newKey.ResetPrivateKey()
+	if !equalKeys(key, newKey) {
+		result.Err = errKeyMismatch
+		return nil, result
+	}
+
+	return &migration{path: path, original: keyjson, migrated: migrated}, result
+}
+
+// backupKeyFiles copies the original key files to the backup directory without overwriting any file.
+func backupKeyFiles(backupDir string, migrations []*migration) error {
+	const dirPerm = 0o700
+	if err := os.MkdirAll(backupDir, dirPerm); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		f, err := os.OpenFile(filepath.Join(backupDir, filepath.Base(m.path)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(m.original)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceKeyFiles replaces the key files with the re-encrypted ones.
+// The re-encrypted ones are written to temporary files first, and then renamed one by one.
+func replaceKeyFiles(migrations []*migration) error {
+	removeTemporaryFiles := func() {
+		for _, m := range migrations {
+			if m.tmpName != "" {
+				os.Remove(m.tmpName)
+			}
+		}
+	}
+
+	for _, m := range migrations {
+		tmpName, err := writeTemporaryKeyFile(m.path, m.migrated)
+		if err != nil {
+			removeTemporaryFiles()
+			return fmt.Errorf("failed to write key files, no file is changed: %v", err)
+		}
+		m.tmpName = tmpName
+	}
+
+	for i, m := range migrations {
+		if err := os.Rename(m.tmpName, m.path); err != nil {
+			removeTemporaryFiles()
+			// Restore the files already replaced
+			for _, replaced := range migrations[:i] {
+				if restoreErr := writeKeyFile(replaced.path, replaced.original); restoreErr != nil {
+					logger.Error("Failed to restore a key file, restore it from the backup",
+						"path", replaced.path, "err", restoreErr)
+				}
+			}
+			return fmt.Errorf("failed to replace %s, the key files are restored: %v", m.path, err)
+		}
+		m.tmpName = ""
+	}
+	return nil
+}
+
+// keyFileVersion returns the keystore version of the key file.
+func keyFileVersion(keyjson []byte) (int, error) {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(keyjson, &m); err != nil {
+		return 0, err
+	}
+	switch v := m["version"].(type) {
+	case string:
+		if v == "1" {
+			return 1, nil
+		}
+	case float64:
+		if v == 3 || v == 4 {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("undefined version: %v", m["version"])
+}
+
+// equalKeys returns whether the two keys have the same address, private keys and HD seed.
+func equalKeys(a, b Key) bool {
+	if a.GetAddress() != b.GetAddress() || !bytes.Equal(hdSeedOf(a), hdSeedOf(b)) {
+		return false
+	}
+	aKeys, bKeys := a.GetPrivateKeys(), b.GetPrivateKeys()
+	if len(aKeys) != len(bKeys) {
+		return false
+	}
+	for i := range aKeys {
+		if len(aKeys[i]) != len(bKeys[i]) {
+			return false
+		}
+		for j := range aKeys[i] {
+			if aKeys[i][j].D.Cmp(bKeys[i][j].D) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isSubDir returns whether dir is the same as or inside parent.
+func isSubDir(parent, dir string) (bool, error) {
+	parent, err := filepath.Abs(parent)
+	if err != nil {
+		return false, err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+	rel, err := filepath.Rel(parent, dir)
+	if err != nil {
+		return false, nil
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))), nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMigrateTestDir creates a keystore directory with a v3 key file, a v4 role-based
+// key file and a non-key file to be skipped, returning the keys by file name.
+func newMigrateTestDir(t *testing.T, passphrase string) (string, map[string]Key) {
+	dir, err := ioutil.TempDir("", "klay-keystore-migrate-test")
+	require.NoError(t, err)
+
+	keys := make(map[string]Key)
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	v3Key := newKeyFromECDSA(pk)
+	v3JSON, err := EncryptKeyV3(v3Key, passphrase, veryLightScryptN, veryLightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "v3"), v3JSON, 0o600))
+	keys["v3"] = v3Key
+
+	pks := make([][]*ecdsa.PrivateKey, 3)
+	for i := range pks {
+		pks[i] = make([]*ecdsa.PrivateKey, i+1)
+		for j := range pks[i] {
+			pks[i][j], err = crypto.GenerateKey()
+			require.NoError(t, err)
+		}
+	}
+	v4Key := &KeyV4{Id: uuid.NewRandom(), Address: crypto.PubkeyToAddress(pks[0][0].PublicKey), PrivateKeys: pks}
+	v4JSON, err := EncryptKey(v4Key, passphrase, veryLightScryptN, veryLightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "v4"), v4JSON, 0o600))
+	keys["v4"] = v4Key
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("not a key"), 0o600))
+	return dir, keys
+}
+
+// Tests that all the key files are re-encrypted with the new passphrase and KDF,
+// and the original files are backed up.
+func TestMigrateKeyDir(t *testing.T) {
+	dir, keys := newMigrateTestDir(t, "old")
+	defer os.RemoveAll(dir)
+	backupDir := dir + "-backup"
+	defer os.RemoveAll(backupDir)
+
+	originals := make(map[string][]byte)
+	for name := range keys {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		originals[name] = b
+	}
+
+	results, err := MigrateKeyDir(dir, MigrateConfig{
+		Passphrase:    "old",
+		NewPassphrase: "new",
+		KDF:           Argon2idKDFParams(1, 64, 1),
+		BackupDir:     backupDir,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	versions := make(map[string]int)
+	for _, result := range results {
+		name := filepath.Base(result.Path)
+		versions[name] = result.Version
+		assert.NoError(t, result.Err)
+		assert.Equal(t, keys[name].GetAddress(), result.Address)
+
+		// The file is converted to v4, and encrypted with the new passphrase and KDF
+		keyjson, err := ioutil.ReadFile(result.Path)
+		require.NoError(t, err)
+		version, err := keyFileVersion(keyjson)
+		require.NoError(t, err)
+		assert.Equal(t, 4, version)
+
+		var encrypted encryptedKeyJSONV4
+		require.NoError(t, json.Unmarshal(keyjson, &encrypted))
+		assert.Equal(t, keyHeaderKDFArgon2id, encrypted.Keyring[0][0].KDF)
+
+		_, err = DecryptKey(keyjson, "old")
+		assert.Equal(t, ErrDecrypt, err)
+		key, err := DecryptKey(keyjson, "new")
+		require.NoError(t, err)
+		assert.True(t, equalKeys(keys[name], key))
+
+		// The original file is backed up
+		backup, err := ioutil.ReadFile(filepath.Join(backupDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, originals[name], backup)
+	}
+	assert.Equal(t, map[string]int{"v3": 3, "v4": 4}, versions)
+
+	// The files not being keys are untouched
+	hidden, err := ioutil.ReadFile(filepath.Join(dir, ".hidden"))
+	require.NoError(t, err)
+	assert.Equal(t, "not a key", string(hidden))
+}
+
+// Tests that no file is changed if any key file fails to be migrated.
+func TestMigrateKeyDir_Failure(t *testing.T) {
+	dir, keys := newMigrateTestDir(t, "old")
+	defer os.RemoveAll(dir)
+	backupDir := dir + "-backup"
+	defer os.RemoveAll(backupDir)
+
+	// A key file encrypted with another passphrase
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := EncryptKey(newKeyFromECDSA(pk), "other", veryLightScryptN, veryLightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other"), other, 0o600))
+
+	readAll := func() map[string][]byte {
+		files := make(map[string][]byte)
+		for _, name := range []string{"v3", "v4", "other"} {
+			b, err := ioutil.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			files[name] = b
+		}
+		return files
+	}
+	before := readAll()
+
+	config := MigrateConfig{
+		Passphrase:    "old",
+		NewPassphrase: "new",
+		KDF:           ScryptKDFParams(veryLightScryptN, veryLightScryptP),
+		BackupDir:     backupDir,
+	}
+	results, err := MigrateKeyDir(dir, config)
+	assert.Error(t, err)
+	require.Len(t, results, len(keys)+1)
+	for _, result := range results {
+		if filepath.Base(result.Path) == "other" {
+			assert.Equal(t, ErrDecrypt, result.Err)
+		} else {
+			assert.NoError(t, result.Err)
+		}
+	}
+	assert.Equal(t, before, readAll())
+	_, err = os.Stat(backupDir)
+	assert.True(t, os.IsNotExist(err))
+
+	// Invalid configs
+	_, err = MigrateKeyDir(dir, MigrateConfig{KDF: ScryptKDFParams(veryLightScryptN, veryLightScryptP)})
+	assert.Equal(t, ErrNoBackupDir, err)
+	config.BackupDir = filepath.Join(dir, "backup")
+	_, err = MigrateKeyDir(dir, config)
+	assert.Equal(t, ErrBackupDirInside, err)
+	config.BackupDir = backupDir
+	config.KDF = ScryptKDFParams(1000, 1)
+	_, err = MigrateKeyDir(dir, config)
+	assert.Error(t, err)
+	assert.Equal(t, before, readAll())
+}
+
+func TestIsSubDir(t *testing.T) {
+	testcases := []struct {
+		parent, dir string
+		expected    bool
+	}{
+		{"/keystore", "/keystore", true},
+		{"/keystore", "/keystore/backup", true},
+		{"/keystore", "/keystore/../keystore/backup", true},
+		{"/keystore", "/keystore-backup", false},
+		{"/keystore", "/backup", false},
+		{"/keystore", "/keystore/..backup", true},
+		{"/data/keystore", "/data", false},
+	}
+	for _, tc := range testcases {
+		inside, err := isSubDir(tc.parent, tc.dir)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, inside, "parent: %s, dir: %s", tc.parent, tc.dir)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -38,12 +39,14 @@ import (
 	"github.com/klaytn/klaytn/common/math"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/pborman/uuid"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
 
 const (
-	keyHeaderKDF = "scrypt"
+	keyHeaderKDF         = "scrypt"
+	keyHeaderKDFArgon2id = "argon2id"
 
 	// StandardScryptN is the N parameter of Scrypt encryption algorithm, using 256MB
 	// memory and taking approximately 1s CPU time on a modern processor.
@@ -63,8 +66,101 @@ const (
 
 	scryptR     = 8
 	scryptDKLen = 32
+
+	// StandardArgon2Time is the number of passes of Argon2id encryption algorithm,
+	// taking approximately 1s CPU time with StandardArgon2Memory on a modern processor.
+	StandardArgon2Time = 4
+
+	// StandardArgon2Memory is the memory size of Argon2id encryption algorithm in KiB, using 256MB memory.
+	StandardArgon2Memory = 256 * 1024
+
+	// StandardArgon2Threads is the degree of parallelism of Argon2id encryption algorithm.
+	StandardArgon2Threads = 4
+
+	argon2DKLen = 32
 )
 
+// KDFParams specifies the key derivation function and its parameters used to encrypt keys.
+type KDFParams struct {
+	KDF string // "scrypt" or "argon2id"
+
+	ScryptN int
+	ScryptP int
+
+	Argon2Time    uint32
+	Argon2Memory  uint32 // in KiB
+	Argon2Threads uint8
+}
+
+// ScryptKDFParams returns the KDF parameters of scrypt.
+func ScryptKDFParams(scryptN, scryptP int) KDFParams {
+	return KDFParams{KDF: keyHeaderKDF, ScryptN: scryptN, ScryptP: scryptP}
+}
+
+// Argon2idKDFParams returns the KDF parameters of Argon2id.
+func Argon2idKDFParams(time, memory uint32, threads uint8) KDFParams {
+	return KDFParams{KDF: keyHeaderKDFArgon2id, Argon2Time: time, Argon2Memory: memory, Argon2Threads: threads}
+}
+
+// Validate checks that the KDF is supported and its parameters are usable.
+func (p KDFParams) Validate() error {
+	switch p.KDF {
+	case keyHeaderKDF:
+		if p.ScryptN <= 1 || p.ScryptN&(p.ScryptN-1) != 0 {
+			return fmt.Errorf("scrypt N must be a power of 2 greater than 1: %d", p.ScryptN)
+		}
+		if p.ScryptP <= 0 || uint64(scryptR)*uint64(p.ScryptP) >= 1<<30 {
+			return fmt.Errorf("invalid scrypt P: %d", p.ScryptP)
+		}
+	case keyHeaderKDFArgon2id:
+		if p.Argon2Time == 0 {
+			return errors.New("argon2 time must be positive")
+		}
+		if p.Argon2Threads == 0 {
+			return errors.New("argon2 threads must be positive")
+		}
+		if p.Argon2Memory < 8*uint32(p.Argon2Threads) {
+			return fmt.Errorf("argon2 memory must be at least 8*threads KiB: %d", p.Argon2Memory)
+		}
+	default:
+		return fmt.Errorf("Unsupported KDF: %s", p.KDF)
+	}
+	return nil
+}
+
+// deriveKey derives a key from the passphrase and the salt, and returns it with the kdfparams to be stored.
+func (p KDFParams) deriveKey(auth, salt []byte) ([]byte, map[string]interface{}, error) {
+	if err := p.Validate(); err != nil {
+		return nil, nil, err
+	}
+	switch p.KDF {
+	case keyHeaderKDFArgon2id:
+		derivedKey := argon2.IDKey(auth, salt, p.Argon2Time, p.Argon2Memory, p.Argon2Threads, argon2DKLen)
+
+		argon2ParamsJSON := make(map[string]interface{}, 5)
+		argon2ParamsJSON["t"] = int(p.Argon2Time)
+		argon2ParamsJSON["m"] = int(p.Argon2Memory)
+		argon2ParamsJSON["p"] = int(p.Argon2Threads)
+		argon2ParamsJSON["dklen"] = argon2DKLen
+		argon2ParamsJSON["salt"] = hex.EncodeToString(salt)
+		return derivedKey, argon2ParamsJSON, nil
+
+	default:
+		derivedKey, err := scrypt.Key(auth, salt, p.ScryptN, scryptR, p.ScryptP, scryptDKLen)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		scryptParamsJSON := make(map[string]interface{}, 5)
+		scryptParamsJSON["n"] = p.ScryptN
+		scryptParamsJSON["r"] = scryptR
+		scryptParamsJSON["p"] = p.ScryptP
+		scryptParamsJSON["dklen"] = scryptDKLen
+		scryptParamsJSON["salt"] = hex.EncodeToString(salt)
+		return derivedKey, scryptParamsJSON, nil
+	}
+}
+
 type keyStorePassphrase struct {
 	keysDirPath string
 	scryptN     int
@@ -132,14 +228,14 @@ func (ks keyStorePassphrase) JoinPath(filename string) string {
 }
 
 // encryptCrypto encrypts a private key to a cryptoJSON object.
-func encryptCrypto(keyBytes []byte, auth string, scryptN, scryptP int) (*cryptoJSON, error) {
+func encryptCrypto(keyBytes []byte, auth string, kdf KDFParams) (*cryptoJSON, error) {
 	authArray := []byte(auth)
 
 	salt := make([]byte, 32)
 	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
 		panic("reading from crypto/rand failed: " + err.Error())
 	}
-	derivedKey, err := scrypt.Key(authArray, salt, scryptN, scryptR, scryptP, scryptDKLen)
+	derivedKey, kdfParamsJSON, err := kdf.deriveKey(authArray, salt)
 	if err != nil {
 		return nil, err
 	}
@@ -155,13 +251,6 @@ func encryptCrypto(keyBytes []byte, auth string, scryptN, scryptP int) (*cryptoJ
 	}
 	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
 
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
-	scryptParamsJSON["dklen"] = scryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
 	}
@@ -170,8 +259,8 @@ func encryptCrypto(keyBytes []byte, auth string, scryptN, scryptP int) (*cryptoJ
 		Cipher:       "aes-128-ctr",
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          keyHeaderKDF,
-		KDFParams:    scryptParamsJSON,
+		KDF:          kdf.KDF,
+		KDFParams:    kdfParamsJSON,
 		MAC:          hex.EncodeToString(mac),
 	}, nil
 }
@@ -179,13 +268,19 @@ func encryptCrypto(keyBytes []byte, auth string, scryptN, scryptP int) (*cryptoJ
 // EncryptKey encrypts a key using the specified scrypt parameters into a json
 // blob that can be decrypted later on. It uses the keystore v4 format.
 func EncryptKey(key Key, auth string, scryptN, scryptP int) ([]byte, error) {
+	return EncryptKeyWithKDF(key, auth, ScryptKDFParams(scryptN, scryptP))
+}
+
+// EncryptKeyWithKDF encrypts a key using the specified KDF parameters into a json
+// blob that can be decrypted later on. It uses the keystore v4 format.
+func EncryptKeyWithKDF(key Key, auth string, kdf KDFParams) ([]byte, error) {
 	pks := key.GetPrivateKeys()
 	crypto := make([][]cryptoJSON, len(pks))
 	for i, keys := range pks {
 		crypto[i] = make([]cryptoJSON, len(keys))
 		for j, k := range keys {
 			keyBytes := math.PaddedBigBytes(k.D, 32)
-			c, err := encryptCrypto(keyBytes, auth, scryptN, scryptP)
+			c, err := encryptCrypto(keyBytes, auth, kdf)
 			if err != nil {
 				return nil, err
 			}
@@ -193,10 +288,17 @@ func EncryptKey(key Key, auth string, scryptN, scryptP int) ([]byte, error) {
 		}
 	}
 	encryptedKeyJSONV4 := encryptedKeyJSONV4{
-		hex.EncodeToString(key.GetAddress().Bytes()),
-		crypto,
-		key.GetId().String(),
-		4,
+		Address: hex.EncodeToString(key.GetAddress().Bytes()),
+		Keyring: crypto,
+		Id:      key.GetId().String(),
+		Version: 4,
+	}
+	if seed := hdSeedOf(key); len(seed) > 0 {
+		c, err := encryptCrypto(seed, auth, kdf)
+		if err != nil {
+			return nil, err
+		}
+		encryptedKeyJSONV4.HDSeed = c
 	}
 	return json.Marshal(encryptedKeyJSONV4)
 }
@@ -267,6 +369,7 @@ func DecryptKey(keyjson []byte, auth string) (Key, error) {
 	var (
 		keyBytes [][][]byte
 		keyId    []byte
+		seed     []byte
 		err      error
 		address  common.Address
 	)
@@ -309,10 +412,14 @@ func DecryptKey(keyjson []byte, auth string) (Key, error) {
 				k.Id = kSingle.Id
 				k.Address = kSingle.Address
 				k.Keyring = [][]cryptoJSON{kSingle.Keyring}
+				k.HDSeed = kSingle.HDSeed
 				k.Version = kSingle.Version
 			}
 
 			keyBytes, keyId, err = decryptKeyV4(k, auth)
+			if err == nil && k.HDSeed != nil {
+				seed, err = decryptKey(*k.HDSeed, auth)
+			}
 			address = common.HexToAddress(k.Address)
 		default:
 			return nil, fmt.Errorf("undefined version: %f", v)
@@ -341,6 +448,7 @@ func DecryptKey(keyjson []byte, auth string) (Key, error) {
 		Id:          uuid.UUID(keyId),
 		Address:     address,
 		PrivateKeys: privateKeys,
+		Seed:        seed,
 	}, nil
 }
 
@@ -464,6 +572,15 @@ func getKDFKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
 		p := ensureInt(cryptoJSON.KDFParams["p"])
 		return scrypt.Key(authArray, salt, n, r, p, dkLen)
 
+	} else if cryptoJSON.KDF == keyHeaderKDFArgon2id {
+		t := ensureInt(cryptoJSON.KDFParams["t"])
+		m := ensureInt(cryptoJSON.KDFParams["m"])
+		p := ensureInt(cryptoJSON.KDFParams["p"])
+		if t <= 0 || m <= 0 || p <= 0 || p > math.MaxUint8 || int64(m) > math.MaxUint32 || int64(t) > math.MaxUint32 {
+			return nil, fmt.Errorf("Invalid argon2id parameters: t=%d, m=%d, p=%d", t, m, p)
+		}
+		return argon2.IDKey(authArray, salt, uint32(t), uint32(m), uint8(p), uint32(dkLen)), nil
+
 	} else if cryptoJSON.KDF == "pbkdf2" {
 		c := ensureInt(cryptoJSON.KDFParams["c"])
 		prf := cryptoJSON.KDFParams["prf"].(string)
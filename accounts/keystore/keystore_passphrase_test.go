@@ -146,6 +146,58 @@ func TestEncryptDecryptV4RoleBased(t *testing.T) {
 	require.Equal(t, key, k)
 }
 
+// Tests encoding and decoding of a keystore v4 object encrypted with Argon2id.
+func TestEncryptDecryptV4Argon2id(t *testing.T) {
+	pks := [][]*ecdsa.PrivateKey{make([]*ecdsa.PrivateKey, 2), make([]*ecdsa.PrivateKey, 1)}
+	for i := range pks {
+		for j := range pks[i] {
+			k, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			pks[i][j] = k
+		}
+	}
+
+	key := &KeyV4{
+		Id:          uuid.NewRandom(),
+		Address:     crypto.PubkeyToAddress(pks[0][0].PublicKey),
+		PrivateKeys: pks,
+	}
+
+	keyjson, err := EncryptKeyWithKDF(key, "password", Argon2idKDFParams(1, 64, 1))
+	require.NoError(t, err)
+	require.Contains(t, string(keyjson), `"kdf":"argon2id"`)
+
+	_, err = DecryptKey(keyjson, "wrong password")
+	require.Equal(t, ErrDecrypt, err)
+
+	k, err := DecryptKey(keyjson, "password")
+	require.NoError(t, err)
+	require.Equal(t, key, k)
+}
+
+// Tests validation of KDF parameters.
+func TestKDFParams_Validate(t *testing.T) {
+	testcases := []struct {
+		params KDFParams
+		valid  bool
+	}{
+		{ScryptKDFParams(StandardScryptN, StandardScryptP), true},
+		{ScryptKDFParams(veryLightScryptN, veryLightScryptP), true},
+		{ScryptKDFParams(1, 1), false},
+		{ScryptKDFParams(1000, 1), false},
+		{ScryptKDFParams(1024, 0), false},
+		{Argon2idKDFParams(StandardArgon2Time, StandardArgon2Memory, StandardArgon2Threads), true},
+		{Argon2idKDFParams(0, 64, 1), false},
+		{Argon2idKDFParams(1, 64, 0), false},
+		{Argon2idKDFParams(1, 31, 4), false},
+		{KDFParams{KDF: "pbkdf2"}, false},
+	}
+	for i, tc := range testcases {
+		err := tc.params.Validate()
+		require.Equal(t, tc.valid, err == nil, "testcase %d: %v", i, err)
+	}
+}
+
 // Tests decoding of a hard-coded keystore v4 JSON object storing a private key.
 func TestKeyDecryptV4Single(t *testing.T) {
 	keyjson := []byte(`{
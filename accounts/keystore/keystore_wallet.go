@@ -21,18 +21,57 @@
 package keystore
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"math/big"
+	"sync"
 
 	"github.com/klaytn/klaytn"
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
 )
 
+// maxSelfDerive is the maximum number of accounts discovered by a self-derivation.
+const maxSelfDerive = 100
+
 // keystoreWallet implements the accounts.Wallet interface for the original
-// keystore.
+// keystore. If the key file has an HD seed, the accounts derived from it are
+// contained in the wallet as well.
 type keystoreWallet struct {
 	account  accounts.Account // Single account contained in this wallet
 	keystore *KeyStore        // Keystore where the account originates from
+
+	derived     []accounts.Account                         // Accounts derived from the HD seed, in the order of pinning
+	paths       map[common.Address]accounts.DerivationPath // Derivation paths of the derived accounts
+	deriveBase  accounts.DerivationPath                    // Base path to discover the derived accounts from
+	deriveChain klaytn.ChainReader                         // Blockchain state reader to discover used accounts with
+	deriving    bool                                       // Whether a self-derivation is in progress
+	lock        sync.RWMutex                               // Lock protecting the derived accounts
+}
+
+// pinned is a derived account pinned in a wallet.
+type pinned struct {
+	address common.Address
+	path    accounts.DerivationPath
+}
+
+// newWallet wraps the account into a wallet, restoring the derived accounts pinned in
+// the previous wallet of the account. The caller should hold ks.mu.
+func (ks *KeyStore) newWallet(account accounts.Account) *keystoreWallet {
+	w := &keystoreWallet{account: account, keystore: ks}
+	for _, p := range ks.pinned[account.Address] {
+		if _, ok := w.paths[p.address]; ok {
+			continue
+		}
+		if w.paths == nil {
+			w.paths = make(map[common.Address]accounts.DerivationPath)
+		}
+		w.paths[p.address] = p.path
+		w.derived = append(w.derived, accounts.Account{Address: p.address, URL: account.URL})
+	}
+	return w
 }
 
 // URL implements accounts.Wallet, returning the URL of the account within.
@@ -52,35 +91,163 @@ func (w *keystoreWallet) Status() (string, error) {
 	return "Locked", nil
 }
 
-// Open implements accounts.Wallet, but is a noop for plain wallets since there
-// is no connection or decryption step necessary to access the list of accounts.
-func (w *keystoreWallet) Open(passphrase string) error { return nil }
+// Open implements accounts.Wallet. There is no connection or decryption step
+// necessary to access the list of accounts, but a wallet having an HD seed is
+// announced as opened so that its derived accounts can be discovered.
+func (w *keystoreWallet) Open(passphrase string) error {
+	if w.keystore.hasHDSeed(w.account) {
+		go w.keystore.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletOpened})
+	}
+	return nil
+}
 
 // Close implements accounts.Wallet, but is a noop for plain wallets since is no
 // meaningful open operation.
 func (w *keystoreWallet) Close() error { return nil }
 
 // Accounts implements accounts.Wallet, returning an account list consisting of
-// a single account that the plain kestore wallet contains.
+// the account of the key file, followed by the accounts pinned from its HD seed.
 func (w *keystoreWallet) Accounts() []accounts.Account {
-	return []accounts.Account{w.account}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	accs := make([]accounts.Account, 0, 1+len(w.derived))
+	return append(append(accs, w.account), w.derived...)
 }
 
 // Contains implements accounts.Wallet, returning whether a particular account is
 // or is not wrapped by this wallet instance.
 func (w *keystoreWallet) Contains(account accounts.Account) bool {
-	return account.Address == w.account.Address && (account.URL == (accounts.URL{}) || account.URL == w.account.URL)
+	_, err := w.lookup(account)
+	return err == nil
+}
+
+// lookup returns the derivation path of the account if it is derived from the HD
+// seed, or nil if it is the account of the key file.
+func (w *keystoreWallet) lookup(account accounts.Account) (accounts.DerivationPath, error) {
+	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
+		return nil, accounts.ErrUnknownAccount
+	}
+	if account.Address == w.account.Address {
+		return nil, nil
+	}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	if path, ok := w.paths[account.Address]; ok {
+		return path, nil
+	}
+	return nil, accounts.ErrUnknownAccount
 }
 
-// Derive implements accounts.Wallet, but is a noop for plain wallets since there
-// is no notion of hierarchical account derivation for plain keystore accounts.
+// Derive implements accounts.Wallet, deriving the account of the path from the HD
+// seed of the key file, which should be unlocked. If pin is set, the account is
+// added to the list of the tracked accounts. The pinned accounts are kept by the
+// keystore while the key file exists, but are not persisted, so they should be
+// derived again after a restart. Use KeyStore.DeriveAccounts to store them.
 func (w *keystoreWallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
-	return accounts.Account{}, accounts.ErrNotSupported
+	key, err := w.keystore.deriveUnlockedKey(w.account.Address, path)
+	if err == ErrNoHDSeed {
+		return accounts.Account{}, accounts.ErrNotSupported
+	}
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	defer zeroKey(key)
+
+	account := accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey), URL: w.account.URL}
+	if pin {
+		w.pin(account, path)
+	}
+	return account, nil
 }
 
-// SelfDerive implements accounts.Wallet, but is a noop for plain wallets since
-// there is no notion of hierarchical account derivation for plain keystore accounts.
-func (w *keystoreWallet) SelfDerive(base accounts.DerivationPath, chain klaytn.ChainReader) {}
+// pin adds the derived account to the list of the tracked accounts.
+func (w *keystoreWallet) pin(account accounts.Account, path accounts.DerivationPath) {
+	if account.Address == w.account.Address {
+		return
+	}
+	w.lock.Lock()
+	if _, ok := w.paths[account.Address]; ok {
+		w.lock.Unlock()
+		return
+	}
+	if w.paths == nil {
+		w.paths = make(map[common.Address]accounts.DerivationPath)
+	}
+	path = append(accounts.DerivationPath{}, path...)
+	w.paths[account.Address] = path
+	w.derived = append(w.derived, account)
+	w.lock.Unlock()
+
+	// Keep the pin in the keystore not to lose it when the wallet is refreshed.
+	// ks.mu is taken after w.lock is released, since refreshWallets takes them reversely.
+	w.keystore.mu.Lock()
+	w.keystore.pinned[w.account.Address] = append(w.keystore.pinned[w.account.Address], pinned{account.Address, path})
+	w.keystore.mu.Unlock()
+}
+
+// SelfDerive implements accounts.Wallet, setting the base path to discover the
+// accounts derived from the HD seed of the key file. The accounts are discovered
+// once the key file is unlocked, until the first one having neither nonce nor
+// balance. A nil chain disables the discovery.
+func (w *keystoreWallet) SelfDerive(base accounts.DerivationPath, chain klaytn.ChainReader) {
+	w.lock.Lock()
+	w.deriveBase = append(accounts.DerivationPath{}, base...)
+	w.deriveChain = chain
+	w.lock.Unlock()
+
+	go w.selfDerive()
+}
+
+// selfDerive discovers the used accounts derived from the HD seed and pins them.
+func (w *keystoreWallet) selfDerive() {
+	w.lock.Lock()
+	if w.deriving || w.deriveChain == nil || len(w.deriveBase) == 0 {
+		w.lock.Unlock()
+		return
+	}
+	reader, ok := w.deriveChain.(klaytn.ChainStateReader)
+	if !ok {
+		w.lock.Unlock()
+		logger.Warn("Self-derivation requires a blockchain state reader", "url", w.account.URL)
+		return
+	}
+	w.deriving = true
+	path := append(accounts.DerivationPath{}, w.deriveBase...)
+	w.lock.Unlock()
+
+	defer func() {
+		w.lock.Lock()
+		w.deriving = false
+		w.lock.Unlock()
+	}()
+
+	ctx := context.Background()
+	for i := 0; i < maxSelfDerive; i++ {
+		account, err := w.Derive(path, false)
+		if err != nil {
+			// The key file is not unlocked or has no HD seed
+			return
+		}
+		nonce, err := reader.NonceAt(ctx, account.Address, nil)
+		if err != nil {
+			logger.Warn("Self-derivation failed to get the nonce", "address", account.Address, "err", err)
+			return
+		}
+		balance, err := reader.BalanceAt(ctx, account.Address, nil)
+		if err != nil {
+			logger.Warn("Self-derivation failed to get the balance", "address", account.Address, "err", err)
+			return
+		}
+		if nonce == 0 && balance.Sign() == 0 {
+			return
+		}
+		w.pin(account, path)
+		logger.Info("Self-derived a used account", "address", account.Address, "path", path)
+		path[len(path)-1]++
+	}
+}
 
 // SignHash implements accounts.Wallet, attempting to sign the given hash with
 // the given account. If the wallet does not wrap this particular account, an
@@ -88,11 +255,17 @@ func (w *keystoreWallet) SelfDerive(base accounts.DerivationPath, chain klaytn.C
 // able to sign via our shared keystore backend).
 func (w *keystoreWallet) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
 	// Make sure the requested account is contained within
-	if account.Address != w.account.Address {
-		return nil, accounts.ErrUnknownAccount
+	path, err := w.lookup(account)
+	if err != nil {
+		return nil, err
 	}
-	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
-		return nil, accounts.ErrUnknownAccount
+	if path != nil {
+		key, err := w.keystore.deriveUnlockedKey(w.account.Address, path)
+		if err != nil {
+			return nil, err
+		}
+		defer zeroKey(key)
+		return crypto.Sign(hash, key)
 	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignHash(account, hash)
@@ -104,11 +277,17 @@ func (w *keystoreWallet) SignHash(account accounts.Account, hash []byte) ([]byte
 // be able to sign via our shared keystore backend).
 func (w *keystoreWallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	// Make sure the requested account is contained within
-	if account.Address != w.account.Address {
-		return nil, accounts.ErrUnknownAccount
+	path, err := w.lookup(account)
+	if err != nil {
+		return nil, err
 	}
-	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
-		return nil, accounts.ErrUnknownAccount
+	if path != nil {
+		key, err := w.keystore.deriveUnlockedKey(w.account.Address, path)
+		if err != nil {
+			return nil, err
+		}
+		defer zeroKey(key)
+		return signTxWithKey(tx, chainID, key, false)
 	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignTx(account, tx, chainID)
@@ -120,11 +299,17 @@ func (w *keystoreWallet) SignTx(account accounts.Account, tx *types.Transaction,
 // (even though in theory we may be able to sign via our shared keystore backend).
 func (w *keystoreWallet) SignTxAsFeePayer(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	// Make sure the requested account is contained within
-	if account.Address != w.account.Address {
-		return nil, accounts.ErrUnknownAccount
+	path, err := w.lookup(account)
+	if err != nil {
+		return nil, err
 	}
-	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
-		return nil, accounts.ErrUnknownAccount
+	if path != nil {
+		key, err := w.keystore.deriveUnlockedKey(w.account.Address, path)
+		if err != nil {
+			return nil, err
+		}
+		defer zeroKey(key)
+		return signTxWithKey(tx, chainID, key, true)
 	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignTxAsFeePayer(account, tx, chainID)
@@ -134,11 +319,17 @@ func (w *keystoreWallet) SignTxAsFeePayer(account accounts.Account, tx *types.Tr
 // given hash with the given account using passphrase as extra authentication.
 func (w *keystoreWallet) SignHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
 	// Make sure the requested account is contained within
-	if account.Address != w.account.Address {
-		return nil, accounts.ErrUnknownAccount
+	path, err := w.lookup(account)
+	if err != nil {
+		return nil, err
 	}
-	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
-		return nil, accounts.ErrUnknownAccount
+	if path != nil {
+		key, err := w.keystore.deriveKeyWithPassphrase(w.account, passphrase, path)
+		if err != nil {
+			return nil, err
+		}
+		defer zeroKey(key)
+		return crypto.Sign(hash, key)
 	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignHashWithPassphrase(account, passphrase, hash)
@@ -148,11 +339,17 @@ func (w *keystoreWallet) SignHashWithPassphrase(account accounts.Account, passph
 // transaction with the given account using passphrase as extra authentication.
 func (w *keystoreWallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	// Make sure the requested account is contained within
-	if account.Address != w.account.Address {
-		return nil, accounts.ErrUnknownAccount
+	path, err := w.lookup(account)
+	if err != nil {
+		return nil, err
 	}
-	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
-		return nil, accounts.ErrUnknownAccount
+	if path != nil {
+		key, err := w.keystore.deriveKeyWithPassphrase(w.account, passphrase, path)
+		if err != nil {
+			return nil, err
+		}
+		defer zeroKey(key)
+		return signTxWithKey(tx, chainID, key, false)
 	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignTxWithPassphrase(account, passphrase, tx, chainID)
@@ -162,12 +359,29 @@ func (w *keystoreWallet) SignTxWithPassphrase(account accounts.Account, passphra
 // transaction as a fee payer with the given account using passphrase as extra authentication.
 func (w *keystoreWallet) SignTxAsFeePayerWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	// Make sure the requested account is contained within
-	if account.Address != w.account.Address {
-		return nil, accounts.ErrUnknownAccount
+	path, err := w.lookup(account)
+	if err != nil {
+		return nil, err
 	}
-	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
-		return nil, accounts.ErrUnknownAccount
+	if path != nil {
+		key, err := w.keystore.deriveKeyWithPassphrase(w.account, passphrase, path)
+		if err != nil {
+			return nil, err
+		}
+		defer zeroKey(key)
+		return signTxWithKey(tx, chainID, key, true)
 	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignTxAsFeePayerWithPassphrase(account, passphrase, tx, chainID)
 }
+
+// signTxWithKey signs the transaction with the key derived from the HD seed.
+func signTxWithKey(tx *types.Transaction, chainID *big.Int, key *ecdsa.PrivateKey, feePayer bool) (*types.Transaction, error) {
+	if chainID == nil {
+		return nil, ErrChainIdNil
+	}
+	if feePayer {
+		return types.SignTxAsFeePayer(tx, types.LatestSignerForChainID(chainID), key)
+	}
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+}
@@ -32,11 +32,14 @@ type KeyV4 struct {
 	Address common.Address
 	// We only store privkey as pubkey/address can be derived from it.
 	PrivateKeys [][]*ecdsa.PrivateKey
+	// BIP-39 seed of the HD wallet which the private keys are derived from, if any.
+	Seed []byte
 }
 
 type plainKeyJSONV4 struct {
 	Address     string     `json:"address"`
 	PrivateKeys [][]string `json:"privatekeys"`
+	Seed        string     `json:"seed,omitempty"`
 	Id          string     `json:"id"`
 	Version     int        `json:"version"`
 }
@@ -44,6 +47,7 @@ type plainKeyJSONV4 struct {
 type encryptedKeyJSONV4 struct {
 	Address string         `json:"address"`
 	Keyring [][]cryptoJSON `json:"keyring"`
+	HDSeed  *cryptoJSON    `json:"hdseed,omitempty"`
 	Id      string         `json:"id"`
 	Version int            `json:"version"`
 }
@@ -51,6 +55,7 @@ type encryptedKeyJSONV4 struct {
 type encryptedKeyJSONV4Single struct {
 	Address string       `json:"address"`
 	Keyring []cryptoJSON `json:"keyring"`
+	HDSeed  *cryptoJSON  `json:"hdseed,omitempty"`
 	Id      string       `json:"id"`
 	Version int          `json:"version"`
 }
@@ -68,6 +73,7 @@ func (k *KeyV4) MarshalJSON() (j []byte, err error) {
 	jStruct := plainKeyJSONV4{
 		Address:     hex.EncodeToString(k.Address.Bytes()),
 		PrivateKeys: privateKeys,
+		Seed:        common.Bytes2Hex(k.Seed),
 		Id:          k.Id.String(),
 		Version:     4,
 	}
@@ -99,6 +105,12 @@ func (k *KeyV4) UnmarshalJSON(j []byte) (err error) {
 		}
 	}
 
+	if keyJSON.Seed != "" {
+		if k.Seed, err = hex.DecodeString(keyJSON.Seed); err != nil {
+			return err
+		}
+	}
+
 	k.Address = common.BytesToAddress(addr)
 
 	return nil
@@ -137,4 +149,5 @@ func (k *KeyV4) ResetPrivateKey() {
 			zeroKey(key)
 		}
 	}
+	zeroBytes(k.Seed)
 }
@@ -0,0 +1,132 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	mnemonicSeedIterations = 2048
+	mnemonicSeedLen        = 64
+)
+
+var (
+	ErrInvalidEntropyLen = errors.New("entropy length should be a multiple of 32 bits within [128, 256]")
+	ErrInvalidMnemonic   = errors.New("invalid mnemonic")
+
+	mnemonicWordIndex = make(map[string]int, len(mnemonicWordList))
+)
+
+func init() {
+	for i, word := range mnemonicWordList {
+		mnemonicWordIndex[word] = i
+	}
+}
+
+// NewMnemonic generates a BIP-39 mnemonic with the given bits of random entropy.
+// The number of words is 12, 15, 18, 21 or 24 for 128, 160, 192, 224 or 256 bits.
+func NewMnemonic(bits int) (string, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", ErrInvalidEntropyLen
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return mnemonicFromEntropy(entropy)
+}
+
+// mnemonicFromEntropy encodes the entropy and its checksum into the words of the word list.
+func mnemonicFromEntropy(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", ErrInvalidEntropyLen
+	}
+	checksumBits := bits / 32
+	hash := sha256.Sum256(entropy)
+
+	// The entropy followed by the first checksumBits bits of its hash, split into 11 bits each
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, uint(checksumBits))
+	data.Or(data, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	numWords := (bits + checksumBits) / 11
+	words := make([]string, numWords)
+	mask := big.NewInt(2047)
+	for i := numWords - 1; i >= 0; i-- {
+		words[i] = mnemonicWordList[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// entropyFromMnemonic decodes the mnemonic into its entropy, verifying the checksum.
+func entropyFromMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	numWords := len(words)
+	if numWords < 12 || numWords > 24 || numWords%3 != 0 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		index, ok := mnemonicWordIndex[word]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(index)))
+	}
+
+	checksumBits := numWords / 3
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1)).Int64()
+	data.Rsh(data, uint(checksumBits))
+
+	entropy := make([]byte, (numWords*11-checksumBits)/8)
+	data.FillBytes(entropy)
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-checksumBits)) != checksum {
+		return nil, ErrInvalidMnemonic
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic returns an error if the mnemonic is not a valid BIP-39 mnemonic
+// of the English word list, or its checksum does not match.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := entropyFromMnemonic(mnemonic)
+	return err
+}
+
+// NewSeedFromMnemonic validates the mnemonic and derives the BIP-39 seed from it with
+// the given passphrase. Both of them are used without the NFKD normalization, so a
+// passphrase having non-ASCII characters should be normalized by the caller.
+func NewSeedFromMnemonic(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), mnemonicSeedIterations, mnemonicSeedLen, sha512.New), nil
+}
@@ -21,6 +21,7 @@
 package accounts
 
 import (
+	"io"
 	"reflect"
 	"sort"
 	"sync"
@@ -84,11 +85,23 @@ func NewManager(backends ...Backend) *Manager {
 	return am
 }
 
-// Close terminates the account manager's internal notification processes.
+// Close terminates the account manager's internal notification processes,
+// and closes the backends holding resources such as the connection to an external signer.
 func (am *Manager) Close() error {
 	errc := make(chan error)
 	am.quit <- errc
-	return <-errc
+	err := <-errc
+
+	for _, backends := range am.backends {
+		for _, backend := range backends {
+			if closer, ok := backend.(io.Closer); ok {
+				if cerr := closer.Close(); cerr != nil && err == nil {
+					err = cerr
+				}
+			}
+		}
+	}
+	return err
 }
 
 // update is the wallet event loop listening for notifications from the backends
@@ -0,0 +1,30 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package typeddata implements the hashing of typed structured data specified in EIP-712,
+which is signed by klay_signTypedData, eth_signTypedData_v4 and personal_signTypedData.
+
+A TypedData consists of the struct types, the primary type, the domain and the message.
+The signed hash is keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)), where
+the domain separator is the hashStruct of the domain as an EIP712Domain struct.
+Nested structs, arrays of any dimension, string, bytes, bytesN, intN, uintN, bool and address are supported.
+
+Source Files
+
+  - typeddata.go : Defines TypedData and the encoding of the types and the data
+*/
+package typeddata
@@ -0,0 +1,507 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package typeddata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/common/math"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// DomainType is the name of the type of the domain separator.
+const DomainType = "EIP712Domain"
+
+// maxDepth limits the depth of the nested structs and arrays to encode.
+const maxDepth = 64
+
+var (
+	ErrPrimaryTypeMissing = errors.New("primary type is not defined")
+	ErrDomainTypeMissing  = errors.New("EIP712Domain type is not defined")
+	errMaxDepth           = errors.New("too deeply nested data")
+
+	// maxSafeFloat is the largest integer up to which every integer is exactly representable as a float64.
+	maxSafeFloat = float64(1 << 53)
+
+	typedDataPrefix = []byte{0x19, 0x01}
+
+	// typeNameRegexp matches the name of a struct type, and the element type of an array is matched without the suffix.
+	typeNameRegexp  = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	arraySuffixExpr = regexp.MustCompile(`\[([0-9]*)\]$`)
+)
+
+// Type is a field of a struct type, whose type is either another struct type,
+// an array type or an atomic type like uint256 and address.
+type Type struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps the name of a struct type to its fields.
+type Types map[string][]Type
+
+// TypedDataDomain is the domain separator of typed data.
+// Only the fields defined in the EIP712Domain type are encoded.
+type TypedDataDomain struct {
+	Name              string                `json:"name"`
+	Version           string                `json:"version"`
+	ChainId           *math.HexOrDecimal256 `json:"chainId"`
+	VerifyingContract string                `json:"verifyingContract"`
+	Salt              string                `json:"salt"`
+}
+
+// UnmarshalJSON accepts the chain ID as a JSON number as well as a hex or decimal string,
+// since dApps usually give the chain ID as a number.
+func (domain *TypedDataDomain) UnmarshalJSON(input []byte) error {
+	type typedDataDomain TypedDataDomain
+	var dec struct {
+		typedDataDomain
+		ChainId json.RawMessage `json:"chainId"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*domain = TypedDataDomain(dec.typedDataDomain)
+	domain.ChainId = nil
+	if len(dec.ChainId) == 0 || string(dec.ChainId) == "null" {
+		return nil
+	}
+	chainId := new(math.HexOrDecimal256)
+	text := bytes.Trim(dec.ChainId, `"`)
+	if err := chainId.UnmarshalText(text); err != nil {
+		return fmt.Errorf("invalid chainId: %v", err)
+	}
+	domain.ChainId = chainId
+	return nil
+}
+
+// Map returns the domain as a message of the EIP712Domain type without the empty fields.
+func (domain *TypedDataDomain) Map() map[string]interface{} {
+	m := map[string]interface{}{}
+	if domain.Name != "" {
+		m["name"] = domain.Name
+	}
+	if domain.Version != "" {
+		m["version"] = domain.Version
+	}
+	if domain.ChainId != nil {
+		m["chainId"] = (*big.Int)(domain.ChainId)
+	}
+	if domain.VerifyingContract != "" {
+		m["verifyingContract"] = domain.VerifyingContract
+	}
+	if domain.Salt != "" {
+		m["salt"] = domain.Salt
+	}
+	return m
+}
+
+// TypedData is structured data to sign as specified in EIP-712, which is the
+// input of eth_signTypedData_v4.
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      TypedDataDomain        `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// UnmarshalJSON decodes the numbers of the message as json.Number, not to lose
+// the precision of the integers larger than 2^53 by decoding them as float64.
+func (td *TypedData) UnmarshalJSON(input []byte) error {
+	type typedData TypedData
+	var dec typedData
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&dec); err != nil {
+		return err
+	}
+	*td = TypedData(dec)
+	return nil
+}
+
+// SigningHash returns the hash to sign for the typed data, which is
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)). If the primary type is EIP712Domain, the hash of the message is omitted.
+func (td *TypedData) SigningHash() (common.Hash, error) {
+	if err := td.Validate(); err != nil {
+		return common.Hash{}, err
+	}
+	domainSeparator, err := td.HashStruct(DomainType, td.Domain.Map())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode the domain: %v", err)
+	}
+	if td.PrimaryType == DomainType {
+		return crypto.Keccak256Hash(typedDataPrefix, domainSeparator), nil
+	}
+	messageHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode the message: %v", err)
+	}
+	return crypto.Keccak256Hash(typedDataPrefix, domainSeparator, messageHash), nil
+}
+
+// Validate checks that the primary type and the domain type are defined, and that
+// the type of every field is either a defined struct type or an atomic type.
+func (td *TypedData) Validate() error {
+	if _, ok := td.Types[DomainType]; !ok {
+		return ErrDomainTypeMissing
+	}
+	if _, ok := td.Types[td.PrimaryType]; !ok {
+		return ErrPrimaryTypeMissing
+	}
+	for name, fields := range td.Types {
+		if !typeNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid type name %q", name)
+		}
+		seen := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			if field.Name == "" {
+				return fmt.Errorf("type %s has a field without a name", name)
+			}
+			if seen[field.Name] {
+				return fmt.Errorf("type %s has a duplicated field %s", name, field.Name)
+			}
+			seen[field.Name] = true
+
+			typ := field.Type
+			for arraySuffixExpr.MatchString(typ) {
+				typ = arraySuffixExpr.ReplaceAllString(typ, "")
+			}
+			if _, ok := td.Types[typ]; ok {
+				continue
+			}
+			if !isAtomicType(typ) {
+				return fmt.Errorf("unknown type %q of field %s.%s", field.Type, name, field.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// HashStruct returns hashStruct(data) = keccak256(typeHash ‖ encodeData(data)) of the given struct type.
+func (td *TypedData) HashStruct(primaryType string, data map[string]interface{}) (hexutil.Bytes, error) {
+	encoded, err := td.encodeData(primaryType, data, 1)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// TypeHash returns keccak256(encodeType(primaryType)).
+func (td *TypedData) TypeHash(primaryType string) hexutil.Bytes {
+	return crypto.Keccak256(td.EncodeType(primaryType))
+}
+
+// EncodeType returns the encoding of the struct type, such as
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)", where the referenced struct types are appended in alphabetical order.
+func (td *TypedData) EncodeType(primaryType string) []byte {
+	deps := td.dependencies(primaryType, nil)
+	sort.Strings(deps[1:])
+
+	var buffer bytes.Buffer
+	for _, dep := range deps {
+		buffer.WriteString(dep)
+		buffer.WriteString("(")
+		for i, field := range td.Types[dep] {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+			buffer.WriteString(field.Type)
+			buffer.WriteString(" ")
+			buffer.WriteString(field.Name)
+		}
+		buffer.WriteString(")")
+	}
+	return buffer.Bytes()
+}
+
+// dependencies returns the given struct type followed by the struct types it references.
+func (td *TypedData) dependencies(primaryType string, found []string) []string {
+	primaryType = elementType(primaryType)
+	for _, dep := range found {
+		if dep == primaryType {
+			return found
+		}
+	}
+	if _, ok := td.Types[primaryType]; !ok {
+		return found
+	}
+	found = append(found, primaryType)
+	for _, field := range td.Types[primaryType] {
+		found = td.dependencies(field.Type, found)
+	}
+	return found
+}
+
+// encodeData returns typeHash ‖ enc(value₁) ‖ … ‖ enc(valueₙ) of the struct.
+func (td *TypedData) encodeData(primaryType string, data map[string]interface{}, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return nil, errMaxDepth
+	}
+	fields, ok := td.Types[primaryType]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", primaryType)
+	}
+	if len(data) > len(fields) {
+		return nil, fmt.Errorf("%s has more fields than defined", primaryType)
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(td.TypeHash(primaryType))
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing value of field %s.%s", primaryType, field.Name)
+		}
+		encoded, err := td.encodeValue(field.Type, value, depth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s.%s: %v", primaryType, field.Name, err)
+		}
+		buffer.Write(encoded)
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeValue returns the 32-byte encoding of a field value. Structs are encoded
+// as their hashStruct, arrays as the hash of the concatenated encodings of the elements,
+// and string and bytes as the hash of their contents.
+func (td *TypedData) encodeValue(typ string, value interface{}, depth int) ([]byte, error) {
+	if match := arraySuffixExpr.FindStringSubmatch(typ); match != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not an array", value)
+		}
+		if match[1] != "" {
+			length, err := strconv.Atoi(match[1])
+			if err != nil || length != len(items) {
+				return nil, fmt.Errorf("array length %d does not match %s", len(items), typ)
+			}
+		}
+		if depth+1 > maxDepth {
+			return nil, errMaxDepth
+		}
+		elemType := typ[:len(typ)-len(match[0])]
+		var buffer bytes.Buffer
+		for _, item := range items {
+			encoded, err := td.encodeValue(elemType, item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			buffer.Write(encoded)
+		}
+		return crypto.Keccak256(buffer.Bytes()), nil
+	}
+
+	if _, ok := td.Types[typ]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not a %s struct", value, typ)
+		}
+		encoded, err := td.encodeData(typ, data, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+	return encodePrimitiveValue(typ, value)
+}
+
+// encodePrimitiveValue encodes a value of an atomic type, string or bytes.
+func encodePrimitiveValue(typ string, value interface{}) ([]byte, error) {
+	if !isAtomicType(typ) {
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	switch {
+	case typ == "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a string", value)
+		}
+		return crypto.Keccak256([]byte(str)), nil
+
+	case typ == "bytes":
+		b, err := parseBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(b), nil
+
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a bool", value)
+		}
+		if b {
+			return math.PaddedBigBytes(common.Big1, 32), nil
+		}
+		return make([]byte, 32), nil
+
+	case typ == "address":
+		str, ok := value.(string)
+		if !ok || !common.IsHexAddress(str) {
+			return nil, fmt.Errorf("%v is not an address", value)
+		}
+		return common.LeftPadBytes(common.HexToAddress(str).Bytes(), 32), nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		size, _ := strconv.Atoi(typ[len("bytes"):])
+		b, err := parseBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != size {
+			return nil, fmt.Errorf("%d bytes is given for %s", len(b), typ)
+		}
+		return common.RightPadBytes(b, 32), nil
+
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"):
+		signed := strings.HasPrefix(typ, "int")
+		bits, _ := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+		n, err := parseInteger(value)
+		if err != nil {
+			return nil, err
+		}
+		if !inRange(n, bits, signed) {
+			return nil, fmt.Errorf("%v overflows %s", n, typ)
+		}
+		return math.U256Bytes(n), nil
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+// isAtomicType returns true if the type is one of the atomic types, string or bytes.
+func isAtomicType(typ string) bool {
+	switch typ {
+	case "address", "bool", "string", "bytes":
+		return true
+	}
+	if strings.HasPrefix(typ, "bytes") {
+		size, err := strconv.Atoi(typ[len("bytes"):])
+		return err == nil && size >= 1 && size <= 32 && !strings.HasPrefix(typ[len("bytes"):], "0")
+	}
+	var bits string
+	if strings.HasPrefix(typ, "uint") {
+		bits = typ[len("uint"):]
+	} else if strings.HasPrefix(typ, "int") {
+		bits = typ[len("int"):]
+	} else {
+		return false
+	}
+	size, err := strconv.Atoi(bits)
+	return err == nil && size >= 8 && size <= 256 && size%8 == 0 && !strings.HasPrefix(bits, "0")
+}
+
+// elementType strips the array suffixes from the type.
+func elementType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+// inRange returns true if n fits in an integer of the given bits.
+func inRange(n *big.Int, bits int, signed bool) bool {
+	if !signed {
+		return n.Sign() >= 0 && n.BitLen() <= bits
+	}
+	limit := new(big.Int).Lsh(common.Big1, uint(bits-1))
+	if n.Sign() >= 0 {
+		return n.Cmp(limit) < 0
+	}
+	return n.Cmp(new(big.Int).Neg(limit)) >= 0
+}
+
+// parseInteger converts a decoded JSON value into a new big.Int. A string is either
+// a decimal or a 0x-prefixed hexadecimal number. A float64 is rejected if it is larger
+// than 2^53 in magnitude, since the integer might have been rounded when it was decoded.
+func parseInteger(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("nil integer")
+		}
+		return new(big.Int).Set(v), nil
+	case *math.HexOrDecimal256:
+		if v == nil {
+			return nil, errors.New("nil integer")
+		}
+		return new(big.Int).Set((*big.Int)(v)), nil
+	case string:
+		str := strings.TrimSpace(v)
+		negative := strings.HasPrefix(str, "-")
+		n, ok := math.ParseBig256(strings.TrimPrefix(str, "-"))
+		if !ok || str == "" {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		if negative {
+			n.Neg(n)
+		}
+		return n, nil
+	case float64:
+		if v > maxSafeFloat || v < -maxSafeFloat {
+			return nil, fmt.Errorf("%v is too large to be an exact integer, use a string instead", v)
+		}
+		n, accuracy := big.NewFloat(v).Int(nil)
+		if accuracy != big.Exact {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		return n, nil
+	case json.Number:
+		if n, err := parseInteger(v.String()); err == nil {
+			return n, nil
+		}
+		// A number in the exponent notation, such as 1e18
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		return parseInteger(f)
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	}
+	return nil, fmt.Errorf("%v is not an integer", value)
+}
+
+// parseBytes converts a 0x-prefixed hex string or a byte slice into bytes.
+func parseBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case hexutil.Bytes:
+		return v, nil
+	case string:
+		b, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytes %q: %v", v, err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("%v is not bytes", value)
+}
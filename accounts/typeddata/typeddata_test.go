@@ -0,0 +1,285 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package typeddata
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mailJSON is the example of EIP-712.
+const mailJSON = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+// mailArraysJSON is the example of eth_signTypedData_v4 with arrays of structs and addresses.
+const mailArraysJSON = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallets", "type": "address[]"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person[]"},
+			{"name": "contents", "type": "string"}
+		],
+		"Group": [
+			{"name": "name", "type": "string"},
+			{"name": "members", "type": "Person[]"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {
+			"name": "Cow",
+			"wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"]
+		},
+		"to": [{
+			"name": "Bob",
+			"wallets": ["0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB", "0xB0BdaBea57B0BDABeA57b0bdABEA57b0BDabEa57", "0xB0B0b0b0b0b0B000000000000000000000000000"]
+		}],
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func parseTypedData(t *testing.T, data string) *TypedData {
+	td := new(TypedData)
+	require.NoError(t, json.Unmarshal([]byte(data), td))
+	return td
+}
+
+func TestTypedData_Mail(t *testing.T) {
+	td := parseTypedData(t, mailJSON)
+
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", string(td.EncodeType("Mail")))
+	assert.Equal(t, "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2", td.TypeHash("Mail").String())
+
+	domainSeparator, err := td.HashStruct(DomainType, td.Domain.Map())
+	require.NoError(t, err)
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", domainSeparator.String())
+
+	messageHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	require.NoError(t, err)
+	assert.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", messageHash.String())
+
+	hash, err := td.SigningHash()
+	require.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hash.String())
+
+	// The signature of the example is made by the key keccak256("cow")
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), crypto.PubkeyToAddress(key.PublicKey))
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b9156201",
+		hexutil.Encode(sig))
+}
+
+func TestTypedData_Arrays(t *testing.T) {
+	td := parseTypedData(t, mailArraysJSON)
+
+	assert.Equal(t, "Mail(Person from,Person[] to,string contents)Person(string name,address[] wallets)", string(td.EncodeType("Mail")))
+	assert.Equal(t, "Group(string name,Person[] members)Person(string name,address[] wallets)", string(td.EncodeType("Group")))
+
+	hash, err := td.SigningHash()
+	require.NoError(t, err)
+	assert.Equal(t, "0xa85c2e2b118698e88db68a8105b794a8cc7cec074e89ef991cb4f5f533819cc2", hash.String())
+}
+
+func TestTypedData_Values(t *testing.T) {
+	td := &TypedData{
+		Types: Types{
+			DomainType: {{Name: "name", Type: "string"}},
+			"Values": {
+				{Name: "u8", Type: "uint8"},
+				{Name: "i16", Type: "int16"},
+				{Name: "b4", Type: "bytes4"},
+				{Name: "data", Type: "bytes"},
+				{Name: "flag", Type: "bool"},
+				{Name: "matrix", Type: "uint256[2][]"},
+			},
+		},
+		PrimaryType: "Values",
+		Domain:      TypedDataDomain{Name: "test"},
+	}
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"u8":     float64(255),
+			"i16":    "-32768",
+			"b4":     "0x01020304",
+			"data":   "0x",
+			"flag":   true,
+			"matrix": []interface{}{[]interface{}{"0x1", "2"}},
+		}
+	}
+
+	td.Message = valid()
+	_, err := td.SigningHash()
+	assert.NoError(t, err)
+
+	// An integer is encoded in two's complement
+	encoded, err := td.encodeValue("int16", "-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff").Bytes(), encoded)
+
+	invalids := []struct {
+		field string
+		value interface{}
+	}{
+		{"u8", float64(256)},
+		{"u8", float64(-1)},
+		{"u8", 1.5},
+		{"i16", "32768"},
+		{"b4", "0x0102"},
+		{"data", "0x0"},
+		{"flag", "true"},
+		{"matrix", []interface{}{[]interface{}{"1"}}},
+		{"matrix", "1"},
+	}
+	for i, tc := range invalids {
+		td.Message = valid()
+		td.Message[tc.field] = tc.value
+		_, err := td.SigningHash()
+		assert.Error(t, err, "invalids[%d] failed", i)
+	}
+
+	// A missing field and an extra field
+	td.Message = valid()
+	delete(td.Message, "flag")
+	_, err = td.SigningHash()
+	assert.Error(t, err)
+	td.Message = valid()
+	td.Message["extra"] = true
+	_, err = td.SigningHash()
+	assert.Error(t, err)
+}
+
+func TestTypedData_Numbers(t *testing.T) {
+	// The numbers of the message are decoded without losing precision
+	var td TypedData
+	require.NoError(t, json.Unmarshal([]byte(`{"message": {"large": 18446744073709551617, "exp": 1e3}}`), &td))
+	large, err := td.encodeValue("uint256", td.Message["large"], 1)
+	require.NoError(t, err)
+	expected, _ := new(big.Int).SetString("18446744073709551617", 10)
+	assert.Equal(t, common.BigToHash(expected).Bytes(), large)
+	exp, err := td.encodeValue("uint256", td.Message["exp"], 1)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(1000)).Bytes(), exp)
+
+	// A float64 larger than 2^53 might have been rounded
+	_, err = td.encodeValue("uint256", float64(1<<53), 1)
+	assert.NoError(t, err)
+	_, err = td.encodeValue("uint256", float64(1<<53+2), 1)
+	assert.Error(t, err)
+	_, err = td.encodeValue("int256", -float64(1<<60), 1)
+	assert.Error(t, err)
+}
+
+func TestTypedData_Validate(t *testing.T) {
+	td := parseTypedData(t, mailJSON)
+	assert.NoError(t, td.Validate())
+
+	td.PrimaryType = "Letter"
+	assert.Equal(t, ErrPrimaryTypeMissing, td.Validate())
+
+	td = parseTypedData(t, mailJSON)
+	delete(td.Types, DomainType)
+	assert.Equal(t, ErrDomainTypeMissing, td.Validate())
+
+	for _, typ := range []string{"Human", "uint7", "uint264", "bytes33", "bytes0", "address[", "int08"} {
+		td = parseTypedData(t, mailJSON)
+		td.Types["Person"][0].Type = typ
+		assert.Error(t, td.Validate(), typ)
+	}
+
+	// A domain field which is defined but not given
+	td = parseTypedData(t, mailJSON)
+	td.Domain.Version = ""
+	_, err := td.SigningHash()
+	assert.Error(t, err)
+
+	// The primary type EIP712Domain signs only the domain separator
+	td = parseTypedData(t, mailJSON)
+	td.PrimaryType = DomainType
+	hash, err := td.SigningHash()
+	require.NoError(t, err)
+	domainSeparator, _ := td.HashStruct(DomainType, td.Domain.Map())
+	assert.Equal(t, crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator), hash)
+}
+
+func TestTypedDataDomain_ChainId(t *testing.T) {
+	for _, chainId := range []string{`1001`, `"1001"`, `"0x3e9"`} {
+		var domain TypedDataDomain
+		require.NoError(t, json.Unmarshal([]byte(`{"name": "test", "chainId": `+chainId+`}`), &domain), chainId)
+		assert.Equal(t, "test", domain.Name)
+		assert.Equal(t, int64(1001), domain.Map()["chainId"].(*big.Int).Int64(), chainId)
+	}
+
+	var domain TypedDataDomain
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "test"}`), &domain))
+	assert.Nil(t, domain.ChainId)
+	assert.Error(t, json.Unmarshal([]byte(`{"chainId": "one"}`), &domain))
+}
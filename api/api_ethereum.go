@@ -29,6 +29,7 @@ import (
 
 	"github.com/klaytn/klaytn/rlp"
 
+	"github.com/klaytn/klaytn/accounts/typeddata"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -1387,6 +1388,12 @@ func (api *EthereumAPI) Sign(addr common.Address, data hexutil.Bytes) (hexutil.B
 	return api.publicTransactionPoolAPI.Sign(addr, data)
 }
 
+// SignTypedData_v4 calculates an ECDSA signature for the EIP-712 typed data as klay_signTypedData does.
+// The V value of the signature will be 27 or 28, and the account associated with addr must be unlocked.
+func (api *EthereumAPI) SignTypedData_v4(addr common.Address, typedData typeddata.TypedData) (hexutil.Bytes, error) {
+	return api.publicTransactionPoolAPI.SignTypedData(addr, typedData)
+}
+
 // SignTransaction will sign the given transaction with the from account.
 // The node needs to have the private key of the account corresponding with
 // the given from address and it needs to be unlocked.
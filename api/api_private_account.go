@@ -28,6 +28,7 @@ import (
 
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/accounts/typeddata"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
@@ -185,6 +186,54 @@ func (s *PrivateAccountAPI) ImportRawKey(privkey string, password string) (commo
 	return acc.Address, err
 }
 
+// ImportMnemonic stores the HD wallet of the given BIP-39 mnemonic into the key
+// directory, encrypting its seed and the key of the default derivation path with the
+// password. The mnemonic passphrase is the optional BIP-39 passphrase of the seed.
+func (s *PrivateAccountAPI) ImportMnemonic(mnemonic string, mnemonicPassphrase string, password string) (common.Address, error) {
+	acc, err := fetchKeystore(s.am).ImportMnemonic(mnemonic, mnemonicPassphrase, password)
+	return acc.Address, err
+}
+
+// DeriveAccounts derives count accounts from the HD seed of the given address, starting
+// at the base path and increasing its last component, and stores each of them into the
+// key directory, encrypting it with the password. At most keystore.MaxDeriveAccounts
+// accounts are derived at once.
+func (s *PrivateAccountAPI) DeriveAccounts(addr common.Address, password string, base string, count uint64) ([]common.Address, error) {
+	if count > keystore.MaxDeriveAccounts {
+		return nil, keystore.ErrDeriveCount
+	}
+	path, err := accounts.ParseDerivationPath(base)
+	if err != nil {
+		return nil, err
+	}
+	derived, err := fetchKeystore(s.am).DeriveAccounts(accounts.Account{Address: addr}, password, path, int(count))
+	addrs := make([]common.Address, len(derived))
+	for i, acc := range derived {
+		addrs[i] = acc.Address
+	}
+	return addrs, err
+}
+
+// DeriveRoleBasedAccount derives the keys of the paths from the HD seed of the given
+// address, and stores them into the key directory as a role-based key, where paths[i]
+// are the paths of the keys of the i-th role. If address is not given, the address of
+// the first key of RoleTransaction is used.
+func (s *PrivateAccountAPI) DeriveRoleBasedAccount(addr common.Address, password string, paths [][]string, address *common.Address) (common.Address, error) {
+	derivPaths := make([][]accounts.DerivationPath, len(paths))
+	for i, rolePaths := range paths {
+		derivPaths[i] = make([]accounts.DerivationPath, len(rolePaths))
+		for j, path := range rolePaths {
+			derivPath, err := accounts.ParseDerivationPath(path)
+			if err != nil {
+				return common.Address{}, err
+			}
+			derivPaths[i][j] = derivPath
+		}
+	}
+	acc, err := fetchKeystore(s.am).DeriveRoleBasedAccount(accounts.Account{Address: addr}, password, derivPaths, address)
+	return acc.Address, err
+}
+
 // UnlockAccount will unlock the account associated with the given address with
 // the given password for duration seconds. If duration is nil it will use a
 // default of 300 seconds. It returns an indication if the account was unlocked.
@@ -468,6 +517,33 @@ func (s *PrivateAccountAPI) Sign(ctx context.Context, data hexutil.Bytes, addr c
 	return signature, nil
 }
 
+// SignTypedData calculates a Klaytn ECDSA signature for the EIP-712 typed data:
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+//
+// Note, the produced signature conforms to the secp256k1 curve R, S and V values,
+// where the V value will be 27 or 28 for legacy reasons.
+//
+// The key used to calculate the signature is decrypted with the given password.
+func (s *PrivateAccountAPI) SignTypedData(ctx context.Context, typedData typeddata.TypedData, addr common.Address, passwd string) (hexutil.Bytes, error) {
+	hash, err := typedData.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	// Look up the wallet containing the requested signer
+	account := accounts.Account{Address: addr}
+
+	wallet, err := s.b.AccountManager().Find(account)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := wallet.SignHashWithPassphrase(account, passwd, hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
+	return signature, nil
+}
+
 // EcRecover returns the address for the account that was used to create the signature.
 // Note, this function is compatible with eth_sign and personal_sign. As such it recovers
 // the address of:
@@ -22,6 +22,7 @@ package api
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/klaytn/klaytn/node/cn/filters"
 
+	"github.com/klaytn/klaytn/accounts/typeddata"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/blockchain/types/account"
@@ -37,6 +39,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/common/math"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/params"
@@ -243,6 +246,155 @@ func (s *PublicBlockChainAPI) GetAccountKey(ctx context.Context, address common.
 	return serAccKey, state.Error()
 }
 
+// MessageSigner is a key of the account that signed the message.
+type MessageSigner struct {
+	Index     int                               `json:"index"` // index of the key in AccountKeyWeightedMultiSig, 0 for the other keys
+	Weight    uint                              `json:"weight"`
+	PublicKey *accountkey.PublicKeySerializable `json:"publicKey"`
+}
+
+// MessageVerificationResult is the result of VerifyMessage.
+type MessageVerificationResult struct {
+	// Valid is true if the signatures satisfy the account key of the role as in the tx validation.
+	Valid bool `json:"valid"`
+	// KeyType is the type of the key of the role, which is never AccountKeyTypeRoleBased.
+	KeyType   accountkey.AccountKeyType `json:"keyType"`
+	Threshold uint                      `json:"threshold"`
+	Weight    uint                      `json:"weight"` // sum of the weights of the signers
+	Signers   []*MessageSigner          `json:"signers"`
+}
+
+// VerifyMessage verifies the signatures of the message against the account key of the given role
+// stored at the given block. Each signature is a Klaytn signature produced by klay_sign for
+// keccak256("\x19Klaytn Signed Message:\n"${message length}${message}), whose V is 27 or 28.
+// A signature of a P-256 key has V of 29 or 30, i.e. the recovery id of crypto.RecoverP256 plus 29.
+// The signatures are checked in the same way as the tx validation, so a multi-sig key requires
+// the weights of the signed keys to reach the threshold, and a role-based key falls back to
+// the RoleTransaction key if the role is not set.
+func (s *PublicBlockChainAPI) VerifyMessage(ctx context.Context, address common.Address, message hexutil.Bytes, signatures []hexutil.Bytes, role accountkey.RoleType, blockNrOrHash rpc.BlockNumberOrHash) (*MessageVerificationResult, error) {
+	return s.verifySignatures(ctx, address, signHash(message), signatures, role, blockNrOrHash)
+}
+
+// VerifyTypedData verifies the signatures of the EIP-712 typed data produced by klay_signTypedData
+// against the account key of the given role stored at the given block, as VerifyMessage does.
+func (s *PublicBlockChainAPI) VerifyTypedData(ctx context.Context, address common.Address, typedData typeddata.TypedData, signatures []hexutil.Bytes, role accountkey.RoleType, blockNrOrHash rpc.BlockNumberOrHash) (*MessageVerificationResult, error) {
+	hash, err := typedData.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	return s.verifySignatures(ctx, address, hash.Bytes(), signatures, role, blockNrOrHash)
+}
+
+// verifySignatures recovers the public keys from the signatures of the hash, and validates them
+// against the account key of the role stored at the given block.
+func (s *PublicBlockChainAPI) verifySignatures(ctx context.Context, address common.Address, hash []byte, signatures []hexutil.Bytes, role accountkey.RoleType, blockNrOrHash rpc.BlockNumberOrHash) (*MessageVerificationResult, error) {
+	if role < accountkey.RoleTransaction || role >= accountkey.RoleLast {
+		return nil, fmt.Errorf("invalid role: %d", role)
+	}
+	if len(signatures) == 0 {
+		return nil, errors.New("no signature is given")
+	}
+	recoveredKeys := make([]*ecdsa.PublicKey, len(signatures))
+	for i, sig := range signatures {
+		if len(sig) != crypto.SignatureLength {
+			return nil, fmt.Errorf("signature %d must be 65 bytes long", i)
+		}
+		var (
+			v      = sig[crypto.RecoveryIDOffset]
+			pubkey *ecdsa.PublicKey
+			err    error
+		)
+		sig = common.CopyBytes(sig)
+		switch v {
+		case 27, 28:
+			sig[crypto.RecoveryIDOffset] -= 27 // Transform yellow paper V from 27/28 to 0/1
+			pubkey, err = crypto.SigToPub(hash, sig)
+		case 29, 30:
+			sig[crypto.RecoveryIDOffset] -= 29 // Transform V of a P-256 signature from 29/30 to 0/1
+			pubkey, err = crypto.RecoverP256(hash, sig)
+		default:
+			return nil, fmt.Errorf("invalid Klaytn signature %d (V is not 27, 28, 29 or 30)", i)
+		}
+		if err != nil {
+			return nil, err
+		}
+		recoveredKeys[i] = pubkey
+	}
+
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	result := verifyMessageSigners(header.Number.Uint64(), address, state.GetKey(address), role, recoveredKeys)
+	return result, state.Error()
+}
+
+// verifyMessageSigners validates the recovered keys against the account key, and finds the keys that signed.
+func verifyMessageSigners(blockNumber uint64, from common.Address, accKey accountkey.AccountKey, role accountkey.RoleType, recoveredKeys []*ecdsa.PublicKey) *MessageVerificationResult {
+	result := &MessageVerificationResult{
+		Valid:   accKey.Validate(blockNumber, role, recoveredKeys, from),
+		Signers: []*MessageSigner{},
+	}
+
+	// Pick the key of the role as AccountKeyRoleBased.Validate does
+	if roleBased, ok := accKey.(*accountkey.AccountKeyRoleBased); ok {
+		if len(*roleBased) > int(role) {
+			accKey = (*roleBased)[role]
+		} else {
+			accKey = (*roleBased)[accountkey.RoleTransaction]
+		}
+	}
+	result.KeyType = accKey.Type()
+
+	// The signers of a session key are found from the key it wraps
+	if session, ok := accKey.(*accountkey.AccountKeySession); ok {
+		accKey = session.Key
+	}
+
+	switch key := accKey.(type) {
+	case *accountkey.AccountKeyLegacy:
+		result.Threshold = 1
+		for _, pubkey := range recoveredKeys {
+			if !crypto.IsP256(pubkey) && crypto.PubkeyToAddress(*pubkey) == from {
+				result.Signers = append(result.Signers, &MessageSigner{Weight: 1, PublicKey: (*accountkey.PublicKeySerializable)(pubkey)})
+				break
+			}
+		}
+	case *accountkey.AccountKeyPublic:
+		result.Threshold = 1
+		for _, pubkey := range recoveredKeys {
+			if key.PublicKeySerializable.Equal((*accountkey.PublicKeySerializable)(pubkey)) {
+				result.Signers = append(result.Signers, &MessageSigner{Weight: 1, PublicKey: key.PublicKeySerializable})
+				break
+			}
+		}
+	case *accountkey.AccountKeyPublicP256:
+		result.Threshold = 1
+		for _, pubkey := range recoveredKeys {
+			if key.PublicKeySerializable.Equal((*accountkey.PublicKeySerializable)(pubkey)) {
+				result.Signers = append(result.Signers, &MessageSigner{Weight: 1, PublicKey: key.PublicKeySerializable})
+				break
+			}
+		}
+	case *accountkey.AccountKeyWeightedMultiSig:
+		result.Threshold = key.Threshold
+		for i, weighted := range key.Keys {
+			for _, pubkey := range recoveredKeys {
+				if weighted.Key.Equal((*accountkey.PublicKeySerializable)(pubkey)) {
+					result.Signers = append(result.Signers, &MessageSigner{Index: i, Weight: weighted.Weight, PublicKey: weighted.Key})
+					break
+				}
+			}
+		}
+	}
+	// AccountKeyFail and AccountKeyNil have no key to sign
+
+	for _, signer := range result.Signers {
+		result.Weight += signer.Weight
+	}
+	return result
+}
+
 // IsParallelDBWrite returns if parallel write is enabled or not.
 // If enabled, data written in WriteBlockWithState is being written in parallel manner.
 func (s *PublicBlockChainAPI) IsParallelDBWrite() bool {
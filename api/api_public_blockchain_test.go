@@ -0,0 +1,267 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/accounts/typeddata"
+	mock_api "github.com/klaytn/klaytn/api/mocks"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyMessage(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{IstanbulCompatibleBlock: big.NewInt(0)})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	var (
+		keys    = make([]*ecdsa.PrivateKey, 4)
+		pubkeys = make([]*accountkey.PublicKeySerializable, 4)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		pubkeys[i] = (*accountkey.PublicKeySerializable)(&keys[i].PublicKey)
+	}
+	legacyAddr := crypto.PubkeyToAddress(keys[0].PublicKey)
+	multiSigAddr := common.HexToAddress("0x1")
+	roleBasedAddr := common.HexToAddress("0x2")
+
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	multiSig := accountkey.NewAccountKeyWeightedMultiSigWithValues(3, accountkey.WeightedPublicKeys{
+		{Weight: 1, Key: pubkeys[0]},
+		{Weight: 2, Key: pubkeys[1]},
+		{Weight: 2, Key: pubkeys[2]},
+	})
+	stateDB.CreateEOA(multiSigAddr, false, multiSig)
+	p256Key, _ := crypto.GenerateP256Key()
+	p256Addr := common.HexToAddress("0x3")
+	stateDB.CreateEOA(p256Addr, false, accountkey.NewAccountKeyPublicP256WithValue(&p256Key.PublicKey))
+	// RoleFeePayer falls back to the RoleTransaction key
+	stateDB.CreateEOA(roleBasedAddr, false, accountkey.NewAccountKeyRoleBasedWithValues([]accountkey.AccountKey{
+		accountkey.NewAccountKeyPublicWithValue(&keys[3].PublicKey),
+		multiSig,
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).
+		Return(stateDB, &types.Header{Number: big.NewInt(1)}, nil).AnyTimes()
+	api := NewPublicBlockChainAPI(mockBackend)
+
+	message := hexutil.Bytes("login nonce 1234")
+	sign := func(keyIdxs ...int) []hexutil.Bytes {
+		sigs := make([]hexutil.Bytes, len(keyIdxs))
+		for i, idx := range keyIdxs {
+			sig, err := crypto.Sign(signHash(message), keys[idx])
+			require.NoError(t, err)
+			sig[crypto.RecoveryIDOffset] += 27
+			sigs[i] = sig
+		}
+		return sigs
+	}
+
+	testcases := []struct {
+		address   common.Address
+		sigs      []hexutil.Bytes
+		role      accountkey.RoleType
+		valid     bool
+		keyType   accountkey.AccountKeyType
+		threshold uint
+		signers   []int // indexes of the keys that signed
+	}{
+		// An account which does not exist has the legacy key
+		{legacyAddr, sign(0), accountkey.RoleTransaction, true, accountkey.AccountKeyTypeLegacy, 1, []int{0}},
+		{legacyAddr, sign(1), accountkey.RoleTransaction, false, accountkey.AccountKeyTypeLegacy, 1, []int{}},
+		{multiSigAddr, sign(1, 2), accountkey.RoleTransaction, true, accountkey.AccountKeyTypeWeightedMultiSig, 3, []int{1, 2}},
+		{multiSigAddr, sign(0, 1), accountkey.RoleTransaction, true, accountkey.AccountKeyTypeWeightedMultiSig, 3, []int{0, 1}},
+		// The weights do not reach the threshold
+		{multiSigAddr, sign(1), accountkey.RoleTransaction, false, accountkey.AccountKeyTypeWeightedMultiSig, 3, []int{1}},
+		// A signature of an unknown key is not allowed after the Istanbul hardfork
+		{multiSigAddr, sign(1, 2, 3), accountkey.RoleTransaction, false, accountkey.AccountKeyTypeWeightedMultiSig, 3, []int{1, 2}},
+		{roleBasedAddr, sign(3), accountkey.RoleTransaction, true, accountkey.AccountKeyTypePublic, 1, []int{0}},
+		{roleBasedAddr, sign(3), accountkey.RoleAccountUpdate, false, accountkey.AccountKeyTypeWeightedMultiSig, 3, []int{}},
+		{roleBasedAddr, sign(0, 2), accountkey.RoleAccountUpdate, true, accountkey.AccountKeyTypeWeightedMultiSig, 3, []int{0, 2}},
+		{roleBasedAddr, sign(3), accountkey.RoleFeePayer, true, accountkey.AccountKeyTypePublic, 1, []int{0}},
+	}
+
+	blockNr := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	for i, tc := range testcases {
+		result, err := api.VerifyMessage(context.Background(), tc.address, message, tc.sigs, tc.role, blockNr)
+		require.NoError(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.valid, result.Valid, "testcases[%d] failed", i)
+		assert.Equal(t, tc.keyType, result.KeyType, "testcases[%d] failed", i)
+		assert.Equal(t, tc.threshold, result.Threshold, "testcases[%d] failed", i)
+
+		signers := []int{}
+		for _, signer := range result.Signers {
+			signers = append(signers, signer.Index)
+		}
+		assert.Equal(t, tc.signers, signers, "testcases[%d] failed", i)
+		if tc.valid {
+			assert.True(t, result.Weight >= result.Threshold, "testcases[%d] failed", i)
+		}
+	}
+
+	// The signatures are not modified
+	sigs := sign(0)
+	_, err = api.VerifyMessage(context.Background(), legacyAddr, message, sigs, accountkey.RoleTransaction, blockNr)
+	assert.NoError(t, err)
+	assert.Equal(t, sign(0), sigs)
+
+	// Invalid arguments
+	_, err = api.VerifyMessage(context.Background(), legacyAddr, message, nil, accountkey.RoleTransaction, blockNr)
+	assert.Error(t, err)
+	_, err = api.VerifyMessage(context.Background(), legacyAddr, message, sign(0), accountkey.RoleLast, blockNr)
+	assert.Error(t, err)
+	_, err = api.VerifyMessage(context.Background(), legacyAddr, message, []hexutil.Bytes{sigs[0][:64]}, accountkey.RoleTransaction, blockNr)
+	assert.Error(t, err)
+
+	// A P-256 signature has V of 29 or 30
+	p256Sig, err := crypto.SignP256(signHash(message), p256Key)
+	require.NoError(t, err)
+	p256Sig[crypto.RecoveryIDOffset] += 29
+	result, err := api.VerifyMessage(context.Background(), p256Addr, message, []hexutil.Bytes{p256Sig}, accountkey.RoleTransaction, blockNr)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, accountkey.AccountKeyTypePublicP256, result.KeyType)
+	require.Len(t, result.Signers, 1)
+	assert.Equal(t, uint(1), result.Weight)
+	result, err = api.VerifyMessage(context.Background(), p256Addr, message, sign(0), accountkey.RoleTransaction, blockNr)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	p256Sig[crypto.RecoveryIDOffset] += 2
+	_, err = api.VerifyMessage(context.Background(), p256Addr, message, []hexutil.Bytes{p256Sig}, accountkey.RoleTransaction, blockNr)
+	assert.Error(t, err)
+}
+
+func TestSignAndVerifyTypedData(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{IstanbulCompatibleBlock: big.NewInt(0)})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	// The Mail example of EIP-712 signed by the key keccak256("cow")
+	var typedData typeddata.TypedData
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"types": {
+			"EIP712Domain": [
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"}
+			],
+			"Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+			"Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+		},
+		"primaryType": "Mail",
+		"domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"},
+		"message": {
+			"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!"
+		}
+	}`), &typedData))
+	cowKey, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	keydir, err := ioutil.TempDir("", "klay-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(keydir)
+	ks := keystore.NewKeyStore(keydir, keystore.LightScryptN, keystore.LightScryptP)
+	cow, err := ks.ImportECDSA(cowKey, "1234")
+	require.NoError(t, err)
+	other, err := ks.ImportECDSA(otherKey, "1234")
+	require.NoError(t, err)
+
+	multiSigAddr := common.HexToAddress("0x1")
+	stateDB, err := state.New(common.Hash{}, state.NewDatabase(database.NewMemoryDBManager()), nil)
+	require.NoError(t, err)
+	stateDB.CreateEOA(multiSigAddr, false, accountkey.NewAccountKeyWeightedMultiSigWithValues(2, accountkey.WeightedPublicKeys{
+		{Weight: 1, Key: (*accountkey.PublicKeySerializable)(&cowKey.PublicKey)},
+		{Weight: 1, Key: (*accountkey.PublicKeySerializable)(&otherKey.PublicKey)},
+	}))
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockBackend.EXPECT().AccountManager().Return(accounts.NewManager(ks)).AnyTimes()
+	mockBackend.EXPECT().StateAndHeaderByNumberOrHash(gomock.Any(), gomock.Any()).
+		Return(stateDB, &types.Header{Number: big.NewInt(1)}, nil).AnyTimes()
+	privateAPI := NewPrivateAccountAPI(mockBackend, new(AddrLocker))
+	blockChainAPI := NewPublicBlockChainAPI(mockBackend)
+
+	cowSig, err := privateAPI.SignTypedData(context.Background(), typedData, cow.Address, "1234")
+	require.NoError(t, err)
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c",
+		cowSig.String())
+	otherSig, err := privateAPI.SignTypedData(context.Background(), typedData, other.Address, "1234")
+	require.NoError(t, err)
+	_, err = privateAPI.SignTypedData(context.Background(), typedData, cow.Address, "wrong")
+	assert.Error(t, err)
+
+	blockNr := rpc.NewBlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	testcases := []struct {
+		address common.Address
+		sigs    []hexutil.Bytes
+		valid   bool
+	}{
+		{cow.Address, []hexutil.Bytes{cowSig}, true},
+		{cow.Address, []hexutil.Bytes{otherSig}, false},
+		{multiSigAddr, []hexutil.Bytes{cowSig, otherSig}, true},
+		{multiSigAddr, []hexutil.Bytes{cowSig}, false},
+	}
+	for i, tc := range testcases {
+		result, err := blockChainAPI.VerifyTypedData(context.Background(), tc.address, typedData, tc.sigs, accountkey.RoleTransaction, blockNr)
+		require.NoError(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.valid, result.Valid, "testcases[%d] failed", i)
+	}
+
+	// A signature of the modified message is not valid
+	typedData.Message["contents"] = "Hello, Alice!"
+	result, err := blockChainAPI.VerifyTypedData(context.Background(), cow.Address, typedData, []hexutil.Bytes{cowSig}, accountkey.RoleTransaction, blockNr)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+
+	// Malformed typed data
+	typedData.PrimaryType = "Letter"
+	_, err = privateAPI.SignTypedData(context.Background(), typedData, cow.Address, "1234")
+	assert.Equal(t, typeddata.ErrPrimaryTypeMissing, err)
+	_, err = blockChainAPI.VerifyTypedData(context.Background(), cow.Address, typedData, []hexutil.Bytes{cowSig}, accountkey.RoleTransaction, blockNr)
+	assert.Equal(t, typeddata.ErrPrimaryTypeMissing, err)
+}
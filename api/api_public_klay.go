@@ -24,10 +24,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 )
 
@@ -127,6 +133,74 @@ func (s *PublicKlayAPI) Syncing() (interface{}, error) {
 
 // EncodeAccountKey gets an account key of JSON format and returns RLP encoded bytes of the key.
 func (s *PublicKlayAPI) EncodeAccountKey(accKey accountkey.AccountKeyJSON) (hexutil.Bytes, error) {
+	key, err := accountKeyFromJSON(accKey)
+	if err != nil {
+		return nil, err
+	}
+	return encodeAccountKey(key)
+}
+
+// SessionAccountKeyArgs represents the arguments to build a session account key.
+type SessionAccountKeyArgs struct {
+	Key         accountkey.AccountKeyJSON `json:"key"`
+	ExpiryBlock hexutil.Uint64            `json:"expiryBlock"`
+	ExpiryTime  hexutil.Uint64            `json:"expiryTime"`
+	Targets     []common.Address          `json:"targets"`
+	Methods     []string                  `json:"methods"` // method signatures like "transfer(address,uint256)" or 4-byte selectors in hex
+	ValueLimit  *hexutil.Big              `json:"valueLimit"`
+}
+
+// EncodeSessionAccountKey builds a session account key wrapping the given key and returns RLP encoded bytes of the key.
+// The methods can be given as method signatures, and they are converted to method selectors.
+// The encoded key can be used as the key of TxTypeAccountUpdate, or as a role of a role-based key.
+func (s *PublicKlayAPI) EncodeSessionAccountKey(args SessionAccountKeyArgs) (hexutil.Bytes, error) {
+	key, err := accountKeyFromJSON(args.Key)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsCompositeType() || key.Type() == accountkey.AccountKeyTypeSession {
+		return nil, errors.New("session key cannot wrap a roleBasedKey or a sessionKey")
+	}
+	if args.ExpiryBlock == 0 && args.ExpiryTime == 0 {
+		return nil, errors.New("either expiryBlock or expiryTime should be specified")
+	}
+	if len(args.Targets) > params.MaxSessionKeyTargets {
+		return nil, fmt.Errorf("the number of targets exceeds the limit %d", params.MaxSessionKeyTargets)
+	}
+	if len(args.Methods) > params.MaxSessionKeySelectors {
+		return nil, fmt.Errorf("the number of methods exceeds the limit %d", params.MaxSessionKeySelectors)
+	}
+
+	selectors := make([]accountkey.Selector, len(args.Methods))
+	for i, method := range args.Methods {
+		if selectors[i], err = methodSelector(method); err != nil {
+			return nil, err
+		}
+	}
+	return encodeAccountKey(accountkey.NewAccountKeySessionWithValues(key, uint64(args.ExpiryBlock), uint64(args.ExpiryTime),
+		args.Targets, selectors, (*big.Int)(args.ValueLimit)))
+}
+
+// methodSelector returns the method selector of a method signature, or decodes a method selector in hex.
+func methodSelector(method string) (accountkey.Selector, error) {
+	var selector accountkey.Selector
+	if strings.HasPrefix(method, "0x") {
+		b, err := hexutil.Decode(method)
+		if err != nil || len(b) != accountkey.SelectorLength {
+			return selector, fmt.Errorf("invalid method selector: %s", method)
+		}
+		copy(selector[:], b)
+		return selector, nil
+	}
+	if !strings.HasSuffix(method, ")") || !strings.Contains(method, "(") {
+		return selector, fmt.Errorf("invalid method signature: %s", method)
+	}
+	copy(selector[:], crypto.Keccak256([]byte(strings.ReplaceAll(method, " ", ""))))
+	return selector, nil
+}
+
+// accountKeyFromJSON creates an account key from an account key of JSON format.
+func accountKeyFromJSON(accKey accountkey.AccountKeyJSON) (accountkey.AccountKey, error) {
 	if accKey.KeyType == nil {
 		return nil, errors.New("key type is not specified")
 	}
@@ -142,6 +216,11 @@ func (s *PublicKlayAPI) EncodeAccountKey(accKey accountkey.AccountKeyJSON) (hexu
 	if err := checkAccountKeyZeroValues(key, false); err != nil {
 		return nil, err
 	}
+	return key, nil
+}
+
+// encodeAccountKey returns RLP encoded bytes of the account key.
+func encodeAccountKey(key accountkey.AccountKey) (hexutil.Bytes, error) {
 	accKeySerializer := accountkey.NewAccountKeySerializerWithAccountKey(key)
 	encodedKey, err := rlp.EncodeToBytes(accKeySerializer)
 	if err != nil {
@@ -182,6 +261,9 @@ func checkAccountKeyZeroValues(key accountkey.AccountKey, isNested bool) error {
 				return err
 			}
 		}
+	case accountkey.AccountKeyTypeSession:
+		sessionKey, _ := key.(*accountkey.AccountKeySession)
+		return checkAccountKeyZeroValues(sessionKey.Key, true)
 	}
 	return nil
 }
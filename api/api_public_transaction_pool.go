@@ -28,9 +28,12 @@ import (
 	"math/big"
 
 	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/typeddata"
+	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/common/math"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/rlp"
@@ -232,6 +235,14 @@ func RpcOutputReceipt(header *types.Header, tx *types.Transaction, blockHash com
 	} else {
 		fields["contractAddress"] = nil
 	}
+	// A batch transaction has the status of each call.
+	if receipt.CallStatuses != nil {
+		callStatuses := make([]hexutil.Uint, len(receipt.CallStatuses))
+		for i, status := range receipt.CallStatuses {
+			callStatuses[i] = hexutil.Uint(status)
+		}
+		fields["callStatuses"] = callStatuses
+	}
 
 	// Rename field name `hash` to `transactionHash` since this function returns a JSON object of a receipt.
 	fields["transactionHash"] = fields["hash"]
@@ -404,6 +415,33 @@ func (s *PublicTransactionPoolAPI) Sign(addr common.Address, data hexutil.Bytes)
 	return signature, err
 }
 
+// SignTypedData calculates an ECDSA signature for the EIP-712 typed data:
+// keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message)).
+//
+// Note, the produced signature conforms to the secp256k1 curve R, S and V values,
+// where the V value will be 27 or 28 for legacy reasons.
+//
+// The account associated with addr must be unlocked.
+func (s *PublicTransactionPoolAPI) SignTypedData(addr common.Address, typedData typeddata.TypedData) (hexutil.Bytes, error) {
+	hash, err := typedData.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	// Look up the wallet containing the requested signer
+	account := accounts.Account{Address: addr}
+
+	wallet, err := s.b.AccountManager().Find(account)
+	if err != nil {
+		return nil, err
+	}
+	// Sign the hash of the typed data with the wallet
+	signature, err := wallet.SignHash(account, hash.Bytes())
+	if err == nil {
+		signature[crypto.RecoveryIDOffset] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
+	}
+	return signature, err
+}
+
 // SignTransactionResult represents a RLP encoded signed transaction.
 type SignTransactionResult struct {
 	Raw hexutil.Bytes      `json:"raw"`
@@ -546,3 +584,102 @@ func (s *PublicTransactionPoolAPI) Resend(ctx context.Context, sendArgs SendTxAr
 
 	return common.Hash{}, fmt.Errorf("Transaction %#x not found", matchTx.Hash())
 }
+
+// SpeedUpTransaction replaces the pending transaction of the given hash with the same one paying
+// the new gas price. It supports all the tx types, and the replacement is signed by the sender
+// and the fee payer if it is fee-delegated, so their accounts should be managed by the node.
+// The new gas price should be higher than the old one after the Magma hardfork.
+func (s *PublicTransactionPoolAPI) SpeedUpTransaction(ctx context.Context, hash common.Hash, gasPrice hexutil.Big) (common.Hash, error) {
+	old, from, err := s.pendingTransaction(hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	args := newSendTxArgsFromTx(old, from)
+	if *args.TypeInt == types.TxTypeEthereumDynamicFee {
+		args.MaxPriorityFeePerGas, args.MaxFeePerGas = &gasPrice, &gasPrice
+	} else {
+		args.Price = &gasPrice
+	}
+	return s.replaceTransaction(ctx, old, args)
+}
+
+// CancelTransaction replaces the pending transaction of the given hash with a cancel transaction
+// having the same nonce, which always replaces the old one in the txpool. A fee-delegated transaction
+// is cancelled by the fee-delegated cancel transaction of the same fee payer and fee ratio, so the accounts
+// of the sender and the fee payer should be managed by the node.
+func (s *PublicTransactionPoolAPI) CancelTransaction(ctx context.Context, hash common.Hash) (common.Hash, error) {
+	old, from, err := s.pendingTransaction(hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	price, err := s.b.SuggestPrice(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	price = math.BigMax(price, old.GasPrice())
+
+	nonce := hexutil.Uint64(old.Nonce())
+	// The gas limit of the old one is enough since a cancel transaction requires the least intrinsic gas
+	gas := hexutil.Uint64(old.Gas())
+	args := &SendTxArgs{
+		From:         from,
+		GasLimit:     &gas,
+		Price:        (*hexutil.Big)(price),
+		AccountNonce: &nonce,
+	}
+
+	txType := types.TxTypeCancel
+	if old.IsFeeDelegatedTransaction() {
+		feePayer, err := old.FeePayer()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		args.FeePayer = &feePayer
+		txType = types.TxTypeFeeDelegatedCancel
+		if feeRatio, ok := old.FeeRatio(); ok {
+			txType = types.TxTypeFeeDelegatedCancelWithRatio
+			args.FeeRatio = &feeRatio
+		}
+	}
+	args.TypeInt = &txType
+	return s.replaceTransaction(ctx, old, args)
+}
+
+// pendingTransaction returns the transaction of the given hash in the txpool and its sender.
+func (s *PublicTransactionPoolAPI) pendingTransaction(hash common.Hash) (*types.Transaction, common.Address, error) {
+	tx := s.b.GetPoolTransaction(hash)
+	if tx == nil {
+		return nil, common.Address{}, fmt.Errorf("transaction %#x is not found in the txpool", hash)
+	}
+	if !tx.IsEthereumTransaction() {
+		from, err := tx.From()
+		return tx, from, err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(s.b.ChainConfig().ChainID), tx)
+	return tx, from, err
+}
+
+// replaceTransaction builds the transaction from args, checks if it can replace old in the txpool,
+// and submits it after being signed by the sender and the fee payer.
+func (s *PublicTransactionPoolAPI) replaceTransaction(ctx context.Context, old *types.Transaction, args *SendTxArgs) (common.Hash, error) {
+	tx, err := args.toTransaction()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	isMagma := s.b.ChainConfig().IsMagmaForkEnabled(new(big.Int).Add(s.b.CurrentBlock().Number(), common.Big1))
+	if err := blockchain.ValidateReplacement(old, tx, isMagma); err != nil {
+		return common.Hash{}, err
+	}
+
+	signedTx, err := s.sign(args.From, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if signedTx.IsFeeDelegatedTransaction() {
+		if signedTx, err = s.signAsFeePayer(*args.FeePayer, signedTx); err != nil {
+			return common.Hash{}, fmt.Errorf("failed to sign as the fee payer %s: %v", args.FeePayer.String(), err)
+		}
+	}
+	return submitTransaction(ctx, s.b, signedTx)
+}
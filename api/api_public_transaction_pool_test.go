@@ -339,3 +339,121 @@ func testTxTypeSupport_unnecessaryFieldValues(t *testing.T, api PublicTransactio
 		assert.Equal(t, "json:\"feeRatio\" is not a field of "+(*args.TypeInt).String(), err.Error())
 	}
 }
+
+// TestReplaceTransaction tests SpeedUpTransaction and CancelTransaction for all tx types.
+func TestReplaceTransaction(t *testing.T) {
+	ctx := context.Background()
+	chainConf := params.ChainConfig{ChainID: big.NewInt(1), MagmaCompatibleBlock: big.NewInt(0)}
+	signer := types.LatestSignerForChainID(chainConf.ChainID)
+
+	dir, err := ioutil.TempDir("", "klay-keystore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ks := keystore.NewKeyStore(dir, 2, 1)
+	acc, err := ks.ImportECDSA(senderPrvKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	accFeePayer, err := ks.ImportECDSA(feePayerPrvKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, ks.Unlock(acc, ""))
+	assert.NoError(t, ks.Unlock(accFeePayer, ""))
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockBackend := mock_api.NewMockBackend(mockCtrl)
+	mockAccountManager := mock_accounts.NewMockAccountManager(mockCtrl)
+	mockBackend.EXPECT().AccountManager().Return(mockAccountManager).AnyTimes()
+	mockBackend.EXPECT().CurrentBlock().Return(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})).AnyTimes()
+	mockBackend.EXPECT().ChainConfig().Return(&chainConf).AnyTimes()
+	mockBackend.EXPECT().SuggestPrice(ctx).Return((*big.Int)(testGasPrice), nil).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: acc.Address}).Return(ks.Wallets()[0], nil).AnyTimes()
+	mockAccountManager.EXPECT().Find(accounts.Account{Address: accFeePayer.Address}).Return(ks.Wallets()[1], nil).AnyTimes()
+
+	var submitted *types.Transaction
+	mockBackend.EXPECT().SendTx(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, tx *types.Transaction) error {
+		submitted = tx
+		return nil
+	}).AnyTimes()
+
+	api := PublicTransactionPoolAPI{b: mockBackend, nonceLock: new(AddrLocker)}
+	newGasPrice := hexutil.Big(*big.NewInt(50 * params.Ston))
+
+	for txType, internalData := range internalDataTypes {
+		if txType.IsCancelTransaction() {
+			continue
+		}
+		args := SendTxArgs{TypeInt: &txType, From: acc.Address}
+		internalType := reflect.TypeOf(internalData)
+		for i := 0; i < internalType.NumField(); i++ {
+			switch internalType.Field(i).Name {
+			case "AccountNonce":
+				args.AccountNonce = &testNonce
+			case "Amount":
+				args.Amount = testValue
+			case "Recipient":
+				args.Recipient = &testTo
+			case "FeePayer":
+				args.FeePayer = &accFeePayer.Address
+			case "FeeRatio":
+				args.FeeRatio = &testFeeRatio
+			case "GasLimit":
+				args.GasLimit = &testGas
+			case "Price":
+				args.Price = testGasPrice
+			case "Payload":
+				args.Payload = &testData
+			case "CodeFormat":
+				args.CodeFormat = &testCodeFormat
+			case "HumanReadable":
+				args.HumanReadable = &testHumanReadable
+			case "Key":
+				args.Key = &testAccountKey
+			}
+		}
+		tx, err := args.toTransaction()
+		assert.NoError(t, err)
+		pending, err := api.sign(acc.Address, tx)
+		assert.NoError(t, err)
+		mockBackend.EXPECT().GetPoolTransaction(pending.Hash()).Return(pending).AnyTimes()
+
+		// The replacement has the same values except the gas price
+		_, err = api.SpeedUpTransaction(ctx, pending.Hash(), newGasPrice)
+		assert.NoError(t, err, txType.String())
+		assert.Equal(t, txType, submitted.Type())
+		assert.Equal(t, (*big.Int)(&newGasPrice), submitted.GasPrice())
+		expected := newSendTxArgsFromTx(pending, acc.Address)
+		expected.Price = &newGasPrice
+		assert.Equal(t, expected, newSendTxArgsFromTx(submitted, acc.Address), txType.String())
+
+		from, err := types.Sender(signer, submitted)
+		assert.NoError(t, err)
+		assert.Equal(t, acc.Address, from)
+		if txType.IsFeeDelegatedTransaction() {
+			feePayer, err := types.SenderFeePayer(signer, submitted)
+			assert.NoError(t, err)
+			assert.Equal(t, accFeePayer.Address, feePayer)
+		}
+
+		// The gas price should be increased
+		_, err = api.SpeedUpTransaction(ctx, pending.Hash(), *testGasPrice)
+		assert.Error(t, err)
+
+		// The cancel transaction keeps the fee payer and the fee ratio
+		_, err = api.CancelTransaction(ctx, pending.Hash())
+		assert.NoError(t, err, txType.String())
+		assert.True(t, submitted.Type().IsCancelTransaction())
+		assert.Equal(t, pending.Nonce(), submitted.Nonce())
+		assert.Equal(t, txType.IsFeeDelegatedTransaction(), submitted.IsFeeDelegatedTransaction())
+		assert.Equal(t, txType.IsFeeDelegatedWithRatioTransaction(), submitted.Type().IsFeeDelegatedWithRatioTransaction())
+	}
+
+	// The transaction should be pending in the txpool
+	mockBackend.EXPECT().GetPoolTransaction(common.Hash{}).Return(nil)
+	_, err = api.CancelTransaction(ctx, common.Hash{})
+	assert.Error(t, err)
+}
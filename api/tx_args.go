@@ -359,6 +359,82 @@ func (args *SendTxArgs) toTransaction() (*types.Transaction, error) {
 	return types.NewTransactionWithMap(*args.TypeInt, values)
 }
 
+// newSendTxArgsFromTx returns SendTxArgs having the same values as the given transaction sent by from.
+// It is used to build a replacement of the transaction.
+func newSendTxArgsFromTx(tx *types.Transaction, from common.Address) *SendTxArgs {
+	var (
+		txType = tx.Type()
+		nonce  = hexutil.Uint64(tx.Nonce())
+		gas    = hexutil.Uint64(tx.Gas())
+		output = tx.GetTxInternalData().MakeRPCOutput()
+	)
+	args := &SendTxArgs{
+		TypeInt:      &txType,
+		From:         from,
+		GasLimit:     &gas,
+		AccountNonce: &nonce,
+	}
+	if txType == types.TxTypeEthereumDynamicFee {
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+	} else {
+		args.Price = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	if txType.IsEthereumTransaction() {
+		args.Recipient = tx.To()
+		args.Amount = (*hexutil.Big)(tx.Value())
+		payload := hexutil.Bytes(tx.Data())
+		args.Payload = &payload
+		if txType.IsEthTypedTransaction() {
+			accessList := tx.AccessList()
+			args.AccessList = &accessList
+			args.ChainID = (*hexutil.Big)(tx.ChainId())
+		}
+		return args
+	}
+
+	// Only the fields of the tx type are set since they are checked by checkArgs
+	fields := isTxField[txType]
+	if fields["Recipient"] {
+		args.Recipient = tx.To()
+	}
+	if fields["Amount"] {
+		args.Amount = (*hexutil.Big)(tx.Value())
+	}
+	if fields["Payload"] {
+		payload := hexutil.Bytes(tx.Data())
+		args.Payload = &payload
+	}
+	if fields["CodeFormat"] {
+		if cf, ok := output["codeFormat"].(hexutil.Uint); ok {
+			codeFormat := params.CodeFormat(cf)
+			args.CodeFormat = &codeFormat
+		}
+	}
+	if fields["HumanReadable"] {
+		if hr, ok := output["humanReadable"].(bool); ok {
+			args.HumanReadable = &hr
+		}
+	}
+	if fields["Key"] {
+		if key, ok := output["key"].(hexutil.Bytes); ok {
+			args.Key = &key
+		}
+	}
+	if fields["FeePayer"] {
+		if feePayer, err := tx.FeePayer(); err == nil {
+			args.FeePayer = &feePayer
+		}
+	}
+	if fields["FeeRatio"] {
+		if feeRatio, ok := tx.FeeRatio(); ok {
+			args.FeeRatio = &feeRatio
+		}
+	}
+	return args
+}
+
 type ValueTransferTxArgs struct {
 	From     common.Address  `json:"from"`
 	Gas      *hexutil.Uint64 `json:"gas"`
@@ -61,6 +61,10 @@ var (
 	// with a different one without the required price bump.
 	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
 
+	// ErrReplaceFeeDelegationMismatch is returned if a transaction is attempted to be replaced
+	// with a different one which does not keep the fee delegation of the old one.
+	ErrReplaceFeeDelegationMismatch = errors.New("replacement transaction should be fee-delegated by the same fee payer")
+
 	// ErrAlreadyNonceExistInPool is returned if there is another tx with the same nonce in the tx pool.
 	ErrAlreadyNonceExistInPool = errors.New("there is another tx which has the same nonce in the tx pool")
 
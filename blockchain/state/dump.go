@@ -23,6 +23,7 @@ package state
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 
 	"github.com/klaytn/klaytn/blockchain/types/account"
 	"github.com/klaytn/klaytn/common"
@@ -89,6 +90,20 @@ func (self *StateDB) RawDump() Dump {
 	return dump
 }
 
+// TotalBalance returns the sum of the balances of all accounts in the state trie.
+func (self *StateDB) TotalBalance() (*big.Int, error) {
+	total := new(big.Int)
+	it := statedb.NewIterator(self.trie.NodeIterator(nil))
+	for it.Next() {
+		serializer := account.NewAccountSerializer()
+		if err := rlp.DecodeBytes(it.Value, serializer); err != nil {
+			return nil, err
+		}
+		total.Add(total, serializer.GetAccount().GetBalance())
+	}
+	return total, it.Err
+}
+
 func (self *StateDB) Dump() []byte {
 	json, err := json.MarshalIndent(self.RawDump(), "", "    ")
 	if err != nil {
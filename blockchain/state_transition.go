@@ -119,6 +119,12 @@ type Message interface {
 	// Validate performs additional validation for each transaction type
 	Validate(stateDB types.StateDB, currentBlockNumber uint64) error
 
+	// BatchCalls returns the calls of a batch transaction, or nil for other transaction types.
+	BatchCalls() []*types.BatchCall
+
+	// ValidateSessionKeys checks the expiry and the scope of the session keys of the sender and the fee payer.
+	ValidateSessionKeys(p types.AccountKeyPicker, currentBlockNumber uint64, time uint64) error
+
 	// Execute performs execution of the transaction according to the transaction type.
 	Execute(vm types.VM, stateDB types.StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) ([]byte, uint64, error)
 }
@@ -129,9 +135,12 @@ type Message interface {
 // - Status: Indicate status of transaction after execution.
 //           This value will be stored in Receipt if Receipt is available.
 //           Please see getReceiptStatusFromErrTxFailed() how this value is calculated.
+// - CallStatuses: Indicate status of each call of a batch transaction.
+//                 Please see getCallStatuses() how this value is calculated.
 type kerror struct {
 	ErrTxInvalid error
 	Status       uint
+	CallStatuses []uint
 }
 
 // NewStateTransition initialises and returns a new state transition object.
@@ -238,6 +247,12 @@ func (st *StateTransition) preCheck() error {
 				"accountNonce", nonce, "txNonce", st.msg.Nonce(), "txHash", st.msg.Hash().String())
 			return ErrNonceTooLow
 		}
+		// Make sure the session keys of the sender and the fee payer allow this transaction.
+		if err := st.msg.ValidateSessionKeys(st.state, st.evm.BlockNumber.Uint64(), st.evm.Time.Uint64()); err != nil {
+			logger.Debug("Session key validation failed", "account", st.msg.ValidatedSender().String(),
+				"txHash", st.msg.Hash().String(), "err", err)
+			return err
+		}
 	}
 	return st.buyGas()
 }
@@ -273,6 +288,12 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, kerr kerr
 
 	ret, st.gas, errTxFailed = msg.Execute(st.evm, st.state, st.evm.BlockNumber.Uint64(), st.gas, st.value)
 
+	// A batch transaction reports the failed call and its error.
+	failedCall := -1
+	if batchErr, ok := errTxFailed.(*types.BatchCallError); ok {
+		failedCall, errTxFailed = batchErr.Index, batchErr.Err
+	}
+
 	if errTxFailed != nil {
 		logger.Debug("VM returned with error", "err", errTxFailed, "txHash", st.msg.Hash().String())
 		// The only possible consensus-error would be if there wasn't
@@ -305,6 +326,7 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, kerr kerr
 
 	kerr.ErrTxInvalid = nil
 	kerr.Status = getReceiptStatusFromErrTxFailed(errTxFailed)
+	kerr.CallStatuses = getCallStatuses(len(msg.BatchCalls()), failedCall, kerr.Status)
 	return ret, st.gasUsed(), kerr
 }
 
@@ -385,6 +407,26 @@ func getReceiptStatusFromErrTxFailed(errTxFailed error) (status uint) {
 	return
 }
 
+// getCallStatuses returns the status of each call of a batch transaction.
+// If a call failed, the calls before it are successful but reverted, and the calls after it are not executed.
+func getCallStatuses(numCalls int, failedCall int, status uint) []uint {
+	if numCalls == 0 {
+		return nil
+	}
+	statuses := make([]uint, numCalls)
+	for i := range statuses {
+		switch {
+		case failedCall < 0 || i < failedCall:
+			statuses[i] = types.ReceiptStatusSuccessful
+		case i == failedCall:
+			statuses[i] = status
+		default:
+			statuses[i] = types.ReceiptStatusFailed
+		}
+	}
+	return statuses
+}
+
 // GetVMerrFromReceiptStatus returns VM error according to status of receipt.
 func GetVMerrFromReceiptStatus(status uint) (errTxFailed error) {
 	errTxFailed, ok := receiptstatus2errTxFailed[status]
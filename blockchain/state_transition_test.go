@@ -19,6 +19,7 @@ package blockchain
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/klaytn/klaytn/blockchain/types"
@@ -82,3 +83,23 @@ func TestPrintErrorCodeTable(t *testing.T) {
 		}
 	}
 }
+
+func TestGetCallStatuses(t *testing.T) {
+	failed := types.ReceiptStatusErrExecutionReverted
+	testcases := []struct {
+		numCalls   int
+		failedCall int
+		expected   []uint
+	}{
+		{0, -1, nil},
+		{2, -1, []uint{types.ReceiptStatusSuccessful, types.ReceiptStatusSuccessful}},
+		{3, 0, []uint{failed, types.ReceiptStatusFailed, types.ReceiptStatusFailed}},
+		{3, 1, []uint{types.ReceiptStatusSuccessful, failed, types.ReceiptStatusFailed}},
+		{3, 2, []uint{types.ReceiptStatusSuccessful, types.ReceiptStatusSuccessful, failed}},
+	}
+	for i, tc := range testcases {
+		if statuses := getCallStatuses(tc.numCalls, tc.failedCall, failed); !reflect.DeepEqual(tc.expected, statuses) {
+			t.Fatalf("testcases[%d] failed: want %v, got %v", i, tc.expected, statuses)
+		}
+	}
+}
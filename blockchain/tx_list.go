@@ -303,34 +303,78 @@ func (l *txList) Overlaps(tx *types.Transaction) bool {
 	return l.txs.Get(tx.Nonce()) != nil
 }
 
+// ValidateReplacement returns nil if tx can replace old having the same nonce in the txpool.
+// A cancel transaction replaces the old one even though it has a lower gas price.
+// Otherwise, no replacement is allowed before the Magma hardfork, and after it the replacement
+// should keep the fee delegation of the old one and have a higher gas price.
+func ValidateReplacement(old, tx *types.Transaction, magmaHardforked bool) error {
+	if tx.Type().IsCancelTransaction() {
+		return nil
+	}
+	if !magmaHardforked {
+		return ErrAlreadyNonceExistInPool
+	}
+	if !sameFeeDelegation(old, tx) {
+		return ErrReplaceFeeDelegationMismatch
+	}
+	if old.GasPrice().Cmp(tx.GasPrice()) >= 0 {
+		return ErrReplaceUnderpriced
+	}
+	return nil
+}
+
+// sameFeeDelegation returns true if both transactions are not fee-delegated,
+// or both are fee-delegated by the same fee payer.
+func sameFeeDelegation(old, tx *types.Transaction) bool {
+	if old.IsFeeDelegatedTransaction() != tx.IsFeeDelegatedTransaction() {
+		return false
+	}
+	if !old.IsFeeDelegatedTransaction() {
+		return true
+	}
+	oldFeePayer, err := old.FeePayer()
+	if err != nil {
+		return false
+	}
+	feePayer, err := tx.FeePayer()
+	if err != nil {
+		return false
+	}
+	return oldFeePayer == feePayer
+}
+
 // Add tries to insert a new transaction into the list, returning whether the
 // transaction was accepted, and if yes, any previous transaction it replaced.
+// If the transaction is not accepted, the reason is returned as an error.
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
 // thresholds are also potentially updated.
-func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bool) (bool, *types.Transaction) {
+func (l *txList) Add(tx *types.Transaction, priceBump uint64, magmaHardforked bool) (bool, *types.Transaction, error) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
-		// If tx is CancelTransaction, replace it even thought tx has lower gasPrice than previous tx.
-		if tx.Type().IsCancelTransaction() {
-			logger.Trace("New tx is a cancel transaction. replace it!", "old", old.String(), "new", tx.String())
-		} else if magmaHardforked {
-			if old.GasPrice().Cmp(tx.GasPrice()) >= 0 {
-				// If gas price of older is bigger than newer, abort.
-				logger.Trace("already nonce exist and the gasprice is lower then older", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
-				return false, nil
+		switch err := ValidateReplacement(old, tx, magmaHardforked); err {
+		case nil:
+			if tx.Type().IsCancelTransaction() {
+				logger.Trace("New tx is a cancel transaction. replace it!", "old", old.String(), "new", tx.String())
+			} else {
+				logger.Trace("The transaction was substituted by competitive gas price", "old", old.String(), "new", tx.String())
 			}
-			// Otherwise overwrite the old transaction with the current one.
-			logger.Trace("The transaction was substituted by competitive gas price", "old", old.String(), "new", tx.String())
-		} else {
+		case ErrReplaceUnderpriced:
+			// If gas price of older is bigger than newer, abort.
+			logger.Trace("already nonce exist and the gasprice is lower then older", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
+			return false, nil, err
+		case ErrReplaceFeeDelegationMismatch:
+			logger.Trace("already nonce exist and the fee delegation is different from older", "nonce", tx.Nonce(), "old", old.String(), "new", tx.String())
+			return false, nil, err
+		default:
 			logger.Trace("already nonce exist", "nonce", tx.Nonce(), "with gasprice", old.GasPrice(), "priceBump", priceBump, "new tx.gasprice", tx.GasPrice())
-			return false, nil
+			return false, nil, err
 		}
 	}
 
 	l.txs.Put(tx)
-	return true, old
+	return true, old, nil
 }
 
 // Forward removes all transactions from the list with a nonce lower than the
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/stretchr/testify/assert"
 )
@@ -173,11 +174,11 @@ func TestSubstituteTxByGasPrice(t *testing.T) {
 	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
 	newTx := pricedTransaction(0, 21000, big.NewInt(60), key)
 
-	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
+	if result, _, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
 		t.Error("it cannot add tx in tx list.")
 	}
 
-	result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true)
+	result, replaced, _ := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true)
 	if !result {
 		t.Error("it cannot replace tx in tx list.")
 	}
@@ -195,11 +196,61 @@ func TestSubstituteTransactionAbort(t *testing.T) {
 	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
 	newTx := pricedTransaction(0, 21000, big.NewInt(40), key)
 
-	if result, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
+	if result, _, _ := txList.Add(oldTx, DefaultTxPoolConfig.PriceBump, true); !result {
 		t.Error("it cannot add tx in tx list.")
 	}
 
-	if result, replaced := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true); result || replaced != nil {
+	result, replaced, err := txList.Add(newTx, DefaultTxPoolConfig.PriceBump, true)
+	if result || replaced != nil {
 		t.Error("Expected to not substitute by a tx with lower gas price")
 	}
+	assert.Equal(t, ErrReplaceUnderpriced, err)
+}
+
+// TestValidateReplacement checks the rules to replace a transaction having the same nonce.
+func TestValidateReplacement(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	oldTx := pricedTransaction(0, 21000, big.NewInt(50), key)
+	cancelTx, err := types.NewTransactionWithMap(types.TxTypeCancel, map[types.TxValueKeyType]interface{}{
+		types.TxValueKeyNonce:    uint64(0),
+		types.TxValueKeyFrom:     crypto.PubkeyToAddress(key.PublicKey),
+		types.TxValueKeyGasLimit: uint64(21000),
+		types.TxValueKeyGasPrice: big.NewInt(40),
+	})
+	assert.NoError(t, err)
+
+	// Before the Magma hardfork, only a cancel transaction can replace the old one
+	assert.Equal(t, ErrAlreadyNonceExistInPool, ValidateReplacement(oldTx, pricedTransaction(0, 21000, big.NewInt(60), key), false))
+	assert.NoError(t, ValidateReplacement(oldTx, cancelTx, false))
+
+	// After the Magma hardfork, a higher gas price is required
+	assert.NoError(t, ValidateReplacement(oldTx, pricedTransaction(0, 21000, big.NewInt(60), key), true))
+	assert.Equal(t, ErrReplaceUnderpriced, ValidateReplacement(oldTx, pricedTransaction(0, 21000, big.NewInt(50), key), true))
+	assert.NoError(t, ValidateReplacement(oldTx, cancelTx, true))
+
+	// After the Magma hardfork, the fee delegation of the old one should be kept
+	feePayerKey, _ := crypto.GenerateKey()
+	feeDelegatedTx := func(feePayer common.Address, gasPrice int64) *types.Transaction {
+		tx, err := types.NewTransactionWithMap(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    uint64(0),
+			types.TxValueKeyFrom:     crypto.PubkeyToAddress(key.PublicKey),
+			types.TxValueKeyTo:       common.Address{},
+			types.TxValueKeyAmount:   big.NewInt(0),
+			types.TxValueKeyGasLimit: uint64(21000),
+			types.TxValueKeyGasPrice: big.NewInt(gasPrice),
+			types.TxValueKeyFeePayer: feePayer,
+		})
+		assert.NoError(t, err)
+		return tx
+	}
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey)
+	oldFeeDelegatedTx := feeDelegatedTx(feePayer, 50)
+
+	assert.NoError(t, ValidateReplacement(oldFeeDelegatedTx, feeDelegatedTx(feePayer, 60), true))
+	assert.Equal(t, ErrReplaceUnderpriced, ValidateReplacement(oldFeeDelegatedTx, feeDelegatedTx(feePayer, 50), true))
+	assert.Equal(t, ErrReplaceFeeDelegationMismatch, ValidateReplacement(oldFeeDelegatedTx, feeDelegatedTx(common.Address{1}, 60), true))
+	assert.Equal(t, ErrReplaceFeeDelegationMismatch, ValidateReplacement(oldFeeDelegatedTx, pricedTransaction(0, 21000, big.NewInt(60), key), true))
+	assert.Equal(t, ErrReplaceFeeDelegationMismatch, ValidateReplacement(oldTx, feeDelegatedTx(feePayer, 60), true))
+	assert.Equal(t, ErrAlreadyNonceExistInPool, ValidateReplacement(oldFeeDelegatedTx, feeDelegatedTx(common.Address{1}, 60), false))
 }
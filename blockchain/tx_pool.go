@@ -180,6 +180,7 @@ type TxPool struct {
 	mu           sync.RWMutex
 
 	currentBlockNumber uint64                    // Current block number
+	currentBlockTime   uint64                    // Current block timestamp
 	currentState       *state.StateDB            // Current state in the blockchain head
 	pendingNonce       map[common.Address]uint64 // Pending nonce tracking virtual nonces
 
@@ -199,9 +200,11 @@ type TxPool struct {
 
 	txMsgCh chan types.Transactions
 
-	eip2718 bool // Fork indicator whether we are using EIP-2718 type transactions.
-	eip1559 bool // Fork indicator whether we are using EIP-1559 type transactions.
-	magma   bool // Fork indicator whether we are using Magma type transactions.
+	eip2718      bool // Fork indicator whether we are using EIP-2718 type transactions.
+	eip1559      bool // Fork indicator whether we are using EIP-1559 type transactions.
+	magma        bool // Fork indicator whether we are using Magma type transactions.
+	batchTx      bool // Fork indicator whether we are using batch transactions.
+	customTxType bool // Fork indicator whether we are using custom tx types.
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -439,6 +442,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.currentState = stateDB
 	pool.pendingNonce = make(map[common.Address]uint64)
 	pool.currentBlockNumber = newHead.Number.Uint64()
+	pool.currentBlockTime = newHead.Time.Uint64()
 
 	// Inject any transactions discarded due to reorgs
 	logger.Debug("Reinjecting stale transactions", "count", len(reinject))
@@ -477,6 +481,10 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.eip1559 = pool.chainconfig.IsEthTxTypeForkEnabled(next)
 	// Enable dynamic base fee
 	pool.magma = pool.chainconfig.IsMagmaForkEnabled(next)
+	// Enable batch transactions
+	pool.batchTx = pool.chainconfig.IsBatchTxForkEnabled(next)
+	// Enable custom tx types
+	pool.customTxType = pool.chainconfig.IsCustomTxTypeForkEnabled(next)
 
 	// It need to update gas price of tx pool after magma hardfork
 	if pool.magma {
@@ -666,6 +674,14 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 	if !pool.eip1559 && tx.Type() == types.TxTypeEthereumDynamicFee {
 		return ErrTxTypeNotSupported
 	}
+	// Reject batch transactions until the batch transaction hardfork activates.
+	if !pool.batchTx && tx.Type().IsBatch() {
+		return ErrTxTypeNotSupported
+	}
+	// Reject custom tx types until the custom tx type fork activates.
+	if !pool.customTxType && tx.Type().IsCustomTxType() {
+		return ErrTxTypeNotSupported
+	}
 
 	gasFeePayer := uint64(0)
 
@@ -822,6 +838,16 @@ func (pool *TxPool) validateTx(tx *types.Transaction) error {
 		return err
 	}
 
+	// Check the expiry and the scope of the session keys of the sender and the fee payer
+	// in the pending block, which is generated at least a block interval after the head.
+	pendingTime := pool.currentBlockTime + uint64(params.BlockGenerationInterval)
+	if now := uint64(time.Now().Unix()); now > pendingTime {
+		pendingTime = now
+	}
+	if err := tx.ValidateSessionKeys(pool.currentState, pool.currentBlockNumber+1, pendingTime); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -910,10 +936,10 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 	from, _ := types.Sender(pool.signer, tx) // already validated
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump, pool.magma)
+		inserted, old, err := list.Add(tx, pool.config.PriceBump, pool.magma)
 		if !inserted {
 			pendingDiscardCounter.Inc(1)
-			return false, ErrAlreadyNonceExistInPool
+			return false, err
 		}
 		// New transaction is better, replace old one
 		if old != nil {
@@ -956,11 +982,11 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 	if pool.queue[from] == nil {
 		pool.queue[from] = newTxList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump, pool.magma)
+	inserted, old, err := pool.queue[from].Add(tx, pool.config.PriceBump, pool.magma)
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardCounter.Inc(1)
-		return false, ErrAlreadyNonceExistInPool
+		return false, err
 	}
 	// Discard any previous transaction and mark this
 	if old != nil {
@@ -1000,7 +1026,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump, pool.magma)
+	inserted, old, _ := list.Add(tx, pool.config.PriceBump, pool.magma)
 	if !inserted {
 		// An older transaction was better, discard this
 		delete(pool.all, hash)
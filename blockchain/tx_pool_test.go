@@ -38,9 +38,11 @@ import (
 
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/storage/database"
 	"github.com/stretchr/testify/assert"
@@ -468,6 +470,47 @@ func genAnchorTx(nonce uint64) *types.Transaction {
 	return tx
 }
 
+func TestSessionKeyExpiryInPendingBlock(t *testing.T) {
+	t.Parallel()
+
+	pool, ownerKey := setupTxPool()
+	defer pool.Stop()
+
+	sessionKey, _ := crypto.GenerateKey()
+	from := common.HexToAddress("0x1234")
+	newTx := func(nonce uint64) *types.Transaction {
+		tx, _ := types.NewTransactionWithMap(types.TxTypeValueTransfer, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    nonce,
+			types.TxValueKeyFrom:     from,
+			types.TxValueKeyTo:       common.HexToAddress("0xAAAA"),
+			types.TxValueKeyAmount:   big.NewInt(1),
+			types.TxValueKeyGasLimit: uint64(100000),
+			types.TxValueKeyGasPrice: big.NewInt(1),
+		})
+		tx.Sign(types.LatestSignerForChainID(params.TestChainConfig.ChainID), sessionKey)
+		return tx
+	}
+	installSessionKey := func(expiryBlock, expiryTime uint64) {
+		session := accountkey.NewAccountKeySessionWithValues(accountkey.NewAccountKeyPublicWithValue(&sessionKey.PublicKey),
+			expiryBlock, expiryTime, nil, nil, big.NewInt(1))
+		pool.mu.Lock()
+		pool.currentState.CreateEOA(from, false, accountkey.NewAccountKeyRoleBasedWithValues(accountkey.AccountKeyRoleBased{
+			session, accountkey.NewAccountKeyPublicWithValue(&ownerKey.PublicKey),
+		}))
+		pool.currentState.AddBalance(from, big.NewInt(10000000))
+		pool.mu.Unlock()
+	}
+
+	// The session key expires at the pending block
+	installSessionKey(pool.currentBlockNumber+1, 0)
+	assert.Equal(t, kerrors.ErrSessionKeyExpired, pool.AddRemote(newTx(0)))
+	installSessionKey(0, uint64(time.Now().Unix()))
+	assert.Equal(t, kerrors.ErrSessionKeyExpired, pool.AddRemote(newTx(0)))
+
+	installSessionKey(pool.currentBlockNumber+2, 0)
+	assert.NoError(t, pool.AddRemote(newTx(0)))
+}
+
 func TestAnchorTransactions(t *testing.T) {
 	t.Parallel()
 
@@ -33,6 +33,8 @@ const (
 	AccountKeyTypeFail
 	AccountKeyTypeWeightedMultiSig
 	AccountKeyTypeRoleBased
+	AccountKeyTypePublicP256
+	AccountKeyTypeSession
 	AccountKeyTypeLast
 )
 
@@ -40,6 +42,8 @@ var (
 	errUndefinedAccountKeyType = errors.New("undefined account key type")
 	errWrongPubkeyLength       = errors.New("wrong pubkey length")
 	errInvalidSignature        = errors.New("invalid signature")
+	errWrongSelectorLength     = errors.New("wrong method selector length")
+	errInvalidValueLimit       = errors.New("invalid value limit")
 )
 
 var logger = log.NewModuleLogger(log.BlockchainTypesAccountKey)
@@ -103,6 +107,10 @@ func NewAccountKey(t AccountKeyType) (AccountKey, error) {
 		return NewAccountKeyWeightedMultiSig(), nil
 	case AccountKeyTypeRoleBased:
 		return NewAccountKeyRoleBased(), nil
+	case AccountKeyTypePublicP256:
+		return NewAccountKeyPublicP256(), nil
+	case AccountKeyTypeSession:
+		return NewAccountKeySession(), nil
 	}
 
 	return nil, errUndefinedAccountKeyType
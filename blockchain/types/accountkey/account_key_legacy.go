@@ -59,6 +59,10 @@ func (a *AccountKeyLegacy) Validate(currentBlockNumber uint64, r RoleType, recov
 	if len(recoveredKeys) != 1 {
 		return false
 	}
+	// The address is derived from an S256 key
+	if crypto.IsP256(recoveredKeys[0]) {
+		return false
+	}
 	return from == crypto.PubkeyToAddress(*recoveredKeys[0])
 }
 
@@ -87,8 +87,8 @@ func (a *AccountKeyPublic) SigValidationGas(currentBlockNumber uint64, r RoleTyp
 }
 
 func (a *AccountKeyPublic) CheckInstallable(currentBlockNumber uint64) error {
-	// If the point is not on the curve, return an error.
-	if a.IsOnCurve(a.X, a.Y) == false {
+	// If the point is not on the S256 curve, return an error.
+	if a.IsP256() || a.IsOnCurve(a.X, a.Y) == false {
 		return kerrors.ErrNotOnCurve
 	}
 	return nil
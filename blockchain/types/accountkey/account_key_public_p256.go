@@ -0,0 +1,119 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package accountkey
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/kerrors"
+	"github.com/klaytn/klaytn/params"
+)
+
+// AccountKeyPublicP256 is used for accounts having one public key on the P-256 (secp256r1) curve,
+// which is generated by passkeys and WebAuthn authenticators.
+// It works in the same way as AccountKeyPublic, but the transactions should be signed
+// with P-256 signatures. Refer to types.P256SignatureVOffset.
+// It can be installed after the P-256 hardfork.
+type AccountKeyPublicP256 struct {
+	*PublicKeySerializable
+}
+
+func NewAccountKeyPublicP256WithValue(pk *ecdsa.PublicKey) *AccountKeyPublicP256 {
+	return &AccountKeyPublicP256{(*PublicKeySerializable)(pk)}
+}
+
+func NewAccountKeyPublicP256() *AccountKeyPublicP256 {
+	return &AccountKeyPublicP256{newP256PublicKeySerializable()}
+}
+
+func (a *AccountKeyPublicP256) Type() AccountKeyType {
+	return AccountKeyTypePublicP256
+}
+
+func (a *AccountKeyPublicP256) IsCompositeType() bool {
+	return false
+}
+
+func (a *AccountKeyPublicP256) DeepCopy() AccountKey {
+	return &AccountKeyPublicP256{
+		a.PublicKeySerializable.DeepCopy(),
+	}
+}
+
+func (a *AccountKeyPublicP256) Equal(b AccountKey) bool {
+	tb, ok := b.(*AccountKeyPublicP256)
+	if !ok {
+		return false
+	}
+	return a.PublicKeySerializable.Equal(tb.PublicKeySerializable)
+}
+
+func (a *AccountKeyPublicP256) Validate(currentBlockNumber uint64, r RoleType, recoveredKeys []*ecdsa.PublicKey, from common.Address) bool {
+	// AccountKeyPublicP256 has only one public key.
+	if len(recoveredKeys) != 1 {
+		return false
+	}
+
+	return a.PublicKeySerializable.Equal((*PublicKeySerializable)(recoveredKeys[0]))
+}
+
+func (a *AccountKeyPublicP256) String() string {
+	return fmt.Sprintf("AccountKeyPublicP256: %s", a.PublicKeySerializable.String())
+}
+
+func (a *AccountKeyPublicP256) AccountCreationGas(currentBlockNumber uint64) (uint64, error) {
+	return numKeys * params.TxAccountCreationGasPerKey, nil
+}
+
+// SigValidationGas returns the additional gas for a P-256 signature since the intrinsic gas
+// covers the validation of one secp256k1 signature only.
+func (a *AccountKeyPublicP256) SigValidationGas(currentBlockNumber uint64, r RoleType, validSigNum int) (uint64, error) {
+	return numKeys * params.TxValidationGasP256, nil
+}
+
+func (a *AccountKeyPublicP256) CheckInstallable(currentBlockNumber uint64) error {
+	if !fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsP256 {
+		return kerrors.ErrNotSupported
+	}
+	// If the point is not on the curve, return an error.
+	if !a.IsP256() || !a.IsOnCurve(a.X, a.Y) {
+		return kerrors.ErrNotOnCurve
+	}
+	return nil
+}
+
+func (a *AccountKeyPublicP256) CheckUpdatable(newKey AccountKey, currentBlockNumber uint64) error {
+	if newKey, ok := newKey.(*AccountKeyPublicP256); ok {
+		return newKey.CheckInstallable(currentBlockNumber)
+	}
+	// Update is not possible if the type is different.
+	return kerrors.ErrDifferentAccountKeyType
+}
+
+func (a *AccountKeyPublicP256) Update(newKey AccountKey, currentBlockNumber uint64) error {
+	if err := a.CheckUpdatable(newKey, currentBlockNumber); err != nil {
+		return err
+	}
+	newPubKey, _ := newKey.(*AccountKeyPublicP256)
+	a.X = newPubKey.X
+	a.Y = newPubKey.Y
+	return nil
+}
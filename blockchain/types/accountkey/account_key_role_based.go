@@ -214,13 +214,50 @@ func (a *AccountKeyRoleBased) CheckInstallable(currentBlockNumber uint64) error
 			return kerrors.ErrNestedCompositeType
 		}
 		// If any key in the role cannot be initialized, return an error.
-		if err := (*a)[i].CheckInstallable(currentBlockNumber); err != nil {
+		if err := checkRoleInstallable((*a)[i], currentBlockNumber); err != nil {
 			return err
 		}
 	}
+	return a.checkAccountUpdateKey()
+}
+
+// checkRoleInstallable returns an error if the key cannot be installed as a role key.
+func checkRoleInstallable(key AccountKey, currentBlockNumber uint64) error {
+	if session, ok := key.(*AccountKeySession); ok {
+		return session.checkRoleInstallable(currentBlockNumber)
+	}
+	return key.CheckInstallable(currentBlockNumber)
+}
+
+// checkAccountUpdateKey returns an error if the key used for RoleAccountUpdate is a session key,
+// which would make the account not updatable.
+func (a *AccountKeyRoleBased) checkAccountUpdateKey() error {
+	key := a.getDefaultKey()
+	if len(*a) > int(RoleAccountUpdate) {
+		key = (*a)[RoleAccountUpdate]
+	}
+	if key.Type() == AccountKeyTypeSession {
+		return kerrors.ErrSessionKeyNotRoleKey
+	}
 	return nil
 }
 
+// merge returns the key where the role keys of newKey replace the ones of a except AccountKeyNil.
+func (a *AccountKeyRoleBased) merge(newKey *AccountKeyRoleBased) *AccountKeyRoleBased {
+	merged := make(AccountKeyRoleBased, len(*a))
+	copy(merged, *a)
+	if len(merged) < len(*newKey) {
+		merged = append(merged, (*newKey)[len(merged):]...)
+	}
+	for i, key := range *newKey {
+		if key.Type() == AccountKeyTypeNil {
+			continue
+		}
+		merged[i] = key
+	}
+	return &merged
+}
+
 func (a *AccountKeyRoleBased) CheckUpdatable(newKey AccountKey, currentBlockNumber uint64) error {
 	if newKey, ok := newKey.(*AccountKeyRoleBased); ok {
 		lenOldKey := len(*a)
@@ -240,12 +277,17 @@ func (a *AccountKeyRoleBased) CheckUpdatable(newKey AccountKey, currentBlockNumb
 				return kerrors.ErrNestedCompositeType
 			// If newKey is longer than oldKey, init the new attributes.
 			case i >= lenOldKey:
-				if err := (*newKey)[i].CheckInstallable(currentBlockNumber); err != nil {
+				if err := checkRoleInstallable((*newKey)[i], currentBlockNumber); err != nil {
 					return err
 				}
 			// Do nothing for AccountKeyTypeNil
 			case (*newKey)[i].Type() == AccountKeyTypeNil:
 
+			// A session key replaces any key if it is installable
+			case (*newKey)[i].Type() == AccountKeyTypeSession:
+				if err := checkRoleInstallable((*newKey)[i], currentBlockNumber); err != nil {
+					return err
+				}
 			// Check whether the newKey is replacable or not
 			default:
 				if err := CheckReplacable((*a)[i], (*newKey)[i], currentBlockNumber); err != nil {
@@ -253,7 +295,7 @@ func (a *AccountKeyRoleBased) CheckUpdatable(newKey AccountKey, currentBlockNumb
 				}
 			}
 		}
-		return nil
+		return a.merge(newKey).checkAccountUpdateKey()
 	}
 	// Update is not possible if the type is different.
 	return kerrors.ErrDifferentAccountKeyType
@@ -264,16 +306,6 @@ func (a *AccountKeyRoleBased) Update(newKey AccountKey, currentBlockNumber uint6
 		return err
 	}
 	newRoleKey, _ := newKey.(*AccountKeyRoleBased)
-	lenNewKey := len(*newRoleKey)
-	lenOldKey := len(*a)
-	if lenOldKey < lenNewKey {
-		*a = append(*a, (*newRoleKey)[lenOldKey:]...)
-	}
-	for i := 0; i < lenNewKey; i++ {
-		if (*newRoleKey)[i].Type() == AccountKeyTypeNil {
-			continue
-		}
-		(*a)[i] = (*newRoleKey)[i]
-	}
+	*a = *a.merge(newRoleKey)
 	return nil
 }
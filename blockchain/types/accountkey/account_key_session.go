@@ -0,0 +1,389 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package accountkey
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"math/big"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/kerrors"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// SelectorLength is the length of a method selector, the first four bytes of the input of a contract call.
+const SelectorLength = 4
+
+// Selector is a method selector allowed by a session key.
+type Selector [SelectorLength]byte
+
+// AccountKeySession is a temporary key which wraps another key with restrictions.
+// It is intended to be given to a browser or a device by game and social dApps.
+// The transactions validated by the key should satisfy the following conditions:
+//  1. The block number is less than ExpiryBlock and the block timestamp is less than ExpiryTime.
+//     Zero means no expiry, but at least one of them should be set.
+//  2. If Targets is not empty, the recipient of every call should be one of Targets.
+//  3. If Selectors is not empty, the input of every call should start with one of Selectors.
+//  4. The value of the transaction is not greater than ValueLimit.
+//
+// The session key is not valid for RoleAccountUpdate. To keep the account updatable,
+// it can be installed only as a role key of AccountKeyRoleBased whose key for RoleAccountUpdate,
+// including the default one, is not a session key.
+// Unlike AccountKeyRoleBased, it is not a composite type so that it can be a role key,
+// but the wrapped key cannot be a composite type or another session key.
+// The expiry and the scope other than the expiry block are checked by ValidateSessionContext
+// since they depend on the transaction and the block. Refer to SessionContext.
+type AccountKeySession struct {
+	Key         AccountKey
+	ExpiryBlock uint64
+	ExpiryTime  uint64
+	Targets     []common.Address
+	Selectors   []Selector
+	ValueLimit  *big.Int
+}
+
+type accountKeySessionRLP struct {
+	Key         []byte
+	ExpiryBlock uint64
+	ExpiryTime  uint64
+	Targets     []common.Address
+	Selectors   []Selector
+	ValueLimit  *big.Int
+}
+
+type accountKeySessionJSON struct {
+	Key         *AccountKeySerializer `json:"key"`
+	ExpiryBlock hexutil.Uint64        `json:"expiryBlock"`
+	ExpiryTime  hexutil.Uint64        `json:"expiryTime"`
+	Targets     []common.Address      `json:"targets"`
+	Selectors   []hexutil.Bytes       `json:"selectors"`
+	ValueLimit  *hexutil.Big          `json:"valueLimit"`
+}
+
+// SessionCall is a call of a transaction checked against the scope of a session key.
+type SessionCall struct {
+	To   *common.Address
+	Data []byte
+}
+
+// SessionContext contains the attributes of a transaction and the block including it,
+// which are checked against the expiry and the scope of a session key.
+type SessionContext struct {
+	BlockNumber uint64
+	Time        uint64
+	Value       *big.Int
+	Calls       []SessionCall
+}
+
+func NewAccountKeySession() *AccountKeySession {
+	return &AccountKeySession{
+		Key:        NewAccountKeyNil(),
+		ValueLimit: new(big.Int),
+	}
+}
+
+func NewAccountKeySessionWithValues(key AccountKey, expiryBlock, expiryTime uint64, targets []common.Address, selectors []Selector, valueLimit *big.Int) *AccountKeySession {
+	if valueLimit == nil {
+		valueLimit = new(big.Int)
+	}
+	return &AccountKeySession{key, expiryBlock, expiryTime, targets, selectors, valueLimit}
+}
+
+func (a *AccountKeySession) Type() AccountKeyType {
+	return AccountKeyTypeSession
+}
+
+func (a *AccountKeySession) IsCompositeType() bool {
+	return false
+}
+
+func (a *AccountKeySession) DeepCopy() AccountKey {
+	targets := make([]common.Address, len(a.Targets))
+	copy(targets, a.Targets)
+	selectors := make([]Selector, len(a.Selectors))
+	copy(selectors, a.Selectors)
+
+	return &AccountKeySession{
+		a.Key.DeepCopy(), a.ExpiryBlock, a.ExpiryTime, targets, selectors, new(big.Int).Set(a.ValueLimit),
+	}
+}
+
+func (a *AccountKeySession) Equal(b AccountKey) bool {
+	tb, ok := b.(*AccountKeySession)
+	if !ok {
+		return false
+	}
+
+	if len(a.Targets) != len(tb.Targets) || len(a.Selectors) != len(tb.Selectors) {
+		return false
+	}
+	for i := range a.Targets {
+		if a.Targets[i] != tb.Targets[i] {
+			return false
+		}
+	}
+	for i := range a.Selectors {
+		if a.Selectors[i] != tb.Selectors[i] {
+			return false
+		}
+	}
+
+	return a.Key.Equal(tb.Key) &&
+		a.ExpiryBlock == tb.ExpiryBlock &&
+		a.ExpiryTime == tb.ExpiryTime &&
+		a.ValueLimit.Cmp(tb.ValueLimit) == 0
+}
+
+func (a *AccountKeySession) EncodeRLP(w io.Writer) error {
+	key, err := rlp.EncodeToBytes(NewAccountKeySerializerWithAccountKey(a.Key))
+	if err != nil {
+		return err
+	}
+
+	return rlp.Encode(w, &accountKeySessionRLP{key, a.ExpiryBlock, a.ExpiryTime, a.Targets, a.Selectors, a.ValueLimit})
+}
+
+func (a *AccountKeySession) DecodeRLP(s *rlp.Stream) error {
+	dec := &accountKeySessionRLP{}
+	if err := s.Decode(dec); err != nil {
+		return err
+	}
+
+	serializer := NewAccountKeySerializer()
+	if err := rlp.DecodeBytes(dec.Key, &serializer); err != nil {
+		return err
+	}
+
+	a.Key = serializer.key
+	a.ExpiryBlock = dec.ExpiryBlock
+	a.ExpiryTime = dec.ExpiryTime
+	a.Targets = dec.Targets
+	a.Selectors = dec.Selectors
+	a.ValueLimit = dec.ValueLimit
+
+	return nil
+}
+
+func (a *AccountKeySession) MarshalJSON() ([]byte, error) {
+	selectors := make([]hexutil.Bytes, len(a.Selectors))
+	for i, s := range a.Selectors {
+		selectors[i] = common.CopyBytes(s[:])
+	}
+
+	return json.Marshal(&accountKeySessionJSON{
+		NewAccountKeySerializerWithAccountKey(a.Key),
+		hexutil.Uint64(a.ExpiryBlock),
+		hexutil.Uint64(a.ExpiryTime),
+		a.Targets,
+		selectors,
+		(*hexutil.Big)(a.ValueLimit),
+	})
+}
+
+func (a *AccountKeySession) UnmarshalJSON(b []byte) error {
+	var dec accountKeySessionJSON
+	if err := json.Unmarshal(b, &dec); err != nil {
+		return err
+	}
+
+	if dec.Key == nil {
+		return errNoKeyType
+	}
+
+	selectors := make([]Selector, len(dec.Selectors))
+	for i, s := range dec.Selectors {
+		if len(s) != SelectorLength {
+			return errWrongSelectorLength
+		}
+		copy(selectors[i][:], s)
+	}
+
+	a.Key = dec.Key.key
+	a.ExpiryBlock = uint64(dec.ExpiryBlock)
+	a.ExpiryTime = uint64(dec.ExpiryTime)
+	a.Targets = dec.Targets
+	a.Selectors = selectors
+	a.ValueLimit = new(big.Int)
+	if dec.ValueLimit != nil {
+		a.ValueLimit = (*big.Int)(dec.ValueLimit)
+	}
+
+	return nil
+}
+
+func (a *AccountKeySession) Validate(currentBlockNumber uint64, r RoleType, recoveredKeys []*ecdsa.PublicKey, from common.Address) bool {
+	// A temporary key cannot replace the keys of the account.
+	if r == RoleAccountUpdate {
+		logger.Debug("AccountKeySession validation failed since the key cannot update the account")
+		return false
+	}
+	if a.ExpiryBlock != 0 && currentBlockNumber >= a.ExpiryBlock {
+		logger.Debug("AccountKeySession validation failed since the key has been expired",
+			"expiryBlock", a.ExpiryBlock, "currentBlockNumber", currentBlockNumber)
+		return false
+	}
+	return a.Key.Validate(currentBlockNumber, r, recoveredKeys, from)
+}
+
+// CheckContext returns an error if the key is expired at the block of ctx or the transaction of ctx is out of the scope.
+func (a *AccountKeySession) CheckContext(ctx *SessionContext) error {
+	if (a.ExpiryBlock != 0 && ctx.BlockNumber >= a.ExpiryBlock) ||
+		(a.ExpiryTime != 0 && ctx.Time >= a.ExpiryTime) {
+		return kerrors.ErrSessionKeyExpired
+	}
+
+	if ctx.Value != nil && ctx.Value.Cmp(a.ValueLimit) > 0 {
+		return kerrors.ErrSessionKeyValueLimitExceeded
+	}
+
+	for _, call := range ctx.Calls {
+		if len(a.Targets) > 0 && !a.isTarget(call.To) {
+			return kerrors.ErrSessionKeyTargetNotAllowed
+		}
+		if len(a.Selectors) > 0 && !a.isSelector(call.Data) {
+			return kerrors.ErrSessionKeyMethodNotAllowed
+		}
+	}
+
+	return nil
+}
+
+func (a *AccountKeySession) isTarget(to *common.Address) bool {
+	if to == nil {
+		return false
+	}
+	for _, target := range a.Targets {
+		if target == *to {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AccountKeySession) isSelector(data []byte) bool {
+	if len(data) < SelectorLength {
+		return false
+	}
+	for _, selector := range a.Selectors {
+		if bytes.Equal(selector[:], data[:SelectorLength]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AccountKeySession) String() string {
+	serializer := NewAccountKeySerializerWithAccountKey(a)
+	b, _ := json.Marshal(serializer)
+	return string(b)
+}
+
+func (a *AccountKeySession) AccountCreationGas(currentBlockNumber uint64) (uint64, error) {
+	gas, err := a.Key.AccountCreationGas(currentBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+	numScopes := uint64(len(a.Targets) + len(a.Selectors))
+
+	return gas + params.TxAccountCreationGasSessionKey + numScopes*params.TxAccountCreationGasPerSessionScope, nil
+}
+
+func (a *AccountKeySession) SigValidationGas(currentBlockNumber uint64, r RoleType, numSigs int) (uint64, error) {
+	gas, err := a.Key.SigValidationGas(currentBlockNumber, r, numSigs)
+	if err != nil {
+		return 0, err
+	}
+
+	return gas + params.TxValidationGasSessionKey, nil
+}
+
+// CheckInstallable always returns an error since the session key cannot be installed as the account key.
+// It is checked by AccountKeyRoleBased with checkRoleInstallable when it is a role key.
+func (a *AccountKeySession) CheckInstallable(currentBlockNumber uint64) error {
+	if !fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsSessionKey {
+		return kerrors.ErrNotSupported
+	}
+	return kerrors.ErrSessionKeyNotRoleKey
+}
+
+// checkRoleInstallable returns an error if any data in the key is invalid as a role key.
+func (a *AccountKeySession) checkRoleInstallable(currentBlockNumber uint64) error {
+	if !fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsSessionKey {
+		return kerrors.ErrNotSupported
+	}
+	// The wrapped key should be a single key.
+	if a.Key == nil || a.Key.IsCompositeType() || a.Key.Type() == AccountKeyTypeSession {
+		return kerrors.ErrNestedCompositeType
+	}
+	if err := a.Key.CheckInstallable(currentBlockNumber); err != nil {
+		return err
+	}
+	if a.ExpiryBlock == 0 && a.ExpiryTime == 0 {
+		return kerrors.ErrSessionKeyNoExpiry
+	}
+	if a.ExpiryBlock != 0 && currentBlockNumber >= a.ExpiryBlock {
+		return kerrors.ErrSessionKeyExpired
+	}
+	if len(a.Targets) > params.MaxSessionKeyTargets || len(a.Selectors) > params.MaxSessionKeySelectors {
+		return kerrors.ErrLengthTooLong
+	}
+	if a.ValueLimit == nil || a.ValueLimit.Sign() < 0 {
+		return errInvalidValueLimit
+	}
+	return nil
+}
+
+func (a *AccountKeySession) CheckUpdatable(newKey AccountKey, currentBlockNumber uint64) error {
+	if newKey, ok := newKey.(*AccountKeySession); ok {
+		return newKey.checkRoleInstallable(currentBlockNumber)
+	}
+	// Update is not possible if the type is different.
+	return kerrors.ErrDifferentAccountKeyType
+}
+
+func (a *AccountKeySession) Update(newKey AccountKey, currentBlockNumber uint64) error {
+	if err := a.CheckUpdatable(newKey, currentBlockNumber); err != nil {
+		return err
+	}
+	newSessionKey, _ := newKey.(*AccountKeySession)
+	*a = *newSessionKey
+	return nil
+}
+
+// ValidateSessionContext returns an error if the key of the given role is a session key
+// and it does not allow the transaction described by ctx.
+// For other types of keys, it returns nil.
+func ValidateSessionContext(accKey AccountKey, r RoleType, ctx *SessionContext) error {
+	// Pick the key of the role as AccountKeyRoleBased.Validate does
+	if roleBased, ok := accKey.(*AccountKeyRoleBased); ok {
+		if len(*roleBased) > int(r) {
+			accKey = (*roleBased)[r]
+		} else {
+			accKey = roleBased.getDefaultKey()
+		}
+	}
+	if session, ok := accKey.(*AccountKeySession); ok {
+		return session.CheckContext(ctx)
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/kerrors"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +42,10 @@ func TestAccountKeySerialization(t *testing.T) {
 		{"Fail", genAccountKeyFail()},
 		{"WeightedMultisig", genAccountKeyWeightedMultisig()},
 		{"RoleBased", genAccountKeyRoleBased()},
+		{"PublicP256", genAccountKeyPublicP256()},
+		{"WeightedMultisigP256", genAccountKeyWeightedMultisigP256()},
+		{"Session", genAccountKeySession()},
+		{"RoleBasedSession", NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{genAccountKeySession(), genAccountKeyPublic()})},
 	}
 
 	testcases := []struct {
@@ -142,6 +147,21 @@ func genAccountKeyWeightedMultisig() AccountKey {
 	return NewAccountKeyWeightedMultiSigWithValues(threshold, keys)
 }
 
+func genAccountKeyPublicP256() AccountKey {
+	k, _ := crypto.GenerateP256Key()
+	return NewAccountKeyPublicP256WithValue(&k.PublicKey)
+}
+
+func genAccountKeyWeightedMultisigP256() AccountKey {
+	k1, _ := crypto.GenerateKey()
+	k2, _ := crypto.GenerateP256Key()
+	keys := WeightedPublicKeys{
+		NewWeightedPublicKey(1, (*PublicKeySerializable)(&k1.PublicKey)),
+		NewWeightedPublicKey(1, (*PublicKeySerializable)(&k2.PublicKey)),
+	}
+	return NewAccountKeyWeightedMultiSigWithValues(1, keys)
+}
+
 func genAccountKeyRoleBased() AccountKey {
 	k1, err := crypto.HexToECDSA("98275a145bc1726eb0445433088f5f882f8a4a9499135239cfb4040e78991dab")
 	if err != nil {
@@ -169,6 +189,12 @@ func genAccountKeyRoleBased() AccountKey {
 	return NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{txKey, updateKey, feeKey})
 }
 
+func genAccountKeySession() AccountKey {
+	targets := []common.Address{common.HexToAddress("0x1111"), common.HexToAddress("0x2222")}
+	selectors := []Selector{{0xa9, 0x05, 0x9c, 0xbb}}
+	return NewAccountKeySessionWithValues(genAccountKeyPublic(), 100, 1700000000, targets, selectors, big.NewInt(1000))
+}
+
 func TestAccountKeyWeightedMultiSig_Validate(t *testing.T) {
 	// declare special block numbers and set hardForkBlockNumberConfig
 	var (
@@ -284,3 +310,147 @@ func getValidKeys(multiSigAccount AccountKey, num int) []*ecdsa.PublicKey {
 	}
 	return pubKeys
 }
+
+func TestAccountKeyPublicP256(t *testing.T) {
+	// declare special block numbers and set hardForkBlockNumberConfig
+	var (
+		blockBeforeHF = uint64(4)
+		blockAfterHF  = uint64(5)
+	)
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{P256CompatibleBlock: new(big.Int).SetUint64(blockAfterHF)})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	p256Key, _ := crypto.GenerateP256Key()
+	s256Key, _ := crypto.GenerateKey()
+	accKey := NewAccountKeyPublicP256WithValue(&p256Key.PublicKey)
+
+	// P-256 keys can be installed after the hardfork only
+	assert.Equal(t, kerrors.ErrNotSupported, accKey.CheckInstallable(blockBeforeHF))
+	assert.NoError(t, accKey.CheckInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrNotOnCurve, NewAccountKeyPublicP256WithValue(&s256Key.PublicKey).CheckInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrNotOnCurve, NewAccountKeyPublicWithValue(&p256Key.PublicKey).CheckInstallable(blockAfterHF))
+
+	multiSig := genAccountKeyWeightedMultisigP256()
+	assert.Equal(t, kerrors.ErrNotSupported, multiSig.CheckInstallable(blockBeforeHF))
+	assert.NoError(t, multiSig.CheckInstallable(blockAfterHF))
+
+	// Validate
+	assert.True(t, accKey.Validate(blockAfterHF, RoleTransaction, []*ecdsa.PublicKey{&p256Key.PublicKey}, common.Address{}))
+	assert.False(t, accKey.Validate(blockAfterHF, RoleTransaction, []*ecdsa.PublicKey{&s256Key.PublicKey}, common.Address{}))
+	assert.False(t, accKey.Validate(blockAfterHF, RoleTransaction, []*ecdsa.PublicKey{&p256Key.PublicKey, &p256Key.PublicKey}, common.Address{}))
+	// The same coordinates on a different curve are not the same key
+	sameCoords := &ecdsa.PublicKey{Curve: crypto.S256(), X: p256Key.X, Y: p256Key.Y}
+	assert.False(t, accKey.Validate(blockAfterHF, RoleTransaction, []*ecdsa.PublicKey{sameCoords}, common.Address{}))
+	assert.False(t, NewAccountKeyLegacy().Validate(blockAfterHF, RoleTransaction, []*ecdsa.PublicKey{&p256Key.PublicKey}, common.Address{}))
+
+	// SigValidationGas
+	gas, err := accKey.SigValidationGas(blockAfterHF, RoleTransaction, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, params.TxValidationGasP256, gas)
+
+	gas, err = multiSig.SigValidationGas(blockAfterHF, RoleTransaction, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, params.TxValidationGasPerKey+params.TxValidationGasP256, gas)
+}
+
+func TestAccountKeySession(t *testing.T) {
+	// declare special block numbers and set hardForkBlockNumberConfig
+	var (
+		blockBeforeHF = uint64(4)
+		blockAfterHF  = uint64(5)
+		expiryBlock   = uint64(100)
+		expiryTime    = uint64(1700000000)
+	)
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{SessionKeyCompatibleBlock: new(big.Int).SetUint64(blockAfterHF)})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	prvKey, _ := crypto.GenerateKey()
+	anotherKey, _ := crypto.GenerateKey()
+	target := common.HexToAddress("0x1111")
+	selector := Selector{0xa9, 0x05, 0x9c, 0xbb}
+	newSessionKey := func(expiryBlock, expiryTime uint64) *AccountKeySession {
+		return NewAccountKeySessionWithValues(NewAccountKeyPublicWithValue(&prvKey.PublicKey), expiryBlock, expiryTime,
+			[]common.Address{target}, []Selector{selector}, big.NewInt(1000))
+	}
+	accKey := newSessionKey(expiryBlock, expiryTime)
+
+	// CheckInstallable
+	assert.Equal(t, kerrors.ErrNotSupported, accKey.CheckInstallable(blockBeforeHF))
+	// A session key cannot be the account key by itself
+	assert.Equal(t, kerrors.ErrSessionKeyNotRoleKey, accKey.CheckInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrNotSupported, accKey.checkRoleInstallable(blockBeforeHF))
+	assert.NoError(t, accKey.checkRoleInstallable(blockAfterHF))
+	assert.NoError(t, newSessionKey(0, expiryTime).checkRoleInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrSessionKeyNoExpiry, newSessionKey(0, 0).checkRoleInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrSessionKeyExpired, accKey.checkRoleInstallable(expiryBlock))
+	assert.Equal(t, kerrors.ErrNestedCompositeType,
+		NewAccountKeySessionWithValues(genAccountKeyRoleBased(), expiryBlock, 0, nil, nil, nil).checkRoleInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrNestedCompositeType,
+		NewAccountKeySessionWithValues(accKey, expiryBlock, 0, nil, nil, nil).checkRoleInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrAccountKeyNilUninitializable,
+		NewAccountKeySessionWithValues(NewAccountKeyNil(), expiryBlock, 0, nil, nil, nil).checkRoleInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrLengthTooLong,
+		NewAccountKeySessionWithValues(genAccountKeyPublic(), expiryBlock, 0, nil, make([]Selector, params.MaxSessionKeySelectors+1), nil).checkRoleInstallable(blockAfterHF))
+	// A session key can be a role key other than the account update key
+	roleBased := NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{accKey, genAccountKeyPublic()})
+	assert.NoError(t, roleBased.CheckInstallable(blockAfterHF))
+	assert.NoError(t, NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{genAccountKeyPublic(), genAccountKeyPublic(), accKey}).CheckInstallable(blockAfterHF))
+	assert.Equal(t, kerrors.ErrSessionKeyNotRoleKey,
+		NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{genAccountKeyPublic(), accKey}).CheckInstallable(blockAfterHF))
+	// The transaction key is used for the account update without the account update key
+	assert.Equal(t, kerrors.ErrSessionKeyNotRoleKey, NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{accKey}).CheckInstallable(blockAfterHF))
+
+	// CheckUpdatable
+	assert.NoError(t, roleBased.CheckUpdatable(NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{newSessionKey(expiryBlock+1, 0)}), blockAfterHF))
+	assert.NoError(t, roleBased.CheckUpdatable(NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{genAccountKeyPublic(), NewAccountKeyNil(), accKey}), blockAfterHF))
+	assert.Equal(t, kerrors.ErrSessionKeyExpired,
+		roleBased.CheckUpdatable(NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{newSessionKey(blockAfterHF, 0)}), blockAfterHF))
+	assert.Equal(t, kerrors.ErrSessionKeyNotRoleKey,
+		roleBased.CheckUpdatable(NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{NewAccountKeyNil(), accKey}), blockAfterHF))
+	singleRole := NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{genAccountKeyPublic()})
+	assert.Equal(t, kerrors.ErrSessionKeyNotRoleKey,
+		singleRole.CheckUpdatable(NewAccountKeyRoleBasedWithValues(AccountKeyRoleBased{accKey}), blockAfterHF))
+
+	// Validate
+	pubKeys := []*ecdsa.PublicKey{&prvKey.PublicKey}
+	assert.True(t, accKey.Validate(blockAfterHF, RoleTransaction, pubKeys, common.Address{}))
+	assert.True(t, accKey.Validate(blockAfterHF, RoleFeePayer, pubKeys, common.Address{}))
+	assert.False(t, accKey.Validate(blockAfterHF, RoleAccountUpdate, pubKeys, common.Address{}))
+	assert.False(t, accKey.Validate(expiryBlock, RoleTransaction, pubKeys, common.Address{}))
+	assert.False(t, accKey.Validate(blockAfterHF, RoleTransaction, []*ecdsa.PublicKey{&anotherKey.PublicKey}, common.Address{}))
+	assert.True(t, roleBased.Validate(blockAfterHF, RoleTransaction, pubKeys, common.Address{}))
+	assert.False(t, roleBased.Validate(blockAfterHF, RoleAccountUpdate, pubKeys, common.Address{}))
+
+	// ValidateSessionContext
+	data := append(common.CopyBytes(selector[:]), make([]byte, 64)...)
+	anotherTarget := common.HexToAddress("0x2222")
+	testcases := []struct {
+		ctx *SessionContext
+		err error
+	}{
+		{&SessionContext{blockAfterHF, expiryTime - 1, big.NewInt(1000), []SessionCall{{&target, data}}}, nil},
+		{&SessionContext{expiryBlock, expiryTime - 1, big.NewInt(0), []SessionCall{{&target, data}}}, kerrors.ErrSessionKeyExpired},
+		{&SessionContext{blockAfterHF, expiryTime, big.NewInt(0), []SessionCall{{&target, data}}}, kerrors.ErrSessionKeyExpired},
+		{&SessionContext{blockAfterHF, expiryTime - 1, big.NewInt(1001), []SessionCall{{&target, data}}}, kerrors.ErrSessionKeyValueLimitExceeded},
+		{&SessionContext{blockAfterHF, expiryTime - 1, big.NewInt(0), []SessionCall{{&anotherTarget, data}}}, kerrors.ErrSessionKeyTargetNotAllowed},
+		{&SessionContext{blockAfterHF, expiryTime - 1, big.NewInt(0), []SessionCall{{nil, data}}}, kerrors.ErrSessionKeyTargetNotAllowed},
+		{&SessionContext{blockAfterHF, expiryTime - 1, big.NewInt(0), []SessionCall{{&target, []byte{0x01, 0x02, 0x03, 0x04}}}}, kerrors.ErrSessionKeyMethodNotAllowed},
+		{&SessionContext{blockAfterHF, expiryTime - 1, big.NewInt(0), []SessionCall{{&target, data}, {&target, []byte{}}}}, kerrors.ErrSessionKeyMethodNotAllowed},
+	}
+	for i, tc := range testcases {
+		assert.Equal(t, tc.err, ValidateSessionContext(accKey, RoleTransaction, tc.ctx), "testcase %d", i)
+		assert.Equal(t, tc.err, ValidateSessionContext(roleBased, RoleTransaction, tc.ctx), "testcase %d", i)
+	}
+	// The keys other than session keys are not restricted
+	assert.NoError(t, ValidateSessionContext(roleBased, RoleAccountUpdate, testcases[1].ctx))
+	assert.NoError(t, ValidateSessionContext(genAccountKeyPublic(), RoleTransaction, testcases[1].ctx))
+
+	// Gas
+	gas, err := accKey.SigValidationGas(blockAfterHF, RoleTransaction, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, params.TxValidationGasSessionKey, gas)
+
+	gas, err = accKey.AccountCreationGas(blockAfterHF)
+	assert.NoError(t, err)
+	assert.Equal(t, params.TxAccountCreationGasPerKey+params.TxAccountCreationGasSessionKey+2*params.TxAccountCreationGasPerSessionScope, gas)
+}
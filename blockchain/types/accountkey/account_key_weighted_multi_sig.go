@@ -163,9 +163,24 @@ func (a *AccountKeyWeightedMultiSig) SigValidationGas(currentBlockNumber uint64,
 
 	isIstanbul := fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsIstanbul
 	if isIstanbul {
-		return uint64(numSigs-1) * params.TxValidationGasPerKey, nil
+		return uint64(numSigs-1)*params.TxValidationGasPerKey + a.p256SigValidationGas(uint64(numSigs)), nil
 	}
-	return (numKeys - 1) * params.TxValidationGasPerKey, nil
+	return (numKeys-1)*params.TxValidationGasPerKey + a.p256SigValidationGas(numKeys), nil
+}
+
+// p256SigValidationGas returns the additional gas for the P-256 signatures among numSigs signatures.
+// Since the signatures are not known yet, it is assumed that the P-256 keys sign as many as possible.
+func (a *AccountKeyWeightedMultiSig) p256SigValidationGas(numSigs uint64) uint64 {
+	numP256Keys := uint64(0)
+	for _, k := range a.Keys {
+		if k.Key.IsP256() {
+			numP256Keys++
+		}
+	}
+	if numP256Keys > numSigs {
+		numP256Keys = numSigs
+	}
+	return numP256Keys * params.TxValidationGasP256
 }
 
 func (a *AccountKeyWeightedMultiSig) CheckInstallable(currentBlockNumber uint64) error {
@@ -178,11 +193,16 @@ func (a *AccountKeyWeightedMultiSig) CheckInstallable(currentBlockNumber uint64)
 		return kerrors.ErrMaxKeysExceed
 	}
 	keyMap := make(map[string]bool)
+	isP256 := fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsP256
 	for _, k := range a.Keys {
 		// Do not allow zero weight.
 		if k.Weight == 0 {
 			return kerrors.ErrZeroKeyWeight
 		}
+		// Do not allow P-256 keys before the P-256 hardfork.
+		if k.Key.IsP256() && !isP256 {
+			return kerrors.ErrNotSupported
+		}
 		sum += k.Weight
 
 		b, err := rlp.EncodeToBytes(k.Key)
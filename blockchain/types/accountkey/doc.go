@@ -27,6 +27,8 @@ The AccountKey types used in Klaytn are as follows:
   - AccountKeyTypeFail
   - AccountKeyTypeWeightedMultiSig
   - AccountKeyTypeRoleBased
+  - AccountKeyTypePublicP256
+  - AccountKeyTypeSession
 
 Each AccountKey type implements the AccountKey interface.
 
@@ -38,10 +40,12 @@ AccountKey related functions and variables are defined in the files listed below
   - account_key_legacy.go             : An AccountKey for AccountKeyLegacy type is defined. If an account has the legacy key, the account's key pair should be coupled with its address.
   - account_key_nil.go                : An AccountKey for AccountKeyNil type is defined. The nil key is used only for TxTypeAccountUpdate transactions representing an empty key.
   - account_key_public.go             : An AccountKey for AccountKeyPublic type is defined. If an account contains a public key as an account key, the public key will be used in the account's transaction validation process.
+  - account_key_public_p256.go        : An AccountKey for AccountKeyPublicP256 type is defined. It is the same as AccountKeyPublic except that the public key is on the P-256 curve, which is used by passkeys and WebAuthn authenticators.
   - account_key_role_based.go         : An AccountKey for AccountKeyRoleBased type is defined. AccountKeyRoleBased contains keys that have three roles: RoleTransaction, RoleAccountUpdate, and RoleFeePayer. If an account has a role-based key that consists of more than one key, the account's transaction validation process will use one key in the role-based key depends on the transaction type.
+  - account_key_session.go            : An AccountKey for AccountKeySession type is defined. AccountKeySession wraps another key with an expiry, an allowlist of target contracts and method selectors, and a value limit. It is a temporary key given to browsers or devices by dApps.
   - account_key_serializer.go         : AccountKeySerializer is defined for serialization of AccountKey.
   - account_key_weighted_multi_sig.go : An AccountKey for AccountKeyWeightedMultiSig type is defined. AccountKeyWeightedMultiSig contains Threshold and WeightedPublicKeys.
-  - public_key.go                     : PublicKeySerializable is defined for serialization of public key.
+  - public_key.go                     : PublicKeySerializable is defined for serialization of public key on the S256 or P-256 curve.
 
 For more information on AccountKey, please see the document below.
 https://docs.klaytn.com/klaytn/design/accounts#account-key
@@ -29,9 +29,19 @@ import (
 	"github.com/klaytn/klaytn/rlp"
 )
 
+// p256PubkeyPrefix precedes a compressed P-256 public key in the RLP encoding
+// to distinguish it from a compressed S256 public key of the same length.
+const p256PubkeyPrefix = byte(0x01)
+
+// p256CurveName is the curve name of P-256 public keys in JSON.
+const p256CurveName = "P-256"
+
 var (
-	errNotS256Curve = errors.New("key is not on the S256 curve")
-	errNoXYValue    = errors.New("X or Y value of the public key does not exist")
+	errNotS256Curve       = errors.New("key is not on the S256 curve")
+	errNotP256Curve       = errors.New("key is not on the P-256 curve")
+	errNoXYValue          = errors.New("X or Y value of the public key does not exist")
+	errUnsupportedCurve   = errors.New("unsupported curve")
+	errInvalidP256KeySize = errors.New("invalid P-256 public key size")
 )
 
 // Since ecdsa.PublicKey does not provide RLP/JSON serialization,
@@ -40,8 +50,9 @@ var (
 type PublicKeySerializable ecdsa.PublicKey
 
 type publicKeySerializableInternalJSON struct {
-	X *hexutil.Big `json:"x"`
-	Y *hexutil.Big `json:"y"`
+	X     *hexutil.Big `json:"x"`
+	Y     *hexutil.Big `json:"y"`
+	Curve string       `json:"curve,omitempty"` // empty for S256, or "P-256"
 }
 
 // newPublicKeySerializable creates a PublicKeySerializable object.
@@ -57,10 +68,34 @@ func newPublicKeySerializable() *PublicKeySerializable {
 	}
 }
 
+// newP256PublicKeySerializable creates a PublicKeySerializable object on the P-256 curve.
+// X = 0
+// Y = 0
+func newP256PublicKeySerializable() *PublicKeySerializable {
+	return &PublicKeySerializable{
+		Curve: crypto.P256(),
+		X:     new(big.Int),
+		Y:     new(big.Int),
+	}
+}
+
+// IsP256 returns true if the key is on the P-256 curve rather than the S256 curve.
+// A key without a curve is regarded as an S256 key.
+func (p *PublicKeySerializable) IsP256() bool {
+	return crypto.IsP256((*ecdsa.PublicKey)(p))
+}
+
 // EncodeRLP encodes ecdsa.PublicKey using RLP.
-// For now, it supports S256 curve only.
-// For that reason, this function serializes only X and Y using CompressPubkey().
+// It supports S256 and P-256 curves.
+// An S256 key is serialized by CompressPubkey(), and a P-256 key is serialized by
+// CompressP256Pubkey() following p256PubkeyPrefix.
 func (p *PublicKeySerializable) EncodeRLP(w io.Writer) error {
+	if p.IsP256() {
+		if !crypto.P256().IsOnCurve(p.X, p.Y) {
+			return errNotP256Curve
+		}
+		return rlp.Encode(w, append([]byte{p256PubkeyPrefix}, crypto.CompressP256Pubkey((*ecdsa.PublicKey)(p))...))
+	}
 	// Do not serialize if it is not on S256 curve.
 	if !crypto.S256().IsOnCurve(p.X, p.Y) {
 		return errNotS256Curve
@@ -69,14 +104,24 @@ func (p *PublicKeySerializable) EncodeRLP(w io.Writer) error {
 }
 
 // DecodeRLP decodes PublicKeySerializable using RLP.
-// For now, it supports S256 curve only.
-// This function deserializes using UncompressPubkey().
+// It supports S256 and P-256 curves. Refer to EncodeRLP() above.
 func (p *PublicKeySerializable) DecodeRLP(s *rlp.Stream) error {
 	b := []byte{}
 	if err := s.Decode(&b); err != nil {
 		return err
 	}
-	pubkey, err := crypto.DecompressPubkey(b)
+	var (
+		pubkey *ecdsa.PublicKey
+		err    error
+	)
+	if len(b) > 0 && b[0] == p256PubkeyPrefix {
+		if len(b) != 34 {
+			return errInvalidP256KeySize
+		}
+		pubkey, err = crypto.DecompressP256Pubkey(b[1:])
+	} else {
+		pubkey, err = crypto.DecompressPubkey(b)
+	}
 	if err != nil {
 		return err
 	}
@@ -86,21 +131,28 @@ func (p *PublicKeySerializable) DecodeRLP(s *rlp.Stream) error {
 }
 
 // MarshalJSON encodes PublicKeySerializable using JSON.
-// For now, it supports S256 curve only.
-// For that reason, this function serializes only X and Y.
+// It serializes X and Y, and the curve name if it is not on S256 curve.
 func (p *PublicKeySerializable) MarshalJSON() ([]byte, error) {
+	if p.IsP256() {
+		if !crypto.P256().IsOnCurve(p.X, p.Y) {
+			return nil, errNotP256Curve
+		}
+		return json.Marshal(&publicKeySerializableInternalJSON{
+			(*hexutil.Big)(p.X), (*hexutil.Big)(p.Y), p256CurveName,
+		})
+	}
 	// Do not serialize if it is not on S256 curve.
 	if !crypto.S256().IsOnCurve(p.X, p.Y) {
 		return nil, errNotS256Curve
 	}
 	return json.Marshal(&publicKeySerializableInternalJSON{
-		(*hexutil.Big)(p.X), (*hexutil.Big)(p.Y),
+		X: (*hexutil.Big)(p.X), Y: (*hexutil.Big)(p.Y),
 	})
 }
 
 // UnmarshalJSON decodes PublicKeySerializable using JSON.
-// For now, it supports S256 curve only.
-// For that reason, this function deserializes only X and Y. Refer to MarshalJSON() above.
+// This function deserializes X and Y, and the curve name if exists. Refer to MarshalJSON() above.
+// If the curve name does not exist, the curve of p is kept.
 func (p *PublicKeySerializable) UnmarshalJSON(b []byte) error {
 	var dec publicKeySerializableInternalJSON
 	if err := json.Unmarshal(b, &dec); err != nil {
@@ -109,6 +161,13 @@ func (p *PublicKeySerializable) UnmarshalJSON(b []byte) error {
 	if dec.X == nil || dec.Y == nil {
 		return errNoXYValue
 	}
+	switch dec.Curve {
+	case "":
+	case p256CurveName:
+		p.Curve = crypto.P256()
+	default:
+		return errUnsupportedCurve
+	}
 	p.X = (*big.Int)(dec.X)
 	p.Y = (*big.Int)(dec.Y)
 
@@ -119,6 +178,9 @@ func (p *PublicKeySerializable) UnmarshalJSON(b []byte) error {
 // Then, the values of the original object are copied to those of the new object.
 func (p *PublicKeySerializable) DeepCopy() *PublicKeySerializable {
 	pk := newPublicKeySerializable()
+	if p.IsP256() {
+		pk = newP256PublicKeySerializable()
+	}
 	pk.X = new(big.Int).Set(p.X)
 	pk.Y = new(big.Int).Set(p.Y)
 
@@ -128,7 +190,8 @@ func (p *PublicKeySerializable) DeepCopy() *PublicKeySerializable {
 // Equal returns true if all attributes between p and pk are the same.
 // Otherwise, it returns false.
 func (p *PublicKeySerializable) Equal(pk *PublicKeySerializable) bool {
-	return p.X.Cmp(pk.X) == 0 &&
+	return p.IsP256() == pk.IsP256() &&
+		p.X.Cmp(pk.X) == 0 &&
 		p.Y.Cmp(pk.Y) == 0
 }
 
@@ -136,5 +199,8 @@ func (p *PublicKeySerializable) Equal(pk *PublicKeySerializable) bool {
 func (p *PublicKeySerializable) String() string {
 	b, _ := json.Marshal(p)
 
+	if p.IsP256() {
+		return fmt.Sprintf("P256Pubkey:%s", string(b))
+	}
 	return fmt.Sprintf("S256Pubkey:%s", string(b))
 }
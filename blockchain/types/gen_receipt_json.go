@@ -21,6 +21,7 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 		TxHash          common.Hash    `json:"transactionHash" gencodec:"required"`
 		ContractAddress common.Address `json:"contractAddress"`
 		GasUsed         hexutil.Uint64 `json:"gasUsed" gencodec:"required"`
+		CallStatuses    []hexutil.Uint `json:"callStatuses,omitempty"`
 	}
 	var enc Receipt
 	enc.Status = hexutil.Uint(r.Status)
@@ -29,6 +30,12 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 	enc.TxHash = r.TxHash
 	enc.ContractAddress = r.ContractAddress
 	enc.GasUsed = hexutil.Uint64(r.GasUsed)
+	if r.CallStatuses != nil {
+		enc.CallStatuses = make([]hexutil.Uint, len(r.CallStatuses))
+		for k, v := range r.CallStatuses {
+			enc.CallStatuses[k] = hexutil.Uint(v)
+		}
+	}
 	return json.Marshal(&enc)
 }
 
@@ -41,6 +48,7 @@ func (r *Receipt) UnmarshalJSON(input []byte) error {
 		TxHash          *common.Hash    `json:"transactionHash" gencodec:"required"`
 		ContractAddress *common.Address `json:"contractAddress"`
 		GasUsed         *hexutil.Uint64 `json:"gasUsed" gencodec:"required"`
+		CallStatuses    []hexutil.Uint  `json:"callStatuses,omitempty"`
 	}
 	var dec Receipt
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -68,5 +76,11 @@ func (r *Receipt) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'gasUsed' for Receipt")
 	}
 	r.GasUsed = uint64(*dec.GasUsed)
+	if dec.CallStatuses != nil {
+		r.CallStatuses = make([]uint, len(dec.CallStatuses))
+		for k, v := range dec.CallStatuses {
+			r.CallStatuses[k] = uint(v)
+		}
+	}
 	return nil
 }
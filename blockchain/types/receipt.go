@@ -101,11 +101,15 @@ type Receipt struct {
 	TxHash          common.Hash    `json:"transactionHash" gencodec:"required"`
 	ContractAddress common.Address `json:"contractAddress"`
 	GasUsed         uint64         `json:"gasUsed" gencodec:"required"`
+
+	// CallStatuses is the status of each call of a batch transaction.
+	CallStatuses []uint `json:"callStatuses,omitempty"`
 }
 
 type receiptMarshaling struct {
-	Status  hexutil.Uint
-	GasUsed hexutil.Uint64
+	Status       hexutil.Uint
+	GasUsed      hexutil.Uint64
+	CallStatuses []hexutil.Uint
 }
 
 // receiptRLP is the consensus encoding of a receipt.
@@ -114,6 +118,8 @@ type receiptRLP struct {
 	GasUsed uint64
 	Bloom   Bloom
 	Logs    []*Log
+
+	CallStatuses []uint `rlp:"optional"`
 }
 
 type receiptStorageRLP struct {
@@ -123,6 +129,7 @@ type receiptStorageRLP struct {
 	ContractAddress common.Address
 	Logs            []*LogForStorage
 	GasUsed         uint64
+	CallStatuses    []uint `rlp:"optional"`
 }
 
 // NewReceipt creates a barebone transaction receipt, copying the init fields.
@@ -137,7 +144,7 @@ func NewReceipt(status uint, txHash common.Hash, gasUsed uint64) *Receipt {
 // EncodeRLP implements rlp.Encoder, and flattens the consensus fields of a receipt
 // into an RLP stream. If no post state is present, byzantium fork is assumed.
 func (r *Receipt) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &receiptRLP{r.Status, r.GasUsed, r.Bloom, r.Logs})
+	return rlp.Encode(w, &receiptRLP{r.Status, r.GasUsed, r.Bloom, r.Logs, r.CallStatuses})
 }
 
 // DecodeRLP implements rlp.Decoder, and loads the consensus fields of a receipt
@@ -149,6 +156,7 @@ func (r *Receipt) DecodeRLP(s *rlp.Stream) error {
 	}
 	r.Status = dec.Status
 	r.GasUsed, r.Bloom, r.Logs = dec.GasUsed, dec.Bloom, dec.Logs
+	r.CallStatuses = dec.CallStatuses
 	return nil
 }
 
@@ -161,6 +169,7 @@ func (r *Receipt) Size() common.StorageSize {
 	for _, log := range r.Logs {
 		size += common.StorageSize(len(log.Topics)*common.HashLength + len(log.Data))
 	}
+	size += common.StorageSize(len(r.CallStatuses)) * common.StorageSize(unsafe.Sizeof(uint(0)))
 	return size
 }
 
@@ -183,6 +192,7 @@ func (r *ReceiptForStorage) EncodeRLP(w io.Writer) error {
 		ContractAddress: r.ContractAddress,
 		Logs:            make([]*LogForStorage, len(r.Logs)),
 		GasUsed:         r.GasUsed,
+		CallStatuses:    r.CallStatuses,
 	}
 	for i, log := range r.Logs {
 		enc.Logs[i] = (*LogForStorage)(log)
@@ -207,6 +217,7 @@ func (r *ReceiptForStorage) DecodeRLP(s *rlp.Stream) error {
 	}
 	// Assign the implementation fields
 	r.TxHash, r.ContractAddress, r.GasUsed = dec.TxHash, dec.ContractAddress, dec.GasUsed
+	r.CallStatuses = dec.CallStatuses
 	return nil
 }
 
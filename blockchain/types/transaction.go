@@ -224,6 +224,19 @@ func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
 	return nil
 }
 
+// DecodeUnsignedTx decodes an RLP-encoded transaction without validating its signatures.
+// It is used to decode a transaction which is not signed yet, e.g., a signing request to an external signer.
+func DecodeUnsignedTx(b []byte) (*Transaction, error) {
+	serializer := newTxInternalDataSerializer()
+	if err := rlp.DecodeBytes(b, serializer); err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{}
+	tx.setDecoded(serializer.tx, len(b))
+	return tx, nil
+}
+
 // UnmarshalBinary decodes the canonical encoding of transactions.
 // It supports legacy RLP transactions and EIP2718 typed transactions.
 func (tx *Transaction) UnmarshalBinary(b []byte) error {
@@ -417,6 +430,16 @@ func (tx *Transaction) Data() []byte {
 	return common.CopyBytes(tp.GetPayload())
 }
 
+// BatchCalls returns the calls of a batch transaction, or nil for other transaction types.
+func (tx *Transaction) BatchCalls() []*BatchCall {
+	tb, ok := tx.data.(TxInternalDataBatchCalls)
+	if !ok {
+		return nil
+	}
+
+	return tb.GetCalls()
+}
+
 // IsFeeDelegatedTransaction returns true if the transaction is a fee-delegated transaction.
 // A fee-delegated transaction has an address of the fee payer which can be different from `from` of the tx.
 func (tx *Transaction) IsFeeDelegatedTransaction() bool {
@@ -822,6 +845,38 @@ func (tx *Transaction) ValidateFeePayer(signer Signer, p AccountKeyPicker, curre
 	return gasKey, nil
 }
 
+// ValidateSessionKeys returns an error if the sender or the fee payer has a session key
+// which is expired at the given block or does not allow the transaction.
+// It should be called after ValidateSender() and ValidateFeePayer().
+func (tx *Transaction) ValidateSessionKeys(p AccountKeyPicker, currentBlockNumber uint64, time uint64) error {
+	// Ethereum transactions can be sent by accounts having a legacy key only.
+	if tx.IsEthereumTransaction() {
+		return nil
+	}
+
+	ctx := &accountkey.SessionContext{
+		BlockNumber: currentBlockNumber,
+		Time:        time,
+		Value:       tx.Value(),
+	}
+	if calls := tx.BatchCalls(); calls != nil {
+		for _, call := range calls {
+			to := call.To
+			ctx.Calls = append(ctx.Calls, accountkey.SessionCall{To: &to, Data: call.Data})
+		}
+	} else {
+		ctx.Calls = []accountkey.SessionCall{{To: tx.To(), Data: tx.Data()}}
+	}
+
+	if err := accountkey.ValidateSessionContext(p.GetKey(tx.ValidatedSender()), tx.GetRoleTypeForValidation(), ctx); err != nil {
+		return err
+	}
+	if tx.IsFeeDelegatedTransaction() {
+		return accountkey.ValidateSessionContext(p.GetKey(tx.ValidatedFeePayer()), accountkey.RoleFeePayer, ctx)
+	}
+	return nil
+}
+
 // Transactions is a Transaction slice type for basic sorting.
 type Transactions []*Transaction
 
@@ -767,6 +767,21 @@ func recoverPlainPubkey(sighash common.Hash, R, S, Vb *big.Int, homestead bool)
 	return pubkey, nil
 }
 
+func recoverP256Pubkey(sighash common.Hash, R, S, Vb *big.Int) (*ecdsa.PublicKey, error) {
+	if Vb.BitLen() > 8 {
+		return nil, ErrInvalidSig
+	}
+	V := byte(Vb.Uint64() - 27)
+	if !crypto.ValidateP256SignatureValues(V, R, S) {
+		return nil, ErrInvalidSig
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	R.FillBytes(sig[:32])
+	S.FillBytes(sig[32:64])
+	sig[crypto.RecoveryIDOffset] = V
+	return crypto.RecoverP256(sighash[:], sig)
+}
+
 // deriveChainId derives the chain id from the given v parameter
 func deriveChainId(v *big.Int) *big.Int {
 	if v.BitLen() <= 64 {
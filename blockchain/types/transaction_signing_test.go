@@ -33,6 +33,8 @@ import (
 
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
 )
 
@@ -2003,6 +2005,63 @@ func testValidateFeeDelegatedChainDataAnchoringWithRatio(t *testing.T) {
 	assert.Equal(t, feePayer, tx.ValidatedFeePayer())
 }
 
+// TestValidateSenderP256 tests the transactions signed with P-256 keys.
+func TestValidateSenderP256(t *testing.T) {
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{IstanbulCompatibleBlock: big.NewInt(0), P256CompatibleBlock: big.NewInt(0)})
+	defer fork.ClearHardForkBlockNumberConfig()
+
+	signer := LatestSignerForChainID(big.NewInt(1))
+	p256Key, _ := crypto.GenerateP256Key()
+	s256Key, from := defaultTestKey()
+
+	p := &AccountKeyPickerForTest{
+		AddrKeyMap: make(map[common.Address]accountkey.AccountKey),
+	}
+
+	// AccountKeyPublicP256
+	tx := &Transaction{data: genValueTransferTransaction()}
+	assert.NoError(t, tx.Sign(signer, p256Key))
+	sigs := tx.RawSignatureValues()
+	assert.Equal(t, 1, len(sigs))
+	assert.True(t, sigs[0].V.Cmp(P256SignatureVOffset) > 0)
+	assert.Equal(t, big.NewInt(1), tx.ChainId())
+
+	p.SetKey(from, accountkey.NewAccountKeyPublicP256WithValue(&p256Key.PublicKey))
+	_, err := tx.ValidateSender(signer, p, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, from, tx.ValidatedSender())
+
+	// The signature survives RLP encoding
+	b, err := rlp.EncodeToBytes(tx)
+	assert.NoError(t, err)
+	decTx := new(Transaction)
+	assert.NoError(t, rlp.DecodeBytes(b, decTx))
+	_, err = decTx.ValidateSender(signer, p, 0)
+	assert.NoError(t, err)
+
+	// A secp256k1 account key does not accept P-256 signatures
+	p.SetKey(from, accountkey.NewAccountKeyPublicWithValue(&s256Key.PublicKey))
+	_, err = tx.ValidateSender(signer, p, 0)
+	assert.Error(t, err)
+	p.SetKey(from, accountkey.NewAccountKeyLegacy())
+	_, err = tx.ValidateSender(signer, p, 0)
+	assert.Error(t, err)
+
+	// AccountKeyWeightedMultiSig mixing the curves
+	tx = &Transaction{data: genValueTransferTransaction()}
+	assert.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{s256Key, p256Key}))
+	p.SetKey(from, accountkey.NewAccountKeyWeightedMultiSigWithValues(2, accountkey.WeightedPublicKeys{
+		accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&s256Key.PublicKey)),
+		accountkey.NewWeightedPublicKey(1, (*accountkey.PublicKeySerializable)(&p256Key.PublicKey)),
+	}))
+	_, err = tx.ValidateSender(signer, p, 0)
+	assert.NoError(t, err)
+
+	// Ethereum transactions cannot be signed with P-256 keys
+	ethTx := NewTransaction(0, from, big.NewInt(1), 21000, big.NewInt(1), nil)
+	assert.Equal(t, ErrTxTypeNotSupported, ethTx.Sign(signer, p256Key))
+}
+
 func getFunctionName(i interface{}) string {
 	return runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
 }
@@ -53,7 +53,7 @@ const (
 	TxTypeSmartContractDeploy, TxTypeFeeDelegatedSmartContractDeploy, TxTypeFeeDelegatedSmartContractDeployWithRatio
 	TxTypeSmartContractExecution, TxTypeFeeDelegatedSmartContractExecution, TxTypeFeeDelegatedSmartContractExecutionWithRatio
 	TxTypeCancel, TxTypeFeeDelegatedCancel, TxTypeFeeDelegatedCancelWithRatio
-	TxTypeBatch, _, _
+	TxTypeBatch, TxTypeFeeDelegatedBatch, _
 	TxTypeChainDataAnchoring, TxTypeFeeDelegatedChainDataAnchoring, TxTypeFeeDelegatedChainDataAnchoringWithRatio
 	TxTypeKlaytnLast, _, _
 	TxTypeEthereumAccessList = TxType(0x7801)
@@ -83,6 +83,7 @@ const (
 	TxValueKeyChainID
 	TxValueKeyGasTipCap
 	TxValueKeyGasFeeCap
+	TxValueKeyCalls
 )
 
 type TxTypeMask uint8
@@ -118,6 +119,7 @@ var (
 	errValueKeyChainIDInvalid            = errors.New("ChainID must be a type of ChainID")
 	errValueKeyGasTipCapMustBigInt       = errors.New("GasTipCap must be a type of *big.Int")
 	errValueKeyGasFeeCapMustBigInt       = errors.New("GasFeeCap must be a type of *big.Int")
+	errValueKeyCallsMustBatchCalls       = errors.New("Calls must be a type of []*BatchCall")
 
 	ErrTxTypeNotSupported         = errors.New("transaction type not supported")
 	ErrSenderPubkeyNotSupported   = errors.New("SenderPubkey is not supported for this signer")
@@ -161,6 +163,8 @@ func (t TxValueKeyType) String() string {
 		return "TxValueKeyGasTipCap"
 	case TxValueKeyGasFeeCap:
 		return "TxValueKeyGasFeeCap"
+	case TxValueKeyCalls:
+		return "TxValueKeyCalls"
 	}
 
 	return "UndefinedTxValueKeyType"
@@ -210,6 +214,8 @@ func (t TxType) String() string {
 		return "TxTypeFeeDelegatedCancelWithRatio"
 	case TxTypeBatch:
 		return "TxTypeBatch"
+	case TxTypeFeeDelegatedBatch:
+		return "TxTypeFeeDelegatedBatch"
 	case TxTypeChainDataAnchoring:
 		return "TxTypeChainDataAnchoring"
 	case TxTypeFeeDelegatedChainDataAnchoring:
@@ -222,6 +228,9 @@ func (t TxType) String() string {
 		return "TxTypeEthereumDynamicFee"
 	}
 
+	if def, ok := getCustomTxType(t); ok {
+		return def.Name
+	}
 	return "UndefinedTxType"
 }
 
@@ -241,6 +250,10 @@ func (t TxType) IsCancelTransaction() bool {
 	return (t &^ ((1 << SubTxTypeBits) - 1)) == TxTypeCancel
 }
 
+func (t TxType) IsBatch() bool {
+	return (t &^ ((1 << SubTxTypeBits) - 1)) == TxTypeBatch
+}
+
 func (t TxType) IsLegacyTransaction() bool {
 	return t == TxTypeLegacyTransaction
 }
@@ -389,6 +402,12 @@ type TxInternalDataPayload interface {
 	GetPayload() []byte
 }
 
+// TxInternalDataBatchCalls has a function `GetCalls()`.
+// It is implemented by batch transactions performing multiple calls atomically.
+type TxInternalDataBatchCalls interface {
+	GetCalls() []*BatchCall
+}
+
 // TxInternalDataEthTyped has a function related to EIP-2718 Ethereum typed transaction.
 // For supporting new typed transaction defined EIP-2718, We provide an interface `TxInternalDataEthTyped `
 type TxInternalDataEthTyped interface {
@@ -424,6 +443,8 @@ type StateDB interface {
 	IsContractAvailable(addr common.Address) bool
 	IsValidCodeFormat(addr common.Address) bool
 	GetKey(addr common.Address) accountkey.AccountKey
+	Snapshot() int
+	RevertToSnapshot(revid int)
 }
 
 func NewTxInternalData(t TxType) (TxInternalData, error) {
@@ -468,6 +489,10 @@ func NewTxInternalData(t TxType) (TxInternalData, error) {
 		return newTxInternalDataFeeDelegatedCancel(), nil
 	case TxTypeFeeDelegatedCancelWithRatio:
 		return newTxInternalDataFeeDelegatedCancelWithRatio(), nil
+	case TxTypeBatch:
+		return newTxInternalDataBatch(), nil
+	case TxTypeFeeDelegatedBatch:
+		return newTxInternalDataFeeDelegatedBatch(), nil
 	case TxTypeChainDataAnchoring:
 		return newTxInternalDataChainDataAnchoring(), nil
 	case TxTypeFeeDelegatedChainDataAnchoring:
@@ -480,6 +505,9 @@ func NewTxInternalData(t TxType) (TxInternalData, error) {
 		return newTxInternalDataEthereumDynamicFee(), nil
 	}
 
+	if t.IsCustomTxType() {
+		return newTxInternalDataCustom(t)
+	}
 	return nil, errUndefinedTxType
 }
 
@@ -525,6 +553,10 @@ func NewTxInternalDataWithMap(t TxType, values map[TxValueKeyType]interface{}) (
 		return newTxInternalDataFeeDelegatedCancelWithMap(values)
 	case TxTypeFeeDelegatedCancelWithRatio:
 		return newTxInternalDataFeeDelegatedCancelWithRatioWithMap(values)
+	case TxTypeBatch:
+		return newTxInternalDataBatchWithMap(values)
+	case TxTypeFeeDelegatedBatch:
+		return newTxInternalDataFeeDelegatedBatchWithMap(values)
 	case TxTypeChainDataAnchoring:
 		return newTxInternalDataChainDataAnchoringWithMap(values)
 	case TxTypeFeeDelegatedChainDataAnchoring:
@@ -537,6 +569,9 @@ func NewTxInternalDataWithMap(t TxType, values map[TxValueKeyType]interface{}) (
 		return newTxInternalDataEthereumDynamicFeeWithMap(values)
 	}
 
+	if t.IsCustomTxType() {
+		return newTxInternalDataCustomWithMap(t, values)
+	}
 	return nil, errUndefinedTxType
 }
 
@@ -0,0 +1,463 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	ErrBatchCallsEmpty       = errors.New("batch transaction has no call")
+	ErrBatchCallsTooMany     = fmt.Errorf("batch transaction has more than %d calls", params.MaxBatchCalls)
+	ErrBatchCallInvalidValue = errors.New("batch call has an invalid value")
+)
+
+// BatchCall is a call in a batch transaction.
+type BatchCall struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+type BatchCallJSON struct {
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value"`
+	Data  hexutil.Bytes  `json:"input"`
+}
+
+// BatchCallError is returned by the execution of a batch transaction when one of its calls fails.
+// All state changes made by the calls before the failed one are reverted.
+type BatchCallError struct {
+	Index int   // index of the failed call
+	Err   error // error returned by the failed call
+}
+
+func (e *BatchCallError) Error() string {
+	return fmt.Sprintf("batch call %d failed: %v", e.Index, e.Err)
+}
+
+func (c *BatchCall) equal(b *BatchCall) bool {
+	return c.To == b.To && c.Value.Cmp(b.Value) == 0 && bytes.Equal(c.Data, b.Data)
+}
+
+func (c *BatchCall) toJSON() *BatchCallJSON {
+	return &BatchCallJSON{c.To, (*hexutil.Big)(c.Value), c.Data}
+}
+
+func batchCallsToJSON(calls []*BatchCall) []*BatchCallJSON {
+	js := make([]*BatchCallJSON, len(calls))
+	for i, c := range calls {
+		js[i] = c.toJSON()
+	}
+	return js
+}
+
+func batchCallsFromJSON(js []*BatchCallJSON) []*BatchCall {
+	calls := make([]*BatchCall, len(js))
+	for i, c := range js {
+		calls[i] = &BatchCall{c.To, (*big.Int)(c.Value), c.Data}
+	}
+	return calls
+}
+
+func equalBatchCalls(a, b []*BatchCall) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func batchCallsString(calls []*BatchCall) string {
+	strs := make([]string, len(calls))
+	for i, c := range calls {
+		strs[i] = fmt.Sprintf("{To: %s, Value: %#x, Data: %x}", c.To.String(), c.Value, c.Data)
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
+
+// batchCallsAmount returns the sum of the values transferred by the calls.
+func batchCallsAmount(calls []*BatchCall) *big.Int {
+	amount := new(big.Int)
+	for _, c := range calls {
+		if c.Value != nil {
+			amount.Add(amount, c.Value)
+		}
+	}
+	return amount
+}
+
+// batchCallsRecipient returns the recipient of the first call.
+func batchCallsRecipient(calls []*BatchCall) *common.Address {
+	if len(calls) == 0 {
+		return nil
+	}
+	to := calls[0].To
+	return &to
+}
+
+// intrinsicGasBatchCalls adds the gas for each call and its data to the given gas.
+func intrinsicGasBatchCalls(gas uint64, calls []*BatchCall) (uint64, error) {
+	var err error
+	for _, c := range calls {
+		gas += params.TxGasBatchCall
+		if gas, err = IntrinsicGasPayload(gas, c.Data); err != nil {
+			return 0, err
+		}
+	}
+	return gas, nil
+}
+
+// validateBatchCalls checks the calls and whether the batch transaction is enabled.
+func validateBatchCalls(calls []*BatchCall, currentBlockNumber uint64) error {
+	if !fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsBatchTx {
+		return ErrTxTypeNotSupported
+	}
+	if len(calls) == 0 {
+		return ErrBatchCallsEmpty
+	}
+	if len(calls) > params.MaxBatchCalls {
+		return ErrBatchCallsTooMany
+	}
+	for _, c := range calls {
+		if c == nil || c.Value == nil || c.Value.Sign() < 0 {
+			return ErrBatchCallInvalidValue
+		}
+	}
+	return nil
+}
+
+// executeBatchCalls performs the calls in order. If a call fails, the state changes made by
+// the preceding calls are reverted and a BatchCallError is returned.
+func executeBatchCalls(calls []*BatchCall, sender ContractRef, vm VM, stateDB StateDB, gas uint64) (ret []byte, usedGas uint64, err error) {
+	stateDB.IncNonce(sender.Address())
+
+	snapshot := stateDB.Snapshot()
+	for i, c := range calls {
+		if ret, gas, err = vm.Call(sender, c.To, c.Data, gas, c.Value); err != nil {
+			stateDB.RevertToSnapshot(snapshot)
+			return ret, gas, &BatchCallError{Index: i, Err: err}
+		}
+	}
+	return ret, gas, nil
+}
+
+// TxInternalDataBatch represents a transaction performing multiple calls atomically.
+// If any of the calls fails, all of them are reverted.
+type TxInternalDataBatch struct {
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	From         common.Address
+	Calls        []*BatchCall
+
+	TxSignatures
+
+	// This is only used when marshaling to JSON.
+	Hash *common.Hash `json:"hash" rlp:"-"`
+}
+
+type TxInternalDataBatchJSON struct {
+	Type         TxType           `json:"typeInt"`
+	TypeStr      string           `json:"type"`
+	AccountNonce hexutil.Uint64   `json:"nonce"`
+	Price        *hexutil.Big     `json:"gasPrice"`
+	GasLimit     hexutil.Uint64   `json:"gas"`
+	From         common.Address   `json:"from"`
+	Calls        []*BatchCallJSON `json:"calls"`
+	TxSignatures TxSignaturesJSON `json:"signatures"`
+	Hash         *common.Hash     `json:"hash"`
+}
+
+func newTxInternalDataBatch() *TxInternalDataBatch {
+	h := common.Hash{}
+	return &TxInternalDataBatch{
+		Price: new(big.Int),
+		Hash:  &h,
+	}
+}
+
+func newTxInternalDataBatchWithMap(values map[TxValueKeyType]interface{}) (*TxInternalDataBatch, error) {
+	t := newTxInternalDataBatch()
+
+	if v, ok := values[TxValueKeyNonce].(uint64); ok {
+		t.AccountNonce = v
+		delete(values, TxValueKeyNonce)
+	} else {
+		return nil, errValueKeyNonceMustUint64
+	}
+
+	if v, ok := values[TxValueKeyGasPrice].(*big.Int); ok {
+		t.Price.Set(v)
+		delete(values, TxValueKeyGasPrice)
+	} else {
+		return nil, errValueKeyGasPriceMustBigInt
+	}
+
+	if v, ok := values[TxValueKeyGasLimit].(uint64); ok {
+		t.GasLimit = v
+		delete(values, TxValueKeyGasLimit)
+	} else {
+		return nil, errValueKeyGasLimitMustUint64
+	}
+
+	if v, ok := values[TxValueKeyFrom].(common.Address); ok {
+		t.From = v
+		delete(values, TxValueKeyFrom)
+	} else {
+		return nil, errValueKeyFromMustAddress
+	}
+
+	if v, ok := values[TxValueKeyCalls].([]*BatchCall); ok {
+		t.Calls = v
+		delete(values, TxValueKeyCalls)
+	} else {
+		return nil, errValueKeyCallsMustBatchCalls
+	}
+
+	if len(values) != 0 {
+		for k := range values {
+			logger.Warn("unnecessary key", k.String())
+		}
+		return nil, errUndefinedKeyRemains
+	}
+
+	return t, nil
+}
+
+func (t *TxInternalDataBatch) Type() TxType {
+	return TxTypeBatch
+}
+
+func (t *TxInternalDataBatch) GetRoleTypeForValidation() accountkey.RoleType {
+	return accountkey.RoleTransaction
+}
+
+func (t *TxInternalDataBatch) Equal(a TxInternalData) bool {
+	ta, ok := a.(*TxInternalDataBatch)
+	if !ok {
+		return false
+	}
+
+	return t.AccountNonce == ta.AccountNonce &&
+		t.Price.Cmp(ta.Price) == 0 &&
+		t.GasLimit == ta.GasLimit &&
+		t.From == ta.From &&
+		equalBatchCalls(t.Calls, ta.Calls) &&
+		t.TxSignatures.equal(ta.TxSignatures)
+}
+
+func (t *TxInternalDataBatch) IsLegacyTransaction() bool {
+	return false
+}
+
+func (t *TxInternalDataBatch) GetCalls() []*BatchCall {
+	return t.Calls
+}
+
+func (t *TxInternalDataBatch) GetAccountNonce() uint64 {
+	return t.AccountNonce
+}
+
+func (t *TxInternalDataBatch) GetPrice() *big.Int {
+	return new(big.Int).Set(t.Price)
+}
+
+func (t *TxInternalDataBatch) GetGasLimit() uint64 {
+	return t.GasLimit
+}
+
+// GetRecipient returns the recipient of the first call.
+func (t *TxInternalDataBatch) GetRecipient() *common.Address {
+	return batchCallsRecipient(t.Calls)
+}
+
+// GetAmount returns the sum of the values transferred by the calls.
+func (t *TxInternalDataBatch) GetAmount() *big.Int {
+	return batchCallsAmount(t.Calls)
+}
+
+func (t *TxInternalDataBatch) GetFrom() common.Address {
+	return t.From
+}
+
+func (t *TxInternalDataBatch) GetHash() *common.Hash {
+	return t.Hash
+}
+
+func (t *TxInternalDataBatch) SetHash(h *common.Hash) {
+	t.Hash = h
+}
+
+func (t *TxInternalDataBatch) SetSignature(s TxSignatures) {
+	t.TxSignatures = s
+}
+
+func (t *TxInternalDataBatch) String() string {
+	ser := newTxInternalDataSerializerWithValues(t)
+	tx := Transaction{data: t}
+	enc, _ := rlp.EncodeToBytes(ser)
+	return fmt.Sprintf(`
+	TX(%x)
+	Type:          %s
+	From:          %s
+	Nonce:         %v
+	GasPrice:      %#x
+	GasLimit:      %#x
+	Calls:         %s
+	Signature:     %s
+	Hex:           %x
+`,
+		tx.Hash(),
+		t.Type().String(),
+		t.From.String(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		batchCallsString(t.Calls),
+		t.TxSignatures.string(),
+		enc)
+}
+
+func (t *TxInternalDataBatch) IntrinsicGas(currentBlockNumber uint64) (uint64, error) {
+	return intrinsicGasBatchCalls(params.TxGasBatch, t.Calls)
+}
+
+func (t *TxInternalDataBatch) SerializeForSignToBytes() []byte {
+	b, _ := rlp.EncodeToBytes(struct {
+		Txtype       TxType
+		AccountNonce uint64
+		Price        *big.Int
+		GasLimit     uint64
+		From         common.Address
+		Calls        []*BatchCall
+	}{
+		t.Type(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.From,
+		t.Calls,
+	})
+
+	return b
+}
+
+func (t *TxInternalDataBatch) SerializeForSign() []interface{} {
+	return []interface{}{
+		t.Type(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.From,
+		t.Calls,
+	}
+}
+
+func (t *TxInternalDataBatch) SenderTxHash() common.Hash {
+	hw := sha3.NewKeccak256()
+	rlp.Encode(hw, t.Type())
+	rlp.Encode(hw, []interface{}{
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.From,
+		t.Calls,
+		t.TxSignatures,
+	})
+
+	h := common.Hash{}
+
+	hw.Sum(h[:0])
+
+	return h
+}
+
+func (t *TxInternalDataBatch) Validate(stateDB StateDB, currentBlockNumber uint64) error {
+	if err := validateBatchCalls(t.Calls, currentBlockNumber); err != nil {
+		return err
+	}
+	return t.ValidateMutableValue(stateDB, currentBlockNumber)
+}
+
+func (t *TxInternalDataBatch) ValidateMutableValue(stateDB StateDB, currentBlockNumber uint64) error {
+	return nil
+}
+
+func (t *TxInternalDataBatch) Execute(sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) (ret []byte, usedGas uint64, err error) {
+	return executeBatchCalls(t.Calls, sender, vm, stateDB, gas)
+}
+
+func (t *TxInternalDataBatch) MakeRPCOutput() map[string]interface{} {
+	return map[string]interface{}{
+		"typeInt":    t.Type(),
+		"type":       t.Type().String(),
+		"gas":        hexutil.Uint64(t.GasLimit),
+		"gasPrice":   (*hexutil.Big)(t.Price),
+		"nonce":      hexutil.Uint64(t.AccountNonce),
+		"calls":      batchCallsToJSON(t.Calls),
+		"signatures": t.TxSignatures.ToJSON(),
+	}
+}
+
+func (t *TxInternalDataBatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(TxInternalDataBatchJSON{
+		t.Type(),
+		t.Type().String(),
+		(hexutil.Uint64)(t.AccountNonce),
+		(*hexutil.Big)(t.Price),
+		(hexutil.Uint64)(t.GasLimit),
+		t.From,
+		batchCallsToJSON(t.Calls),
+		t.TxSignatures.ToJSON(),
+		t.Hash,
+	})
+}
+
+func (t *TxInternalDataBatch) UnmarshalJSON(b []byte) error {
+	js := &TxInternalDataBatchJSON{}
+	if err := json.Unmarshal(b, js); err != nil {
+		return err
+	}
+
+	t.AccountNonce = uint64(js.AccountNonce)
+	t.Price = (*big.Int)(js.Price)
+	t.GasLimit = uint64(js.GasLimit)
+	t.From = js.From
+	t.Calls = batchCallsFromJSON(js.Calls)
+	t.TxSignatures = js.TxSignatures.ToTxSignatures()
+	t.Hash = js.Hash
+
+	return nil
+}
@@ -0,0 +1,484 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// The range of the tx types which can be registered by RegisterTxType.
+// It is between the Klaytn tx types and the Ethereum typed transactions.
+const (
+	TxTypeCustomFirst = TxType(0x7000)
+	TxTypeCustomLast  = TxType(0x7800) // exclusive
+)
+
+var (
+	ErrTxTypeNotCustom          = errors.New("custom tx type should be within [0x7000, 0x7800) without sub type bits")
+	ErrTxTypeRegistered         = errors.New("tx type is already registered")
+	ErrTxTypeHooksMissing       = errors.New("custom tx type should have the name and the DecodePayload, IntrinsicGas and Execute hooks")
+	errCustomTxTypeUnregistered = errors.New("custom tx type is not registered")
+)
+
+// CustomTxType defines a tx type registered by a service chain. A transaction of the type
+// has the common fields of TxInternalDataCustom and a type-specific payload, which are
+// handled by the hooks below. Fee delegation is not supported for custom tx types.
+type CustomTxType struct {
+	// Name is returned by TxType.String.
+	Name string
+
+	// DecodePayload validates and decodes the payload of a transaction whenever the
+	// transaction is decoded or created. The result is available to the other hooks
+	// through TxInternalDataCustom.DecodedPayload.
+	DecodePayload func(payload []byte) (interface{}, error)
+
+	// SerializeForSign returns the values signed by the sender. If nil, the type and all
+	// the common fields including the payload are signed.
+	SerializeForSign func(t *TxInternalDataCustom) []interface{}
+
+	// IntrinsicGas returns the intrinsic gas of the transaction.
+	IntrinsicGas func(t *TxInternalDataCustom, currentBlockNumber uint64) (uint64, error)
+
+	// Validate validates the transaction with the state before the execution. It is optional.
+	Validate func(t *TxInternalDataCustom, stateDB StateDB, currentBlockNumber uint64) error
+
+	// Execute executes the transaction. The sender's nonce is already increased.
+	Execute func(t *TxInternalDataCustom, sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) ([]byte, uint64, error)
+}
+
+var (
+	customTxTypes   = make(map[TxType]*CustomTxType)
+	customTxTypesMu sync.RWMutex
+)
+
+// RegisterTxType registers a custom tx type, so that the transactions of the type are
+// decoded and executed without changing this package. The transactions are rejected
+// until ChainConfig.CustomTxTypeCompatibleBlock, which should never be set on the main
+// networks. It should be called at the initialization of a service chain binary, before
+// any transaction is decoded.
+func RegisterTxType(t TxType, def CustomTxType) error {
+	if !t.IsCustomTxType() || t&((1<<SubTxTypeBits)-1) != 0 {
+		return ErrTxTypeNotCustom
+	}
+	if def.Name == "" || def.DecodePayload == nil || def.IntrinsicGas == nil || def.Execute == nil {
+		return ErrTxTypeHooksMissing
+	}
+
+	customTxTypesMu.Lock()
+	defer customTxTypesMu.Unlock()
+
+	if _, ok := customTxTypes[t]; ok {
+		return ErrTxTypeRegistered
+	}
+	customTxTypes[t] = &def
+	return nil
+}
+
+// IsCustomTxType returns whether the type is within the range of custom tx types.
+func (t TxType) IsCustomTxType() bool {
+	return TxTypeCustomFirst <= t && t < TxTypeCustomLast
+}
+
+func getCustomTxType(t TxType) (*CustomTxType, bool) {
+	customTxTypesMu.RLock()
+	defer customTxTypesMu.RUnlock()
+
+	def, ok := customTxTypes[t]
+	return def, ok
+}
+
+// TxInternalDataCustom represents a transaction of a custom tx type registered by RegisterTxType.
+type TxInternalDataCustom struct {
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	Recipient    common.Address
+	Amount       *big.Int
+	From         common.Address
+	Payload      []byte
+
+	TxSignatures
+
+	// This is only used when marshaling to JSON.
+	Hash *common.Hash `json:"hash" rlp:"-"`
+
+	txType  TxType
+	def     *CustomTxType
+	decoded interface{}
+}
+
+type TxInternalDataCustomJSON struct {
+	Type         TxType           `json:"typeInt"`
+	TypeStr      string           `json:"type"`
+	AccountNonce hexutil.Uint64   `json:"nonce"`
+	Price        *hexutil.Big     `json:"gasPrice"`
+	GasLimit     hexutil.Uint64   `json:"gas"`
+	Recipient    common.Address   `json:"to"`
+	Amount       *hexutil.Big     `json:"value"`
+	From         common.Address   `json:"from"`
+	Payload      hexutil.Bytes    `json:"input"`
+	TxSignatures TxSignaturesJSON `json:"signatures"`
+	Hash         *common.Hash     `json:"hash"`
+}
+
+// txInternalDataCustomRLP is TxInternalDataCustom without its RLP decoder.
+type txInternalDataCustomRLP TxInternalDataCustom
+
+func newTxInternalDataCustom(t TxType) (*TxInternalDataCustom, error) {
+	def, ok := getCustomTxType(t)
+	if !ok {
+		return nil, errCustomTxTypeUnregistered
+	}
+	h := common.Hash{}
+	return &TxInternalDataCustom{
+		Price:  new(big.Int),
+		Amount: new(big.Int),
+		Hash:   &h,
+		txType: t,
+		def:    def,
+	}, nil
+}
+
+func newTxInternalDataCustomWithMap(txType TxType, values map[TxValueKeyType]interface{}) (*TxInternalDataCustom, error) {
+	t, err := newTxInternalDataCustom(txType)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := values[TxValueKeyNonce].(uint64); ok {
+		t.AccountNonce = v
+		delete(values, TxValueKeyNonce)
+	} else {
+		return nil, errValueKeyNonceMustUint64
+	}
+
+	if v, ok := values[TxValueKeyGasPrice].(*big.Int); ok {
+		t.Price.Set(v)
+		delete(values, TxValueKeyGasPrice)
+	} else {
+		return nil, errValueKeyGasPriceMustBigInt
+	}
+
+	if v, ok := values[TxValueKeyGasLimit].(uint64); ok {
+		t.GasLimit = v
+		delete(values, TxValueKeyGasLimit)
+	} else {
+		return nil, errValueKeyGasLimitMustUint64
+	}
+
+	// The recipient and the amount are optional for custom tx types
+	if v, exist := values[TxValueKeyTo]; exist {
+		if to, ok := v.(common.Address); ok {
+			t.Recipient = to
+			delete(values, TxValueKeyTo)
+		} else {
+			return nil, errValueKeyToMustAddress
+		}
+	}
+
+	if v, exist := values[TxValueKeyAmount]; exist {
+		if amount, ok := v.(*big.Int); ok {
+			t.Amount.Set(amount)
+			delete(values, TxValueKeyAmount)
+		} else {
+			return nil, errValueKeyAmountMustBigInt
+		}
+	}
+
+	if v, ok := values[TxValueKeyFrom].(common.Address); ok {
+		t.From = v
+		delete(values, TxValueKeyFrom)
+	} else {
+		return nil, errValueKeyFromMustAddress
+	}
+
+	if v, ok := values[TxValueKeyData].([]byte); ok {
+		t.Payload = v
+		delete(values, TxValueKeyData)
+	} else {
+		return nil, errValueKeyDataMustByteSlice
+	}
+
+	if len(values) != 0 {
+		for k := range values {
+			logger.Warn("unnecessary key", k.String())
+		}
+		return nil, errUndefinedKeyRemains
+	}
+
+	if err := t.decodePayload(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// decodePayload decodes the payload with the DecodePayload hook.
+func (t *TxInternalDataCustom) decodePayload() error {
+	decoded, err := t.def.DecodePayload(t.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid payload of %s: %v", t.def.Name, err)
+	}
+	t.decoded = decoded
+	return nil
+}
+
+// DecodedPayload returns the payload decoded by the DecodePayload hook.
+func (t *TxInternalDataCustom) DecodedPayload() interface{} {
+	return t.decoded
+}
+
+func (t *TxInternalDataCustom) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, (*txInternalDataCustomRLP)(t))
+}
+
+func (t *TxInternalDataCustom) DecodeRLP(s *rlp.Stream) error {
+	if err := s.Decode((*txInternalDataCustomRLP)(t)); err != nil {
+		return err
+	}
+	return t.decodePayload()
+}
+
+func (t *TxInternalDataCustom) Type() TxType {
+	return t.txType
+}
+
+func (t *TxInternalDataCustom) GetRoleTypeForValidation() accountkey.RoleType {
+	return accountkey.RoleTransaction
+}
+
+func (t *TxInternalDataCustom) Equal(a TxInternalData) bool {
+	ta, ok := a.(*TxInternalDataCustom)
+	if !ok {
+		return false
+	}
+
+	return t.txType == ta.txType &&
+		t.AccountNonce == ta.AccountNonce &&
+		t.Price.Cmp(ta.Price) == 0 &&
+		t.GasLimit == ta.GasLimit &&
+		t.Recipient == ta.Recipient &&
+		t.Amount.Cmp(ta.Amount) == 0 &&
+		t.From == ta.From &&
+		bytes.Equal(t.Payload, ta.Payload) &&
+		t.TxSignatures.equal(ta.TxSignatures)
+}
+
+func (t *TxInternalDataCustom) IsLegacyTransaction() bool {
+	return false
+}
+
+func (t *TxInternalDataCustom) GetPayload() []byte {
+	return t.Payload
+}
+
+func (t *TxInternalDataCustom) GetAccountNonce() uint64 {
+	return t.AccountNonce
+}
+
+func (t *TxInternalDataCustom) GetPrice() *big.Int {
+	return new(big.Int).Set(t.Price)
+}
+
+func (t *TxInternalDataCustom) GetGasLimit() uint64 {
+	return t.GasLimit
+}
+
+func (t *TxInternalDataCustom) GetRecipient() *common.Address {
+	if t.Recipient == (common.Address{}) {
+		return nil
+	}
+
+	to := common.Address(t.Recipient)
+	return &to
+}
+
+func (t *TxInternalDataCustom) GetAmount() *big.Int {
+	return new(big.Int).Set(t.Amount)
+}
+
+func (t *TxInternalDataCustom) GetFrom() common.Address {
+	return t.From
+}
+
+func (t *TxInternalDataCustom) GetHash() *common.Hash {
+	return t.Hash
+}
+
+func (t *TxInternalDataCustom) SetHash(h *common.Hash) {
+	t.Hash = h
+}
+
+func (t *TxInternalDataCustom) SetSignature(s TxSignatures) {
+	t.TxSignatures = s
+}
+
+func (t *TxInternalDataCustom) String() string {
+	ser := newTxInternalDataSerializerWithValues(t)
+	tx := Transaction{data: t}
+	enc, _ := rlp.EncodeToBytes(ser)
+	return fmt.Sprintf(`
+	TX(%x)
+	Type:          %s
+	From:          %s
+	To:            %s
+	Nonce:         %v
+	GasPrice:      %#x
+	GasLimit:      %#x
+	Value:         %#x
+	Signature:     %s
+	Data:          %x
+	Hex:           %x
+`,
+		tx.Hash(),
+		t.Type().String(),
+		t.From.String(),
+		t.Recipient.String(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.Amount,
+		t.TxSignatures.string(),
+		common.Bytes2Hex(t.Payload),
+		enc)
+}
+
+func (t *TxInternalDataCustom) IntrinsicGas(currentBlockNumber uint64) (uint64, error) {
+	return t.def.IntrinsicGas(t, currentBlockNumber)
+}
+
+func (t *TxInternalDataCustom) SerializeForSignToBytes() []byte {
+	b, _ := rlp.EncodeToBytes(t.SerializeForSign())
+	return b
+}
+
+func (t *TxInternalDataCustom) SerializeForSign() []interface{} {
+	if t.def.SerializeForSign != nil {
+		return append([]interface{}{t.Type()}, t.def.SerializeForSign(t)...)
+	}
+	return []interface{}{
+		t.Type(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.Recipient,
+		t.Amount,
+		t.From,
+		t.Payload,
+	}
+}
+
+func (t *TxInternalDataCustom) SenderTxHash() common.Hash {
+	hw := sha3.NewKeccak256()
+	rlp.Encode(hw, t.Type())
+	rlp.Encode(hw, []interface{}{
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.Recipient,
+		t.Amount,
+		t.From,
+		t.Payload,
+		t.TxSignatures,
+	})
+
+	h := common.Hash{}
+
+	hw.Sum(h[:0])
+
+	return h
+}
+
+func (t *TxInternalDataCustom) Validate(stateDB StateDB, currentBlockNumber uint64) error {
+	if !fork.Rules(new(big.Int).SetUint64(currentBlockNumber)).IsCustomTxType {
+		return ErrTxTypeNotSupported
+	}
+	return t.ValidateMutableValue(stateDB, currentBlockNumber)
+}
+
+func (t *TxInternalDataCustom) ValidateMutableValue(stateDB StateDB, currentBlockNumber uint64) error {
+	if t.def.Validate != nil {
+		return t.def.Validate(t, stateDB, currentBlockNumber)
+	}
+	return nil
+}
+
+func (t *TxInternalDataCustom) Execute(sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) (ret []byte, usedGas uint64, err error) {
+	stateDB.IncNonce(sender.Address())
+	return t.def.Execute(t, sender, vm, stateDB, currentBlockNumber, gas, value)
+}
+
+func (t *TxInternalDataCustom) MakeRPCOutput() map[string]interface{} {
+	return map[string]interface{}{
+		"typeInt":    t.Type(),
+		"type":       t.Type().String(),
+		"gas":        hexutil.Uint64(t.GasLimit),
+		"gasPrice":   (*hexutil.Big)(t.Price),
+		"input":      hexutil.Bytes(t.Payload),
+		"nonce":      hexutil.Uint64(t.AccountNonce),
+		"to":         t.Recipient,
+		"value":      (*hexutil.Big)(t.Amount),
+		"signatures": t.TxSignatures.ToJSON(),
+	}
+}
+
+func (t *TxInternalDataCustom) MarshalJSON() ([]byte, error) {
+	return json.Marshal(TxInternalDataCustomJSON{
+		t.Type(),
+		t.Type().String(),
+		(hexutil.Uint64)(t.AccountNonce),
+		(*hexutil.Big)(t.Price),
+		(hexutil.Uint64)(t.GasLimit),
+		t.Recipient,
+		(*hexutil.Big)(t.Amount),
+		t.From,
+		t.Payload,
+		t.TxSignatures.ToJSON(),
+		t.Hash,
+	})
+}
+
+func (t *TxInternalDataCustom) UnmarshalJSON(b []byte) error {
+	js := &TxInternalDataCustomJSON{}
+	if err := json.Unmarshal(b, js); err != nil {
+		return err
+	}
+
+	t.AccountNonce = uint64(js.AccountNonce)
+	t.Price = (*big.Int)(js.Price)
+	t.GasLimit = uint64(js.GasLimit)
+	t.Recipient = js.Recipient
+	t.Amount = (*big.Int)(js.Amount)
+	t.From = js.From
+	t.Payload = js.Payload
+	t.TxSignatures = js.TxSignatures.ToTxSignatures()
+	t.Hash = js.Hash
+
+	return t.decodePayload()
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/fork"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCustomTxType = TxTypeCustomFirst + 0x10
+
+// registerTestCustomTxType registers a custom tx type of which the payload is a 4-byte tag.
+func registerTestCustomTxType(t *testing.T) {
+	err := RegisterTxType(testCustomTxType, CustomTxType{
+		Name: "TxTypeTestCustom",
+		DecodePayload: func(payload []byte) (interface{}, error) {
+			if len(payload) != 4 {
+				return nil, errors.New("tag should be 4 bytes")
+			}
+			return string(payload), nil
+		},
+		IntrinsicGas: func(t *TxInternalDataCustom, currentBlockNumber uint64) (uint64, error) {
+			return params.TxGas, nil
+		},
+		Execute: func(t *TxInternalDataCustom, sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) ([]byte, uint64, error) {
+			return nil, gas, nil
+		},
+	})
+	require.NoError(t, err)
+}
+
+func unregisterTestCustomTxType() {
+	customTxTypesMu.Lock()
+	defer customTxTypesMu.Unlock()
+	delete(customTxTypes, testCustomTxType)
+}
+
+func TestRegisterTxType(t *testing.T) {
+	registerTestCustomTxType(t)
+	defer unregisterTestCustomTxType()
+
+	assert.True(t, testCustomTxType.IsCustomTxType())
+	assert.False(t, TxTypeBatch.IsCustomTxType())
+	assert.False(t, TxTypeEthereumAccessList.IsCustomTxType())
+	assert.Equal(t, "TxTypeTestCustom", testCustomTxType.String())
+	assert.Equal(t, "UndefinedTxType", (testCustomTxType + 8).String())
+
+	def := CustomTxType{
+		Name:          "TxTypeInvalid",
+		DecodePayload: func(payload []byte) (interface{}, error) { return nil, nil },
+		IntrinsicGas:  func(t *TxInternalDataCustom, currentBlockNumber uint64) (uint64, error) { return 0, nil },
+		Execute: func(t *TxInternalDataCustom, sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) ([]byte, uint64, error) {
+			return nil, 0, nil
+		},
+	}
+	assert.Equal(t, ErrTxTypeRegistered, RegisterTxType(testCustomTxType, def))
+	assert.Equal(t, ErrTxTypeNotCustom, RegisterTxType(TxTypeValueTransfer, def))
+	assert.Equal(t, ErrTxTypeNotCustom, RegisterTxType(TxTypeCustomLast, def))
+	assert.Equal(t, ErrTxTypeNotCustom, RegisterTxType(testCustomTxType+1, def), "sub type bits are reserved for fee delegation")
+	def.Execute = nil
+	assert.Equal(t, ErrTxTypeHooksMissing, RegisterTxType(testCustomTxType+8, def))
+}
+
+func TestTxInternalDataCustom_Serialization(t *testing.T) {
+	registerTestCustomTxType(t)
+	defer unregisterTestCustomTxType()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	values := func(payload []byte) map[TxValueKeyType]interface{} {
+		return map[TxValueKeyType]interface{}{
+			TxValueKeyNonce:    uint64(1),
+			TxValueKeyFrom:     from,
+			TxValueKeyGasLimit: uint64(100000),
+			TxValueKeyGasPrice: big.NewInt(25000000000),
+			TxValueKeyData:     payload,
+		}
+	}
+
+	tx, err := NewTransactionWithMap(testCustomTxType, values([]byte("klay")))
+	require.NoError(t, err)
+	signer := LatestSignerForChainID(big.NewInt(1001))
+	require.NoError(t, tx.SignWithKeys(signer, []*ecdsa.PrivateKey{key}))
+	assert.Equal(t, "klay", tx.GetTxInternalData().(*TxInternalDataCustom).DecodedPayload())
+
+	// RLP
+	b, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+	decoded := new(Transaction)
+	require.NoError(t, rlp.DecodeBytes(b, decoded))
+	assert.True(t, tx.Equal(decoded))
+	assert.Equal(t, tx.Hash(), decoded.Hash())
+	assert.Equal(t, "klay", decoded.GetTxInternalData().(*TxInternalDataCustom).DecodedPayload())
+	sender, err := Sender(signer, decoded)
+	require.NoError(t, err)
+	assert.Equal(t, from, sender)
+
+	// JSON
+	j, err := json.Marshal(tx)
+	require.NoError(t, err)
+	decoded = new(Transaction)
+	require.NoError(t, json.Unmarshal(j, decoded))
+	assert.True(t, tx.Equal(decoded))
+
+	// An invalid payload is rejected on creation and decoding
+	_, err = NewTransactionWithMap(testCustomTxType, values([]byte("klaytn")))
+	assert.Error(t, err)
+	invalid := tx.GetTxInternalData().(*TxInternalDataCustom)
+	invalid.Payload = []byte("klaytn")
+	b, err = rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+	assert.Error(t, rlp.DecodeBytes(b, new(Transaction)))
+
+	// A custom tx type is undefined without the registration
+	unregisterTestCustomTxType()
+	_, err = NewTransactionWithMap(testCustomTxType, values([]byte("klay")))
+	assert.Equal(t, errCustomTxTypeUnregistered, err)
+	_, err = NewTransactionWithMap(TxTypeCustomLast, values([]byte("klay")))
+	assert.Equal(t, errUndefinedTxType, err)
+}
+
+func TestTxInternalDataCustom_Validate(t *testing.T) {
+	registerTestCustomTxType(t)
+	defer unregisterTestCustomTxType()
+
+	tx, err := newTxInternalDataCustomWithMap(testCustomTxType, map[TxValueKeyType]interface{}{
+		TxValueKeyNonce:    uint64(1),
+		TxValueKeyFrom:     common.HexToAddress("0x1111"),
+		TxValueKeyGasLimit: uint64(100000),
+		TxValueKeyGasPrice: big.NewInt(25000000000),
+		TxValueKeyData:     []byte("klay"),
+	})
+	require.NoError(t, err)
+
+	// Rejected until the custom tx type fork
+	fork.SetHardForkBlockNumberConfig(&params.ChainConfig{CustomTxTypeCompatibleBlock: big.NewInt(10)})
+	defer fork.ClearHardForkBlockNumberConfig()
+	assert.Equal(t, ErrTxTypeNotSupported, tx.Validate(nil, 9))
+	assert.NoError(t, tx.Validate(nil, 10))
+}
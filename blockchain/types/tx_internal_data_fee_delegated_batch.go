@@ -0,0 +1,359 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// TxInternalDataFeeDelegatedBatch represents a fee-delegated transaction performing multiple calls atomically.
+type TxInternalDataFeeDelegatedBatch struct {
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	From         common.Address
+	Calls        []*BatchCall
+
+	TxSignatures
+
+	FeePayer           common.Address
+	FeePayerSignatures TxSignatures
+
+	// This is only used when marshaling to JSON.
+	Hash *common.Hash `json:"hash" rlp:"-"`
+}
+
+type TxInternalDataFeeDelegatedBatchJSON struct {
+	Type               TxType           `json:"typeInt"`
+	TypeStr            string           `json:"type"`
+	AccountNonce       hexutil.Uint64   `json:"nonce"`
+	Price              *hexutil.Big     `json:"gasPrice"`
+	GasLimit           hexutil.Uint64   `json:"gas"`
+	From               common.Address   `json:"from"`
+	Calls              []*BatchCallJSON `json:"calls"`
+	TxSignatures       TxSignaturesJSON `json:"signatures"`
+	FeePayer           common.Address   `json:"feePayer"`
+	FeePayerSignatures TxSignaturesJSON `json:"feePayerSignatures"`
+	Hash               *common.Hash     `json:"hash"`
+}
+
+func newTxInternalDataFeeDelegatedBatch() *TxInternalDataFeeDelegatedBatch {
+	h := common.Hash{}
+	return &TxInternalDataFeeDelegatedBatch{
+		Price: new(big.Int),
+		Hash:  &h,
+	}
+}
+
+func newTxInternalDataFeeDelegatedBatchWithMap(values map[TxValueKeyType]interface{}) (*TxInternalDataFeeDelegatedBatch, error) {
+	t := newTxInternalDataFeeDelegatedBatch()
+
+	if v, ok := values[TxValueKeyNonce].(uint64); ok {
+		t.AccountNonce = v
+		delete(values, TxValueKeyNonce)
+	} else {
+		return nil, errValueKeyNonceMustUint64
+	}
+
+	if v, ok := values[TxValueKeyGasPrice].(*big.Int); ok {
+		t.Price.Set(v)
+		delete(values, TxValueKeyGasPrice)
+	} else {
+		return nil, errValueKeyGasPriceMustBigInt
+	}
+
+	if v, ok := values[TxValueKeyGasLimit].(uint64); ok {
+		t.GasLimit = v
+		delete(values, TxValueKeyGasLimit)
+	} else {
+		return nil, errValueKeyGasLimitMustUint64
+	}
+
+	if v, ok := values[TxValueKeyFrom].(common.Address); ok {
+		t.From = v
+		delete(values, TxValueKeyFrom)
+	} else {
+		return nil, errValueKeyFromMustAddress
+	}
+
+	if v, ok := values[TxValueKeyCalls].([]*BatchCall); ok {
+		t.Calls = v
+		delete(values, TxValueKeyCalls)
+	} else {
+		return nil, errValueKeyCallsMustBatchCalls
+	}
+
+	if v, ok := values[TxValueKeyFeePayer].(common.Address); ok {
+		t.FeePayer = v
+		delete(values, TxValueKeyFeePayer)
+	} else {
+		return nil, errValueKeyFeePayerMustAddress
+	}
+
+	if len(values) != 0 {
+		for k := range values {
+			logger.Warn("unnecessary key", k.String())
+		}
+		return nil, errUndefinedKeyRemains
+	}
+
+	return t, nil
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) Type() TxType {
+	return TxTypeFeeDelegatedBatch
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetRoleTypeForValidation() accountkey.RoleType {
+	return accountkey.RoleTransaction
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) Equal(a TxInternalData) bool {
+	ta, ok := a.(*TxInternalDataFeeDelegatedBatch)
+	if !ok {
+		return false
+	}
+
+	return t.AccountNonce == ta.AccountNonce &&
+		t.Price.Cmp(ta.Price) == 0 &&
+		t.GasLimit == ta.GasLimit &&
+		t.From == ta.From &&
+		equalBatchCalls(t.Calls, ta.Calls) &&
+		t.TxSignatures.equal(ta.TxSignatures) &&
+		t.FeePayer == ta.FeePayer &&
+		t.FeePayerSignatures.equal(ta.FeePayerSignatures)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) IsLegacyTransaction() bool {
+	return false
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetCalls() []*BatchCall {
+	return t.Calls
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetAccountNonce() uint64 {
+	return t.AccountNonce
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetPrice() *big.Int {
+	return new(big.Int).Set(t.Price)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetGasLimit() uint64 {
+	return t.GasLimit
+}
+
+// GetRecipient returns the recipient of the first call.
+func (t *TxInternalDataFeeDelegatedBatch) GetRecipient() *common.Address {
+	return batchCallsRecipient(t.Calls)
+}
+
+// GetAmount returns the sum of the values transferred by the calls.
+func (t *TxInternalDataFeeDelegatedBatch) GetAmount() *big.Int {
+	return batchCallsAmount(t.Calls)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetFrom() common.Address {
+	return t.From
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetHash() *common.Hash {
+	return t.Hash
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetFeePayer() common.Address {
+	return t.FeePayer
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) GetFeePayerRawSignatureValues() TxSignatures {
+	return t.FeePayerSignatures.RawSignatureValues()
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) SetHash(h *common.Hash) {
+	t.Hash = h
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) SetSignature(s TxSignatures) {
+	t.TxSignatures = s
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) SetFeePayerSignatures(s TxSignatures) {
+	t.FeePayerSignatures = s
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) RecoverFeePayerPubkey(txhash common.Hash, homestead bool, vfunc func(*big.Int) *big.Int) ([]*ecdsa.PublicKey, error) {
+	return t.FeePayerSignatures.RecoverPubkey(txhash, homestead, vfunc)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) String() string {
+	ser := newTxInternalDataSerializerWithValues(t)
+	tx := Transaction{data: t}
+	enc, _ := rlp.EncodeToBytes(ser)
+	return fmt.Sprintf(`
+	TX(%x)
+	Type:          %s
+	From:          %s
+	Nonce:         %v
+	GasPrice:      %#x
+	GasLimit:      %#x
+	Calls:         %s
+	Signature:     %s
+	FeePayer:      %s
+	FeePayerSig:   %s
+	Hex:           %x
+`,
+		tx.Hash(),
+		t.Type().String(),
+		t.From.String(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		batchCallsString(t.Calls),
+		t.TxSignatures.string(),
+		t.FeePayer.String(),
+		t.FeePayerSignatures.string(),
+		enc)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) IntrinsicGas(currentBlockNumber uint64) (uint64, error) {
+	return intrinsicGasBatchCalls(params.TxGasBatch+params.TxGasFeeDelegated, t.Calls)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) SerializeForSignToBytes() []byte {
+	b, _ := rlp.EncodeToBytes(struct {
+		Txtype       TxType
+		AccountNonce uint64
+		Price        *big.Int
+		GasLimit     uint64
+		From         common.Address
+		Calls        []*BatchCall
+	}{
+		t.Type(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.From,
+		t.Calls,
+	})
+
+	return b
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) SerializeForSign() []interface{} {
+	return []interface{}{
+		t.Type(),
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.From,
+		t.Calls,
+	}
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) SenderTxHash() common.Hash {
+	hw := sha3.NewKeccak256()
+	rlp.Encode(hw, t.Type())
+	rlp.Encode(hw, []interface{}{
+		t.AccountNonce,
+		t.Price,
+		t.GasLimit,
+		t.From,
+		t.Calls,
+		t.TxSignatures,
+	})
+
+	h := common.Hash{}
+
+	hw.Sum(h[:0])
+
+	return h
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) Validate(stateDB StateDB, currentBlockNumber uint64) error {
+	if err := validateBatchCalls(t.Calls, currentBlockNumber); err != nil {
+		return err
+	}
+	return t.ValidateMutableValue(stateDB, currentBlockNumber)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) ValidateMutableValue(stateDB StateDB, currentBlockNumber uint64) error {
+	return nil
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) Execute(sender ContractRef, vm VM, stateDB StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) (ret []byte, usedGas uint64, err error) {
+	return executeBatchCalls(t.Calls, sender, vm, stateDB, gas)
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) MakeRPCOutput() map[string]interface{} {
+	return map[string]interface{}{
+		"typeInt":            t.Type(),
+		"type":               t.Type().String(),
+		"gas":                hexutil.Uint64(t.GasLimit),
+		"gasPrice":           (*hexutil.Big)(t.Price),
+		"nonce":              hexutil.Uint64(t.AccountNonce),
+		"calls":              batchCallsToJSON(t.Calls),
+		"signatures":         t.TxSignatures.ToJSON(),
+		"feePayer":           t.FeePayer,
+		"feePayerSignatures": t.FeePayerSignatures.ToJSON(),
+	}
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(TxInternalDataFeeDelegatedBatchJSON{
+		t.Type(),
+		t.Type().String(),
+		(hexutil.Uint64)(t.AccountNonce),
+		(*hexutil.Big)(t.Price),
+		(hexutil.Uint64)(t.GasLimit),
+		t.From,
+		batchCallsToJSON(t.Calls),
+		t.TxSignatures.ToJSON(),
+		t.FeePayer,
+		t.FeePayerSignatures.ToJSON(),
+		t.Hash,
+	})
+}
+
+func (t *TxInternalDataFeeDelegatedBatch) UnmarshalJSON(b []byte) error {
+	js := &TxInternalDataFeeDelegatedBatchJSON{}
+	if err := json.Unmarshal(b, js); err != nil {
+		return err
+	}
+
+	t.AccountNonce = uint64(js.AccountNonce)
+	t.Price = (*big.Int)(js.Price)
+	t.GasLimit = uint64(js.GasLimit)
+	t.From = js.From
+	t.Calls = batchCallsFromJSON(js.Calls)
+	t.TxSignatures = js.TxSignatures.ToTxSignatures()
+	t.FeePayer = js.FeePayer
+	t.FeePayerSignatures = js.FeePayerSignatures.ToTxSignatures()
+	t.Hash = js.Hash
+
+	return nil
+}
@@ -42,6 +42,7 @@ func TestTxRLPDecode(t *testing.T) {
 		testTxRLPDecodeSmartContractExecution,
 		testTxRLPDecodeCancel,
 		testTxRLPDecodeChainDataAnchoring,
+		testTxRLPDecodeBatch,
 
 		testTxRLPDecodeFeeDelegatedValueTransfer,
 		testTxRLPDecodeFeeDelegatedValueTransferMemo,
@@ -50,6 +51,7 @@ func TestTxRLPDecode(t *testing.T) {
 		testTxRLPDecodeFeeDelegatedSmartContractExecution,
 		testTxRLPDecodeFeeDelegatedCancel,
 		testTxRLPDecodeFeeDelegatedChainDataAnchoring,
+		testTxRLPDecodeFeeDelegatedBatch,
 
 		testTxRLPDecodeFeeDelegatedValueTransferWithRatio,
 		testTxRLPDecodeFeeDelegatedValueTransferMemoWithRatio,
@@ -532,6 +534,72 @@ func testTxRLPDecodeFeeDelegatedCancel(t *testing.T) {
 	}
 }
 
+func testTxRLPDecodeBatch(t *testing.T) {
+	tx := genBatchTransaction().(*TxInternalDataBatch)
+
+	buffer := new(bytes.Buffer)
+	err := rlp.Encode(buffer, tx.Type())
+	assert.Equal(t, nil, err)
+
+	calls := make([]interface{}, len(tx.Calls))
+	for i, c := range tx.Calls {
+		calls[i] = []interface{}{c.To, c.Value, c.Data}
+	}
+	err = rlp.Encode(buffer, []interface{}{
+		tx.AccountNonce,
+		tx.Price,
+		tx.GasLimit,
+		tx.From,
+		calls,
+		tx.TxSignatures,
+	})
+	assert.Equal(t, nil, err)
+
+	dec := newTxInternalDataSerializer()
+
+	if err := rlp.DecodeBytes(buffer.Bytes(), &dec); err != nil {
+		panic(err)
+	}
+
+	if !tx.Equal(dec.tx) {
+		t.Fatalf("tx != dec.tx\ntx=%v\ndec.tx=%v", tx, dec.tx)
+	}
+}
+
+func testTxRLPDecodeFeeDelegatedBatch(t *testing.T) {
+	tx := genFeeDelegatedBatchTransaction().(*TxInternalDataFeeDelegatedBatch)
+
+	buffer := new(bytes.Buffer)
+	err := rlp.Encode(buffer, tx.Type())
+	assert.Equal(t, nil, err)
+
+	calls := make([]interface{}, len(tx.Calls))
+	for i, c := range tx.Calls {
+		calls[i] = []interface{}{c.To, c.Value, c.Data}
+	}
+	err = rlp.Encode(buffer, []interface{}{
+		tx.AccountNonce,
+		tx.Price,
+		tx.GasLimit,
+		tx.From,
+		calls,
+		tx.TxSignatures,
+		tx.FeePayer,
+		tx.FeePayerSignatures,
+	})
+	assert.Equal(t, nil, err)
+
+	dec := newTxInternalDataSerializer()
+
+	if err := rlp.DecodeBytes(buffer.Bytes(), &dec); err != nil {
+		panic(err)
+	}
+
+	if !tx.Equal(dec.tx) {
+		t.Fatalf("tx != dec.tx\ntx=%v\ndec.tx=%v", tx, dec.tx)
+	}
+}
+
 func testTxRLPDecodeFeeDelegatedChainDataAnchoring(t *testing.T) {
 	tx := genFeeDelegatedChainDataTransaction().(*TxInternalDataFeeDelegatedChainDataAnchoring)
 
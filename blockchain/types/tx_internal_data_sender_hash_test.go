@@ -56,6 +56,8 @@ func TestTransactionSenderTxHash(t *testing.T) {
 		{"Cancel", genCancelTransaction()},
 		{"FeeDelegatedCancel", genFeeDelegatedCancelTransaction()},
 		{"FeeDelegatedCancelWithRatio", genFeeDelegatedCancelWithRatioTransaction()},
+		{"Batch", genBatchTransaction()},
+		{"FeeDelegatedBatch", genFeeDelegatedBatchTransaction()},
 		{"AccessList", genAccessListTransaction()},
 		{"DynamicFee", genDynamicFeeTransaction()},
 	}
@@ -382,6 +384,28 @@ func testTransactionSenderTxHash(t *testing.T, tx TxInternalData) {
 		senderTxHash := rawTx.GetTxInternalData().SenderTxHash()
 		assert.Equal(t, h, senderTxHash)
 
+	case *TxInternalDataBatch:
+		senderTxHash := rawTx.GetTxInternalData().SenderTxHash()
+		assert.Equal(t, rawTx.Hash(), senderTxHash)
+
+	case *TxInternalDataFeeDelegatedBatch:
+		hw := sha3.NewKeccak256()
+		rlp.Encode(hw, rawTx.Type())
+		rlp.Encode(hw, []interface{}{
+			v.AccountNonce,
+			v.Price,
+			v.GasLimit,
+			v.From,
+			v.Calls,
+			v.TxSignatures,
+		})
+
+		h := common.Hash{}
+
+		hw.Sum(h[:0])
+		senderTxHash := rawTx.GetTxInternalData().SenderTxHash()
+		assert.Equal(t, h, senderTxHash)
+
 	case *TxInternalDataChainDataAnchoring:
 		senderTxHash := rawTx.GetTxInternalData().SenderTxHash()
 		assert.Equal(t, rawTx.Hash(), senderTxHash)
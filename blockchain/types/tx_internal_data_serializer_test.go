@@ -71,6 +71,8 @@ func TestTransactionSerialization(t *testing.T) {
 		{"Cancel", genCancelTransaction()},
 		{"FeeDelegatedCancel", genFeeDelegatedCancelTransaction()},
 		{"FeeDelegatedCancelWithRatio", genFeeDelegatedCancelWithRatioTransaction()},
+		{"Batch", genBatchTransaction()},
+		{"FeeDelegatedBatch", genFeeDelegatedBatchTransaction()},
 		{"AccessList", genAccessListTransaction()},
 		{"DynamicFee", genDynamicFeeTransaction()},
 	}
@@ -740,3 +742,44 @@ func genFeeDelegatedCancelWithRatioTransaction() TxInternalData {
 
 	return d
 }
+
+func genBatchCalls() []*BatchCall {
+	return []*BatchCall{
+		{To: to, Value: amount, Data: []byte{}},
+		// A abi-packed bytes calling "reward" of contracts/reward/contract/KlaytnReward.sol with an address "bc5951f055a85f41a3b62fd6f68ab7de76d299b2".
+		{To: to, Value: big.NewInt(0), Data: common.Hex2Bytes("6353586b000000000000000000000000bc5951f055a85f41a3b62fd6f68ab7de76d299b2")},
+	}
+}
+
+func genBatchTransaction() TxInternalData {
+	d, err := NewTxInternalDataWithMap(TxTypeBatch, map[TxValueKeyType]interface{}{
+		TxValueKeyNonce:    nonce,
+		TxValueKeyGasLimit: gasLimit,
+		TxValueKeyGasPrice: gasPrice,
+		TxValueKeyFrom:     from,
+		TxValueKeyCalls:    genBatchCalls(),
+	})
+	if err != nil {
+		// Since we do not have testing.T here, call panic() instead of t.Fatal().
+		panic(err)
+	}
+
+	return d
+}
+
+func genFeeDelegatedBatchTransaction() TxInternalData {
+	d, err := NewTxInternalDataWithMap(TxTypeFeeDelegatedBatch, map[TxValueKeyType]interface{}{
+		TxValueKeyNonce:    nonce,
+		TxValueKeyGasLimit: gasLimit,
+		TxValueKeyGasPrice: gasPrice,
+		TxValueKeyFrom:     from,
+		TxValueKeyCalls:    genBatchCalls(),
+		TxValueKeyFeePayer: feePayer,
+	})
+	if err != nil {
+		// Since we do not have testing.T here, call panic() instead of t.Fatal().
+		panic(err)
+	}
+
+	return d
+}
@@ -48,8 +48,36 @@ func NewTxSignature() *TxSignature {
 	}
 }
 
+// P256SignatureVOffset is added to V of a P-256 signature to distinguish it from secp256k1 signatures.
+// A P-256 signature has V = {0,1} + chainID * 2 + 35 + P256SignatureVOffset, where {0,1} is
+// the recovery id of crypto.RecoverP256. P-256 signatures are supported only by Klaytn transaction types.
+var P256SignatureVOffset = new(big.Int).Lsh(common.Big1, 128)
+
+// splitP256V returns V without P256SignatureVOffset and whether V is of a P-256 signature.
+func splitP256V(v *big.Int) (*big.Int, bool) {
+	if v.Cmp(P256SignatureVOffset) < 0 {
+		return v, false
+	}
+	return new(big.Int).Sub(v, P256SignatureVOffset), true
+}
+
+// NewTxSignatureWithValues signs the txhash with the private key.
+// If the private key is on the P-256 curve, a P-256 signature is returned.
 func NewTxSignatureWithValues(signer Signer, tx *Transaction, txhash common.Hash, prv *ecdsa.PrivateKey) (*TxSignature, error) {
-	sig, err := crypto.Sign(txhash[:], prv)
+	isP256 := crypto.IsP256(&prv.PublicKey)
+	if isP256 && tx.IsEthereumTransaction() {
+		return nil, ErrTxTypeNotSupported
+	}
+
+	var (
+		sig []byte
+		err error
+	)
+	if isP256 {
+		sig, err = crypto.SignP256(txhash[:], prv)
+	} else {
+		sig, err = crypto.Sign(txhash[:], prv)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -60,12 +88,22 @@ func NewTxSignatureWithValues(signer Signer, tx *Transaction, txhash common.Hash
 	if err != nil {
 		return nil, err
 	}
+	if isP256 {
+		txsig.V.Add(txsig.V, P256SignatureVOffset)
+	}
 
 	return txsig, nil
 }
 
+// IsP256 returns true if the signature is made by a P-256 key.
+func (t *TxSignature) IsP256() bool {
+	_, isP256 := splitP256V(t.V)
+	return isP256
+}
+
 func (t *TxSignature) ChainId() *big.Int {
-	return deriveChainId(t.V)
+	v, _ := splitP256V(t.V)
+	return deriveChainId(v)
 }
 
 func (t *TxSignature) RawSignatureValues() *TxSignature {
@@ -73,6 +111,15 @@ func (t *TxSignature) RawSignatureValues() *TxSignature {
 }
 
 func (t *TxSignature) ValidateSignature() bool {
+	v, isP256 := splitP256V(t.V)
+	if isP256 {
+		// A P-256 signature should be replay-protected
+		if !isProtectedV(v) || v.BitLen() > 64 {
+			return false
+		}
+		V := byte(v.Uint64() - 35 - 2*deriveChainId(v).Uint64())
+		return crypto.ValidateP256SignatureValues(V, t.R, t.S)
+	}
 	return validateSignature(t.V, t.R, t.S)
 }
 
@@ -80,7 +127,11 @@ func (t *TxSignature) ValidateSignature() bool {
 // txhash: a hash generated by tx.Hash().
 // homestead: true if Homestead or later.
 // vfunc: V in the signature is treated differently by Signer. This function is for the treatment.
+// A P-256 signature has no address to be recovered.
 func (t *TxSignature) RecoverAddress(txhash common.Hash, homestead bool, vfunc func(*big.Int) *big.Int) (common.Address, error) {
+	if t.IsP256() {
+		return common.Address{}, ErrInvalidSig
+	}
 	V := vfunc(t.V)
 	return recoverPlain(txhash, t.R, t.S, V, homestead)
 }
@@ -89,9 +140,13 @@ func (t *TxSignature) RecoverAddress(txhash common.Hash, homestead bool, vfunc f
 // txhash: a hash generated by tx.Hash().
 // homestead: true if Homestead or later.
 // vfunc: V in the signature is treated differently by Signer. This function is for the treatment.
+// The public key of a P-256 signature is on the P-256 curve.
 func (t *TxSignature) RecoverPubkey(txhash common.Hash, homestead bool, vfunc func(*big.Int) *big.Int) (*ecdsa.PublicKey, error) {
-	v := vfunc(t.V)
-	return recoverPlainPubkey(txhash, t.R, t.S, v, homestead)
+	v, isP256 := splitP256V(t.V)
+	if isP256 {
+		return recoverP256Pubkey(txhash, t.R, t.S, vfunc(v))
+	}
+	return recoverPlainPubkey(txhash, t.R, t.S, vfunc(v), homestead)
 }
 
 func (t *TxSignature) equal(tb *TxSignature) bool {
@@ -116,7 +116,26 @@ var PrecompiledContractsKore = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{3, 255}): &validateSender{},
 }
 
+// PrecompiledContractsP256 contains the default set of pre-compiled Klaytn
+// contracts after the P-256 hardfork, adding the P-256 signature verification.
+var PrecompiledContractsP256 = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}):      &ecrecover{},
+	common.BytesToAddress([]byte{2}):      &sha256hash{},
+	common.BytesToAddress([]byte{3}):      &ripemd160hash{},
+	common.BytesToAddress([]byte{4}):      &dataCopy{},
+	common.BytesToAddress([]byte{5}):      &bigModExp{eip2565: true},
+	common.BytesToAddress([]byte{6}):      &bn256AddIstanbul{},
+	common.BytesToAddress([]byte{7}):      &bn256ScalarMulIstanbul{},
+	common.BytesToAddress([]byte{8}):      &bn256PairingIstanbul{},
+	common.BytesToAddress([]byte{9}):      &blake2F{},
+	common.BytesToAddress([]byte{1, 0}):   &p256Verify{},
+	common.BytesToAddress([]byte{3, 253}): &vmLog{},
+	common.BytesToAddress([]byte{3, 254}): &feePayer{},
+	common.BytesToAddress([]byte{3, 255}): &validateSender{},
+}
+
 var (
+	PrecompiledAddressesP256                []common.Address
 	PrecompiledAddressesIstanbulCompatible  []common.Address
 	PrecompiledAddressesByzantiumCompatible []common.Address
 )
@@ -134,11 +153,19 @@ func init() {
 	}
 	PrecompiledAddressesIstanbulCompatible = append(PrecompiledAddressesIstanbulCompatible,
 		[]common.Address{common.BytesToAddress([]byte{10}), common.BytesToAddress([]byte{11})}...)
+
+	for k := range PrecompiledContractsP256 {
+		PrecompiledAddressesP256 = append(PrecompiledAddressesP256, k)
+	}
+	PrecompiledAddressesP256 = append(PrecompiledAddressesP256,
+		[]common.Address{common.BytesToAddress([]byte{10}), common.BytesToAddress([]byte{11})}...)
 }
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
 	switch {
+	case rules.IsP256:
+		return PrecompiledAddressesP256
 	case rules.IsIstanbul:
 		return PrecompiledAddressesIstanbulCompatible
 	default:
@@ -727,3 +754,33 @@ func (c *validateSender) validateSender(input []byte, picker types.AccountKeyPic
 
 	return nil
 }
+
+// P256VERIFY implemented as a native contract.
+// It verifies a P-256 signature in the same format as RIP-7212, so that the passkeys and
+// WebAuthn authenticators can be verified on-chain.
+type p256Verify struct{}
+
+func (c *p256Verify) GetRequiredGasAndComputationCost(input []byte) (uint64, uint64) {
+	return params.P256VerifyGas, params.P256VerifyComputationCost
+}
+
+// Run verifies the input (hash, r, s, x, y), each 32 bytes.
+// It returns 1 in 32 bytes if the signature is valid, and nothing otherwise.
+func (c *p256Verify) Run(input []byte, contract *Contract, evm *EVM) ([]byte, error) {
+	const p256VerifyInputLength = 160
+
+	if len(input) != p256VerifyInputLength {
+		return nil, nil
+	}
+	var (
+		hash = input[0:32]
+		r    = new(big.Int).SetBytes(input[32:64])
+		s    = new(big.Int).SetBytes(input[64:96])
+		x    = new(big.Int).SetBytes(input[96:128])
+		y    = new(big.Int).SetBytes(input[128:160])
+	)
+	if !crypto.VerifyP256(&ecdsa.PublicKey{Curve: crypto.P256(), X: x, Y: y}, hash, r, s) {
+		return nil, nil
+	}
+	return common.LeftPadBytes([]byte{1}, 32), nil
+}
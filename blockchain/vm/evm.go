@@ -567,6 +567,8 @@ func (evm *EVM) GetPrecompiledContractMap(addr common.Address) map[common.Addres
 	}
 
 	switch {
+	case evm.chainRules.IsP256:
+		return PrecompiledContractsP256
 	case evm.chainRules.IsKore:
 		return PrecompiledContractsKore
 	case evm.chainRules.IsIstanbul:
@@ -1,14 +1,14 @@
 #!/bin/bash
 
 DAEMON_BINARIES=(kcn kpn ken kbn kscn kspn ksen)
-BINARIES=(kgen homi)
+BINARIES=(kgen ksigner kfeepayer homi)
 
 set -e
 
 function printUsage {
     echo "Usage: $0 [-b] <target binary>"
     echo "               -b: use baobab configuration."
-    echo "  <target binary>: kcn | kpn | ken | kbn | kscn | kspn | ksen | kgen | homi"
+    echo "  <target binary>: kcn | kpn | ken | kbn | kscn | kspn | ksen | kgen | ksigner | kfeepayer | homi"
     exit 1
 }
 
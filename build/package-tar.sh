@@ -2,7 +2,7 @@
 
 MYDIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" >/dev/null 2>&1 && pwd )"
 DAEMON_BINARIES=(kcn kpn ken kbn kscn kspn ksen)
-BINARIES=(kgen homi)
+BINARIES=(kgen ksigner kfeepayer homi)
 
 set -e
 
@@ -10,7 +10,7 @@ function printUsage {
     echo "Usage: ${0} [-b] <arch> <target>"
     echo "         -b: use baobab configuration"
     echo "     <arch>:  linux-386 | linux-amd64 | darwin-amd64 | windows-386 | windows-amd64"
-    echo "   <target>:  kcn | kpn | ken | kbn | kscn | kspn | ksen | kgen | homi"
+    echo "   <target>:  kcn | kpn | ken | kbn | kscn | kspn | ksen | kgen | ksigner | kfeepayer | homi"
     echo ""
     echo "    ${0} linux-amd64 kcn"
     exit 1
@@ -21,6 +21,8 @@ const (
 	BN   = "kbn"
 	HOMI = "homi"
 	GEN  = "kgen"
+	SIGN = "ksigner"
+	FEE  = "kfeepayer"
 )
 
 type NodeInfo struct {
@@ -75,6 +77,16 @@ var BINARY_TYPE = map[string]NodeInfo{
 		"private key generator",
 		"kgen is a generator of private keys.",
 	},
+	SIGN: {
+		"ksigner",
+		"istanbul remote signer",
+		"ksigner is a remote signer holding the validator key of a Klaytn consensus node.",
+	},
+	FEE: {
+		"kfeepayer",
+		"fee payer service",
+		"kfeepayer is a fee payer service paying the fees of fee-delegated transactions.",
+	},
 }
 
 type RpmSpec struct {
@@ -117,7 +129,7 @@ func main() {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  "binary_type",
-					Usage: "Klaytn binary type (kcn, kpn, ken, kscn, kspn, ksen, kbn, kgen, homi)",
+					Usage: "Klaytn binary type (kcn, kpn, ken, kscn, kspn, ksen, kbn, kgen, ksigner, kfeepayer, homi)",
 				},
 				cli.BoolFlag{
 					Name:  "devel",
@@ -165,7 +177,7 @@ func genspec(c *cli.Context) error {
 
 	binaryType := c.String("binary_type")
 	if _, ok := BINARY_TYPE[binaryType]; ok != true {
-		return fmt.Errorf("binary_type[\"%s\"] is not supported. Use --binary_type [kcn, kpn, ken, kscn, kspn, ksen, kbn, kgen, homi]", binaryType)
+		return fmt.Errorf("binary_type[\"%s\"] is not supported. Use --binary_type [kcn, kpn, ken, kscn, kspn, ksen, kbn, kgen, ksigner, kfeepayer, homi]", binaryType)
 	}
 
 	rpmSpec.ProgramName = strings.ToLower(binaryType)
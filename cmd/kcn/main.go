@@ -69,6 +69,19 @@ func init() {
 
 		// See utils/nodecmd/snapshot.go:
 		nodecmd.SnapshotCommand,
+
+		// See utils/nodecmd/stakinginfocmd.go:
+		nodecmd.StakingInfoCommand,
+
+		// See utils/nodecmd/proposercmd.go:
+		nodecmd.SimulateProposersCommand,
+
+		// See utils/nodecmd/rewardcmd.go:
+		nodecmd.ExportRewardsCommand,
+		nodecmd.BackfillTotalSupplyCommand,
+
+		// See utils/nodecmd/govcmd.go:
+		nodecmd.ValidateVotesCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -69,6 +69,13 @@ func init() {
 
 		// See utils/nodecmd/snapshot.go:
 		nodecmd.SnapshotCommand,
+
+		// See utils/nodecmd/stakinginfocmd.go:
+		nodecmd.StakingInfoCommand,
+
+		// See utils/nodecmd/rewardcmd.go:
+		nodecmd.ExportRewardsCommand,
+		nodecmd.BackfillTotalSupplyCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
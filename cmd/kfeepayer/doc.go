@@ -0,0 +1,51 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+kfeepayer is a fee payer service paying the fees of fee-delegated transactions signed by the senders.
+
+dApps send the sender-signed transactions to feepayer_sendRawTransaction. kfeepayer checks them against
+the policy given by --policy, signs them as the fee payer with the account unlocked from --keystore, and
+submits them to the node given by --endpoint. The daily gas usages of the senders and the audit log of
+all requests are kept in --datadir. See accounts/feepayer for the API and the policy.
+
+An example of the policy file is as follows.
+
+	{
+	  "contracts": [
+	    {"address": "0x1234567890123456789012345678901234567890", "methods": ["0xa9059cbb"]}
+	  ],
+	  "dailyGasBudget": 1000000,
+	  "maxGasLimit": 300000,
+	  "maxFeeRatio": 100,
+	  "simulate": true
+	}
+
+Options
+
+All available options are as follows.
+   --endpoint value       RPC endpoint of the Klaytn node to simulate and submit the transactions (default: "http://localhost:8551")
+   --keystore value       Directory of the keystore holding the fee payer account
+   --feepayer value       Address of the fee payer account
+   --password value       Password file to unlock the fee payer account
+   --policy value         JSON file of the policy (default: allow any fee-delegated transaction not reverting)
+   --datadir value        Directory for the daily gas usages and the audit log (default: "kfeepayer")
+   --rpcaddr value        Address to serve the fee payer API over HTTP (default: "localhost:8560")
+   --rpccorsdomain value  Comma separated list of domains from which to accept cross origin requests (browser enforced)
+   --rpcvhosts value      Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard. (default: "localhost")
+   --help, -h             Show help
+*/
+package main
@@ -0,0 +1,185 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/klaytn/klaytn/accounts"
+	"github.com/klaytn/klaytn/accounts/feepayer"
+	"github.com/klaytn/klaytn/accounts/keystore"
+	"github.com/klaytn/klaytn/client"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/cmd/utils/nodecmd"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"gopkg.in/urfave/cli.v1"
+)
+
+const auditDBName = "auditdb" // directory name of the database keeping the audit log
+
+var (
+	logger       = log.NewModuleLogger(log.CMDKFEEPAYER)
+	endpointFlag = cli.StringFlag{
+		Name:  "endpoint",
+		Usage: "RPC endpoint of the Klaytn node to simulate and submit the transactions",
+		Value: "http://localhost:8551",
+	}
+	keystoreFlag = cli.StringFlag{
+		Name:  "keystore",
+		Usage: "Directory of the keystore holding the fee payer account",
+	}
+	feePayerFlag = cli.StringFlag{
+		Name:  "feepayer",
+		Usage: "Address of the fee payer account",
+	}
+	passwordFlag = cli.StringFlag{
+		Name:  "password",
+		Usage: "Password file to unlock the fee payer account",
+	}
+	policyFlag = cli.StringFlag{
+		Name:  "policy",
+		Usage: "JSON file of the policy (default: allow any fee-delegated transaction not reverting)",
+	}
+	dataDirFlag = cli.StringFlag{
+		Name:  "datadir",
+		Usage: "Directory for the daily gas usages and the audit log",
+		Value: "kfeepayer",
+	}
+	rpcAddrFlag = cli.StringFlag{
+		Name:  "rpcaddr",
+		Usage: "Address to serve the fee payer API over HTTP",
+		Value: "localhost:8560",
+	}
+	rpcCORSDomainFlag = cli.StringFlag{
+		Name:  "rpccorsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+	}
+	rpcVirtualHostsFlag = cli.StringFlag{
+		Name:  "rpcvhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: "localhost",
+	}
+)
+
+func init() {
+	cli.AppHelpTemplate = utils.KgenHelpTemplate
+	cli.HelpPrinter = utils.NewHelpPrinter(nil)
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "kfeepayer"
+	app.Usage = "The fee payer service paying the fees of fee-delegated transactions"
+	app.Copyright = "Copyright 2018-2022 The klaytn Authors"
+	app.Action = runFeePayer
+	app.Flags = []cli.Flag{
+		endpointFlag,
+		keystoreFlag,
+		feePayerFlag,
+		passwordFlag,
+		policyFlag,
+		dataDirFlag,
+		rpcAddrFlag,
+		rpcCORSDomainFlag,
+		rpcVirtualHostsFlag,
+	}
+	app.Commands = []cli.Command{
+		nodecmd.VersionCommand,
+	}
+	app.HideVersion = true
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runFeePayer serves the fee payer API until the process is interrupted.
+func runFeePayer(ctx *cli.Context) error {
+	for _, flag := range []cli.StringFlag{keystoreFlag, feePayerFlag, passwordFlag} {
+		if !ctx.IsSet(flag.Name) {
+			return fmt.Errorf("--%s is required", flag.Name)
+		}
+	}
+	if !common.IsHexAddress(ctx.String(feePayerFlag.Name)) {
+		return fmt.Errorf("invalid fee payer address: %s", ctx.String(feePayerFlag.Name))
+	}
+	feePayerAddr := common.HexToAddress(ctx.String(feePayerFlag.Name))
+
+	policy := feepayer.DefaultPolicy()
+	if file := ctx.String(policyFlag.Name); file != "" {
+		var err error
+		if policy, err = feepayer.LoadPolicy(file); err != nil {
+			return err
+		}
+	}
+
+	ks := keystore.NewKeyStore(ctx.String(keystoreFlag.Name), keystore.StandardScryptN, keystore.StandardScryptP)
+	password, err := ioutil.ReadFile(ctx.String(passwordFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to read the password file: %v", err)
+	}
+	if err := ks.Unlock(accounts.Account{Address: feePayerAddr}, strings.TrimRight(string(password), "\r\n")); err != nil {
+		return fmt.Errorf("failed to unlock the fee payer account: %v", err)
+	}
+
+	backend, err := client.Dial(ctx.String(endpointFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the node: %v", err)
+	}
+	defer backend.Close()
+	chainID, err := backend.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get the chain ID: %v", err)
+	}
+
+	db, err := database.NewLevelDBWithOption(filepath.Join(ctx.String(dataDirFlag.Name), auditDBName), &opt.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to open the audit database: %v", err)
+	}
+	defer db.Close()
+
+	service, err := feepayer.NewService(backend, ks, feePayerAddr, chainID, policy, db)
+	if err != nil {
+		return err
+	}
+	listener, handler, err := rpc.StartHTTPEndpoint(ctx.String(rpcAddrFlag.Name), service.APIs(), nil,
+		utils.SplitAndTrim(ctx.String(rpcCORSDomainFlag.Name)), utils.SplitAndTrim(ctx.String(rpcVirtualHostsFlag.Name)), rpc.DefaultHTTPTimeouts)
+	if err != nil {
+		return fmt.Errorf("failed to open the HTTP endpoint: %v", err)
+	}
+	defer handler.Stop()
+	defer listener.Close()
+	logger.Info("Fee payer HTTP endpoint opened", "addr", listener.Addr(), "feePayer", feePayerAddr, "chainID", chainID)
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	<-sigc
+	logger.Info("Got interrupt, shutting down...")
+	return nil
+}
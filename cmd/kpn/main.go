@@ -69,6 +69,9 @@ func init() {
 
 		// See utils/nodecmd/snapshot.go:
 		nodecmd.SnapshotCommand,
+
+		// See utils/nodecmd/stakinginfocmd.go:
+		nodecmd.StakingInfoCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
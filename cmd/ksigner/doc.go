@@ -0,0 +1,38 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+ksigner is a reference remote signer daemon holding the validator key of a Klaytn consensus node (CN).
+
+The CN connects to ksigner with the --remotesigner flag, either by the path of the Unix socket
+or by "grpc://host:port". The gRPC endpoint is only opened with mutual TLS, so --tlscert, --tlskey
+and --tlsca are required together with --grpcaddr.
+
+ksigner keeps the high-water mark of the signed views in --datadir and refuses to sign for an older view.
+
+Options
+
+All available options are as follows.
+   --nodekey value   Validator key file to sign with
+   --datadir value   Directory for the high-water mark of signed views (default: "ksigner")
+   --ipcpath value   Unix socket path to serve the signer API (default: "<datadir>/ksigner.ipc")
+   --grpcaddr value  Address to serve the signer API over gRPC (e.g. 0.0.0.0:50061)
+   --tlscert value   Server certificate file for the gRPC endpoint
+   --tlskey value    Server key file for the gRPC endpoint
+   --tlsca value     CA certificate file to verify the CN client certificates
+   --help, -h        Show help
+*/
+package main
@@ -0,0 +1,146 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/cmd/utils/nodecmd"
+	"github.com/klaytn/klaytn/consensus/istanbul/signer"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/log"
+	"gopkg.in/urfave/cli.v1"
+)
+
+const (
+	hwmFileName = "highwatermark.json" // file name of the high-water mark of signed views
+	ipcFileName = "ksigner.ipc"        // default file name of the Unix socket
+)
+
+var (
+	logger      = log.NewModuleLogger(log.CMDKSIGNER)
+	nodeKeyFlag = cli.StringFlag{
+		Name:  "nodekey",
+		Usage: "Validator key file to sign with",
+	}
+	dataDirFlag = cli.StringFlag{
+		Name:  "datadir",
+		Usage: "Directory for the high-water mark of signed views",
+		Value: "ksigner",
+	}
+	ipcPathFlag = cli.StringFlag{
+		Name:  "ipcpath",
+		Usage: `Unix socket path to serve the signer API (default: "<datadir>/ksigner.ipc")`,
+	}
+	grpcAddrFlag = cli.StringFlag{
+		Name:  "grpcaddr",
+		Usage: "Address to serve the signer API over gRPC (e.g. 0.0.0.0:50061)",
+	}
+	tlsCertFlag = cli.StringFlag{
+		Name:  "tlscert",
+		Usage: "Server certificate file for the gRPC endpoint",
+	}
+	tlsKeyFlag = cli.StringFlag{
+		Name:  "tlskey",
+		Usage: "Server key file for the gRPC endpoint",
+	}
+	tlsCAFlag = cli.StringFlag{
+		Name:  "tlsca",
+		Usage: "CA certificate file to verify the CN client certificates",
+	}
+)
+
+func init() {
+	cli.AppHelpTemplate = utils.KgenHelpTemplate
+	cli.HelpPrinter = utils.NewHelpPrinter(nil)
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "ksigner"
+	app.Usage = "The remote signer holding the validator key of a Klaytn consensus node"
+	app.Copyright = "Copyright 2018-2022 The klaytn Authors"
+	app.Action = runSigner
+	app.Flags = []cli.Flag{
+		nodeKeyFlag,
+		dataDirFlag,
+		ipcPathFlag,
+		grpcAddrFlag,
+		tlsCertFlag,
+		tlsKeyFlag,
+		tlsCAFlag,
+	}
+	app.Commands = []cli.Command{
+		nodecmd.VersionCommand,
+	}
+	app.HideVersion = true
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// runSigner serves the signer API until the process is interrupted.
+func runSigner(ctx *cli.Context) error {
+	if !ctx.IsSet(nodeKeyFlag.Name) {
+		return fmt.Errorf("--%s is required", nodeKeyFlag.Name)
+	}
+	key, err := crypto.LoadECDSA(ctx.String(nodeKeyFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load the validator key: %v", err)
+	}
+
+	dataDir := ctx.String(dataDirFlag.Name)
+	guard, err := signer.NewViewGuard(filepath.Join(dataDir, hwmFileName))
+	if err != nil {
+		return fmt.Errorf("failed to load the high-water mark: %v", err)
+	}
+	server, err := signer.NewServer(key, guard)
+	if err != nil {
+		return err
+	}
+	defer server.Stop()
+	logger.Info("Loaded the high-water mark", "view", guard.View())
+
+	ipcPath := ctx.String(ipcPathFlag.Name)
+	if ipcPath == "" {
+		ipcPath = filepath.Join(dataDir, ipcFileName)
+	}
+	if err := server.ServeIPC(ipcPath); err != nil {
+		return fmt.Errorf("failed to open the IPC endpoint: %v", err)
+	}
+
+	if addr := ctx.String(grpcAddrFlag.Name); addr != "" {
+		tlsConfig, err := signer.NewServerTLSConfig(ctx.String(tlsCertFlag.Name), ctx.String(tlsKeyFlag.Name), ctx.String(tlsCAFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to load the TLS configuration of the gRPC endpoint: %v", err)
+		}
+		go server.ServeGRPC(addr, tlsConfig)
+	}
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	<-sigc
+	logger.Info("Got interrupt, shutting down...")
+	return nil
+}
@@ -344,6 +344,9 @@ func (kCfg *KlayConfig) SetNodeConfig(ctx *cli.Context) {
 	if ctx.GlobalIsSet(LightKDFFlag.Name) {
 		cfg.UseLightweightKDF = ctx.GlobalBool(LightKDFFlag.Name)
 	}
+	if ctx.GlobalIsSet(ExternalSignerFlag.Name) {
+		cfg.ExternalSigner = ctx.GlobalString(ExternalSignerFlag.Name)
+	}
 	if ctx.GlobalIsSet(RPCNonEthCompatibleFlag.Name) {
 		rpc.NonEthCompatible = ctx.GlobalBool(RPCNonEthCompatibleFlag.Name)
 	}
@@ -464,6 +467,7 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	ks := stack.AccountManager().Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
 	setServiceChainSigner(ctx, ks, cfg)
 	setRewardbase(ctx, ks, cfg)
+	setRemoteSigner(ctx, cfg)
 	setTxPool(ctx, &cfg.TxPool)
 
 	if ctx.GlobalIsSet(SyncModeFlag.Name) {
@@ -558,6 +562,7 @@ func (kCfg *KlayConfig) SetKlayConfig(ctx *cli.Context, stack *node.Node) {
 	}
 
 	cfg.SenderTxHashIndexing = ctx.GlobalIsSet(SenderTxHashIndexingFlag.Name)
+	cfg.RewardIndexing = ctx.GlobalIsSet(RewardIndexingFlag.Name)
 	cfg.ParallelDBWrite = !ctx.GlobalIsSet(NoParallelDBWriteFlag.Name)
 	cfg.TrieNodeCacheConfig = statedb.TrieNodeCacheConfig{
 		CacheType: statedb.TrieNodeCacheType(ctx.GlobalString(TrieNodeCacheTypeFlag.
@@ -673,6 +678,22 @@ func setRewardbase(ctx *cli.Context, ks *keystore.KeyStore, cfg *cn.Config) {
 	}
 }
 
+// setRemoteSigner applies the remote signer related command line flags to the config.
+func setRemoteSigner(ctx *cli.Context, cfg *cn.Config) {
+	if ctx.GlobalIsSet(RemoteSignerFlag.Name) {
+		cfg.Istanbul.RemoteSigner = ctx.GlobalString(RemoteSignerFlag.Name)
+	}
+	if ctx.GlobalIsSet(RemoteSignerTLSCertFlag.Name) {
+		cfg.Istanbul.RemoteSignerTLSCert = ctx.GlobalString(RemoteSignerTLSCertFlag.Name)
+	}
+	if ctx.GlobalIsSet(RemoteSignerTLSKeyFlag.Name) {
+		cfg.Istanbul.RemoteSignerTLSKey = ctx.GlobalString(RemoteSignerTLSKeyFlag.Name)
+	}
+	if ctx.GlobalIsSet(RemoteSignerTLSCAFlag.Name) {
+		cfg.Istanbul.RemoteSignerTLSCA = ctx.GlobalString(RemoteSignerTLSCAFlag.Name)
+	}
+}
+
 // makeAddress converts an account specified directly as a hex encoded string or
 // a key index in the key store to an internal account representation.
 func MakeAddress(ks *keystore.KeyStore, account string) (accounts.Account, error) {
@@ -61,6 +61,7 @@ var FlagGroups = []FlagGroup{
 		Flags: []cli.Flag{
 			UnlockedAccountFlag,
 			PasswordFileFlag,
+			ExternalSignerFlag,
 		},
 	},
 	{
@@ -99,6 +100,7 @@ var FlagGroups = []FlagGroup{
 			DynamoDBWriteCapacityFlag,
 			NoParallelDBWriteFlag,
 			SenderTxHashIndexingFlag,
+			RewardIndexingFlag,
 			DBNoPerformanceMetricsFlag,
 		},
 	},
@@ -201,6 +203,10 @@ var FlagGroups = []FlagGroup{
 		Flags: []cli.Flag{
 			ServiceChainSignerFlag,
 			RewardbaseFlag,
+			RemoteSignerFlag,
+			RemoteSignerTLSCertFlag,
+			RemoteSignerTLSKeyFlag,
+			RemoteSignerTLSCAFlag,
 		},
 	},
 	{
@@ -427,6 +427,11 @@ var (
 		Usage:  "Enables storing mapping information of senderTxHash to txHash",
 		EnvVar: "KLAYTN_SENDERTXHASHINDEXING",
 	}
+	RewardIndexingFlag = cli.BoolFlag{
+		Name:   "rewardindexing",
+		Usage:  "Enables storing the reward distribution of every block for per-address reward queries",
+		EnvVar: "KLAYTN_REWARDINDEXING",
+	}
 	ChildChainIndexingFlag = cli.BoolFlag{
 		Name:   "childchainindexing",
 		Usage:  "Enables storing transaction hash of child chain transaction for fast access to child chain data",
@@ -450,6 +455,26 @@ var (
 		Value:  "0",
 		EnvVar: "KLAYTN_REWARDBASE",
 	}
+	RemoteSignerFlag = cli.StringFlag{
+		Name:   "remotesigner",
+		Usage:  "Endpoint of the remote signer holding the validator key (IPC path or grpc://host:port)",
+		EnvVar: "KLAYTN_REMOTESIGNER",
+	}
+	RemoteSignerTLSCertFlag = cli.StringFlag{
+		Name:   "remotesigner.tlscert",
+		Usage:  "Client certificate file to authenticate to the gRPC remote signer",
+		EnvVar: "KLAYTN_REMOTESIGNER_TLSCERT",
+	}
+	RemoteSignerTLSKeyFlag = cli.StringFlag{
+		Name:   "remotesigner.tlskey",
+		Usage:  "Client key file to authenticate to the gRPC remote signer",
+		EnvVar: "KLAYTN_REMOTESIGNER_TLSKEY",
+	}
+	RemoteSignerTLSCAFlag = cli.StringFlag{
+		Name:   "remotesigner.tlsca",
+		Usage:  "CA certificate file to verify the gRPC remote signer",
+		EnvVar: "KLAYTN_REMOTESIGNER_TLSCA",
+	}
 	ExtraDataFlag = cli.StringFlag{
 		Name:   "extradata",
 		Usage:  "Block extra data set by the work (default = client version)",
@@ -487,6 +512,12 @@ var (
 		Value:  "",
 		EnvVar: "KLAYTN_PASSWORD",
 	}
+	ExternalSignerFlag = cli.StringFlag{
+		Name:   "signer",
+		Usage:  "External signer (HTTP url or path to an IPC endpoint) to sign transactions with",
+		Value:  "",
+		EnvVar: "KLAYTN_SIGNER",
+	}
 
 	VMEnableDebugFlag = cli.BoolFlag{
 		Name:   "vmdebug",
@@ -22,6 +22,8 @@ package nodecmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/klaytn/klaytn/accounts"
 	"github.com/klaytn/klaytn/accounts/keystore"
@@ -33,6 +35,41 @@ import (
 	"gopkg.in/urfave/cli.v1"
 )
 
+var (
+	migrateKDFFlag = cli.StringFlag{
+		Name:  "kdf",
+		Usage: "Key derivation function to re-encrypt the keys with (scrypt or argon2id)",
+		Value: "scrypt",
+	}
+	migrateScryptNFlag = cli.IntFlag{
+		Name:  "scrypt.n",
+		Usage: "N parameter of scrypt (default: 262144, or 4096 with --lightkdf)",
+	}
+	migrateScryptPFlag = cli.IntFlag{
+		Name:  "scrypt.p",
+		Usage: "P parameter of scrypt (default: 1, or 6 with --lightkdf)",
+	}
+	migrateArgon2TimeFlag = cli.UintFlag{
+		Name:  "argon2.time",
+		Usage: "Number of passes of argon2id",
+		Value: keystore.StandardArgon2Time,
+	}
+	migrateArgon2MemoryFlag = cli.UintFlag{
+		Name:  "argon2.memory",
+		Usage: "Memory size of argon2id in KiB",
+		Value: keystore.StandardArgon2Memory,
+	}
+	migrateArgon2ThreadsFlag = cli.UintFlag{
+		Name:  "argon2.threads",
+		Usage: "Degree of parallelism of argon2id",
+		Value: keystore.StandardArgon2Threads,
+	}
+	migrateBackupDirFlag = cli.StringFlag{
+		Name:  "backupdir",
+		Usage: "Directory to back up the original key files (default: <keystore>-backup-<UTC time>)",
+	}
+)
+
 var AccountCommand = cli.Command{
 	Name:     "account",
 	Usage:    "Manage accounts",
@@ -154,6 +191,44 @@ Note:
 As you can directly copy your encrypted accounts to another klay instance,
 this import mechanism is not needed when you transfer an account between
 nodes.
+`,
+		},
+		{
+			Name:   "migrate",
+			Usage:  "Re-encrypt all the key files with a new password and KDF parameters",
+			Action: utils.MigrateFlags(accountMigrate),
+			Flags: []cli.Flag{
+				utils.DataDirFlag,
+				utils.KeyStoreDirFlag,
+				utils.PasswordFileFlag,
+				utils.LightKDFFlag,
+				migrateKDFFlag,
+				migrateScryptNFlag,
+				migrateScryptPFlag,
+				migrateArgon2TimeFlag,
+				migrateArgon2MemoryFlag,
+				migrateArgon2ThreadsFlag,
+				migrateBackupDirFlag,
+			},
+			Description: `
+    klay account migrate [options]
+
+Re-encrypts all the key files in the keystore directory with a new password and
+KDF parameters, converting them to the newest keystore format (v4). It can be used
+to rotate the password of the keys, or to upgrade the KDF to scrypt with stronger
+parameters or to argon2id.
+
+All the key files should be encrypted with the same password. Every file is
+decrypted, re-encrypted and validated before any file is changed, so no file is
+changed if any of them fails. The original files are copied to the backup directory
+before being replaced.
+
+You are prompted for the current password and a new one. For non-interactive use,
+the passwords can be given with the --password flag, the current one in the first
+line and the new one in the second line. If the file has a single line, only the
+KDF parameters are changed.
+
+The node using the keystore should be stopped before running this command.
 `,
 		},
 	},
@@ -335,3 +410,77 @@ func accountImport(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+// accountMigrate re-encrypts all the key files in the keystore directory.
+func accountMigrate(ctx *cli.Context) error {
+	if glogger, err := debug.GetGlogger(); err == nil {
+		log.ChangeGlobalLogLevel(glogger, log.Lvl(log.LvlError))
+	}
+	cfg := utils.KlayConfig{Node: utils.DefaultNodeConfig()}
+	// Load config file.
+	if file := ctx.GlobalString(utils.ConfigFileFlag.Name); file != "" {
+		if err := utils.LoadConfig(file, &cfg); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+	cfg.SetNodeConfig(ctx)
+	scryptN, scryptP, keydir, err := cfg.Node.AccountConfig()
+	if err != nil {
+		log.Fatalf("Failed to read configuration: %v", err)
+	}
+
+	var kdf keystore.KDFParams
+	switch ctx.String(migrateKDFFlag.Name) {
+	case "scrypt":
+		if ctx.IsSet(migrateScryptNFlag.Name) {
+			scryptN = ctx.Int(migrateScryptNFlag.Name)
+		}
+		if ctx.IsSet(migrateScryptPFlag.Name) {
+			scryptP = ctx.Int(migrateScryptPFlag.Name)
+		}
+		kdf = keystore.ScryptKDFParams(scryptN, scryptP)
+	case "argon2id":
+		threads := ctx.Uint(migrateArgon2ThreadsFlag.Name)
+		if threads > 255 {
+			log.Fatalf("Invalid argon2 threads: %d", threads)
+		}
+		kdf = keystore.Argon2idKDFParams(uint32(ctx.Uint(migrateArgon2TimeFlag.Name)), uint32(ctx.Uint(migrateArgon2MemoryFlag.Name)), uint8(threads))
+	default:
+		log.Fatalf("Unsupported KDF: %s", ctx.String(migrateKDFFlag.Name))
+	}
+	if err := kdf.Validate(); err != nil {
+		log.Fatalf("Invalid KDF parameters: %v", err)
+	}
+
+	backupDir := ctx.String(migrateBackupDirFlag.Name)
+	if backupDir == "" {
+		backupDir = filepath.Clean(keydir) + "-backup-" + time.Now().UTC().Format("20060102T150405Z")
+	}
+
+	passwords := utils.MakePasswordList(ctx)
+	passphrase := getPassPhrase("Please give the current password of the key files.", false, 0, passwords)
+	newPassphrase := getPassPhrase("Please give a new password. Do not forget this password.", true, 1, passwords)
+
+	results, err := keystore.MigrateKeyDir(keydir, keystore.MigrateConfig{
+		Passphrase:    passphrase,
+		NewPassphrase: newPassphrase,
+		KDF:           kdf,
+		BackupDir:     backupDir,
+	})
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("Failed: %s (%v)\n", result.Path, result.Err)
+		} else {
+			fmt.Printf("Migrated: {%x} %s (v%d)\n", result.Address, result.Path, result.Version)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to migrate the keystore: %v", err)
+	}
+	if len(results) == 0 {
+		fmt.Println("No key file to migrate in", keydir)
+		return nil
+	}
+	fmt.Printf("Migrated %d key files. The original files are backed up in %s\n", len(results), backupDir)
+	return nil
+}
@@ -21,12 +21,15 @@
 package nodecmd
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/cespare/cp"
+	"github.com/klaytn/klaytn/accounts/keystore"
 )
 
 // These tests are 'smoke tests' for the account related
@@ -109,6 +112,57 @@ Repeat passphrase: {{.InputLine "foobar2"}}
 `)
 }
 
+func TestAccountMigrate(t *testing.T) {
+	datadir := tmpdir(t)
+	source := filepath.Join("..", "..", "..", "accounts", "keystore", "testdata", "keystore")
+	if err := os.MkdirAll(filepath.Join(datadir, "keystore"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"aaa", "zzz"} {
+		if err := cp.CopyFile(filepath.Join(datadir, "keystore", name), filepath.Join(source, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backupDir := filepath.Join(datadir, "backup")
+	klay := runKlay(t, "klay-test", "account", "migrate",
+		"--datadir", datadir, "--backupdir", backupDir,
+		"--kdf", "argon2id", "--argon2.time", "1", "--argon2.memory", "64", "--argon2.threads", "1")
+	klay.Expect(`
+Please give the current password of the key files.
+!! Unsupported terminal, password will be echoed.
+Passphrase: {{.InputLine "foobar"}}
+Please give a new password. Do not forget this password.
+Passphrase: {{.InputLine "foobar2"}}
+Repeat passphrase: {{.InputLine "foobar2"}}
+Migrated: {f466859ead1932d743d622cb74fc058882e8648a} {{.Datadir}}/keystore/aaa (v3)
+Migrated: {289d485d9771714cce91d3393d764e1311907acc} {{.Datadir}}/keystore/zzz (v3)
+Migrated 2 key files. The original files are backed up in {{.Datadir}}/backup
+`)
+	klay.ExpectExit()
+
+	for _, name := range []string{"aaa", "zzz"} {
+		keyjson, err := ioutil.ReadFile(filepath.Join(datadir, "keystore", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := keystore.DecryptKey(keyjson, "foobar2"); err != nil {
+			t.Errorf("failed to decrypt the migrated key file %s: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(backupDir, name)); err != nil {
+			t.Errorf("the original key file %s is not backed up: %v", name, err)
+		}
+	}
+}
+
+func TestAccountMigrateInvalidFiles(t *testing.T) {
+	datadir := tmpDatadirWithKeystore(t)
+	klay := runKlay(t, "klay-test", "account", "migrate",
+		"--datadir", datadir, "--lightkdf", "--password", "testdata/passwords.txt")
+	defer klay.ExpectExit()
+	klay.ExpectRegexp(`Failed: .*README`)
+	klay.ExpectRegexp(`Fatal: Failed to migrate the keystore: failed to migrate 3 of 8 key files, no file is changed`)
+}
+
 func TestUnlockFlag(t *testing.T) {
 	datadir := tmpDatadirWithKeystore(t)
 	klay := runKlay(t, "klay-test",
@@ -27,6 +27,7 @@ Each file contains following contents
  - defaultcmd.go		: Provides functions to start a node
  - dumpconfigcmd.go		: Provides functions to dump and print current config to stdout
  - nodeflags.go		: Defines various flags that configure the node
+ - stakinginfocmd.go		: Provides functions to export, import and verify the stored staking information
  - versioncmd.go		: Provides functions to print application's version
 */
 package nodecmd
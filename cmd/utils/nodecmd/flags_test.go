@@ -282,6 +282,10 @@ var flagsWithValues = []struct {
 		flag:     "--sendertxhashindexing",
 		flagType: FlagTypeBoolean,
 	},
+	{
+		flag:     "--rewardindexing",
+		flagType: FlagTypeBoolean,
+	},
 	{
 		flag:     "--childchainindexing",
 		flagType: FlagTypeBoolean,
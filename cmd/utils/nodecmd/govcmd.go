@@ -0,0 +1,128 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var voteBlockFlag = cli.Uint64Flag{
+	Name:  "voteblock",
+	Usage: "Block number where the votes are cast",
+}
+
+var ValidateVotesCommand = cli.Command{
+	Action:    utils.MigrateFlags(validateVotes),
+	Name:      "validate-votes",
+	Usage:     "Validate governance votes offline and print the resulting parameters",
+	ArgsUsage: "<genesisPath> <key>=<value> [<key>=<value> ...]",
+	Flags: []cli.Flag{
+		voteBlockFlag,
+	},
+	Category: "MISCELLANEOUS COMMANDS",
+	Description: `
+The validate-votes command checks the proposed governance votes with the same rules
+and type conversion as governance.vote on the console, without running a node.
+The parameters are read from the genesis file, or a chain config file.
+
+Each value is read as a JSON value, or as a string if it is not valid JSON.
+
+    validate-votes genesis.json kip71.gastarget=40000000 reward.ratio=50/20/30 \
+        reward.mintingamount='"9600000000000000000"'
+
+If all the votes are valid, it prints the validated votes, the parameters after the votes
+are approved, and the block where the parameters take effect if the votes are cast at --voteblock.`,
+}
+
+func validateVotes(ctx *cli.Context) error {
+	if len(ctx.Args()) < 2 {
+		return errors.New("genesis file and votes should be given")
+	}
+	config, err := readChainConfig(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	votes := make([]governance.ProposalVote, 0, len(ctx.Args())-1)
+	for _, arg := range ctx.Args()[1:] {
+		vote, err := parseProposalVote(arg)
+		if err != nil {
+			return err
+		}
+		votes = append(votes, vote)
+	}
+
+	result, err := governance.ValidateProposal(config, votes, ctx.Uint64(voteBlockFlag.Name))
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// readChainConfig reads the chain config from a genesis file or a chain config file.
+func readChainConfig(path string) (*params.ChainConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the config of the genesis is read, so that the alloc may be omitted
+	var genesis struct {
+		Config *params.ChainConfig `json:"config"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return nil, fmt.Errorf("invalid genesis file: %v", err)
+	}
+	config := genesis.Config
+	if config == nil {
+		config = new(params.ChainConfig)
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("invalid chain config file: %v", err)
+		}
+	}
+	config.SetDefaultsForGenesis()
+	return config, nil
+}
+
+// parseProposalVote parses a vote given as <key>=<value>.
+func parseProposalVote(arg string) (governance.ProposalVote, error) {
+	idx := strings.Index(arg, "=")
+	if idx <= 0 {
+		return governance.ProposalVote{}, fmt.Errorf("invalid vote %q: should be <key>=<value>", arg)
+	}
+	key, raw := arg[:idx], arg[idx+1:]
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		value = raw
+	}
+	return governance.ProposalVote{Key: key, Value: value}, nil
+}
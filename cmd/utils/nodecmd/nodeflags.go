@@ -118,6 +118,7 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewStringFlag(utils.IdentityFlag),
 	altsrc.NewStringFlag(utils.UnlockedAccountFlag),
 	altsrc.NewStringFlag(utils.PasswordFileFlag),
+	altsrc.NewStringFlag(utils.ExternalSignerFlag),
 	altsrc.NewStringFlag(utils.DbTypeFlag),
 	utils.NewWrappedDirectoryFlag(utils.DataDirFlag),
 	altsrc.NewBoolFlag(utils.OverwriteGenesisFlag),
@@ -153,6 +154,7 @@ var CommonNodeFlags = []cli.Flag{
 	altsrc.NewIntFlag(utils.LevelDBCacheSizeFlag),
 	altsrc.NewBoolFlag(utils.NoParallelDBWriteFlag),
 	altsrc.NewBoolFlag(utils.SenderTxHashIndexingFlag),
+	altsrc.NewBoolFlag(utils.RewardIndexingFlag),
 	altsrc.NewIntFlag(utils.TrieMemoryCacheSizeFlag),
 	altsrc.NewUintFlag(utils.TrieBlockIntervalFlag),
 	altsrc.NewUint64Flag(utils.TriesInMemoryFlag),
@@ -238,6 +240,10 @@ var CommonRPCFlags = []cli.Flag{
 
 var KCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(utils.RewardbaseFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerTLSCertFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerTLSKeyFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerTLSCAFlag),
 	altsrc.NewBoolFlag(utils.CypressFlag),
 	altsrc.NewBoolFlag(utils.BaobabFlag),
 	altsrc.NewInt64Flag(utils.BlockGenerationIntervalFlag),
@@ -286,6 +292,10 @@ var KENFlags = []cli.Flag{
 
 var KSCNFlags = []cli.Flag{
 	altsrc.NewStringFlag(utils.RewardbaseFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerTLSCertFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerTLSKeyFlag),
+	altsrc.NewStringFlag(utils.RemoteSignerTLSCAFlag),
 	altsrc.NewInt64Flag(utils.BlockGenerationIntervalFlag),
 	altsrc.NewDurationFlag(utils.BlockGenerationTimeLimitFlag),
 	altsrc.NewStringFlag(utils.ServiceChainSignerFlag),
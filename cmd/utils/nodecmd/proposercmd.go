@@ -0,0 +1,127 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul/validator"
+	"github.com/klaytn/klaytn/params"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var simulationBlocksFlag = cli.Uint64Flag{
+	Name:  "blocks",
+	Usage: "Number of blocks to simulate (default: the proposer update interval)",
+}
+
+var SimulateProposersCommand = cli.Command{
+	Action:    utils.MigrateFlags(simulateProposers),
+	Name:      "simulate-proposers",
+	Usage:     "Simulate the proposer selection with a hypothetical staking status",
+	ArgsUsage: "<simulation.json>",
+	Flags: []cli.Flag{
+		simulationBlocksFlag,
+	},
+	Category: "MISCELLANEOUS COMMANDS",
+	Description: `
+The simulate-proposers command runs the weighted random proposer selection
+with the staking status and the governance parameters given in a JSON file,
+and prints the proposers of the following blocks and the proposal share of each validator.
+
+    {
+      "nodes": [
+        {"nodeAddr": "0x...", "rewardAddr": "0x...", "stakingAmount": 5000000},
+        ...
+      ],
+      "useGini": true,
+      "minimumStake": 5000000,
+      "proposerUpdateInterval": 3600,
+      "blockNumber": 36000,
+      "seed": "0x<hash of the block blockNumber>",
+      "kore": false
+    }
+
+"kore" selects the selection rule after the Kore hard fork, where staking amounts no longer weigh proposers.
+"governingNode" may be given additionally to simulate the single governance mode.
+"startBlock" may be given to print the proposers from the block, which defaults to blockNumber+1.
+
+To simulate with the staking status of a running node, use klay.simulateProposers on the console.`,
+}
+
+// proposerSimulationInput is the JSON format of the simulate-proposers input file.
+type proposerSimulationInput struct {
+	Nodes                  []validator.SimulationNode `json:"nodes"`
+	UseGini                bool                       `json:"useGini"`
+	MinimumStake           uint64                     `json:"minimumStake"`
+	ProposerUpdateInterval uint64                     `json:"proposerUpdateInterval"`
+	BlockNumber            uint64                     `json:"blockNumber"`
+	Seed                   common.Hash                `json:"seed"`
+	StartBlock             uint64                     `json:"startBlock"`
+	Kore                   bool                       `json:"kore"`
+	GoverningNode          *common.Address            `json:"governingNode"`
+}
+
+func simulateProposers(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("simulation file is not given")
+	}
+	data, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	var input proposerSimulationInput
+	if err := json.Unmarshal(data, &input); err != nil {
+		return fmt.Errorf("invalid simulation file: %v", err)
+	}
+
+	config := &validator.ProposerSimulationConfig{
+		Nodes:                  input.Nodes,
+		UseGini:                input.UseGini,
+		MinimumStake:           input.MinimumStake,
+		ProposerUpdateInterval: input.ProposerUpdateInterval,
+		BlockNumber:            input.BlockNumber,
+		Seed:                   input.Seed,
+		StartBlock:             input.StartBlock,
+		Rules:                  params.Rules{IsIstanbul: true, IsKore: input.Kore},
+	}
+	if input.GoverningNode != nil {
+		config.IsSingle = true
+		config.GoverningNode = *input.GoverningNode
+	}
+
+	numBlocks := input.ProposerUpdateInterval
+	if ctx.IsSet(simulationBlocksFlag.Name) {
+		numBlocks = ctx.Uint64(simulationBlocksFlag.Name)
+	}
+
+	result, err := validator.SimulateProposers(config, numBlocks)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var rewardsOutputFlag = cli.StringFlag{
+	Name:  "output",
+	Usage: "CSV file to write the rewards (default: standard output)",
+}
+
+var ExportRewardsCommand = cli.Command{
+	Action:    utils.MigrateFlags(exportRewards),
+	Name:      "export-rewards",
+	Usage:     "Export the rewards given to an address to a CSV file",
+	ArgsUsage: "<address> <fromBlock> <toBlock>",
+	Flags: []cli.Flag{
+		rewardsOutputFlag,
+		utils.DbTypeFlag,
+		utils.SingleDBFlag,
+		utils.NumStateTrieShardsFlag,
+		utils.DynamoDBTableNameFlag,
+		utils.DynamoDBRegionFlag,
+		utils.DynamoDBIsProvisionedFlag,
+		utils.DynamoDBReadCapacityFlag,
+		utils.DynamoDBWriteCapacityFlag,
+		utils.LevelDBCompressionTypeFlag,
+		utils.DataDirFlag,
+	},
+	Category: "MISCELLANEOUS COMMANDS",
+	Description: `
+The export-rewards command reads the reward history of a node run with --rewardindexing,
+and writes the rewards given to the address in the blocks [fromBlock, toBlock] in CSV,
+one row per block rewarding the address.
+
+    block,proposer,stakers,kgf,kir,total
+
+The amounts are in peb. The node should be stopped before running this command.`,
+}
+
+var BackfillTotalSupplyCommand = cli.Command{
+	Action:    utils.MigrateFlags(backfillTotalSupply),
+	Name:      "backfill-total-supply",
+	Usage:     "Accumulate the total supply from the genesis block offline",
+	ArgsUsage: "[toBlock]",
+	Flags: []cli.Flag{
+		utils.DbTypeFlag,
+		utils.SingleDBFlag,
+		utils.NumStateTrieShardsFlag,
+		utils.DynamoDBTableNameFlag,
+		utils.DynamoDBRegionFlag,
+		utils.DynamoDBIsProvisionedFlag,
+		utils.DynamoDBReadCapacityFlag,
+		utils.DynamoDBWriteCapacityFlag,
+		utils.LevelDBCompressionTypeFlag,
+		utils.DataDirFlag,
+	},
+	Category: "MISCELLANEOUS COMMANDS",
+	Description: `
+The backfill-total-supply command does the same as admin.backfillTotalSupply() without running the node.
+It accumulates the KLAY minted and burnt from the genesis block, or from the last stored checkpoint,
+up to toBlock (default: the current block), and stores the checkpoints used by klay.getTotalSupply().
+It resumes from the last checkpoint if interrupted. The node should be stopped before running this command.`,
+}
+
+func backfillTotalSupply(ctx *cli.Context) error {
+	if len(ctx.Args()) > 1 {
+		return errors.New("only toBlock can be given")
+	}
+
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	config := db.ReadChainConfig(db.ReadCanonicalHash(0))
+	if config == nil {
+		return errors.New("chain config is not found in the database")
+	}
+	blockchain.InitDeriveSha(config)
+
+	// The consensus engine is not used since no block is inserted
+	gov := governance.NewMixedEngine(config, db)
+	bc, err := blockchain.NewBlockChain(db, nil, config, gxhash.NewFaker(), vm.Config{})
+	if err != nil {
+		return err
+	}
+	defer bc.Stop()
+	gov.SetBlockchain(bc)
+	if err := gov.UpdateParams(); err != nil {
+		return err
+	}
+	params.SetStakingUpdateInterval(gov.Params().StakeUpdateInterval())
+	if gov.Params().Policy() == uint64(istanbul.WeightedRandom) {
+		reward.NewStakingManager(bc, gov, db)
+	}
+
+	to := bc.CurrentHeader().Number.Uint64()
+	if len(ctx.Args()) == 1 {
+		if to, err = strconv.ParseUint(ctx.Args().First(), 10, 64); err != nil {
+			return fmt.Errorf("invalid toBlock: %v", err)
+		}
+	}
+
+	sm := reward.NewSupplyManager(bc, gov, db)
+	last, err := sm.BackfillTo(to)
+	if err != nil {
+		return fmt.Errorf("failed to backfill the total supply after block %d: %v", last, err)
+	}
+	logger.Info("Backfilled the total supply", "lastCheckpoint", last)
+	return nil
+}
+
+func exportRewards(ctx *cli.Context) error {
+	if len(ctx.Args()) != 3 {
+		return errors.New("address, fromBlock and toBlock should be given")
+	}
+	if !common.IsHexAddress(ctx.Args().Get(0)) {
+		return fmt.Errorf("invalid address %q", ctx.Args().Get(0))
+	}
+	addr := common.HexToAddress(ctx.Args().Get(0))
+	from, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid fromBlock: %v", err)
+	}
+	to, err := strconv.ParseUint(ctx.Args().Get(2), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid toBlock: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if ctx.IsSet(rewardsOutputFlag.Name) {
+		f, err := os.Create(ctx.String(rewardsOutputFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	return reward.ExportRewardsByAddress(w, db, addr, from, to)
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package nodecmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/cmd/utils"
+	"github.com/klaytn/klaytn/consensus/gxhash"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var stakingInfoOverwriteFlag = cli.BoolFlag{
+	Name:  "overwrite",
+	Usage: "Overwrite the staking information already stored",
+}
+
+var stakingInfoDBFlags = []cli.Flag{
+	utils.DbTypeFlag,
+	utils.SingleDBFlag,
+	utils.NumStateTrieShardsFlag,
+	utils.DynamoDBTableNameFlag,
+	utils.DynamoDBRegionFlag,
+	utils.DynamoDBIsProvisionedFlag,
+	utils.DynamoDBReadCapacityFlag,
+	utils.DynamoDBWriteCapacityFlag,
+	utils.LevelDBCompressionTypeFlag,
+	utils.DataDirFlag,
+}
+
+var StakingInfoCommand = cli.Command{
+	Name:     "stakinginfo",
+	Usage:    "A set of commands to manage the stored staking information",
+	Category: "MISCELLANEOUS COMMANDS",
+	Description: `
+The staking information is stored when a staking block is processed, and is used to distribute
+the rewards and to select the proposers afterwards. A node restored from a backup, or synced
+without processing the old blocks, may lack it. These commands copy the staking information
+between data directories. The node should be stopped before running them.`,
+	Subcommands: []cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Export all the stored staking information to a file",
+			ArgsUsage: "<file>",
+			Action:    utils.MigrateFlags(exportStakingInfo),
+			Flags:     stakingInfoDBFlags,
+			Description: `
+Writes all the stored staking information to the file in JSON, one entry per line.`,
+		},
+		{
+			Name:      "import",
+			Usage:     "Import the staking information from a file",
+			ArgsUsage: "<file>",
+			Action:    utils.MigrateFlags(importStakingInfo),
+			Flags:     append([]cli.Flag{stakingInfoOverwriteFlag}, stakingInfoDBFlags...),
+			Description: `
+Stores the staking information written by "stakinginfo export".
+The entries already stored are kept unless --overwrite is given.`,
+		},
+		{
+			Name:   "verify",
+			Usage:  "Verify the stored staking information against the AddressBook contract",
+			Action: utils.MigrateFlags(verifyStakingInfo),
+			Flags:  stakingInfoDBFlags,
+			Description: `
+Rebuilds the staking information from the AddressBook contract state at each staking block,
+and reports the differences from the stored one. The entries whose state is not available,
+e.g., pruned or migrated, are reported as not verifiable.
+
+The Gini coefficient is not stored by the node but filled in when the staking information is loaded,
+so a missing Gini coefficient is reported when the Gini coefficient is in use.`,
+		},
+	},
+}
+
+func exportStakingInfo(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("file is not given")
+	}
+	f, err := os.Create(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	n, err := reward.ExportStakingInfos(f, db)
+	if err != nil {
+		return err
+	}
+	logger.Info("Exported staking information", "entries", n, "file", ctx.Args().First())
+	return nil
+}
+
+func importStakingInfo(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("file is not given")
+	}
+	f, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	imported, skipped, err := reward.ImportStakingInfos(f, db, ctx.Bool(stakingInfoOverwriteFlag.Name))
+	if err != nil {
+		return err
+	}
+	logger.Info("Imported staking information", "imported", imported, "skipped", skipped)
+	return nil
+}
+
+func verifyStakingInfo(ctx *cli.Context) error {
+	stack := MakeFullNode(ctx)
+	db := stack.OpenDatabase(getConfig(ctx))
+	defer db.Close()
+
+	config := db.ReadChainConfig(db.ReadCanonicalHash(0))
+	if config == nil {
+		return errors.New("chain config is not found in the database")
+	}
+	blockchain.InitDeriveSha(config)
+
+	// The consensus engine is not used since no block is inserted
+	gov := governance.NewMixedEngine(config, db)
+	bc, err := blockchain.NewBlockChain(db, nil, config, gxhash.NewFaker(), vm.Config{})
+	if err != nil {
+		return err
+	}
+	defer bc.Stop()
+	gov.SetBlockchain(bc)
+	if err := gov.UpdateParams(); err != nil {
+		return err
+	}
+	params.SetStakingUpdateInterval(gov.Params().StakeUpdateInterval())
+
+	mismatches, err := reward.VerifyStakingInfos(db, bc, gov)
+	if err != nil {
+		return err
+	}
+	for _, m := range mismatches {
+		fmt.Println(m)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d mismatches are found", len(mismatches))
+	}
+	fmt.Println("All the stored staking information is consistent with the AddressBook contract")
+	return nil
+}
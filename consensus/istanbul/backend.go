@@ -85,4 +85,8 @@ type Backend interface {
 	SetCurrentView(view *View)
 
 	NodeType() common.ConnType
+
+	// IsRoundTimeoutForkEnabled returns whether the round change timeout schedule of the given block height
+	// follows RoundTimeoutCompatibleBlock
+	IsRoundTimeoutForkEnabled(number *big.Int) bool
 }
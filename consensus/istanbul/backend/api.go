@@ -30,9 +30,15 @@ import (
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/checkpoint"
+	"github.com/klaytn/klaytn/consensus/istanbul/validator"
+	"github.com/klaytn/klaytn/governance"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
 )
 
 // API is a user facing RPC API to dump Istanbul state
@@ -161,6 +167,222 @@ func (api *API) GetDemotedValidatorsAtHash(hash common.Hash) ([]common.Address,
 	}
 }
 
+// GetCheckpointProof returns a proof that the header at target is committed by the council,
+// starting from the checkpoint of the snapshot at the block from.
+// The proof contains every header in (from, target], so the range is limited to maxCheckpointProofRange blocks
+// and a longer range should be proven by a chain of proofs.
+// If from is omitted, the last epoch block before target is used, within the limit.
+func (api *API) GetCheckpointProof(target *rpc.BlockNumber, from *hexutil.Uint64) (*checkpoint.Proof, error) {
+	header, err := headerByRpcNumber(api.chain, target)
+	if err != nil {
+		return nil, err
+	}
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil, errNoCommittedSeals
+	}
+	parentSnap, err := api.istanbul.snapshot(api.chain, number-1, header.ParentHash, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var start uint64
+	if from != nil {
+		start = uint64(*from)
+	} else {
+		start = (number - 1) - (number-1)%parentSnap.Epoch
+		if number-start > maxCheckpointProofRange {
+			start = number - maxCheckpointProofRange
+		}
+	}
+	if start >= number {
+		return nil, errStartLargerThanEnd
+	}
+	if number-start > maxCheckpointProofRange {
+		return nil, errCheckpointProofTooLong
+	}
+
+	startHeader := api.chain.GetHeaderByNumber(start)
+	if startHeader == nil {
+		return nil, errUnknownBlock
+	}
+	snap, err := api.istanbul.snapshot(api.chain, start, startHeader.Hash(), nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if reconfiguredBefore(snap, number) {
+		return nil, errReconfiguredCheckpoint
+	}
+	pset, err := api.istanbul.governance.ParamsAt(start)
+	if err != nil {
+		return nil, err
+	}
+	targetPset, err := api.istanbul.governance.ParamsAt(number)
+	if err != nil {
+		return nil, err
+	}
+	if pset.GovernanceModeStr() != targetPset.GovernanceModeStr() || pset.GoverningNode() != targetPset.GoverningNode() {
+		return nil, errCheckpointStateChanged
+	}
+	proof := &checkpoint.Proof{
+		Checkpoint: newCheckpoint(snap, pset),
+		Headers:    make([]*types.Header, 0, number-start),
+	}
+
+	// The verifier takes the demoted validators, the committee size and the epoch from the checkpoint,
+	// so the range is refused if they change at a header it verifies.
+	for n := start + 1; n <= number; n++ {
+		h := api.chain.GetHeaderByNumber(n)
+		if h == nil {
+			return nil, errUnknownBlock
+		}
+		proof.Headers = append(proof.Headers, h)
+		if len(h.Vote) == 0 && n != number {
+			continue
+		}
+		parent := parentSnap
+		if n != number {
+			if parent, err = api.istanbul.snapshot(api.chain, n-1, h.ParentHash, nil, false); err != nil {
+				return nil, err
+			}
+		}
+		if parent.CommitteeSize != snap.CommitteeSize || parent.Epoch != snap.Epoch ||
+			!sameAddresses(parent.demotedValidators(), snap.demotedValidators()) {
+			return nil, errCheckpointStateChanged
+		}
+	}
+	return proof, nil
+}
+
+// newCheckpoint returns the checkpoint of the snapshot, keeping the pending council votes only.
+func newCheckpoint(snap *Snapshot, pset *params.GovParamSet) checkpoint.Checkpoint {
+	c := checkpoint.Checkpoint{
+		Number:            snap.Number,
+		Hash:              snap.Hash,
+		Validators:        snap.validators(),
+		DemotedValidators: snap.demotedValidators(),
+		CommitteeSize:     snap.CommitteeSize,
+		Epoch:             snap.Epoch,
+		GovernanceMode:    pset.GovernanceModeStr(),
+		GoverningNode:     pset.GoverningNode(),
+		Votes:             []checkpoint.Vote{},
+		Tally:             []checkpoint.TallyItem{},
+	}
+	if len(snap.Reconfigurations) > 0 {
+		c.NextReconfiguration = snap.Reconfigurations[0].Block
+	}
+	for _, vote := range snap.Votes {
+		if addrs, list, ok := councilVoteValue(vote.Key, vote.Value); ok {
+			c.Votes = append(c.Votes, checkpoint.Vote{Validator: vote.Validator, Key: vote.Key, Addresses: addrs, List: list})
+		}
+	}
+	for _, item := range snap.Tally {
+		if addrs, list, ok := councilVoteValue(item.Key, item.Value); ok {
+			c.Tally = append(c.Tally, checkpoint.TallyItem{Key: item.Key, Addresses: addrs, List: list, Votes: item.Votes})
+		}
+	}
+	return c
+}
+
+// councilVoteValue returns the addresses of a governance.addvalidator or governance.removevalidator vote value.
+func councilVoteValue(key string, value interface{}) ([]common.Address, bool, bool) {
+	if k, ok := governance.GovernanceKeyMap[key]; !ok || (k != params.AddValidator && k != params.RemoveValidator) {
+		return nil, false, false
+	}
+	switch v := value.(type) {
+	case common.Address:
+		return []common.Address{v}, false, true
+	case []common.Address:
+		return append([]common.Address{}, v...), true, true
+	}
+	return nil, false, false
+}
+
+// reconfiguredBefore reports whether a pending reconfiguration of the snapshot takes effect at or before the block.
+func reconfiguredBefore(snap *Snapshot, number uint64) bool {
+	return len(snap.Reconfigurations) > 0 && snap.Reconfigurations[0].Block <= number
+}
+
+// CommitteePreview is the council and the committee size of the blocks in [From, To].
+type CommitteePreview struct {
+	From              uint64                        `json:"from"`
+	To                uint64                        `json:"to"`
+	Reconfigurations  []*governance.Reconfiguration `json:"reconfigurations,omitempty"` // reconfigurations taking effect at From
+	CommitteeSize     uint64                        `json:"committeeSize"`
+	Validators        []common.Address              `json:"validators"`
+	DemotedValidators []common.Address              `json:"demotedValidators"`
+	Committee         []common.Address              `json:"committee,omitempty"` // set only if all validators are in the committee
+}
+
+func newCommitteePreview(snap *Snapshot, from, to uint64, reconfigs []*governance.Reconfiguration) *CommitteePreview {
+	preview := &CommitteePreview{
+		From:              from,
+		To:                to,
+		Reconfigurations:  reconfigs,
+		CommitteeSize:     snap.CommitteeSize,
+		Validators:        snap.validators(),
+		DemotedValidators: snap.demotedValidators(),
+	}
+	// Otherwise, the committee of each block is randomly selected with its parent hash
+	if snap.CommitteeSize >= uint64(len(preview.Validators)) {
+		preview.Committee = preview.Validators
+	}
+	return preview
+}
+
+// PreviewCommittee shows the council and the committee size of the next numBlocks blocks, applying
+// the scheduled reconfigurations. If a reconfiguration is given as a vote value of "governance.reconfiguration",
+// it is applied as well without being voted.
+// Staking amounts are not considered, so a new validator may be demoted when proposers are refreshed.
+func (api *API) PreviewCommittee(numBlocks uint64, reconfiguration *string) ([]*CommitteePreview, error) {
+	if numBlocks == 0 || numBlocks > maxCommitteePreviewBlocks {
+		return nil, errInvalidPreviewRange
+	}
+	header := api.chain.CurrentHeader()
+	head := header.Number.Uint64()
+	snap, err := api.istanbul.snapshot(api.chain, head, header.Hash(), nil, false)
+	if err != nil {
+		return nil, err
+	}
+	snap = snap.copy()
+
+	if reconfiguration != nil {
+		r, err := governance.ParseReconfiguration(*reconfiguration)
+		if err != nil {
+			return nil, err
+		}
+		if !api.chain.Config().IsReconfigurationForkEnabled(new(big.Int).SetUint64(head + 1)) {
+			return nil, errReconfigurationNotEnabled
+		}
+		if r.Block <= head+1 {
+			return nil, errPastReconfiguration
+		}
+		if !r.IsMeaningful(snap.ValSet) {
+			return nil, errMeaninglessReconfiguration
+		}
+		snap.scheduleReconfigurations([]*governance.Reconfiguration{r})
+	}
+
+	var (
+		previews []*CommitteePreview
+		applied  []*governance.Reconfiguration
+		from     = head + 1
+		last     = head + numBlocks
+	)
+	for _, r := range snap.Reconfigurations {
+		if r.Block > last {
+			break
+		}
+		if r.Block > from {
+			previews = append(previews, newCommitteePreview(snap, from, r.Block-1, applied))
+			from, applied = r.Block, nil
+		}
+		snap.applyReconfiguration(r)
+		applied = append(applied, r)
+	}
+	return append(previews, newCommitteePreview(snap, from, last, applied)), nil
+}
+
 // Candidates returns the current candidates the node tries to uphold and vote on.
 func (api *API) Candidates() map[common.Address]bool {
 	api.istanbul.candidatesLock.RLock()
@@ -198,16 +420,35 @@ type APIExtension struct {
 }
 
 var (
-	errPendingNotAllowed       = errors.New("pending is not allowed")
-	errInternalError           = errors.New("internal error")
-	errStartNotPositive        = errors.New("start block number should be positive")
-	errEndLargetThanLatest     = errors.New("end block number should be smaller than the latest block number")
-	errStartLargerThanEnd      = errors.New("start should be smaller than end")
-	errRequestedBlocksTooLarge = errors.New("number of requested blocks should be smaller than 50")
-	errRangeNil                = errors.New("range values should not be nil")
-	errExtractIstanbulExtra    = errors.New("extract Istanbul Extra from block header of the given block number")
-	errNoBlockExist            = errors.New("block with the given block number is not existed")
-	errNoBlockNumber           = errors.New("block number is not assigned")
+	errPendingNotAllowed          = errors.New("pending is not allowed")
+	errInternalError              = errors.New("internal error")
+	errStartNotPositive           = errors.New("start block number should be positive")
+	errEndLargetThanLatest        = errors.New("end block number should be smaller than the latest block number")
+	errStartLargerThanEnd         = errors.New("start should be smaller than end")
+	errRequestedBlocksTooLarge    = errors.New("number of requested blocks should be smaller than 50")
+	errRangeNil                   = errors.New("range values should not be nil")
+	errExtractIstanbulExtra       = errors.New("extract Istanbul Extra from block header of the given block number")
+	errNoBlockExist               = errors.New("block with the given block number is not existed")
+	errNoBlockNumber              = errors.New("block number is not assigned")
+	errSimulationTooLong          = fmt.Errorf("number of simulated blocks should be smaller than or equal to %d", maxSimulationBlocks)
+	errNoStakingInfo              = errors.New("staking info is not available")
+	errNoCommittedSeals           = errors.New("genesis block has no committed seals")
+	errCheckpointProofTooLong     = fmt.Errorf("target should be within %d blocks from the checkpoint", maxCheckpointProofRange)
+	errReconfiguredCheckpoint     = errors.New("council is reconfigured between the checkpoint and the target")
+	errCheckpointStateChanged     = errors.New("demoted validators, committee size, epoch or governance mode changes between the checkpoint and the target")
+	errInvalidPreviewRange        = fmt.Errorf("number of blocks should be between 1 and %d", maxCommitteePreviewBlocks)
+	errPastReconfiguration        = errors.New("reconfiguration block should be after the next block")
+	errMeaninglessReconfiguration = errors.New("reconfiguration adds a council member or removes a non-member")
+	errReconfigurationNotEnabled  = errors.New("reconfiguration is not enabled before the reconfiguration hardfork")
+)
+
+const (
+	// maxSimulationBlocks is the maximum number of blocks SimulateProposers schedules at once.
+	maxSimulationBlocks = 100000
+	// maxCheckpointProofRange is the maximum number of headers GetCheckpointProof returns.
+	maxCheckpointProofRange = 1024
+	// maxCommitteePreviewBlocks is the maximum number of blocks PreviewCommittee shows, which is the default epoch.
+	maxCommitteePreviewBlocks = 604800
 )
 
 // GetCouncil retrieves the list of authorized validators at the specified block.
@@ -233,7 +474,7 @@ func (api *APIExtension) GetCouncil(number *rpc.BlockNumber) ([]common.Address,
 		return nil, err
 	}
 
-	return append(snap.validators(), snap.demotedValidators()...), nil
+	return snap.council(), nil
 }
 
 func (api *APIExtension) GetCouncilSize(number *rpc.BlockNumber) (int, error) {
@@ -455,10 +696,108 @@ func (api *APIExtension) GetBlockWithConsensusInfoByHash(blockHash common.Hash)
 	return api.makeRPCBlockOutput(block, cInfo, block.Transactions(), receipts), nil
 }
 
+// ProposerSimulationArgs represents the arguments of SimulateProposers.
+// Omitted fields are taken from the staking info and the governance parameters of the latest block.
+type ProposerSimulationArgs struct {
+	Nodes                  []validator.SimulationNode `json:"nodes"`
+	UseGini                *bool                      `json:"useGini"`
+	MinimumStake           *uint64                    `json:"minimumStake"`
+	ProposerUpdateInterval *uint64                    `json:"proposerUpdateInterval"`
+	NumBlocks              *uint64                    `json:"numBlocks"` // defaults to the proposer update interval
+}
+
+// SimulateProposers runs the proposer selection with a hypothetical staking status from the latest
+// proposer update block, and returns the proposer order of the upcoming blocks from the head+1
+// and the proposal share of each validator.
+func (api *APIExtension) SimulateProposers(args ProposerSimulationArgs) (*validator.ProposerSimulation, error) {
+	header := api.chain.CurrentHeader()
+	pset, err := api.istanbul.governance.ParamsAt(header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	interval := pset.ProposerRefreshInterval()
+	if args.ProposerUpdateInterval != nil {
+		interval = *args.ProposerUpdateInterval
+	}
+	if interval == 0 {
+		return nil, errors.New("proposer update interval should be positive")
+	}
+	numBlocks := interval
+	if args.NumBlocks != nil {
+		numBlocks = *args.NumBlocks
+	}
+	if numBlocks > maxSimulationBlocks {
+		return nil, errSimulationTooLong
+	}
+
+	pHeader := api.chain.GetHeaderByNumber(params.CalcProposerBlockNumberWithInterval(header.Number.Uint64()+1, interval))
+	if pHeader == nil {
+		return nil, errUnknownBlock
+	}
+
+	config := &validator.ProposerSimulationConfig{
+		Nodes:                  args.Nodes,
+		UseGini:                pset.UseGiniCoeff(),
+		MinimumStake:           pset.MinimumStakeBig().Uint64(),
+		ProposerUpdateInterval: interval,
+		IsSingle:               pset.GovernanceModeInt() == params.GovernanceMode_Single,
+		GoverningNode:          pset.GoverningNode(),
+		BlockNumber:            pHeader.Number.Uint64(),
+		Seed:                   pHeader.Hash(),
+		StartBlock:             header.Number.Uint64() + 1,
+		Rules:                  api.chain.Config().Rules(pHeader.Number),
+	}
+	if args.UseGini != nil {
+		config.UseGini = *args.UseGini
+	}
+	if args.MinimumStake != nil {
+		config.MinimumStake = *args.MinimumStake
+	}
+	if len(config.Nodes) == 0 {
+		stakingInfo := reward.GetStakingInfo(config.BlockNumber + 1)
+		if stakingInfo == nil {
+			return nil, errNoStakingInfo
+		}
+		// only the current council members can be validators, as in weightedCouncil.Refresh
+		snap, err := api.istanbul.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil, false)
+		if err != nil {
+			return nil, err
+		}
+		config.Council = snap.council()
+		for i, nodeAddr := range stakingInfo.CouncilNodeAddrs {
+			config.Nodes = append(config.Nodes, validator.SimulationNode{
+				NodeAddr:      nodeAddr,
+				RewardAddr:    stakingInfo.CouncilRewardAddrs[i],
+				StakingAmount: stakingInfo.CouncilStakingAmounts[i],
+			})
+		}
+	}
+
+	return validator.SimulateProposers(config, numBlocks)
+}
+
 func (api *API) GetTimeout() uint64 {
 	return istanbul.DefaultConfig.Timeout
 }
 
+// sameAddresses reports whether a and b have the same addresses regardless of the order.
+func sameAddresses(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[common.Address]bool, len(a))
+	for _, addr := range a {
+		set[addr] = true
+	}
+	for _, addr := range b {
+		if !set[addr] {
+			return false
+		}
+	}
+	return true
+}
+
 // Retrieve the header at requested block number
 func headerByRpcNumber(chain consensus.ChainReader, number *rpc.BlockNumber) (*types.Header, error) {
 	var header *types.Header
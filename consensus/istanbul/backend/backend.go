@@ -35,7 +35,6 @@ import (
 	"github.com/klaytn/klaytn/consensus/istanbul"
 	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
 	"github.com/klaytn/klaytn/consensus/istanbul/validator"
-	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/governance"
 	"github.com/klaytn/klaytn/log"
@@ -51,14 +50,19 @@ const (
 var logger = log.NewModuleLogger(log.ConsensusIstanbulBackend)
 
 func New(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.PrivateKey, db database.DBManager, governance governance.Engine, nodetype common.ConnType) consensus.Istanbul {
+	return NewWithSigner(rewardbase, config, NewLocalSigner(privateKey), db, governance, nodetype)
+}
+
+// NewWithSigner creates an istanbul backend which signs consensus messages and blocks with the given signer.
+func NewWithSigner(rewardbase common.Address, config *istanbul.Config, signer Signer, db database.DBManager, governance governance.Engine, nodetype common.ConnType) consensus.Istanbul {
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
 	backend := &backend{
 		config:            config,
 		istanbulEventMux:  new(event.TypeMux),
-		privateKey:        privateKey,
-		address:           crypto.PubkeyToAddress(privateKey.PublicKey),
+		signer:            signer,
+		address:           signer.Address(),
 		logger:            logger.NewWith(),
 		db:                db,
 		commitCh:          make(chan *types.Result, 1),
@@ -82,7 +86,7 @@ func New(rewardbase common.Address, config *istanbul.Config, privateKey *ecdsa.P
 type backend struct {
 	config           *istanbul.Config
 	istanbulEventMux *event.TypeMux
-	privateKey       *ecdsa.PrivateKey
+	signer           Signer
 	address          common.Address
 	core             istanbulCore.Engine
 	logger           log.Logger
@@ -121,6 +125,11 @@ type backend struct {
 
 	rewardDistributor *reward.RewardDistributor
 
+	// The reward policy is fixed in the chain config, so it is resolved once at the first use
+	rewardPolicy     reward.RewardPolicy
+	rewardPolicyErr  error
+	rewardPolicyOnce sync.Once
+
 	// Node type
 	nodetype common.ConnType
 }
@@ -129,6 +138,14 @@ func (sb *backend) NodeType() common.ConnType {
 	return sb.nodetype
 }
 
+// IsRoundTimeoutForkEnabled implements istanbul.Backend.IsRoundTimeoutForkEnabled
+func (sb *backend) IsRoundTimeoutForkEnabled(number *big.Int) bool {
+	if sb.chain == nil {
+		return false
+	}
+	return sb.chain.Config().IsRoundTimeoutForkEnabled(number)
+}
+
 func (sb *backend) GetRewardBase() common.Address {
 	return sb.rewardbase
 }
@@ -146,6 +163,12 @@ func (sb *backend) Address() common.Address {
 	return sb.address
 }
 
+// SignNodeID signs the p2p node ID with the validator key, proving that the node acts for the
+// validator when the validator key is kept apart from the nodekey.
+func (sb *backend) SignNodeID(nodeID []byte) ([]byte, error) {
+	return sb.signer.SignNodeID(nodeID)
+}
+
 // Validators implements istanbul.Backend.Validators
 func (sb *backend) Validators(proposal istanbul.Proposal) istanbul.ValidatorSet {
 	return sb.getValidators(proposal.Number().Uint64(), proposal.Hash())
@@ -349,8 +372,14 @@ func (sb *backend) Verify(proposal istanbul.Proposal) (time.Duration, error) {
 
 // Sign implements istanbul.Backend.Sign
 func (sb *backend) Sign(data []byte) ([]byte, error) {
-	hashData := crypto.Keccak256([]byte(data))
-	return crypto.Sign(hashData, sb.privateKey)
+	return sb.signer.Sign(sb.currentView.Load().(*istanbul.View), data)
+}
+
+// Close releases the signer, such as the connection to a remote signer. It is called when the node stops.
+func (sb *backend) Close() {
+	if closer, ok := sb.signer.(interface{ Close() }); ok {
+		closer.Close()
+	}
 }
 
 // CheckSignature implements istanbul.Backend.CheckSignature
@@ -40,6 +40,7 @@ import (
 	"github.com/klaytn/klaytn/consensus/misc"
 	"github.com/klaytn/klaytn/crypto/sha3"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/rlp"
 )
@@ -433,6 +434,14 @@ func (sb *backend) Prepare(chain consensus.ChainReader, header *types.Header) er
 	return nil
 }
 
+// getRewardPolicy returns the reward policy selected by the chain config, resolving it only once.
+func (sb *backend) getRewardPolicy(config *params.ChainConfig) (reward.RewardPolicy, error) {
+	sb.rewardPolicyOnce.Do(func() {
+		sb.rewardPolicy, sb.rewardPolicyErr = reward.NewRewardPolicy(config)
+	})
+	return sb.rewardPolicy, sb.rewardPolicyErr
+}
+
 // Finalize runs any post-transaction state modifications (e.g. block rewards)
 // and assembles the final block.
 //
@@ -459,6 +468,10 @@ func (sb *backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 	if err != nil {
 		return nil, err
 	}
+	policy, err := sb.getRewardPolicy(chain.Config())
+	if err != nil {
+		return nil, err
+	}
 
 	// If sb.chain is nil, it means backend is not initialized yet.
 	if sb.chain != nil && !reward.IsRewardSimple(sb.governance.Params()) {
@@ -484,9 +497,9 @@ func (sb *backend) Finalize(chain consensus.ChainReader, header *types.Header, s
 			logger.Trace(logMsg, "header.Number", header.Number.Uint64(), "node address", sb.address, "rewardbase", header.Rewardbase)
 		}
 
-		rewardSpec, err = reward.CalcDeferredReward(header, rules, pset)
+		rewardSpec, err = policy.CalcDeferredReward(header, rules, pset)
 	} else {
-		rewardSpec, err = reward.CalcDeferredRewardSimple(header, rules, pset)
+		rewardSpec, err = policy.CalcDeferredRewardSimple(header, rules, pset)
 	}
 
 	if err != nil {
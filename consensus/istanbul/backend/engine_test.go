@@ -23,6 +23,8 @@ package backend
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
 	"sort"
@@ -59,6 +61,8 @@ type (
 	EthTxTypeCompatibleBlock *big.Int
 	magmaCompatibleBlock     *big.Int
 	koreCompatibleBlock      *big.Int
+
+	reconfigurationCompatibleBlock *big.Int
 )
 
 type (
@@ -135,6 +139,8 @@ func newBlockChain(n int, items ...interface{}) (*blockchain.BlockChain, *backen
 			genesis.Config.MagmaCompatibleBlock = v
 		case koreCompatibleBlock:
 			genesis.Config.KoreCompatibleBlock = v
+		case reconfigurationCompatibleBlock:
+			genesis.Config.ReconfigurationCompatibleBlock = v
 		case proposerPolicy:
 			genesis.Config.Istanbul.ProposerPolicy = uint64(v)
 		case epoch:
@@ -165,7 +171,7 @@ func newBlockChain(n int, items ...interface{}) (*blockchain.BlockChain, *backen
 		b = newTestBackend()
 	}
 
-	nodeKeys[0] = b.privateKey
+	nodeKeys[0] = b.signer.(*localSigner).privateKey
 	addrs[0] = b.address // if governance mode is single, this address is the governing node address
 	for i := 1; i < n; i++ {
 		nodeKeys[i], _ = crypto.GenerateKey()
@@ -421,7 +427,8 @@ func TestVerifySeal(t *testing.T) {
 	signatureAddresses.Purge()
 
 	// unauthorized users but still can get correct signer address
-	engine.privateKey, _ = crypto.GenerateKey()
+	key, _ := crypto.GenerateKey()
+	engine.signer = NewLocalSigner(key)
 	err = engine.VerifySeal(chain, block.Header())
 	if err != nil {
 		t.Errorf("error mismatch: have %v, want nil", err)
@@ -1256,6 +1263,150 @@ func TestSnapshot_Validators_AddRemove(t *testing.T) {
 	}
 }
 
+func TestSnapshot_Reconfiguration(t *testing.T) {
+	var configItems []interface{}
+	configItems = append(configItems, proposerPolicy(params.WeightedRandom))
+	configItems = append(configItems, proposerUpdateInterval(1))
+	configItems = append(configItems, epoch(3))
+	configItems = append(configItems, subGroupSize(4))
+	configItems = append(configItems, governanceMode("single"))
+	configItems = append(configItems, minimumStake(new(big.Int).SetUint64(4000000)))
+	configItems = append(configItems, istanbulCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, LondonCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, EthTxTypeCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, magmaCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, koreCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, reconfigurationCompatibleBlock(new(big.Int).SetUint64(0)))
+	configItems = append(configItems, blockPeriod(0)) // set block period to 0 to prevent creating future block
+	chain, engine := newBlockChain(4, configItems...)
+	defer engine.Stop()
+
+	oldStakingManager := reward.GetStakingManager()
+	defer reward.SetTestStakingManager(oldStakingManager)
+	reward.SetTestStakingManagerWithStakingInfoCache(makeFakeStakingInfo(0, nodeKeys, []uint64{4000000, 4000000, 4000000, 4000000}))
+
+	removed := addrs[3]
+	reconfiguration := fmt.Sprintf(`{"block":6,"committeesize":2,"remove":["%s"]}`, removed.Hex())
+
+	// the reconfiguration is voted on block 1, and block 6 is committed by the reconfigured validators
+	var previousBlock, currentBlock *types.Block = nil, chain.Genesis()
+	for i := 0; i < 10; i++ {
+		if i == 1 {
+			assert.True(t, engine.governance.AddVote("governance.reconfiguration", reconfiguration))
+		}
+		previousBlock = currentBlock
+		currentBlock = makeBlockWithSeal(chain, engine, previousBlock)
+		_, err := chain.InsertChain(types.Blocks{currentBlock})
+		assert.NoError(t, err)
+
+		if currentBlock.NumberU64() == 5 {
+			excludeNodeByAddr(removed)
+		}
+	}
+
+	snapshotAt := func(number uint64) *Snapshot {
+		block := chain.GetBlockByNumber(number)
+		snap, err := engine.snapshot(chain, block.NumberU64(), block.Hash(), nil, false)
+		assert.NoError(t, err)
+		return snap
+	}
+
+	// the reconfiguration is scheduled and shown in the snapshot JSON ahead of time
+	snap := snapshotAt(2)
+	assert.Equal(t, 4, len(snap.validators()))
+	assert.Equal(t, uint64(4), snap.CommitteeSize)
+	if assert.Equal(t, 1, len(snap.Reconfigurations)) {
+		assert.Equal(t, uint64(6), snap.Reconfigurations[0].Block)
+	}
+	scheduled := snap.toJSONStruct().ScheduledCouncils
+	if assert.Equal(t, 1, len(scheduled)) {
+		assert.Equal(t, uint64(2), scheduled[0].CommitteeSize)
+		assert.NotContains(t, scheduled[0].Council, removed)
+	}
+	assert.Empty(t, snap.Votes)
+
+	// the snapshot of block 5 has the validators of block 6
+	snap = snapshotAt(4)
+	assert.Contains(t, snap.validators(), removed)
+	snap = snapshotAt(5)
+	assert.NotContains(t, snap.validators(), removed)
+	assert.Equal(t, uint64(2), snap.CommitteeSize)
+	assert.Nil(t, snap.Reconfigurations)
+
+	// the committee size is kept over the epoch, as the governance value is unchanged
+	snap = snapshotAt(10)
+	assert.Equal(t, 3, len(snap.validators()))
+	assert.Equal(t, uint64(2), snap.CommitteeSize)
+	assert.Equal(t, uint64(4), snap.GovernedCommitteeSize)
+
+	// the reconfigured committee size survives the JSON round trip
+	blob, err := json.Marshal(snap)
+	assert.NoError(t, err)
+	restored := new(Snapshot)
+	assert.NoError(t, json.Unmarshal(blob, restored))
+	assert.Equal(t, snap.ReconfiguredCommitteeSize, restored.ReconfiguredCommitteeSize)
+	assert.Equal(t, snap.GovernedCommitteeSize, restored.GovernedCommitteeSize)
+}
+
+func TestAPI_PreviewCommittee(t *testing.T) {
+	chain, engine := newBlockChain(4,
+		istanbulCompatibleBlock(common.Big0), LondonCompatibleBlock(common.Big0), EthTxTypeCompatibleBlock(common.Big0),
+		magmaCompatibleBlock(common.Big0), koreCompatibleBlock(common.Big0), reconfigurationCompatibleBlock(big.NewInt(3)))
+	defer engine.Stop()
+	api := &API{chain: chain, istanbul: engine}
+
+	// a reconfiguration cannot be voted before the hardfork
+	early := `{"block":6,"committeesize":3}`
+	_, err := api.PreviewCommittee(10, &early)
+	assert.Equal(t, errReconfigurationNotEnabled, err)
+
+	var previousBlock, currentBlock *types.Block = nil, chain.Genesis()
+	for i := 0; i < 2; i++ {
+		previousBlock = currentBlock
+		currentBlock = makeBlockWithSeal(chain, engine, previousBlock)
+		_, err := chain.InsertChain(types.Blocks{currentBlock})
+		assert.NoError(t, err)
+	}
+
+	previews, err := api.PreviewCommittee(10, nil)
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(previews)) {
+		assert.Equal(t, uint64(3), previews[0].From)
+		assert.Equal(t, uint64(12), previews[0].To)
+		assert.Equal(t, 4, len(previews[0].Validators))
+	}
+
+	// a hypothetical reconfiguration adds a validator and shrinks the committee from block 6
+	newAddr := common.HexToAddress("0x0000000000000000000000000000000000000123")
+	reconfiguration := fmt.Sprintf(`{"block":6,"committeesize":3,"add":["%s"]}`, newAddr.Hex())
+	previews, err = api.PreviewCommittee(10, &reconfiguration)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(previews)) {
+		assert.Equal(t, uint64(5), previews[0].To)
+		assert.Equal(t, 4, len(previews[0].Committee))
+		assert.Equal(t, uint64(6), previews[1].From)
+		assert.Equal(t, uint64(12), previews[1].To)
+		assert.Equal(t, uint64(3), previews[1].CommitteeSize)
+		assert.Contains(t, previews[1].Validators, newAddr)
+		assert.Nil(t, previews[1].Committee)
+		assert.Equal(t, 1, len(previews[1].Reconfigurations))
+	}
+
+	// the preview does not change the snapshot
+	previews, err = api.PreviewCommittee(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(previews))
+
+	_, err = api.PreviewCommittee(0, nil)
+	assert.Equal(t, errInvalidPreviewRange, err)
+	past := `{"block":3,"committeesize":3}`
+	_, err = api.PreviewCommittee(10, &past)
+	assert.Equal(t, errPastReconfiguration, err)
+	meaningless := fmt.Sprintf(`{"block":6,"remove":["%s"]}`, newAddr.Hex())
+	_, err = api.PreviewCommittee(10, &meaningless)
+	assert.Equal(t, errMeaninglessReconfiguration, err)
+}
+
 func TestSnapshot_Writable(t *testing.T) {
 	var configItems []interface{}
 	configItems = append(configItems, proposerPolicy(params.WeightedRandom))
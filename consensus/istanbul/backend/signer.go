@@ -0,0 +1,66 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+)
+
+// Signer signs istanbul messages, committed seals and block seals on behalf of the validator.
+// The view is the consensus view of the backend at the time of signing. A signer may use it
+// to refuse signing for a view older than the ones it has already signed.
+type Signer interface {
+	// Address returns the address of the signing key
+	Address() common.Address
+
+	// Sign signs the keccak256 hash of data with the signing key
+	Sign(view *istanbul.View, data []byte) ([]byte, error)
+
+	// SignNodeID signs istanbul.NodeIDData of the p2p node ID with the signing key
+	SignNodeID(nodeID []byte) ([]byte, error)
+}
+
+// localSigner is a Signer holding the private key in-process.
+type localSigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewLocalSigner returns a Signer which signs with the given private key directly.
+func NewLocalSigner(privateKey *ecdsa.PrivateKey) Signer {
+	return &localSigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+func (s *localSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *localSigner) Sign(view *istanbul.View, data []byte) ([]byte, error) {
+	hashData := crypto.Keccak256(data)
+	return crypto.Sign(hashData, s.privateKey)
+}
+
+func (s *localSigner) SignNodeID(nodeID []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(istanbul.NodeIDData(nodeID)), s.privateKey)
+}
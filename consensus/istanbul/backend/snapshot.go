@@ -23,6 +23,7 @@ package backend
 import (
 	"bytes"
 	"encoding/json"
+	"sort"
 
 	"github.com/klaytn/klaytn/consensus"
 
@@ -49,6 +50,10 @@ type Snapshot struct {
 	CommitteeSize uint64
 	Votes         []governance.GovernanceVote      // List of votes cast in chronological order
 	Tally         []governance.GovernanceTallyItem // Current vote tally to avoid recalculating
+
+	Reconfigurations          []*governance.Reconfiguration // Approved reconfigurations not applied yet, in ascending order of the block
+	ReconfiguredCommitteeSize uint64                        // Committee size set by a reconfiguration, overriding the governance value
+	GovernedCommitteeSize     uint64                        // Governance value of the committee size, ReconfiguredCommitteeSize is kept while it is unchanged
 }
 
 func getGovernanceValue(gov governance.Engine, number uint64) (epoch uint64, policy uint64, committeeSize uint64) {
@@ -121,10 +126,17 @@ func (s *Snapshot) copy() *Snapshot {
 		CommitteeSize: s.CommitteeSize,
 		Votes:         make([]governance.GovernanceVote, len(s.Votes)),
 		Tally:         make([]governance.GovernanceTallyItem, len(s.Tally)),
+
+		Reconfigurations:          make([]*governance.Reconfiguration, len(s.Reconfigurations)),
+		ReconfiguredCommitteeSize: s.ReconfiguredCommitteeSize,
+		GovernedCommitteeSize:     s.GovernedCommitteeSize,
 	}
 
 	copy(cpy.Votes, s.Votes)
 	copy(cpy.Tally, s.Tally)
+	for i, r := range s.Reconfigurations {
+		cpy.Reconfigurations[i] = r.Copy()
+	}
 
 	return cpy
 }
@@ -156,7 +168,7 @@ func (s *Snapshot) apply(headers []*types.Header, gov governance.Engine, addr co
 	snap := s.copy()
 
 	// Copy values which might be changed by governance vote
-	snap.Epoch, snap.Policy, snap.CommitteeSize = getGovernanceValue(gov, snap.Number)
+	snap.loadGovernanceValue(gov, snap.Number)
 
 	for _, header := range headers {
 		// Remove any votes on checkpoint blocks
@@ -174,18 +186,26 @@ func (s *Snapshot) apply(headers []*types.Header, gov governance.Engine, addr co
 		if number%snap.Epoch == 0 {
 			if writable {
 				gov.UpdateCurrentSet(number)
-				if len(header.Governance) > 0 {
-					gov.WriteGovernanceForNextEpoch(number, header.Governance)
-				}
+				// Called without the governance as well, to drop the changes scheduled by a reorganized header
+				gov.WriteGovernanceForNextEpoch(number, header.Governance)
 				gov.ClearVotes(number)
 			}
 			// Reload governance values because epoch changed
-			snap.Epoch, snap.Policy, snap.CommitteeSize = getGovernanceValue(gov, number)
+			snap.loadGovernanceValue(gov, number)
 			snap.Votes = make([]governance.GovernanceVote, 0)
 			snap.Tally = make([]governance.GovernanceTallyItem, 0)
 		}
 
 		snap.ValSet, snap.Votes, snap.Tally = gov.HandleGovernanceVote(snap.ValSet, snap.Votes, snap.Tally, header, validator, addr, writable)
+
+		// Schedule the approved reconfigurations, and apply the ones taking effect at the next block
+		if chain.Config().IsReconfigurationForkEnabled(header.Number) {
+			var approved []*governance.Reconfiguration
+			approved, snap.Votes, snap.Tally = gov.TakeApprovedReconfigurations(snap.ValSet, snap.Votes, snap.Tally)
+			snap.scheduleReconfigurations(approved)
+			snap.applyReconfigurations(number + 1)
+		}
+
 		if policy == uint64(params.WeightedRandom) {
 			// Snapshot of block N (Snapshot_N) should contain proposers for N+1 and following blocks.
 			// Validators for Block N+1 can be calculated based on the staking information from the previous stakingUpdateInterval block.
@@ -233,6 +253,92 @@ func (s *Snapshot) apply(headers []*types.Header, gov governance.Engine, addr co
 	return snap, nil
 }
 
+// loadGovernanceValue reloads the values which might be changed by governance vote.
+// The committee size set by a reconfiguration is kept until the governance value of the committee size changes.
+func (s *Snapshot) loadGovernanceValue(gov governance.Engine, number uint64) {
+	var committeeSize uint64
+	s.Epoch, s.Policy, committeeSize = getGovernanceValue(gov, number)
+
+	if s.ReconfiguredCommitteeSize != 0 && committeeSize == s.GovernedCommitteeSize {
+		s.CommitteeSize = s.ReconfiguredCommitteeSize
+		return
+	}
+	s.ReconfiguredCommitteeSize, s.GovernedCommitteeSize = 0, committeeSize
+	s.CommitteeSize = committeeSize
+}
+
+// scheduleReconfigurations adds the reconfigurations to the pending ones, keeping them in ascending order of the block.
+func (s *Snapshot) scheduleReconfigurations(reconfigs []*governance.Reconfiguration) {
+	if len(reconfigs) == 0 {
+		return
+	}
+	for _, r := range reconfigs {
+		logger.Debug("Reconfiguration is scheduled", "snap.Number", s.Number, "block", r.Block, "reconfiguration", r.String())
+		s.Reconfigurations = append(s.Reconfigurations, r.Copy())
+	}
+	sort.SliceStable(s.Reconfigurations, func(i, j int) bool {
+		return s.Reconfigurations[i].Block < s.Reconfigurations[j].Block
+	})
+}
+
+// applyReconfigurations applies the pending reconfigurations taking effect at or before the given block.
+// The snapshot of the block N-1 contains the validators of the block N, so it should be called with N.
+func (s *Snapshot) applyReconfigurations(number uint64) {
+	for len(s.Reconfigurations) > 0 && s.Reconfigurations[0].Block <= number {
+		s.applyReconfiguration(s.Reconfigurations[0])
+		logger.Debug("Reconfiguration is applied", "block", s.Reconfigurations[0].Block, "committeeSize", s.CommitteeSize, "councilSize", len(s.council()))
+		s.Reconfigurations = s.Reconfigurations[1:]
+	}
+	if len(s.Reconfigurations) == 0 {
+		s.Reconfigurations = nil
+	}
+}
+
+// applyReconfiguration changes the council and the committee size as the reconfiguration specifies.
+// An address which was already added or removed since the reconfiguration was approved is ignored.
+func (s *Snapshot) applyReconfiguration(r *governance.Reconfiguration) {
+	for _, addr := range r.Add {
+		s.ValSet.AddValidator(addr)
+	}
+	for _, addr := range r.Remove {
+		if s.ValSet.RemoveValidator(addr) {
+			s.Votes = removeVotesFrom(s.Votes, addr)
+		}
+	}
+	if r.CommitteeSize != 0 {
+		s.ReconfiguredCommitteeSize, s.CommitteeSize = r.CommitteeSize, r.CommitteeSize
+		s.ValSet.SetSubGroupSize(s.CommitteeSize)
+	}
+}
+
+// scheduledCouncils returns the council and the committee size after each pending reconfiguration.
+func (s *Snapshot) scheduledCouncils() []*scheduledCouncilJSON {
+	if len(s.Reconfigurations) == 0 {
+		return nil
+	}
+	snap := s.copy()
+	scheduled := make([]*scheduledCouncilJSON, 0, len(snap.Reconfigurations))
+	for _, r := range snap.Reconfigurations {
+		snap.applyReconfiguration(r)
+		scheduled = append(scheduled, &scheduledCouncilJSON{
+			Block:         r.Block,
+			CommitteeSize: snap.CommitteeSize,
+			Council:       snap.council(),
+		})
+	}
+	return scheduled
+}
+
+func removeVotesFrom(votes []governance.GovernanceVote, addr common.Address) []governance.GovernanceVote {
+	ret := make([]governance.GovernanceVote, 0, len(votes))
+	for _, vote := range votes {
+		if vote.Validator != addr {
+			ret = append(ret, vote)
+		}
+	}
+	return ret
+}
+
 func (s *Snapshot) getMyVotingPower(addr common.Address) uint64 {
 	for _, a := range s.ValSet.List() {
 		if a.Address() == addr {
@@ -260,6 +366,11 @@ func (s *Snapshot) demotedValidators() []common.Address {
 	return sortValidatorArray(demotedValidators)
 }
 
+// council retrieves the list of validators and demoted validators.
+func (s *Snapshot) council() []common.Address {
+	return append(s.validators(), s.demotedValidators()...)
+}
+
 func (s *Snapshot) committee(prevHash common.Hash, view *istanbul.View) []common.Address {
 	committeeList := s.ValSet.SubList(prevHash, view)
 
@@ -300,6 +411,19 @@ type snapshotJSON struct {
 	Proposers         []common.Address `json:"proposers"`
 	ProposersBlockNum uint64           `json:"proposersBlockNum"`
 	DemotedValidators []common.Address `json:"demotedValidators"`
+
+	// for scheduled reconfiguration
+	Reconfigurations          []*governance.Reconfiguration `json:"reconfigurations,omitempty"`
+	ReconfiguredCommitteeSize uint64                        `json:"reconfiguredCommitteeSize,omitempty"`
+	GovernedCommitteeSize     uint64                        `json:"governedCommitteeSize,omitempty"`
+	ScheduledCouncils         []*scheduledCouncilJSON       `json:"scheduledCouncils,omitempty"` // derived from Reconfigurations, ignored when unmarshalled
+}
+
+// scheduledCouncilJSON is the council and the committee size from Block, after a pending reconfiguration is applied.
+type scheduledCouncilJSON struct {
+	Block         uint64           `json:"block"`
+	CommitteeSize uint64           `json:"committeeSize"`
+	Council       []common.Address `json:"council"`
 }
 
 func (s *Snapshot) toJSONStruct() *snapshotJSON {
@@ -333,6 +457,11 @@ func (s *Snapshot) toJSONStruct() *snapshotJSON {
 		Proposers:         proposers,
 		ProposersBlockNum: proposersBlockNum,
 		DemotedValidators: demotedValidators,
+
+		Reconfigurations:          s.Reconfigurations,
+		ReconfiguredCommitteeSize: s.ReconfiguredCommitteeSize,
+		GovernedCommitteeSize:     s.GovernedCommitteeSize,
+		ScheduledCouncils:         s.scheduledCouncils(),
 	}
 }
 
@@ -348,6 +477,9 @@ func (s *Snapshot) UnmarshalJSON(b []byte) error {
 	s.Hash = j.Hash
 	s.Votes = j.Votes
 	s.Tally = j.Tally
+	s.Reconfigurations = j.Reconfigurations
+	s.ReconfiguredCommitteeSize = j.ReconfiguredCommitteeSize
+	s.GovernedCommitteeSize = j.GovernedCommitteeSize
 
 	// TODO-Klaytn-Issue1166 For weightedCouncil
 	if j.Policy == istanbul.WeightedRandom {
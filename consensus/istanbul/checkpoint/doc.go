@@ -0,0 +1,63 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package checkpoint implements header-only proofs that a Klaytn block header is committed by the istanbul validators.
+
+Bridge relayers and light clients can verify a header of a Klaytn chain without trusting a single endpoint,
+starting from a trusted checkpoint: a block hash, the validators and demoted validators of the snapshot at the block,
+the committee size, the epoch, the governance mode and the council votes cast since the last epoch block.
+
+Proof
+
+A Proof consists of the checkpoint it starts from and every header in (Checkpoint.Number, target].
+It can be obtained with istanbul_getCheckpointProof, which returns at most 1024 headers,
+so a longer range is proven by a chain of proofs.
+
+Verification
+
+Verifier checks that the headers are contiguous and linked by their parent hashes from the trusted checkpoint.
+The headers carrying a governance vote and the target header should have committed seals from more than 2F
+distinct council members, where F is derived from the committee size in the same way as the istanbul consensus.
+Any other header is proven by the parent hash of a verified descendant.
+
+The votes on governance.addvalidator and governance.removevalidator are tallied as the snapshot does:
+the votes are cleared at every epoch block, a validator's previous vote on the same key is replaced,
+and a council change is applied only if it passes under the governance mode (none, single or ballot).
+After a proof is verified, the target header becomes the new checkpoint, so a chain of proofs can be verified.
+
+Limitations
+
+- The demoted validators are taken from the checkpoint, as the staking amounts are not available to the verifier.
+  istanbul_getCheckpointProof refuses a range where the demoted validators, the committee size, the epoch or
+  the governance mode change.
+
+- A vote on a key changing the tally itself (governance.governancemode, governance.governingnode,
+  governance.reconfiguration, istanbul.committeesize and istanbul.epoch) is not replayed.
+  A proof containing it is rejected, and a new trusted checkpoint is required after it.
+
+- A scheduled reconfiguration changes the council at a block without a vote.
+  A proof reaching the block is rejected, and a new trusted checkpoint is required after it.
+
+Files
+
+- proof.go    : Proof and Checkpoint
+
+- verifier.go : Verifier and the verification of committed seals
+
+- tally.go    : Replay of the council votes
+*/
+package checkpoint
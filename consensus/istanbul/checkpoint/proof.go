@@ -0,0 +1,75 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package checkpoint
+
+import (
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+)
+
+// Checkpoint is a trusted state to verify the headers following Number.
+// Besides the council, it carries the pending council votes of the snapshot at Number,
+// so that the votes in the following headers are tallied as the istanbul consensus does.
+type Checkpoint struct {
+	Number              uint64           `json:"number"`
+	Hash                common.Hash      `json:"hash"`
+	Validators          []common.Address `json:"validators"`
+	DemotedValidators   []common.Address `json:"demotedValidators"`
+	CommitteeSize       uint64           `json:"committeeSize"`
+	Epoch               uint64           `json:"epoch"`
+	GovernanceMode      string           `json:"governanceMode"`
+	GoverningNode       common.Address   `json:"governingNode"`
+	Votes               []Vote           `json:"votes"` // council votes cast since the last epoch block
+	Tally               []TallyItem      `json:"tally"`
+	NextReconfiguration uint64           `json:"nextReconfiguration,omitempty"` // block of the first pending reconfiguration, if any
+}
+
+// Council returns the validators and the demoted validators committing the block Number+1.
+func (c *Checkpoint) Council() []common.Address {
+	return append(copyAddresses(c.Validators), c.DemotedValidators...)
+}
+
+// Vote is a governance.addvalidator or governance.removevalidator vote.
+// List tells whether the addresses were voted as a list, which is tallied apart from a single address.
+type Vote struct {
+	Validator common.Address   `json:"validator"`
+	Key       string           `json:"key"`
+	Addresses []common.Address `json:"addresses"`
+	List      bool             `json:"list"`
+}
+
+// TallyItem is the voting power of the validators who voted for the same council change.
+type TallyItem struct {
+	Key       string           `json:"key"`
+	Addresses []common.Address `json:"addresses"`
+	List      bool             `json:"list"`
+	Votes     uint64           `json:"votes"`
+}
+
+// Proof proves that the last of Headers is committed by the council, starting from Checkpoint.
+type Proof struct {
+	Checkpoint Checkpoint      `json:"checkpoint"`
+	Headers    []*types.Header `json:"headers"` // contiguous headers in (Checkpoint.Number, target], linked by their parent hashes
+}
+
+// Header returns the target header of the proof.
+func (p *Proof) Header() *types.Header {
+	if len(p.Headers) == 0 {
+		return nil
+	}
+	return p.Headers[len(p.Headers)-1]
+}
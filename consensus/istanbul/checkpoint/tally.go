@@ -0,0 +1,227 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package checkpoint
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+const (
+	addValidatorKey    = "governance.addvalidator"
+	removeValidatorKey = "governance.removevalidator"
+
+	// votingPower is the voting power of every validator in a governance tally.
+	votingPower = 1000
+)
+
+// untrackedKeys are the governance keys changing how the council is tallied or committed.
+// They are not replayed, so a proof containing a vote on them is rejected.
+var untrackedKeys = map[string]bool{
+	"governance.governancemode":  true,
+	"governance.governingnode":   true,
+	"governance.reconfiguration": true,
+	"istanbul.committeesize":     true,
+	"istanbul.epoch":             true,
+}
+
+var (
+	errUntrackedVote         = errors.New("vote on a key changing the tally is not replayed, a new trusted checkpoint is required")
+	errUnknownGovernanceMode = errors.New("unknown governance mode")
+	errUnauthorizedVoter     = errors.New("previous voter is not a validator")
+)
+
+// headerVote is a governance vote in a header, whose value is kept undecoded.
+type headerVote struct {
+	Validator common.Address
+	Key       string
+	Value     rlp.RawValue
+}
+
+// applyVote tallies the governance vote in a header proposed by proposer, as governance.HandleGovernanceVote does.
+// A vote which the governance ignores is ignored as well.
+func (c *Checkpoint) applyVote(vote []byte, proposer common.Address) error {
+	if len(vote) == 0 {
+		return nil
+	}
+	var v headerVote
+	if err := rlp.DecodeBytes(vote, &v); err != nil {
+		return nil
+	}
+	if untrackedKeys[v.Key] {
+		return errUntrackedVote
+	}
+	if v.Key != addValidatorKey && v.Key != removeValidatorKey {
+		return nil
+	}
+	addrs, list, ok := decodeAddresses(v.Value)
+	if !ok {
+		return nil
+	}
+	for _, addr := range addrs {
+		if c.isMember(addr) != (v.Key == removeValidatorKey) {
+			return nil
+		}
+	}
+
+	// Remove the previous vote of the proposer on the same key
+	for i, prev := range c.Votes {
+		if prev.Validator == proposer && prev.Key == v.Key {
+			if !containsAddress(c.Validators, prev.Validator) {
+				return errUnauthorizedVoter
+			}
+			c.changeTally(prev.Key, prev.Addresses, prev.List, false)
+			c.Votes = append(c.Votes[:i], c.Votes[i+1:]...)
+			break
+		}
+	}
+	c.Votes = append(c.Votes, Vote{Validator: v.Validator, Key: v.Key, Addresses: addrs, List: list})
+
+	if !containsAddress(c.Validators, v.Validator) {
+		return nil
+	}
+	votes := c.changeTally(v.Key, addrs, list, true)
+
+	switch c.GovernanceMode {
+	case "none":
+	case "single":
+		if v.Validator != c.GoverningNode {
+			return nil
+		}
+	case "ballot":
+		if votes <= uint64(len(c.Validators))*votingPower/2 {
+			return nil
+		}
+	default:
+		return errUnknownGovernanceMode
+	}
+
+	for _, addr := range addrs {
+		if v.Key == addValidatorKey {
+			if !c.isMember(addr) {
+				c.Validators = append(c.Validators, addr)
+			}
+			continue
+		}
+		c.Validators = removeAddress(c.Validators, addr)
+		c.DemotedValidators = removeAddress(c.DemotedValidators, addr)
+		// The governance uncasts the votes of a removed validator only if it is voted in a list
+		if list {
+			c.removeVotesFrom(addr)
+		}
+	}
+	return nil
+}
+
+// changeTally adds or subtracts a voting power to or from the tally of the council change, and returns its votes.
+func (c *Checkpoint) changeTally(key string, addrs []common.Address, list bool, isAdd bool) uint64 {
+	for i := range c.Tally {
+		item := &c.Tally[i]
+		if item.Key != key || item.List != list || !reflect.DeepEqual(item.Addresses, addrs) {
+			continue
+		}
+		if isAdd {
+			item.Votes += votingPower
+		} else if item.Votes > votingPower {
+			item.Votes -= votingPower
+		} else {
+			item.Votes = 0
+		}
+		votes := item.Votes
+		if votes == 0 {
+			c.Tally = append(c.Tally[:i], c.Tally[i+1:]...)
+		}
+		return votes
+	}
+	if !isAdd {
+		return 0
+	}
+	c.Tally = append(c.Tally, TallyItem{Key: key, Addresses: copyAddresses(addrs), List: list, Votes: votingPower})
+	return votingPower
+}
+
+func (c *Checkpoint) removeVotesFrom(addr common.Address) {
+	votes := make([]Vote, 0, len(c.Votes))
+	for _, vote := range c.Votes {
+		if vote.Validator != addr {
+			votes = append(votes, vote)
+		}
+	}
+	c.Votes = votes
+}
+
+func (c *Checkpoint) isMember(addr common.Address) bool {
+	return containsAddress(c.Validators, addr) || containsAddress(c.DemotedValidators, addr)
+}
+
+// decodeAddresses decodes the value of a council vote, which is either a single address or
+// a non-empty list of unique 20-byte addresses, as governance.ParseVoteValue and ValidateVote accept.
+func decodeAddresses(value rlp.RawValue) ([]common.Address, bool, bool) {
+	kind, content, _, err := rlp.Split(value)
+	if err != nil {
+		return nil, false, false
+	}
+	if kind != rlp.List {
+		var b []byte
+		if err := rlp.DecodeBytes(value, &b); err != nil {
+			return nil, false, false
+		}
+		return []common.Address{common.BytesToAddress(b)}, false, true
+	}
+
+	var addrs []common.Address
+	seen := make(map[common.Address]bool)
+	for len(content) > 0 {
+		kind, item, rest, err := rlp.Split(content)
+		if err != nil || kind == rlp.List || len(item) != common.AddressLength {
+			return nil, false, false
+		}
+		addr := common.BytesToAddress(item)
+		if seen[addr] {
+			return nil, false, false
+		}
+		seen[addr] = true
+		addrs = append(addrs, addr)
+		content = rest
+	}
+	if len(addrs) == 0 {
+		return nil, false, false
+	}
+	return addrs, true, true
+}
+
+func containsAddress(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func removeAddress(addrs []common.Address, addr common.Address) []common.Address {
+	ret := make([]common.Address, 0, len(addrs))
+	for _, a := range addrs {
+		if a != addr {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
+	"github.com/klaytn/klaytn/crypto/sha3"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+var (
+	errNilHeader             = errors.New("header is not given")
+	errCheckpointMismatch    = errors.New("proof does not start from the trusted checkpoint")
+	errEmptyProof            = errors.New("target should be after the checkpoint")
+	errBrokenChain           = errors.New("headers are not contiguous from the checkpoint")
+	errReconfiguredRange     = errors.New("council is reconfigured within the proof, a new trusted checkpoint is required")
+	errEmptyCommittedSeals   = errors.New("no committed seal")
+	errInvalidCommittedSeals = errors.New("invalid committed seals")
+	errInsufficientSeals     = errors.New("insufficient committed seals")
+	errUnauthorizedProposer  = errors.New("proposer is not in the council")
+)
+
+// Verifier verifies proofs starting from a trusted checkpoint.
+type Verifier struct {
+	checkpoint Checkpoint
+}
+
+// NewVerifier returns a Verifier trusting the given checkpoint.
+func NewVerifier(trusted Checkpoint) *Verifier {
+	return &Verifier{checkpoint: copyCheckpoint(trusted)}
+}
+
+// Checkpoint returns the latest trusted checkpoint.
+func (v *Verifier) Checkpoint() Checkpoint {
+	return copyCheckpoint(v.checkpoint)
+}
+
+// Verify verifies the proof and, if valid, trusts its target header as the new checkpoint.
+// The proof should start from the latest trusted checkpoint.
+//
+// The headers should be linked by their parent hashes from the checkpoint. The committed seals of
+// the headers carrying a governance vote and the target header are verified against the council at
+// their parents, and the votes are tallied to derive the council. Any other header is proven by
+// the parent hash of a verified descendant.
+func (v *Verifier) Verify(proof *Proof) error {
+	if proof == nil {
+		return errNilHeader
+	}
+	if proof.Checkpoint.Number != v.checkpoint.Number || proof.Checkpoint.Hash != v.checkpoint.Hash {
+		return errCheckpointMismatch
+	}
+	if len(proof.Headers) == 0 {
+		return errEmptyProof
+	}
+
+	state := copyCheckpoint(v.checkpoint)
+	for i, header := range proof.Headers {
+		if header == nil || header.Number == nil {
+			return errNilHeader
+		}
+		number := header.Number.Uint64()
+		if number != state.Number+1 || header.ParentHash != state.Hash {
+			return errBrokenChain
+		}
+		if state.NextReconfiguration != 0 && number >= state.NextReconfiguration {
+			return errReconfiguredRange
+		}
+		// As the snapshot does, the votes are cleared at an epoch block before its vote is tallied.
+		if state.Epoch != 0 && number%state.Epoch == 0 {
+			state.Votes, state.Tally = nil, nil
+		}
+		if len(header.Vote) > 0 || i == len(proof.Headers)-1 {
+			proposer, err := verifyHeader(header, state.Council(), state.CommitteeSize)
+			if err != nil {
+				return fmt.Errorf("header %d: %v", number, err)
+			}
+			// The snapshot accepts a header only from a validator, which is not demoted.
+			if !containsAddress(state.Validators, proposer) {
+				return fmt.Errorf("header %d: %v", number, errUnauthorizedProposer)
+			}
+			if err := state.applyVote(header.Vote, proposer); err != nil {
+				return fmt.Errorf("header %d: %v", number, err)
+			}
+		}
+		state.Number, state.Hash = number, headerHash(header)
+	}
+
+	v.checkpoint = state
+	return nil
+}
+
+// VerifyChain verifies the proofs in order.
+func (v *Verifier) VerifyChain(proofs []*Proof) error {
+	for i, proof := range proofs {
+		if err := v.Verify(proof); err != nil {
+			return fmt.Errorf("proof %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// VerifyHeader checks that the header is proposed by a council member and
+// has committed seals from more than 2F distinct council members.
+func VerifyHeader(header *types.Header, council []common.Address, committeeSize uint64) error {
+	_, err := verifyHeader(header, council, committeeSize)
+	return err
+}
+
+// verifyHeader verifies the header as VerifyHeader does, and returns its proposer.
+func verifyHeader(header *types.Header, council []common.Address, committeeSize uint64) (common.Address, error) {
+	if header == nil {
+		return common.Address{}, errNilHeader
+	}
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	members := make(map[common.Address]bool, len(council))
+	for _, addr := range council {
+		members[addr] = true
+	}
+
+	proposer, err := istanbul.GetSignatureAddress(sigHash(header).Bytes(), extra.Seal)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !members[proposer] {
+		return common.Address{}, errUnauthorizedProposer
+	}
+
+	if len(extra.CommittedSeal) == 0 {
+		return common.Address{}, errEmptyCommittedSeals
+	}
+	proposalSeal := istanbulCore.PrepareCommittedSeal(headerHash(header))
+	validSeal := 0
+	for _, seal := range extra.CommittedSeal {
+		addr, err := istanbul.GetSignatureAddress(proposalSeal, seal)
+		if err != nil {
+			return common.Address{}, errInvalidCommittedSeals
+		}
+		// Every member can have only one seal.
+		if !members[addr] {
+			return common.Address{}, errInvalidCommittedSeals
+		}
+		delete(members, addr)
+		validSeal++
+	}
+
+	if validSeal <= 2*faultTolerance(len(council), committeeSize) {
+		return common.Address{}, errInsufficientSeals
+	}
+	return proposer, nil
+}
+
+// faultTolerance returns F of a committee, as weightedCouncil.F does.
+// The whole council is regarded as the validators, so F never underestimates the one of the consensus.
+func faultTolerance(councilSize int, committeeSize uint64) int {
+	size := uint64(councilSize)
+	if committeeSize > 0 && size > committeeSize {
+		size = committeeSize
+	}
+	return int(math.Ceil(float64(size)/3)) - 1
+}
+
+// headerHash returns the hash of an istanbul header, which the committed seals sign.
+func headerHash(header *types.Header) common.Hash {
+	return rlpHash(types.IstanbulFilteredHeader(header, true))
+}
+
+// sigHash returns the hash which the proposer seal signs.
+func sigHash(header *types.Header) common.Hash {
+	return rlpHash(types.IstanbulFilteredHeader(header, false))
+}
+
+func rlpHash(x interface{}) (h common.Hash) {
+	hw := sha3.NewKeccak256()
+	rlp.Encode(hw, x)
+	hw.Sum(h[:0])
+	return h
+}
+
+func copyAddresses(addrs []common.Address) []common.Address {
+	return append([]common.Address{}, addrs...)
+}
+
+func copyCheckpoint(c Checkpoint) Checkpoint {
+	c.Validators = copyAddresses(c.Validators)
+	c.DemotedValidators = copyAddresses(c.DemotedValidators)
+	c.Votes = append([]Vote{}, c.Votes...)
+	for i := range c.Votes {
+		c.Votes[i].Addresses = copyAddresses(c.Votes[i].Addresses)
+	}
+	c.Tally = append([]TallyItem{}, c.Tally...)
+	for i := range c.Tally {
+		c.Tally[i].Addresses = copyAddresses(c.Tally[i].Addresses)
+	}
+	return c
+}
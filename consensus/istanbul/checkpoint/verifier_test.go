@@ -0,0 +1,286 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package checkpoint
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	istanbulCore "github.com/klaytn/klaytn/consensus/istanbul/core"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCouncil struct {
+	keys  []*ecdsa.PrivateKey
+	addrs []common.Address
+}
+
+func newTestCouncil(t *testing.T, n int) *testCouncil {
+	c := &testCouncil{}
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		assert.NoError(t, err)
+		c.keys = append(c.keys, key)
+		c.addrs = append(c.addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return c
+}
+
+// makeHeader makes a header proposed by proposer and committed by signers.
+func makeHeader(t *testing.T, number int64, parentHash common.Hash, vote []byte, proposer *ecdsa.PrivateKey, signers []*ecdsa.PrivateKey) *types.Header {
+	header := &types.Header{
+		ParentHash: parentHash,
+		Number:     big.NewInt(number),
+		BlockScore: big.NewInt(1),
+		Vote:       vote,
+	}
+	setExtra := func(extra *types.IstanbulExtra) {
+		payload, err := rlp.EncodeToBytes(extra)
+		assert.NoError(t, err)
+		header.Extra = append(make([]byte, types.IstanbulExtraVanity), payload...)
+	}
+
+	extra := &types.IstanbulExtra{}
+	setExtra(extra)
+	seal, err := crypto.Sign(crypto.Keccak256(sigHash(header).Bytes()), proposer)
+	assert.NoError(t, err)
+	extra.Seal = seal
+	setExtra(extra)
+
+	proposalSeal := crypto.Keccak256(istanbulCore.PrepareCommittedSeal(headerHash(header)))
+	for _, key := range signers {
+		committedSeal, err := crypto.Sign(proposalSeal, key)
+		assert.NoError(t, err)
+		extra.CommittedSeal = append(extra.CommittedSeal, committedSeal)
+	}
+	setExtra(extra)
+	return header
+}
+
+func encodeVote(t *testing.T, proposer common.Address, key string, value interface{}) []byte {
+	vote, err := rlp.EncodeToBytes(&struct {
+		Validator common.Address
+		Key       string
+		Value     interface{}
+	}{proposer, key, value})
+	assert.NoError(t, err)
+	return vote
+}
+
+func TestVerifyHeader(t *testing.T) {
+	c := newTestCouncil(t, 4)
+
+	// F is 1 with 4 validators, so 3 seals are required
+	assert.NoError(t, VerifyHeader(makeHeader(t, 1, common.Hash{}, nil, c.keys[0], c.keys[:3]), c.addrs, 21))
+	assert.Equal(t, errInsufficientSeals, VerifyHeader(makeHeader(t, 1, common.Hash{}, nil, c.keys[0], c.keys[:2]), c.addrs, 21))
+
+	// a seal from a non-member or a duplicated seal is invalid
+	outsider := newTestCouncil(t, 1)
+	assert.Equal(t, errInvalidCommittedSeals, VerifyHeader(makeHeader(t, 1, common.Hash{}, nil, c.keys[0], []*ecdsa.PrivateKey{c.keys[0], c.keys[1], outsider.keys[0]}), c.addrs, 21))
+	assert.Equal(t, errInvalidCommittedSeals, VerifyHeader(makeHeader(t, 1, common.Hash{}, nil, c.keys[0], []*ecdsa.PrivateKey{c.keys[0], c.keys[1], c.keys[0]}), c.addrs, 21))
+
+	// the proposer should be a member
+	assert.Equal(t, errUnauthorizedProposer, VerifyHeader(makeHeader(t, 1, common.Hash{}, nil, outsider.keys[0], c.keys[:3]), c.addrs, 21))
+
+	// with the committee size 1, F is 0
+	assert.NoError(t, VerifyHeader(makeHeader(t, 1, common.Hash{}, nil, c.keys[0], c.keys[:1]), c.addrs, 1))
+}
+
+// testChain makes the headers following a checkpoint.
+type testChain struct {
+	t       *testing.T
+	headers []*types.Header
+	last    uint64
+	hash    common.Hash
+}
+
+func newTestChain(t *testing.T, checkpoint Checkpoint) *testChain {
+	return &testChain{t: t, last: checkpoint.Number, hash: checkpoint.Hash}
+}
+
+// add appends a header proposed by proposer and committed by signers.
+func (c *testChain) add(vote []byte, proposer *ecdsa.PrivateKey, signers []*ecdsa.PrivateKey) *types.Header {
+	header := makeHeader(c.t, int64(c.last+1), c.hash, vote, proposer, signers)
+	c.headers = append(c.headers, header)
+	c.last, c.hash = c.last+1, headerHash(header)
+	return header
+}
+
+// proof returns the proof of the headers added since the last call.
+func (c *testChain) proof(checkpoint Checkpoint) *Proof {
+	proof := &Proof{Checkpoint: checkpoint, Headers: c.headers}
+	c.headers = nil
+	return proof
+}
+
+func newTestCheckpoint(validators []common.Address, mode string) Checkpoint {
+	return Checkpoint{
+		Number:         100,
+		Hash:           common.HexToHash("0x100"),
+		Validators:     validators,
+		CommitteeSize:  21,
+		Epoch:          30,
+		GovernanceMode: mode,
+		GoverningNode:  validators[0],
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	c := newTestCouncil(t, 5)
+	trusted := newTestCheckpoint(c.addrs[:4], "none")
+	verifier := NewVerifier(trusted)
+	chain := newTestChain(t, trusted)
+
+	// block 102 adds the 5th validator, so block 104 can be committed with its seal
+	chain.add(nil, c.keys[0], c.keys[:3])
+	chain.add(encodeVote(t, c.addrs[0], addValidatorKey, c.addrs[4]), c.keys[0], c.keys[:3])
+	chain.add(nil, c.keys[1], nil)
+	chain.add(nil, c.keys[4], c.keys[2:5])
+	assert.NoError(t, verifier.Verify(chain.proof(trusted)))
+
+	trusted = verifier.Checkpoint()
+	assert.Equal(t, uint64(104), trusted.Number)
+	assert.Equal(t, chain.hash, trusted.Hash)
+	assert.Equal(t, c.addrs, trusted.Validators)
+	assert.Len(t, trusted.Votes, 1)
+
+	// the next proof starts from the new checkpoint, and removes two validators at its target
+	chain.add(encodeVote(t, c.addrs[0], removeValidatorKey, []common.Address{c.addrs[3], c.addrs[4]}), c.keys[0], c.keys[1:5])
+	assert.NoError(t, verifier.Verify(chain.proof(trusted)))
+	assert.Equal(t, c.addrs[:3], verifier.Checkpoint().Validators)
+	assert.Len(t, verifier.Checkpoint().Votes, 2)
+}
+
+func TestVerifier_Ballot(t *testing.T) {
+	c := newTestCouncil(t, 5)
+	trusted := newTestCheckpoint(c.addrs[:4], "ballot")
+	addVote := func(voter int) []byte { return encodeVote(t, c.addrs[voter], addValidatorKey, c.addrs[4]) }
+
+	// two votes out of four validators do not pass, and a revote does not count twice
+	verifier := NewVerifier(trusted)
+	chain := newTestChain(t, trusted)
+	chain.add(addVote(0), c.keys[0], c.keys[:3])
+	chain.add(addVote(1), c.keys[1], c.keys[:3])
+	chain.add(addVote(1), c.keys[1], c.keys[:3])
+	assert.NoError(t, verifier.Verify(chain.proof(trusted)))
+	assert.Equal(t, c.addrs[:4], verifier.Checkpoint().Validators)
+	assert.Equal(t, []TallyItem{{Key: addValidatorKey, Addresses: c.addrs[4:5], Votes: 2000}}, verifier.Checkpoint().Tally)
+
+	// the seal of the 5th validator is not accepted until the change passes
+	next := verifier.Checkpoint()
+	chain.add(nil, c.keys[4], c.keys[2:5])
+	assert.Error(t, verifier.Verify(chain.proof(next)))
+
+	// the third vote passes
+	verifier = NewVerifier(trusted)
+	chain = newTestChain(t, trusted)
+	chain.add(addVote(0), c.keys[0], c.keys[:3])
+	chain.add(addVote(1), c.keys[1], c.keys[:3])
+	chain.add(addVote(2), c.keys[2], c.keys[:3])
+	chain.add(nil, c.keys[4], c.keys[2:5])
+	assert.NoError(t, verifier.Verify(chain.proof(trusted)))
+	assert.Equal(t, c.addrs, verifier.Checkpoint().Validators)
+
+	// the votes are cleared at an epoch block, so the third vote after it does not pass
+	verifier = NewVerifier(trusted)
+	chain = newTestChain(t, trusted)
+	chain.add(addVote(0), c.keys[0], c.keys[:3])
+	chain.add(addVote(1), c.keys[1], c.keys[:3])
+	for chain.last+1 < 120 {
+		chain.add(nil, c.keys[0], c.keys[:3])
+	}
+	chain.add(addVote(2), c.keys[2], c.keys[:3])
+	assert.NoError(t, verifier.Verify(chain.proof(trusted)))
+	assert.Equal(t, c.addrs[:4], verifier.Checkpoint().Validators)
+	assert.Len(t, verifier.Checkpoint().Votes, 1)
+}
+
+func TestVerifier_Single(t *testing.T) {
+	c := newTestCouncil(t, 5)
+	trusted := newTestCheckpoint(c.addrs[:4], "single")
+
+	// only the vote of the governing node passes
+	verifier := NewVerifier(trusted)
+	chain := newTestChain(t, trusted)
+	chain.add(encodeVote(t, c.addrs[1], addValidatorKey, c.addrs[4]), c.keys[1], c.keys[:3])
+	assert.NoError(t, verifier.Verify(chain.proof(trusted)))
+	assert.Equal(t, c.addrs[:4], verifier.Checkpoint().Validators)
+
+	next := verifier.Checkpoint()
+	chain.add(encodeVote(t, c.addrs[0], addValidatorKey, c.addrs[4]), c.keys[0], c.keys[:3])
+	assert.NoError(t, verifier.Verify(chain.proof(next)))
+	assert.Equal(t, c.addrs, verifier.Checkpoint().Validators)
+}
+
+func TestVerifier_VerifyInvalid(t *testing.T) {
+	c := newTestCouncil(t, 5)
+	trusted := newTestCheckpoint(c.addrs[:4], "none")
+
+	// without the vote, the seal of the 5th validator is not accepted
+	chain := newTestChain(t, trusted)
+	chain.add(nil, c.keys[0], c.keys[2:5])
+	assert.Error(t, NewVerifier(trusted).Verify(chain.proof(trusted)))
+
+	// a proof from another checkpoint
+	other := trusted
+	other.Hash = common.HexToHash("0x99")
+	chain = newTestChain(t, other)
+	chain.add(nil, c.keys[0], c.keys[:3])
+	assert.Equal(t, errCheckpointMismatch, NewVerifier(trusted).Verify(chain.proof(other)))
+
+	// a proof without a header
+	assert.Equal(t, errEmptyProof, NewVerifier(trusted).Verify(&Proof{Checkpoint: trusted}))
+
+	// a header not linked to the checkpoint, or a gap in the headers
+	chain = newTestChain(t, other)
+	chain.add(nil, c.keys[0], c.keys[:3])
+	assert.Equal(t, errBrokenChain, NewVerifier(trusted).Verify(chain.proof(trusted)))
+
+	chain = newTestChain(t, trusted)
+	chain.add(nil, c.keys[0], c.keys[:3])
+	chain.last++
+	chain.add(nil, c.keys[0], c.keys[:3])
+	assert.Equal(t, errBrokenChain, NewVerifier(trusted).Verify(chain.proof(trusted)))
+
+	// a forged vote in an unsealed header is detected by the parent hash of its descendant
+	chain = newTestChain(t, trusted)
+	chain.add(nil, c.keys[0], c.keys[:3])
+	chain.add(nil, c.keys[0], c.keys[:3])
+	proof := chain.proof(trusted)
+	forged := types.CopyHeader(proof.Headers[0])
+	forged.Vote = encodeVote(t, c.addrs[0], addValidatorKey, c.addrs[4])
+	proof.Headers[0] = forged
+	assert.Error(t, NewVerifier(trusted).Verify(proof))
+
+	// a vote on a key changing the tally is not replayed
+	chain = newTestChain(t, trusted)
+	chain.add(encodeVote(t, c.addrs[0], "governance.governancemode", "ballot"), c.keys[0], c.keys[:3])
+	assert.Error(t, NewVerifier(trusted).Verify(chain.proof(trusted)))
+
+	// a proof reaching a pending reconfiguration
+	reconfigured := trusted
+	reconfigured.NextReconfiguration = 102
+	chain = newTestChain(t, reconfigured)
+	chain.add(nil, c.keys[0], c.keys[:3])
+	chain.add(nil, c.keys[0], c.keys[:3])
+	assert.Equal(t, errReconfiguredRange, NewVerifier(reconfigured).Verify(chain.proof(reconfigured)))
+}
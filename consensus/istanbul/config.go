@@ -20,6 +20,13 @@
 
 package istanbul
 
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
 type ProposerPolicy uint64
 
 const (
@@ -29,18 +36,70 @@ const (
 )
 
 type Config struct {
-	Timeout        uint64         `toml:",omitempty"` // The timeout for each Istanbul round in milliseconds.
-	BlockPeriod    uint64         `toml:",omitempty"` // Default minimum difference between two consecutive block's timestamps in second
-	ProposerPolicy ProposerPolicy `toml:",omitempty"` // The policy for proposer selection
-	Epoch          uint64         `toml:",omitempty"` // The number of blocks after which to checkpoint and reset the pending votes
-	SubGroupSize   uint64         `toml:",omitempty"`
+	Timeout           uint64         `toml:",omitempty"` // The timeout for each Istanbul round in milliseconds.
+	TimeoutMultiplier uint64         `toml:",omitempty"` // The growth rate of the timeout per round in percent (after RoundTimeoutCompatibleBlock)
+	MaxTimeout        uint64         `toml:",omitempty"` // The upper bound of the round timeout in milliseconds (after RoundTimeoutCompatibleBlock)
+	BlockPeriod       uint64         `toml:",omitempty"` // Default minimum difference between two consecutive block's timestamps in second
+	ProposerPolicy    ProposerPolicy `toml:",omitempty"` // The policy for proposer selection
+	Epoch             uint64         `toml:",omitempty"` // The number of blocks after which to checkpoint and reset the pending votes
+	SubGroupSize      uint64         `toml:",omitempty"`
+
+	RemoteSigner        string `toml:",omitempty"` // The endpoint of the remote signer holding the validator key (IPC path or grpc://host:port)
+	RemoteSignerTLSCert string `toml:",omitempty"` // The client certificate file for the gRPC remote signer
+	RemoteSignerTLSKey  string `toml:",omitempty"` // The client key file for the gRPC remote signer
+	RemoteSignerTLSCA   string `toml:",omitempty"` // The CA certificate file verifying the gRPC remote signer
 }
 
 // TODO-Klaytn-Istanbul: Do not use DefaultConfig except for assigning new config
 var DefaultConfig = &Config{
-	Timeout:        10000,
-	BlockPeriod:    1,
-	ProposerPolicy: RoundRobin,
-	Epoch:          30000,
-	SubGroupSize:   21,
+	Timeout:           10000,
+	TimeoutMultiplier: 200,
+	MaxTimeout:        120000,
+	BlockPeriod:       1,
+	ProposerPolicy:    RoundRobin,
+	Epoch:             30000,
+	SubGroupSize:      21,
+}
+
+// RoundChangeTimeout returns the round change timeout of the given round.
+// Before RoundTimeoutCompatibleBlock, the timeout grows by 2^round seconds without an upper bound.
+// After that, it starts from Timeout, grows by TimeoutMultiplier percent every round and is capped by MaxTimeout.
+// Only integer arithmetic is used so that every validator derives the same schedule.
+func (c *Config) RoundChangeTimeout(round uint64, isRoundTimeoutFork bool) time.Duration {
+	base := atomic.LoadUint64(&c.Timeout)
+	if !isRoundTimeoutFork {
+		timeout := time.Duration(base) * time.Millisecond
+		if round > 0 {
+			timeout += time.Duration(math.Pow(2, float64(round))) * time.Second
+		}
+		return timeout
+	}
+
+	multiplier := atomic.LoadUint64(&c.TimeoutMultiplier)
+	maxTimeout := atomic.LoadUint64(&c.MaxTimeout)
+	return time.Duration(CalcRoundTimeout(base, multiplier, maxTimeout, round)) * time.Millisecond
+}
+
+// CalcRoundTimeout returns min(timeout * (multiplier/100)^round, maxTimeout) in milliseconds.
+// A maxTimeout of zero means there is no upper bound. The timeout never shrinks even if multiplier is below 100.
+func CalcRoundTimeout(timeout, multiplier, maxTimeout, round uint64) uint64 {
+	for i := uint64(0); i < round; i++ {
+		if maxTimeout != 0 && timeout >= maxTimeout {
+			break
+		}
+		hi, lo := bits.Mul64(timeout, multiplier)
+		if hi != 0 {
+			timeout = math.MaxUint64
+			break
+		}
+		next := lo / 100
+		if next <= timeout {
+			break
+		}
+		timeout = next
+	}
+	if maxTimeout != 0 && timeout > maxTimeout {
+		return maxTimeout
+	}
+	return timeout
 }
@@ -0,0 +1,62 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package istanbul
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcRoundTimeout(t *testing.T) {
+	testCases := []struct {
+		timeout, multiplier, maxTimeout, round uint64
+		expected                               uint64
+	}{
+		{10000, 200, 120000, 0, 10000},
+		{10000, 200, 120000, 1, 20000},
+		{10000, 200, 120000, 3, 80000},
+		{10000, 200, 120000, 4, 120000},
+		{10000, 200, 120000, 100, 120000},
+		{10000, 150, 0, 2, 22500},
+		{10000, 100, 120000, 10, 10000},
+		{10000, 50, 120000, 10, 10000},   // never shrinks
+		{200000, 200, 120000, 0, 120000}, // the base is capped as well
+		{math.MaxUint64 / 2, 300, 0, 1, math.MaxUint64},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, CalcRoundTimeout(tc.timeout, tc.multiplier, tc.maxTimeout, tc.round), tc)
+	}
+}
+
+func TestConfig_RoundChangeTimeout(t *testing.T) {
+	config := &Config{Timeout: 10000, TimeoutMultiplier: 200, MaxTimeout: 60000}
+
+	// before the hardfork, the legacy schedule is used
+	assert.Equal(t, 10*time.Second, config.RoundChangeTimeout(0, false))
+	assert.Equal(t, 12*time.Second, config.RoundChangeTimeout(1, false))
+	assert.Equal(t, 42*time.Second, config.RoundChangeTimeout(5, false))
+
+	// after the hardfork, the timeout grows exponentially up to MaxTimeout
+	assert.Equal(t, 10*time.Second, config.RoundChangeTimeout(0, true))
+	assert.Equal(t, 20*time.Second, config.RoundChangeTimeout(1, true))
+	assert.Equal(t, 40*time.Second, config.RoundChangeTimeout(2, true))
+	assert.Equal(t, 60*time.Second, config.RoundChangeTimeout(5, true))
+}
@@ -22,7 +22,6 @@ package core
 
 import (
 	"bytes"
-	"math"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -61,6 +60,9 @@ func New(backend istanbul.Backend, config *istanbul.Config) Engine {
 		councilSizeGauge:   metrics.NewRegisteredGauge("consensus/istanbul/core/councilSize", nil),
 		committeeSizeGauge: metrics.NewRegisteredGauge("consensus/istanbul/core/committeeSize", nil),
 		hashLockGauge:      metrics.NewRegisteredGauge("consensus/istanbul/core/hashLock", nil),
+
+		roundChangeTimeoutGauge: metrics.NewRegisteredGauge("consensus/istanbul/core/roundChangeTimeout", nil),
+		timeoutMeter:            metrics.NewRegisteredMeter("consensus/istanbul/core/timeout", nil),
 	}
 	c.validateFn = c.checkValidatorSignature
 	return c
@@ -106,6 +108,10 @@ type core struct {
 	consensusTimeGauge metrics.Gauge
 	// the gauge to record hashLock status (1 if hash-locked. 0 otherwise)
 	hashLockGauge metrics.Gauge
+	// the gauge to record the round change timeout of the current round in milliseconds
+	roundChangeTimeoutGauge metrics.Gauge
+	// the meter to record the rate of fired round change timeouts
+	timeoutMeter metrics.Meter
 
 	councilSizeGauge   metrics.Gauge
 	committeeSizeGauge metrics.Gauge
@@ -361,13 +367,12 @@ func (c *core) stopTimer() {
 func (c *core) newRoundChangeTimer() {
 	c.stopTimer()
 
-	// TODO-Klaytn-Istanbul: Replace &istanbul.DefaultConfig.Timeout to c.config.Timeout
+	// TODO-Klaytn-Istanbul: Replace istanbul.DefaultConfig to c.config
 	// set timeout based on the round number
-	timeout := time.Duration(atomic.LoadUint64(&istanbul.DefaultConfig.Timeout)) * time.Millisecond
 	round := c.current.Round().Uint64()
-	if round > 0 {
-		timeout += time.Duration(math.Pow(2, float64(round))) * time.Second
-	}
+	isRoundTimeoutFork := c.backend.IsRoundTimeoutForkEnabled(c.current.Sequence())
+	timeout := istanbul.DefaultConfig.RoundChangeTimeout(round, isRoundTimeoutFork)
+	c.roundChangeTimeoutGauge.Update(int64(timeout / time.Millisecond))
 
 	current := c.current
 	proposer := c.valSet.GetProposer()
@@ -402,6 +407,7 @@ func (c *core) newRoundChangeTimer() {
 			}
 		}
 
+		c.timeoutMeter.Mark(1)
 		c.sendEvent(timeoutEvent{&istanbul.View{
 			Sequence: current.sequence,
 			Round:    new(big.Int).Add(current.round, common.Big1),
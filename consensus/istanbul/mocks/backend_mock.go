@@ -221,6 +221,20 @@ func (mr *MockBackendMockRecorder) LastProposal() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastProposal", reflect.TypeOf((*MockBackend)(nil).LastProposal))
 }
 
+// IsRoundTimeoutForkEnabled mocks base method
+func (m *MockBackend) IsRoundTimeoutForkEnabled(arg0 *big.Int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRoundTimeoutForkEnabled", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsRoundTimeoutForkEnabled indicates an expected call of IsRoundTimeoutForkEnabled
+func (mr *MockBackendMockRecorder) IsRoundTimeoutForkEnabled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRoundTimeoutForkEnabled", reflect.TypeOf((*MockBackend)(nil).IsRoundTimeoutForkEnabled), arg0)
+}
+
 // NodeType mocks base method
 func (m *MockBackend) NodeType() common.ConnType {
 	m.ctrl.T.Helper()
@@ -0,0 +1,196 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	klaytngrpc "github.com/klaytn/klaytn/networks/grpc"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// GRPCScheme is the endpoint prefix selecting the gRPC transport. Other endpoints are IPC paths.
+	GRPCScheme = "grpc://"
+
+	// dialTimeout bounds connecting to the signer daemon
+	dialTimeout = 10 * time.Second
+	// signTimeout bounds a single signing request not to stall the consensus
+	signTimeout = 2 * time.Second
+)
+
+var errRequireTLS = errors.New("gRPC remote signer requires a mutual TLS configuration")
+
+type callFunc func(ctx context.Context, result interface{}, method string, args ...interface{}) error
+
+// RemoteSigner requests signatures to a signer daemon. It implements the Signer of the istanbul backend.
+type RemoteSigner struct {
+	address common.Address
+	call    callFunc
+	close   func()
+}
+
+// NewRemoteSigner connects to the signer daemon at the given endpoint.
+// An endpoint of the form "grpc://host:port" is connected over gRPC with the given TLS configuration,
+// and any other endpoint is regarded as the path of a Unix socket.
+func NewRemoteSigner(endpoint string, tlsConfig *tls.Config) (*RemoteSigner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	s := &RemoteSigner{}
+	if strings.HasPrefix(endpoint, GRPCScheme) {
+		if tlsConfig == nil {
+			return nil, errRequireTLS
+		}
+		conn, err := grpc.DialContext(ctx, strings.TrimPrefix(endpoint, GRPCScheme),
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), grpc.WithBlock())
+		if err != nil {
+			return nil, err
+		}
+		client := klaytngrpc.NewKlaytnNodeClient(conn)
+		s.call = func(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+			return grpcCall(ctx, client, result, method, args...)
+		}
+		s.close = func() { conn.Close() }
+	} else {
+		client, err := rpc.DialIPC(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		s.call = client.CallContext
+		s.close = client.Close
+	}
+
+	if err := s.call(ctx, &s.address, namespace+"_address"); err != nil {
+		s.close()
+		return nil, err
+	}
+	logger.Info("Connected to the remote signer", "endpoint", endpoint, "address", s.address)
+	return s, nil
+}
+
+// Address returns the address of the validator key held by the signer daemon.
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// Sign requests the signature of keccak256(data) made at the given view.
+// The returned signature is verified to be made by the validator key.
+func (s *RemoteSigner) Sign(view *istanbul.View, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	args := SignArgs{
+		Sequence: (*hexutil.Big)(view.Sequence),
+		Round:    (*hexutil.Big)(view.Round),
+		Data:     data,
+	}
+	var sig hexutil.Bytes
+	if err := s.call(ctx, &sig, namespace+"_sign", args); err != nil {
+		return nil, err
+	}
+
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(data), sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != s.address {
+		return nil, fmt.Errorf("remote signer returned a signature of %s, expected %s", signer.String(), s.address.String())
+	}
+	return sig, nil
+}
+
+// SignNodeID requests the signature of istanbul.NodeIDData of the p2p node ID.
+// The returned signature is verified to be made by the validator key.
+func (s *RemoteSigner) SignNodeID(nodeID []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	var sig hexutil.Bytes
+	if err := s.call(ctx, &sig, namespace+"_signNodeID", hexutil.Bytes(nodeID)); err != nil {
+		return nil, err
+	}
+	signer, err := istanbul.GetSignatureAddress(istanbul.NodeIDData(nodeID), sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer != s.address {
+		return nil, fmt.Errorf("remote signer returned a signature of %s, expected %s", signer.String(), s.address.String())
+	}
+	return sig, nil
+}
+
+// Close closes the connection to the signer daemon.
+func (s *RemoteSigner) Close() {
+	s.close()
+}
+
+// grpcCall sends a JSON-RPC request through KlaytnNode.Call of networks/grpc.
+func grpcCall(ctx context.Context, client klaytngrpc.KlaytnNodeClient, result interface{}, method string, args ...interface{}) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+	params, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	req, err := json.Marshal(&jsonRequest{Version: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Call(ctx, &klaytngrpc.RPCRequest{Service: namespace, Method: method, Params: req})
+	if err != nil {
+		return err
+	}
+
+	var msg jsonResponse
+	if err := json.Unmarshal(resp.Payload, &msg); err != nil {
+		return err
+	}
+	if msg.Error != nil {
+		return errors.New(msg.Error.Message)
+	}
+	return json.Unmarshal(msg.Result, result)
+}
+
+type jsonRequest struct {
+	Version string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type jsonResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
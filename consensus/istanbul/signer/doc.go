@@ -0,0 +1,61 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+Package signer implements a remote signer for the istanbul consensus engine.
+
+A consensus node (CN) normally signs istanbul messages, committed seals and block seals
+with its in-process nodekey. With a remote signer, the validator key is kept by a separate
+signer daemon (see cmd/ksigner) and the CN requests signatures over a local Unix socket or a
+mutually-authenticated gRPC connection. The validator address is taken from the signer, so the
+CN does not load the validator key, and its nodekey is used only as its p2p identity.
+
+# Protocol
+
+The signer daemon exposes a JSON-RPC service in the "signer" namespace.
+
+- signer_address : returns the address of the validator key.
+
+- signer_sign    : takes {sequence, round, data} and returns the signature of keccak256(data).
+
+- signer_signNodeID : takes the p2p node ID of the CN and returns the signature of
+keccak256("\x19Klaytn Validator Node ID:\n" || nodeID). The CN sends it in the handshake,
+so that its peers find it by the validator address instead of the address of its nodekey.
+
+Over a Unix socket, the requests are plain JSON-RPC messages. Over gRPC, the requests are
+carried by the KlaytnNode.Call method of networks/grpc, whose RPCRequest.Params holds the
+JSON-RPC request and RPCResponse.Payload holds the JSON-RPC response.
+
+# Double-signing guard
+
+The daemon keeps a persistent high-water mark of the signed views (ViewGuard). A request for
+a view older than the high-water mark is refused, so a restarted or failed-over CN cannot sign
+for a view it might have signed before. The daemon also decodes the istanbul messages and the
+committed seals it signs, and records their digests per (view in the message, message code).
+A different message of the same code for the same view is refused, so the validator cannot
+equivocate. The state is synced to the disk before a signature is released.
+
+# Files
+
+- guard.go  : ViewGuard, the persistent high-water mark of signed views and the signed digests
+
+- server.go : Server and the signer JSON-RPC API served by the signer daemon
+
+- client.go : RemoteSigner, the client used by the istanbul backend
+
+- tls.go    : helpers to build mutually-authenticated TLS configurations
+*/
+package signer
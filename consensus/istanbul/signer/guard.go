@@ -0,0 +1,265 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+// The codes of istanbul messages, c.f. consensus/istanbul/core/types.go
+const (
+	msgPreprepare uint64 = iota
+	msgPrepare
+	msgCommit
+	msgRoundChange
+)
+
+// kindCommittedSeal is the kind of a committed seal, which is not an istanbul message.
+const kindCommittedSeal = 0xff
+
+var (
+	errOldView      = errors.New("refused to sign for a view older than the high-water mark")
+	errEquivocation = errors.New("refused to sign a different message for an already signed view")
+)
+
+// signedRecord is the digest of a message signed for a view. A message of the same kind
+// for the same view must have the same digest, otherwise the validator equivocates.
+type signedRecord struct {
+	Sequence *big.Int    `json:"sequence"`
+	Round    *big.Int    `json:"round"`
+	Kind     uint64      `json:"kind"`
+	Digest   common.Hash `json:"digest"`
+}
+
+func (r *signedRecord) view() *istanbul.View {
+	return &istanbul.View{Sequence: r.Sequence, Round: r.Round}
+}
+
+// highWaterMark is the on-disk format of ViewGuard.
+type highWaterMark struct {
+	Sequence *big.Int       `json:"sequence"`
+	Round    *big.Int       `json:"round"`
+	Signed   []signedRecord `json:"signed,omitempty"`
+}
+
+// ViewGuard keeps the highest view signed so far and refuses to sign for an older view.
+// It also keeps the digests of the istanbul messages and committed seals signed for the
+// views of the current sequence, and refuses to sign a different one of the same kind for
+// the same view. The state is persisted before a signature is released.
+type ViewGuard struct {
+	path   string
+	view   *istanbul.View
+	signed []signedRecord
+	mu     sync.Mutex
+}
+
+// NewViewGuard loads the high-water mark from the given file.
+// If the file does not exist, the guard starts from the zero view.
+func NewViewGuard(path string) (*ViewGuard, error) {
+	g := &ViewGuard{
+		path: path,
+		view: &istanbul.View{Sequence: new(big.Int), Round: new(big.Int)},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return g, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hwm highWaterMark
+	if err := json.Unmarshal(data, &hwm); err != nil {
+		return nil, err
+	}
+	if hwm.Sequence == nil || hwm.Round == nil {
+		return nil, errors.New("malformed high-water mark file")
+	}
+	for _, record := range hwm.Signed {
+		if record.Sequence == nil || record.Round == nil {
+			return nil, errors.New("malformed high-water mark file")
+		}
+	}
+	g.view = &istanbul.View{Sequence: hwm.Sequence, Round: hwm.Round}
+	g.signed = hwm.Signed
+	return g, nil
+}
+
+// View returns a copy of the current high-water mark.
+func (g *ViewGuard) View() *istanbul.View {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return &istanbul.View{
+		Sequence: new(big.Int).Set(g.view.Sequence),
+		Round:    new(big.Int).Set(g.view.Round),
+	}
+}
+
+// Check returns an error if the view is older than the high-water mark, or if the data is
+// an istanbul message or a committed seal conflicting with the one already signed.
+// Otherwise, it raises the high-water mark to the view, records the digest of the data,
+// and persists them.
+func (g *ViewGuard) Check(view *istanbul.View, data []byte) error {
+	if view == nil || view.Sequence == nil || view.Round == nil {
+		return errors.New("view is not given")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if view.Cmp(g.view) < 0 {
+		return errOldView
+	}
+
+	record := newSignedRecord(view, data)
+	if record != nil {
+		if record.Sequence.Cmp(g.view.Sequence) < 0 {
+			return errOldView
+		}
+		for _, signed := range g.signed {
+			if signed.Kind != record.Kind || signed.view().Cmp(record.view()) != 0 {
+				continue
+			}
+			if signed.Digest != record.Digest {
+				return errEquivocation
+			}
+			// The same message is signed again
+			record = nil
+			break
+		}
+	}
+	if record == nil && view.Cmp(g.view) == 0 {
+		return nil
+	}
+
+	next := &istanbul.View{
+		Sequence: new(big.Int).Set(view.Sequence),
+		Round:    new(big.Int).Set(view.Round),
+	}
+	// The records of the previous sequences are dropped, since no message is signed for them anymore
+	signed := make([]signedRecord, 0, len(g.signed)+1)
+	for _, s := range g.signed {
+		if s.Sequence.Cmp(next.Sequence) >= 0 {
+			signed = append(signed, s)
+		}
+	}
+	if record != nil {
+		signed = append(signed, *record)
+	}
+	if err := g.write(next, signed); err != nil {
+		return err
+	}
+	g.view = next
+	g.signed = signed
+	return nil
+}
+
+// newSignedRecord returns the record of the data if it is an istanbul message or a committed seal.
+// The view of an istanbul message is the one in the message, because a round change message is
+// signed for the next round. Other data, such as a block seal, is guarded by the view only,
+// since it cannot finalize a block without the committed seals.
+func newSignedRecord(view *istanbul.View, data []byte) *signedRecord {
+	// c.f. PrepareCommittedSeal of consensus/istanbul/core
+	if len(data) == common.HashLength+1 && uint64(data[common.HashLength]) == msgCommit {
+		return &signedRecord{
+			Sequence: new(big.Int).Set(view.Sequence),
+			Round:    new(big.Int).Set(view.Round),
+			Kind:     kindCommittedSeal,
+			Digest:   crypto.Keccak256Hash(data),
+		}
+	}
+
+	// c.f. message of consensus/istanbul/core, whose payload starts with the view
+	var msg struct {
+		Hash          common.Hash
+		Code          uint64
+		Msg           []byte
+		Address       common.Address
+		Signature     []byte
+		CommittedSeal []byte
+	}
+	if err := rlp.DecodeBytes(data, &msg); err != nil || msg.Code > msgRoundChange {
+		return nil
+	}
+	var payload struct {
+		View *istanbul.View
+		Rest []rlp.RawValue `rlp:"tail"`
+	}
+	if err := rlp.DecodeBytes(msg.Msg, &payload); err != nil || payload.View == nil ||
+		payload.View.Sequence == nil || payload.View.Round == nil {
+		return nil
+	}
+	return &signedRecord{
+		Sequence: payload.View.Sequence,
+		Round:    payload.View.Round,
+		Kind:     msg.Code,
+		Digest:   crypto.Keccak256Hash(data),
+	}
+}
+
+// write atomically replaces the high-water mark file, and syncs it to the disk.
+func (g *ViewGuard) write(view *istanbul.View, signed []signedRecord) error {
+	data, err := json.Marshal(&highWaterMark{Sequence: view.Sequence, Round: view.Round, Signed: signed})
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(g.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp := g.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, g.path); err != nil {
+		return err
+	}
+
+	// Sync the directory so that the rename survives a crash
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
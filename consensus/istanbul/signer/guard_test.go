@@ -0,0 +1,133 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newView(seq, round int64) *istanbul.View {
+	return &istanbul.View{Sequence: big.NewInt(seq), Round: big.NewInt(round)}
+}
+
+func TestViewGuard_Check(t *testing.T) {
+	dir, err := ioutil.TempDir("", "klaytn-signer-guard")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hwm.json")
+	guard, err := NewViewGuard(path)
+	assert.NoError(t, err)
+
+	data := []byte("block seal")
+	assert.NoError(t, guard.Check(newView(10, 0), data))
+	assert.NoError(t, guard.Check(newView(10, 0), data))
+	assert.NoError(t, guard.Check(newView(10, 2), data))
+	assert.Equal(t, errOldView, guard.Check(newView(10, 1), data))
+	assert.Equal(t, errOldView, guard.Check(newView(9, 5), data))
+	assert.NoError(t, guard.Check(newView(11, 0), data))
+	assert.Error(t, guard.Check(nil, data))
+
+	// the high-water mark survives a restart
+	reloaded, err := NewViewGuard(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reloaded.View().Cmp(newView(11, 0)))
+	assert.Equal(t, errOldView, reloaded.Check(newView(10, 2), data))
+}
+
+// encodeMessage returns the data signed for an istanbul message, c.f. message.PayloadNoSig of consensus/istanbul/core.
+func encodeMessage(t *testing.T, code uint64, view *istanbul.View, digest common.Hash) []byte {
+	subject, err := rlp.EncodeToBytes(&istanbul.Subject{View: view, Digest: digest})
+	require.NoError(t, err)
+	data, err := rlp.EncodeToBytes([]interface{}{common.Hash{}, code, subject, common.Address{}, []byte{}, []byte{}})
+	require.NoError(t, err)
+	return data
+}
+
+func TestViewGuard_Equivocation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "klaytn-signer-guard")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hwm.json")
+	guard, err := NewViewGuard(path)
+	require.NoError(t, err)
+
+	var (
+		view       = newView(10, 0)
+		blockA     = common.HexToHash("0xa")
+		blockB     = common.HexToHash("0xb")
+		prepareA   = encodeMessage(t, msgPrepare, view, blockA)
+		prepareB   = encodeMessage(t, msgPrepare, view, blockB)
+		commitA    = encodeMessage(t, msgCommit, view, blockA)
+		sealA      = append(blockA.Bytes(), byte(msgCommit))
+		sealB      = append(blockB.Bytes(), byte(msgCommit))
+		nextRound  = newView(10, 1)
+		roundChg1  = encodeMessage(t, msgRoundChange, nextRound, common.Hash{})
+		roundChg2  = encodeMessage(t, msgRoundChange, newView(10, 2), common.Hash{})
+		prepareB11 = encodeMessage(t, msgPrepare, nextRound, blockB)
+	)
+
+	// Messages of different codes for the same view, and the same message again
+	assert.NoError(t, guard.Check(view, prepareA))
+	assert.NoError(t, guard.Check(view, prepareA))
+	assert.NoError(t, guard.Check(view, sealA))
+	assert.NoError(t, guard.Check(view, commitA))
+
+	// A different message of the same code for the same view
+	assert.Equal(t, errEquivocation, guard.Check(view, prepareB))
+	assert.Equal(t, errEquivocation, guard.Check(view, sealB))
+
+	// Round changes are signed for the following rounds at the current view
+	assert.NoError(t, guard.Check(view, roundChg1))
+	assert.NoError(t, guard.Check(view, roundChg2))
+
+	// The signed digests survive a restart
+	reloaded, err := NewViewGuard(path)
+	require.NoError(t, err)
+	assert.Equal(t, errEquivocation, reloaded.Check(view, prepareB))
+	assert.NoError(t, reloaded.Check(nextRound, prepareB11))
+	assert.NoError(t, reloaded.Check(nextRound, sealB))
+
+	// The records are dropped in the next sequence, and a message of an old sequence is refused
+	assert.NoError(t, reloaded.Check(newView(11, 0), encodeMessage(t, msgPrepare, newView(11, 0), blockA)))
+	for _, record := range reloaded.signed {
+		assert.Equal(t, int64(11), record.Sequence.Int64())
+	}
+	assert.Equal(t, errOldView, reloaded.Check(newView(11, 0), prepareB))
+}
+
+func TestViewGuard_MalformedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "klaytn-signer-guard")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hwm.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{}"), 0o600))
+	_, err = NewViewGuard(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/log"
+	klaytngrpc "github.com/klaytn/klaytn/networks/grpc"
+	"github.com/klaytn/klaytn/networks/rpc"
+)
+
+const namespace = "signer"
+
+var logger = log.NewModuleLogger(log.ConsensusIstanbulSigner)
+
+// SignArgs represents the arguments of signer_sign.
+type SignArgs struct {
+	Sequence *hexutil.Big  `json:"sequence"`
+	Round    *hexutil.Big  `json:"round"`
+	Data     hexutil.Bytes `json:"data"`
+}
+
+// Server holds the validator key and serves the signer API.
+type Server struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+	guard      *ViewGuard
+
+	handler      *rpc.Server
+	ipcListener  net.Listener
+	grpcListener *klaytngrpc.Listener
+}
+
+// NewServer creates a signer server which signs with the given key, guarded by the given ViewGuard.
+func NewServer(privateKey *ecdsa.PrivateKey, guard *ViewGuard) (*Server, error) {
+	if guard == nil {
+		return nil, errors.New("double-signing guard is not given")
+	}
+	s := &Server{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		guard:      guard,
+		handler:    rpc.NewServer(),
+	}
+	if err := s.handler.RegisterName(namespace, &PrivateSignerAPI{s}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Address returns the address of the validator key.
+func (s *Server) Address() common.Address {
+	return s.address
+}
+
+// sign signs the keccak256 hash of data if the double-signing guard allows it.
+func (s *Server) sign(view *istanbul.View, data []byte) ([]byte, error) {
+	if err := s.guard.Check(view, data); err != nil {
+		logger.Warn("Refused to sign", "view", view, "highWaterMark", s.guard.View(), "err", err)
+		return nil, err
+	}
+	return crypto.Sign(crypto.Keccak256(data), s.privateKey)
+}
+
+// ServeIPC serves the signer API over the Unix socket at the given path.
+// The socket is only accessible by the owner of the process.
+func (s *Server) ServeIPC(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return err
+	}
+	s.ipcListener = listener
+	go s.handler.ServeListener(listener)
+	logger.Info("Signer IPC endpoint opened", "path", path, "address", s.address)
+	return nil
+}
+
+// ServeGRPC serves the signer API over gRPC at the given address. It blocks until the server stops.
+// The tlsConfig should require and verify client certificates (see NewServerTLSConfig).
+func (s *Server) ServeGRPC(addr string, tlsConfig *tls.Config) {
+	s.grpcListener = &klaytngrpc.Listener{Addr: addr, TLSConfig: tlsConfig}
+	s.grpcListener.SetRPCServer(s.handler)
+	logger.Info("Signer gRPC endpoint opened", "addr", addr, "address", s.address, "tls", tlsConfig != nil)
+	s.grpcListener.Start()
+}
+
+// Stop closes all endpoints.
+func (s *Server) Stop() {
+	if s.ipcListener != nil {
+		s.ipcListener.Close()
+	}
+	if s.grpcListener != nil {
+		s.grpcListener.Stop()
+	}
+	s.handler.Stop()
+}
+
+// PrivateSignerAPI is the signer API served by the signer daemon.
+type PrivateSignerAPI struct {
+	s *Server
+}
+
+// Address returns the address of the validator key.
+func (api *PrivateSignerAPI) Address() common.Address {
+	return api.s.address
+}
+
+// Sign returns the signature of keccak256(data) made at the given consensus view.
+func (api *PrivateSignerAPI) Sign(args SignArgs) (hexutil.Bytes, error) {
+	if args.Sequence == nil || args.Round == nil {
+		return nil, errors.New("view is not given")
+	}
+	view := &istanbul.View{
+		Sequence: args.Sequence.ToInt(),
+		Round:    args.Round.ToInt(),
+	}
+	return api.s.sign(view, args.Data)
+}
+
+// SignNodeID returns the signature of istanbul.NodeIDData of the p2p node ID.
+// It is not guarded by the views, since the prefix of the data keeps it from being
+// taken for an istanbul message or a seal.
+func (api *PrivateSignerAPI) SignNodeID(nodeID hexutil.Bytes) (hexutil.Bytes, error) {
+	return crypto.Sign(crypto.Keccak256(istanbul.NodeIDData(nodeID)), api.s.privateKey)
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSigner_IPC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "klaytn-signer")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key, _ := crypto.GenerateKey()
+	guard, err := NewViewGuard(filepath.Join(dir, "hwm.json"))
+	assert.NoError(t, err)
+	server, err := NewServer(key, guard)
+	assert.NoError(t, err)
+
+	endpoint := filepath.Join(dir, "signer.ipc")
+	assert.NoError(t, server.ServeIPC(endpoint))
+	defer server.Stop()
+
+	client, err := NewRemoteSigner(endpoint, nil)
+	assert.NoError(t, err)
+	defer client.Close()
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), client.Address())
+
+	data := []byte("istanbul message")
+	sig, err := client.Sign(newView(5, 1), data)
+	assert.NoError(t, err)
+	expected, _ := crypto.Sign(crypto.Keccak256(data), key)
+	assert.Equal(t, expected, sig)
+
+	// the same view can be signed again, but not an older one
+	_, err = client.Sign(newView(5, 1), data)
+	assert.NoError(t, err)
+	_, err = client.Sign(newView(5, 0), data)
+	assert.Error(t, err)
+
+	// the node ID is signed regardless of the views
+	nodeID := crypto.FromECDSAPub(&key.PublicKey)[1:]
+	sig, err = client.SignNodeID(nodeID)
+	assert.NoError(t, err)
+	signer, err := istanbul.GetSignatureAddress(istanbul.NodeIDData(nodeID), sig)
+	assert.NoError(t, err)
+	assert.Equal(t, client.Address(), signer)
+}
+
+func TestNewRemoteSigner_GRPCRequiresTLS(t *testing.T) {
+	_, err := NewRemoteSigner(GRPCScheme+"127.0.0.1:0", nil)
+	assert.Equal(t, errRequireTLS, err)
+}
@@ -30,6 +30,16 @@ import (
 
 var logger = log.NewModuleLogger(log.ConsensusIstanbul)
 
+// nodeIDPrefix is prepended to a signed node ID, not to be taken for an istanbul message or a seal.
+var nodeIDPrefix = []byte("\x19Klaytn Validator Node ID:\n")
+
+// NodeIDData returns the data a validator signs to bind the p2p node ID of its node to the
+// validator address, when the validator key is kept apart from the nodekey.
+// The signer is recovered by GetSignatureAddress.
+func NodeIDData(nodeID []byte) []byte {
+	return append(append([]byte{}, nodeIDPrefix...), nodeID...)
+}
+
 func RLPHash(v interface{}) (h common.Hash) {
 	hw := sha3.NewKeccak256()
 	rlp.Encode(hw, v)
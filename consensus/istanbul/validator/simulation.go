@@ -0,0 +1,169 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package validator
+
+import (
+	"errors"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
+)
+
+var (
+	errNoSimulationNode         = errors.New("no node is given for the simulation")
+	errDuplicatedSimulationNode = errors.New("duplicated node address")
+	errZeroProposerInterval     = errors.New("proposer update interval should be positive")
+	errNotProposerUpdateBlock   = errors.New("block number should be a multiple of the proposer update interval")
+	errInvalidStartBlock        = errors.New("start block should be after the proposer update block")
+)
+
+// SimulationNode is a council member of a hypothetical staking status.
+type SimulationNode struct {
+	NodeAddr      common.Address `json:"nodeAddr"`
+	RewardAddr    common.Address `json:"rewardAddr"`
+	StakingAmount uint64         `json:"stakingAmount"` // in KLAY
+}
+
+// ProposerSimulationConfig is a hypothetical staking status and governance parameters
+// under which the proposer selection is simulated.
+type ProposerSimulationConfig struct {
+	Nodes                  []SimulationNode
+	Council                []common.Address // council members among Nodes; all Nodes if empty
+	UseGini                bool
+	MinimumStake           uint64 // in KLAY
+	ProposerUpdateInterval uint64
+	IsSingle               bool           // true if the governance mode is single
+	GoverningNode          common.Address // the governing node, used only if IsSingle
+
+	BlockNumber uint64      // the block where the proposers are refreshed; a multiple of ProposerUpdateInterval
+	Seed        common.Hash // the hash of the block BlockNumber
+	StartBlock  uint64      // the first block to simulate the proposer of; BlockNumber+1 if zero
+	Rules       params.Rules
+}
+
+// ProposerSimulation is the result of SimulateProposers.
+type ProposerSimulation struct {
+	Validators        []common.Address           `json:"validators"`
+	DemotedValidators []common.Address           `json:"demotedValidators"`
+	Gini              float64                    `json:"gini"`
+	Weights           map[common.Address]uint64  `json:"weights"`
+	Proposers         []common.Address           `json:"proposers"`  // the shuffled proposers list refreshed at BlockNumber
+	StartBlock        uint64                     `json:"startBlock"` // the block proposed by Order[0]
+	Order             []common.Address           `json:"order"`      // proposers of the blocks from StartBlock at round 0
+	Counts            map[common.Address]uint64  `json:"counts"`     // number of proposals in Order
+	Shares            map[common.Address]float64 `json:"shares"`     // expected proposal share
+}
+
+// SimulateProposers runs the proposer selection of weightedCouncil under the given config.
+// It returns the proposers of the blocks StartBlock to StartBlock+numBlocks-1 at round 0.
+// Proposers refreshed after BlockNumber depend on the hash of a future block, so the
+// proposers list refreshed at BlockNumber is used for all the following blocks.
+func SimulateProposers(config *ProposerSimulationConfig, numBlocks uint64) (*ProposerSimulation, error) {
+	if len(config.Nodes) == 0 {
+		return nil, errNoSimulationNode
+	}
+	if config.ProposerUpdateInterval == 0 {
+		return nil, errZeroProposerInterval
+	}
+	if config.BlockNumber%config.ProposerUpdateInterval != 0 {
+		return nil, errNotProposerUpdateBlock
+	}
+	startBlock := config.StartBlock
+	if startBlock == 0 {
+		startBlock = config.BlockNumber + 1
+	}
+	if startBlock <= config.BlockNumber {
+		return nil, errInvalidStartBlock
+	}
+
+	stakingInfo := &reward.StakingInfo{BlockNum: config.BlockNumber, UseGini: config.UseGini}
+	var addrs []common.Address
+	seen := make(map[common.Address]bool)
+	for _, node := range config.Nodes {
+		if seen[node.NodeAddr] {
+			return nil, errDuplicatedSimulationNode
+		}
+		seen[node.NodeAddr] = true
+		if len(config.Council) == 0 {
+			addrs = append(addrs, node.NodeAddr)
+		}
+
+		stakingInfo.CouncilNodeAddrs = append(stakingInfo.CouncilNodeAddrs, node.NodeAddr)
+		stakingInfo.CouncilStakingAddrs = append(stakingInfo.CouncilStakingAddrs, common.Address{})
+		stakingInfo.CouncilRewardAddrs = append(stakingInfo.CouncilRewardAddrs, node.RewardAddr)
+		stakingInfo.CouncilStakingAmounts = append(stakingInfo.CouncilStakingAmounts, node.StakingAmount)
+	}
+
+	if len(config.Council) > 0 {
+		addrs = append(addrs, config.Council...)
+	}
+
+	votingPowers := make([]uint64, len(addrs))
+	for i := range votingPowers {
+		votingPowers[i] = 1000
+	}
+	valSet := NewWeightedCouncil(addrs, nil, nil, votingPowers, nil, istanbul.WeightedRandom, 0, config.BlockNumber, 0, nil)
+	if valSet == nil {
+		return nil, errors.New("failed to create a council")
+	}
+
+	seed, err := seedFromHash(config.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	weightedValidators, stakingAmounts, err := valSet.refreshValidators(stakingInfo, config.Rules, config.IsSingle, config.GoverningNode, config.MinimumStake)
+	if err != nil {
+		return nil, err
+	}
+	gini := refreshWeights(weightedValidators, stakingAmounts, stakingInfo, config.Rules)
+	valSet.refreshProposers(seed, config.BlockNumber)
+
+	result := &ProposerSimulation{
+		StartBlock: startBlock,
+		Gini:       gini,
+		Weights:    make(map[common.Address]uint64),
+		Counts:     make(map[common.Address]uint64),
+		Shares:     make(map[common.Address]float64),
+	}
+	for _, val := range valSet.validators {
+		result.Validators = append(result.Validators, val.Address())
+		result.Weights[val.Address()] = val.Weight()
+	}
+	for _, val := range valSet.demotedValidators {
+		result.DemotedValidators = append(result.DemotedValidators, val.Address())
+	}
+	slots := make(map[common.Address]int)
+	for _, proposer := range valSet.proposers {
+		result.Proposers = append(result.Proposers, proposer.Address())
+		slots[proposer.Address()]++
+	}
+	for addr, n := range slots {
+		result.Shares[addr] = float64(n) / float64(len(valSet.proposers))
+	}
+
+	// The proposer of a block is picked by the council of its parent block
+	for num := startBlock - 1; num < startBlock-1+numBlocks; num++ {
+		picker := weightedRandomProposerIndex(num, 0, config.ProposerUpdateInterval, len(valSet.proposers))
+		proposer := valSet.proposers[picker].Address()
+		result.Order = append(result.Order, proposer)
+		result.Counts[proposer]++
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSimulationConfig(amounts []uint64) *ProposerSimulationConfig {
+	config := &ProposerSimulationConfig{
+		MinimumStake:           5000000,
+		ProposerUpdateInterval: 100,
+		BlockNumber:            1000,
+		Seed:                   common.HexToHash("0x1c9f4b5b6a7e3d2c1b0a9f8e7d6c5b4a3928171605f4e3d2c1b0a9f8e7d6c5b4"),
+		Rules:                  params.Rules{IsIstanbul: true},
+	}
+	for i, amount := range amounts {
+		config.Nodes = append(config.Nodes, SimulationNode{
+			NodeAddr:      testAddrs[i],
+			RewardAddr:    testRewardAddrs[i],
+			StakingAmount: amount,
+		})
+	}
+	return config
+}
+
+func TestSimulateProposers(t *testing.T) {
+	config := newSimulationConfig([]uint64{5000000, 5000000, 10000000, 1000000})
+
+	result, err := SimulateProposers(config, 100)
+	assert.NoError(t, err)
+
+	// the node staking less than the minimum is demoted
+	assert.Equal(t, []common.Address{testAddrs[3]}, result.DemotedValidators)
+	assert.Equal(t, 3, len(result.Validators))
+
+	assert.Equal(t, uint64(25), result.Weights[testAddrs[0]])
+	assert.Equal(t, uint64(25), result.Weights[testAddrs[1]])
+	assert.Equal(t, uint64(50), result.Weights[testAddrs[2]])
+	assert.Equal(t, 0.5, result.Shares[testAddrs[2]])
+
+	// all proposers are used once during an interval
+	assert.Equal(t, 100, len(result.Order))
+	assert.Equal(t, result.Proposers, result.Order)
+	assert.Equal(t, uint64(50), result.Counts[testAddrs[2]])
+
+	// the order is the same as the proposer selection of weightedCouncil
+	valSet := NewWeightedCouncil(result.Validators, nil, nil, []uint64{1000, 1000, 1000}, nil, istanbul.WeightedRandom, 0, 0, 0, nil)
+	valSet.proposers = nil
+	for _, addr := range result.Proposers {
+		_, val := valSet.GetByAddress(addr)
+		valSet.proposers = append(valSet.proposers, val)
+	}
+	params.SetProposerUpdateInterval(config.ProposerUpdateInterval)
+	defer params.SetProposerUpdateInterval(params.DefaultProposerRefreshInterval)
+	assert.Equal(t, config.BlockNumber+1, result.StartBlock)
+	for i, proposer := range result.Order {
+		valSet.SetBlockNum(config.BlockNumber + uint64(i))
+		assert.Equal(t, proposer, weightedRandomProposer(valSet, common.Address{}, 0).Address())
+	}
+
+	// The simulation from a later block, such as the head+1, is aligned with the full order
+	config.StartBlock = config.BlockNumber + 31
+	later, err := SimulateProposers(config, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, config.StartBlock, later.StartBlock)
+	assert.Equal(t, result.Order[30:80], later.Order)
+
+	config.StartBlock = config.BlockNumber
+	_, err = SimulateProposers(config, 10)
+	assert.Equal(t, errInvalidStartBlock, err)
+}
+
+func TestSimulateProposers_Kore(t *testing.T) {
+	config := newSimulationConfig([]uint64{5000000, 5000000, 10000000})
+	config.Rules.IsKore = true
+
+	result, err := SimulateProposers(config, 30)
+	assert.NoError(t, err)
+	for _, addr := range result.Validators {
+		assert.Equal(t, uint64(0), result.Weights[addr])
+		assert.Equal(t, uint64(10), result.Counts[addr])
+	}
+}
+
+func TestSimulateProposers_InvalidConfig(t *testing.T) {
+	config := newSimulationConfig(nil)
+	_, err := SimulateProposers(config, 10)
+	assert.Equal(t, errNoSimulationNode, err)
+
+	config = newSimulationConfig([]uint64{5000000})
+	config.Nodes = append(config.Nodes, config.Nodes[0])
+	_, err = SimulateProposers(config, 10)
+	assert.Equal(t, errDuplicatedSimulationNode, err)
+
+	config = newSimulationConfig([]uint64{5000000})
+	config.BlockNumber = 1001
+	_, err = SimulateProposers(config, 10)
+	assert.Equal(t, errNotProposerUpdateBlock, err)
+
+	config.ProposerUpdateInterval = 0
+	_, err = SimulateProposers(config, 10)
+	assert.Equal(t, errZeroProposerInterval, err)
+}
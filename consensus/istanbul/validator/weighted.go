@@ -280,7 +280,7 @@ func weightedRandomProposer(valSet istanbul.ValidatorSet, lastProposer common.Ad
 	// At Refresh(), proposers is already randomly shuffled considering weights.
 	// So let's just round robin this array
 	blockNum := weightedCouncil.blockNum
-	picker := (blockNum + round - params.CalcProposerBlockNumber(blockNum+1)) % uint64(numProposers)
+	picker := weightedRandomProposerIndex(blockNum, round, params.ProposerUpdateInterval(), numProposers)
 	proposer := weightedCouncil.proposers[picker]
 
 	// Enable below more detailed log when debugging
@@ -289,6 +289,11 @@ func weightedRandomProposer(valSet istanbul.ValidatorSet, lastProposer common.Ad
 	return proposer
 }
 
+// weightedRandomProposerIndex returns the index in proposers of the proposer for block blockNum+1 at the given round.
+func weightedRandomProposerIndex(blockNum uint64, round uint64, proposerInterval uint64, numProposers int) uint64 {
+	return (blockNum + round - params.CalcProposerBlockNumberWithInterval(blockNum+1, proposerInterval)) % uint64(numProposers)
+}
+
 func (valSet *weightedCouncil) Size() uint64 {
 	valSet.validatorMu.RLock()
 	defer valSet.validatorMu.RUnlock()
@@ -610,6 +615,15 @@ func (valSet *weightedCouncil) F() int {
 
 func (valSet *weightedCouncil) Policy() istanbul.ProposerPolicy { return valSet.policy }
 
+// seedFromHash converts a block hash to the seed used to shuffle proposers.
+func seedFromHash(hash common.Hash) (int64, error) {
+	hashString := strings.TrimPrefix(hash.Hex(), "0x")
+	if len(hashString) > 15 {
+		hashString = hashString[:15]
+	}
+	return strconv.ParseInt(hashString, 16, 64)
+}
+
 // Refresh recalculates up-to-date proposers only when blockNum is the proposer update interval.
 // It returns an error if it can't make up-to-date proposers
 //   (1) due toe wrong parameters
@@ -628,11 +642,7 @@ func (valSet *weightedCouncil) Refresh(hash common.Hash, blockNum uint64, config
 		return errors.New("No validator")
 	}
 
-	hashString := strings.TrimPrefix(hash.Hex(), "0x")
-	if len(hashString) > 15 {
-		hashString = hashString[:15]
-	}
-	seed, err := strconv.ParseInt(hashString, 16, 64)
+	seed, err := seedFromHash(hash)
 	if err != nil {
 		return err
 	}
@@ -642,37 +652,21 @@ func (valSet *weightedCouncil) Refresh(hash common.Hash, blockNum uint64, config
 		// Just return without updating proposer
 		return errors.New("skip refreshing proposers due to no staking info")
 	}
-	valSet.stakingInfo = newStakingInfo
 
 	blockNumBig := new(big.Int).SetUint64(blockNum)
 	chainRules := config.Rules(blockNumBig)
 
-	candidates := append(valSet.validators, valSet.demotedValidators...)
-	weightedValidators, stakingAmounts, err := getStakingAmountsOfValidators(candidates, newStakingInfo)
+	weightedValidators, stakingAmounts, err := valSet.refreshValidators(newStakingInfo, chainRules, isSingle, governingNode, minStaking)
 	if err != nil {
 		return err
 	}
 
-	if chainRules.IsIstanbul {
-		var demotedValidators []*weightedValidator
-
-		weightedValidators, stakingAmounts, demotedValidators, _ = filterValidators(isSingle, governingNode, weightedValidators, stakingAmounts, minStaking)
-		valSet.setValidators(weightedValidators, demotedValidators)
-	}
-
 	if valSet.proposersBlockNum == blockNum {
 		// proposers are already refreshed
 		return nil
 	}
 
-	// weight and gini were neutralized after Kore hard fork
-	if chainRules.IsKore {
-		setZeroWeight(weightedValidators)
-	} else {
-		totalStaking, _ := calcTotalAmount(weightedValidators, newStakingInfo, stakingAmounts)
-		calcWeight(weightedValidators, stakingAmounts, totalStaking)
-	}
-
+	refreshWeights(weightedValidators, stakingAmounts, newStakingInfo, chainRules)
 	valSet.refreshProposers(seed, blockNum)
 
 	logger.Debug("Refresh done.", "blockNum", blockNum, "hash", hash, "valSet.blockNum", valSet.blockNum, "stakingInfo.BlockNum", valSet.stakingInfo.BlockNum)
@@ -681,6 +675,39 @@ func (valSet *weightedCouncil) Refresh(hash common.Hash, blockNum uint64, config
 	return nil
 }
 
+// refreshValidators applies the given staking information to the validators and the demoted validators.
+// After Istanbul hard fork, the validators are divided again by the minimum amount of staking.
+// It returns the validators and their staking amounts, which are used to calculate weights.
+func (valSet *weightedCouncil) refreshValidators(stakingInfo *reward.StakingInfo, chainRules params.Rules, isSingle bool, governingNode common.Address, minStaking uint64) ([]*weightedValidator, []float64, error) {
+	valSet.stakingInfo = stakingInfo
+
+	candidates := append(valSet.validators, valSet.demotedValidators...)
+	weightedValidators, stakingAmounts, err := getStakingAmountsOfValidators(candidates, stakingInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if chainRules.IsIstanbul {
+		var demotedValidators []*weightedValidator
+
+		weightedValidators, stakingAmounts, demotedValidators, _ = filterValidators(isSingle, governingNode, weightedValidators, stakingAmounts, minStaking)
+		valSet.setValidators(weightedValidators, demotedValidators)
+	}
+	return weightedValidators, stakingAmounts, nil
+}
+
+// refreshWeights updates the weights of validators and returns the gini coefficient reflected to them.
+func refreshWeights(weightedValidators []*weightedValidator, stakingAmounts []float64, stakingInfo *reward.StakingInfo, chainRules params.Rules) float64 {
+	// weight and gini were neutralized after Kore hard fork
+	if chainRules.IsKore {
+		setZeroWeight(weightedValidators)
+		return reward.DefaultGiniCoefficient
+	}
+	totalStaking, gini := calcTotalAmount(weightedValidators, stakingInfo, stakingAmounts)
+	calcWeight(weightedValidators, stakingAmounts, totalStaking)
+	return gini
+}
+
 // setValidators converts weighted validator slice to istanbul.Validators and sets them to the council.
 func (valSet *weightedCouncil) setValidators(validators []*weightedValidator, demoted []*weightedValidator) {
 	var (
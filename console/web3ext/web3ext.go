@@ -54,6 +54,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'signTypedData_v4',
+			call: 'eth_signTypedData_v4',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'resend',
 			call: 'eth_resend',
@@ -320,6 +326,12 @@ web3._extend({
 			call: 'governance_chainConfigAt',
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getParamHistory',
+			call: 'governance_getParamHistory',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
 		})
 	],
 	properties: [
@@ -351,6 +363,10 @@ web3._extend({
 			name: 'pendingChanges',
 			getter: 'governance_pendingChanges',
 		}),
+		new web3._extend.Property({
+			name: 'paramUpdates',
+			getter: 'governance_paramUpdates',
+		}),
 		new web3._extend.Property({
 			name: 'votes',
 			getter: 'governance_votes',
@@ -434,6 +450,10 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'backfillTotalSupply',
+			call: 'admin_backfillTotalSupply',
+		}),
 		new web3._extend.Method({
 			name: 'startStateMigration',
 			call: 'admin_startStateMigration',
@@ -513,6 +533,12 @@ web3._extend({
 			call: 'debug_dumpBlock',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'checkTotalSupply',
+			call: 'debug_checkTotalSupply',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'dumpStateTrie',
 			call: 'debug_dumpStateTrie',
@@ -872,12 +898,29 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'signTypedData',
+			call: 'klay_signTypedData',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'resend',
 			call: 'klay_resend',
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter, web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'speedUpTransaction',
+			call: 'klay_speedUpTransaction',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'cancelTransaction',
+			call: 'klay_cancelTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'signTransaction',
 			call: 'klay_signTransaction',
@@ -920,6 +963,11 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'simulateProposers',
+			call: 'klay_simulateProposers',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'gasPriceAt',
 			call: 'klay_gasPriceAt',
@@ -933,6 +981,24 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'getTotalSupply',
+			call: 'klay_getTotalSupply',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getRewardsByAddress',
+			call: 'klay_getRewardsByAddress',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getRewardsRange',
+			call: 'klay_getRewardsRange',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'accountCreated',
 			call: 'klay_accountCreated'
@@ -997,6 +1063,18 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'verifyMessage',
+			call: 'klay_verifyMessage',
+			params: 5,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'verifyTypedData',
+			call: 'klay_verifyTypedData',
+			params: 5,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransactionFromBlock',
 			call: function(args) {
@@ -1043,6 +1121,11 @@ web3._extend({
 			call: 'klay_decodeAccountKey',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'encodeSessionAccountKey',
+			call: 'klay_encodeSessionAccountKey',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'createAccessList',
 			call: 'klay_createAccessList',
@@ -1055,6 +1138,17 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'feeHistoryDetailed',
+			call: 'klay_feeHistoryDetailed',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'predictBaseFee',
+			call: 'klay_predictBaseFee',
+			params: 2,
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -1134,12 +1228,35 @@ web3._extend({
 			call: 'personal_replaceRawKey',
 			params: 3
 		}),
+		new web3._extend.Method({
+			name: 'importMnemonic',
+			call: 'personal_importMnemonic',
+			params: 3
+		}),
+		new web3._extend.Method({
+			name: 'deriveAccounts',
+			call: 'personal_deriveAccounts',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'deriveRoleBasedAccount',
+			call: 'personal_deriveRoleBasedAccount',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'sign',
 			call: 'personal_sign',
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputAddressFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'signTypedData',
+			call: 'personal_signTypedData',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputAddressFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'ecRecover',
 			call: 'personal_ecRecover',
@@ -1265,6 +1382,18 @@ web3._extend({
 			name: 'discard',
 			call: 'istanbul_discard',
 			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getCheckpointProof',
+			call: 'istanbul_getCheckpointProof',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'previewCommittee',
+			call: 'istanbul_previewCommittee',
+			params: 2,
+			inputFormatter: [null, null]
 		})
 	],
 	properties:
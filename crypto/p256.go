@@ -0,0 +1,167 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"filippo.io/nistec"
+	"github.com/klaytn/klaytn/common"
+)
+
+var (
+	p256N, _  = new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	p256halfN = new(big.Int).Div(p256N, big.NewInt(2))
+
+	errInvalidP256Signature = errors.New("invalid P-256 signature")
+	errInvalidP256Pubkey    = errors.New("invalid P-256 public key")
+)
+
+// P256 returns the NIST P-256 (secp256r1) curve used by passkeys and WebAuthn authenticators.
+func P256() elliptic.Curve {
+	return elliptic.P256()
+}
+
+// IsP256 returns true if the public key is on the P-256 curve.
+func IsP256(pub *ecdsa.PublicKey) bool {
+	return pub != nil && pub.Curve != nil && pub.Curve.Params().Name == P256().Params().Name
+}
+
+// GenerateP256Key generates a new P-256 private key.
+func GenerateP256Key() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(P256(), rand.Reader)
+}
+
+// CompressP256Pubkey encodes a P-256 public key to the 33-byte compressed format.
+func CompressP256Pubkey(pub *ecdsa.PublicKey) []byte {
+	return elliptic.MarshalCompressed(P256(), pub.X, pub.Y)
+}
+
+// DecompressP256Pubkey parses a P-256 public key in the 33-byte compressed format.
+func DecompressP256Pubkey(pubkey []byte) (*ecdsa.PublicKey, error) {
+	x, y := elliptic.UnmarshalCompressed(P256(), pubkey)
+	if x == nil {
+		return nil, errInvalidP256Pubkey
+	}
+	return &ecdsa.PublicKey{Curve: P256(), X: x, Y: y}, nil
+}
+
+// ValidateP256SignatureValues verifies whether the signature values are valid for P-256.
+// As the transaction signatures, the upper range of s values is rejected to prevent malleability.
+func ValidateP256SignatureValues(v byte, r, s *big.Int) bool {
+	if r.Cmp(common.Big1) < 0 || s.Cmp(common.Big1) < 0 {
+		return false
+	}
+	return r.Cmp(p256N) < 0 && s.Cmp(p256halfN) <= 0 && (v == 0 || v == 1)
+}
+
+// VerifyP256 checks that the signature values r and s of the hash are made by the P-256 public key.
+// Unlike ValidateP256SignatureValues, the whole range of s values is allowed as WebAuthn authenticators do.
+func VerifyP256(pub *ecdsa.PublicKey, hash []byte, r, s *big.Int) bool {
+	if !IsP256(pub) || !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+	return ecdsa.Verify(pub, hash, r, s)
+}
+
+// SignP256 calculates a P-256 ECDSA signature in the [R || S || V] format where V is 0 or 1.
+// The signature has a low s value, and V is the recovery id used by RecoverP256.
+func SignP256(hash []byte, prv *ecdsa.PrivateKey) ([]byte, error) {
+	if len(hash) != DigestLength {
+		return nil, fmt.Errorf("hash is required to be exactly %d bytes (%d)", DigestLength, len(hash))
+	}
+	if !IsP256(&prv.PublicKey) {
+		return nil, errInvalidP256Pubkey
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, prv, hash)
+	if err != nil {
+		return nil, err
+	}
+	if s.Cmp(p256halfN) > 0 {
+		s.Sub(p256N, s)
+	}
+
+	sig := make([]byte, SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[RecoveryIDOffset] = v
+		if pub, err := RecoverP256(hash, sig); err == nil && pub.X.Cmp(prv.X) == 0 && pub.Y.Cmp(prv.Y) == 0 {
+			return sig, nil
+		}
+	}
+	return nil, errInvalidP256Signature
+}
+
+// RecoverP256 returns the P-256 public key that created the signature in the [R || S || V] format.
+// V is the parity of the y coordinate of the point R.
+// The point arithmetic is done in constant time by the nistec package.
+func RecoverP256(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	if len(sig) != SignatureLength {
+		return nil, errInvalidP256Signature
+	}
+	var (
+		r = new(big.Int).SetBytes(sig[:32])
+		s = new(big.Int).SetBytes(sig[32:64])
+		v = sig[RecoveryIDOffset]
+	)
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(p256N) >= 0 || s.Cmp(p256N) >= 0 || v > 1 {
+		return nil, errInvalidP256Signature
+	}
+
+	// Recover the point R from its x coordinate r and the parity of its y coordinate v
+	compressed := make([]byte, 33)
+	compressed[0] = 0x02 | v
+	copy(compressed[1:], sig[:32])
+	rPoint, err := nistec.NewP256Point().SetBytes(compressed)
+	if err != nil {
+		return nil, errInvalidP256Signature
+	}
+
+	// Q = r^-1 * (s*R - e*G)
+	rInv := new(big.Int).ModInverse(r, p256N)
+	e := new(big.Int).SetBytes(hash)
+	u1 := new(big.Int).Mul(e, rInv)
+	u1.Neg(u1).Mod(u1, p256N)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, p256N)
+
+	p1, err := nistec.NewP256Point().ScalarBaseMult(u1.FillBytes(make([]byte, 32)))
+	if err != nil {
+		return nil, errInvalidP256Signature
+	}
+	p2, err := nistec.NewP256Point().ScalarMult(rPoint, u2.FillBytes(make([]byte, 32)))
+	if err != nil {
+		return nil, errInvalidP256Signature
+	}
+	q := p1.Add(p1, p2).Bytes()
+	// The point at infinity is encoded as a single zero byte
+	if len(q) != 65 {
+		return nil, errInvalidP256Signature
+	}
+
+	pub := &ecdsa.PublicKey{Curve: P256(), X: new(big.Int).SetBytes(q[1:33]), Y: new(big.Int).SetBytes(q[33:])}
+	if !ecdsa.Verify(pub, hash, r, s) {
+		return nil, errInvalidP256Signature
+	}
+	return pub, nil
+}
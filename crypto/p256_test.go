@@ -0,0 +1,128 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignP256(t *testing.T) {
+	key, err := GenerateP256Key()
+	require.NoError(t, err)
+	assert.True(t, IsP256(&key.PublicKey))
+
+	for i := 0; i < 20; i++ {
+		hash := Keccak256([]byte{byte(i)})
+		sig, err := SignP256(hash, key)
+		require.NoError(t, err)
+		require.Len(t, sig, SignatureLength)
+
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+		assert.True(t, ValidateP256SignatureValues(sig[RecoveryIDOffset], r, s))
+		assert.True(t, VerifyP256(&key.PublicKey, hash, r, s))
+
+		pub, err := RecoverP256(hash, sig)
+		require.NoError(t, err)
+		assert.Equal(t, key.X, pub.X)
+		assert.Equal(t, key.Y, pub.Y)
+
+		// The wrong recovery id does not recover the signer
+		sig[RecoveryIDOffset] ^= 1
+		if pub, err := RecoverP256(hash, sig); err == nil {
+			assert.NotEqual(t, key.X, pub.X)
+		}
+	}
+
+	// A secp256k1 key cannot sign P-256 signatures
+	s256Key, _ := GenerateKey()
+	_, err = SignP256(Keccak256([]byte{0}), s256Key)
+	assert.Error(t, err)
+	assert.False(t, IsP256(&s256Key.PublicKey))
+}
+
+func TestValidateP256SignatureValues(t *testing.T) {
+	one := big.NewInt(1)
+	assert.True(t, ValidateP256SignatureValues(0, one, one))
+	assert.True(t, ValidateP256SignatureValues(1, one, p256halfN))
+	assert.False(t, ValidateP256SignatureValues(2, one, one))
+	assert.False(t, ValidateP256SignatureValues(0, big.NewInt(0), one))
+	assert.False(t, ValidateP256SignatureValues(0, one, big.NewInt(0)))
+	assert.False(t, ValidateP256SignatureValues(0, p256N, one))
+	// The upper range of s values is not allowed
+	assert.False(t, ValidateP256SignatureValues(0, one, new(big.Int).Add(p256halfN, one)))
+}
+
+func TestCompressP256Pubkey(t *testing.T) {
+	key, _ := GenerateP256Key()
+	compressed := CompressP256Pubkey(&key.PublicKey)
+	assert.Len(t, compressed, 33)
+
+	pub, err := DecompressP256Pubkey(compressed)
+	require.NoError(t, err)
+	assert.True(t, IsP256(pub))
+	assert.Equal(t, key.X, pub.X)
+	assert.Equal(t, key.Y, pub.Y)
+
+	_, err = DecompressP256Pubkey(compressed[:32])
+	assert.Error(t, err)
+}
+
+func TestRecoverP256InvalidSignature(t *testing.T) {
+	key, _ := GenerateP256Key()
+	hash := Keccak256([]byte("hello"))
+	sig, err := SignP256(hash, key)
+	require.NoError(t, err)
+
+	withRS := func(r, s *big.Int) []byte {
+		b := make([]byte, SignatureLength)
+		copy(b, sig)
+		r.FillBytes(b[:32])
+		s.FillBytes(b[32:64])
+		return b
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	testcases := map[string][]byte{
+		"short signature": sig[:SignatureLength-1],
+		"invalid v":       append(append([]byte{}, sig[:64]...), 2),
+		"zero r":          withRS(big.NewInt(0), s),
+		"zero s":          withRS(r, big.NewInt(0)),
+		"r equal to N":    withRS(p256N, s),
+		"s equal to N":    withRS(r, p256N),
+		"r above N":       withRS(maxUint256, s),
+		"s above N":       withRS(r, maxUint256),
+		// There is no point on the curve whose x coordinate is 1, since 1 - 3 + b is not a square
+		"r not on curve": withRS(big.NewInt(1), s),
+	}
+	for name, tc := range testcases {
+		_, err := RecoverP256(hash, tc)
+		assert.Equal(t, errInvalidP256Signature, err, name)
+	}
+
+	// A public key not on the curve is not verified nor decompressed
+	offCurve := &ecdsa.PublicKey{Curve: P256(), X: key.X, Y: new(big.Int).Add(key.Y, big.NewInt(1))}
+	assert.False(t, VerifyP256(offCurve, hash, r, s))
+
+	_, err = DecompressP256Pubkey(append([]byte{0x02}, big.NewInt(1).FillBytes(make([]byte, 32))...))
+	assert.Error(t, err)
+}
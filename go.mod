@@ -3,6 +3,7 @@ module github.com/klaytn/klaytn
 go 1.15
 
 require (
+	filippo.io/nistec v0.0.3
 	github.com/Shopify/sarama v1.26.4
 	github.com/VictoriaMetrics/fastcache v1.6.0
 	github.com/alecthomas/units v0.0.0-20151022065526-2efee857e7cf
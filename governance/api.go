@@ -61,6 +61,7 @@ var (
 	errInvalidKeyValue        = errors.New("Your vote couldn't be placed. Please check your vote's key and value")
 	errInvalidLowerBound      = errors.New("lowerboundbasefee cannot be set exceeding upperboundbasefee")
 	errInvalidUpperBound      = errors.New("upperboundbasefee cannot be set lower than lowerboundbasefee")
+	errReconfigurationBlock   = errors.New("reconfiguration cannot be voted before the reconfiguration hardfork")
 )
 
 // GasPriceAt returns the base fee of the given block in peb,
@@ -112,7 +113,11 @@ func (api *GovernanceKlayAPI) GetRewards(num *rpc.BlockNumber) (*reward.RewardSp
 		return nil, err
 	}
 
-	return reward.GetBlockReward(header, rules, pset)
+	policy, err := reward.NewRewardPolicy(api.chain.Config())
+	if err != nil {
+		return nil, err
+	}
+	return reward.GetBlockReward(policy, header, rules, pset)
 }
 
 // Vote injects a new vote for governance targets such as unitprice and governingnode.
@@ -132,6 +137,11 @@ func (api *PublicGovernanceAPI) Vote(key string, val interface{}) (string, error
 			return "", errRemoveSelf
 		}
 	}
+	if vote.Key == "governance.reconfiguration" {
+		if chain := api.governance.BlockChain(); chain == nil || !chain.Config().IsReconfigurationForkEnabled(new(big.Int).Add(chain.CurrentHeader().Number, common.Big1)) {
+			return "", errReconfigurationBlock
+		}
+	}
 	if vote.Key == "kip71.lowerboundbasefee" {
 		if vote.Value.(uint64) > api.governance.Params().UpperBoundBaseFee() {
 			return "", errInvalidLowerBound
@@ -195,6 +205,20 @@ func (api *PublicGovernanceAPI) ItemsAt(num *rpc.BlockNumber) (map[string]interf
 	return pset.StrMap(), nil
 }
 
+// GetParamHistory returns the changes of the parameter `key` which took effect in (fromBlock, toBlock],
+// with the votes of header governance or the transaction of contract governance.
+// At most 10 changes are returned at once. If the history is truncated, the rest can be read from its toBlock.
+func (api *PublicGovernanceAPI) GetParamHistory(key string, fromBlock, toBlock rpc.BlockNumber) (*ParamHistory, error) {
+	head := api.governance.BlockChain().CurrentHeader().Number.Uint64()
+	blockNumber := func(num rpc.BlockNumber) uint64 {
+		if num == rpc.LatestBlockNumber || num == rpc.PendingBlockNumber {
+			return head
+		}
+		return uint64(num.Int64())
+	}
+	return api.governance.ParamHistory(strings.ToLower(key), blockNumber(fromBlock), blockNumber(toBlock))
+}
+
 func (api *PublicGovernanceAPI) GetStakingInfo(num *rpc.BlockNumber) (*reward.StakingInfo, error) {
 	blockNumber := uint64(0)
 	if num == nil || *num == rpc.LatestBlockNumber || *num == rpc.PendingBlockNumber {
@@ -209,6 +233,12 @@ func (api *PublicGovernanceAPI) PendingChanges() map[string]interface{} {
 	return api.governance.PendingChanges()
 }
 
+// ParamUpdates returns the changes scheduled by timelock and not activated yet,
+// and the recent changes applied to the current params since the node started.
+func (api *PublicGovernanceAPI) ParamUpdates() *ParamUpdates {
+	return api.governance.ParamUpdates()
+}
+
 func (api *PublicGovernanceAPI) Votes() []GovernanceVote {
 	return api.governance.Votes()
 }
@@ -60,6 +60,25 @@ func (c *contractCaller) getAllParamsAt(num *big.Int) (*params.GovParamSet, erro
 	return pset, nil
 }
 
+// getCheckpoints returns all the checkpoints of the parameter `name` stored in the contract.
+func (c *contractCaller) getCheckpoints(name string) ([]govcontract.IGovParamParam, error) {
+	tx, evm, err := c.prepareCall(govParamAbi, "checkpoints", name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.callTx(tx, evm)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints := new([]govcontract.IGovParamParam)
+	if err := govParamAbi.Unpack(checkpoints, "checkpoints", res); err != nil {
+		return nil, err
+	}
+	return *checkpoints, nil
+}
+
 func (c *contractCaller) prepareCall(contractAbi abi.ABI, fn string, args ...interface{}) (*types.Transaction, *vm.EVM, error) {
 	tx, err := c.makeTx(contractAbi, fn, args...)
 	if err != nil {
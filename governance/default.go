@@ -27,6 +27,7 @@ import (
 	"sync/atomic"
 
 	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/params"
 	"github.com/klaytn/klaytn/rlp"
@@ -72,6 +73,10 @@ var (
 		"governance.removevalidator":      params.RemoveValidator,
 		"param.txgashumanreadable":        params.ConstTxGasHumanReadable,
 		"istanbul.timeout":                params.Timeout,
+		"istanbul.timeoutmultiplier":      params.TimeoutMultiplier,
+		"istanbul.maxtimeout":             params.MaxTimeout,
+		"governance.reconfiguration":      params.Reconfiguration,
+		"governance.timelock":             params.Timelock,
 	}
 
 	GovernanceForbiddenKeyMap = map[string]int{
@@ -106,7 +111,11 @@ var (
 		params.RemoveValidator:           "governance.removevalidator",
 		params.ConstTxGasHumanReadable:   "param.txgashumanreadable",
 		params.Timeout:                   "istanbul.timeout",
+		params.TimeoutMultiplier:         "istanbul.timeoutmultiplier",
+		params.MaxTimeout:                "istanbul.maxtimeout",
 		params.Kip82Ratio:                "reward.kip82ratio",
+		params.Reconfiguration:           "governance.reconfiguration",
+		params.Timelock:                  "governance.timelock",
 	}
 
 	ProposerPolicyMap = map[string]int{
@@ -192,6 +201,9 @@ type Governance struct {
 	idxCache     []uint64 // elements should be in ascending order
 	idxCacheLock *sync.RWMutex
 
+	// The decoded scheduled changes by the block storing them
+	timelockCache common.Cache
+
 	// The block number when current governance information was changed
 	actualGovernanceBlock atomic.Value // uint64
 
@@ -203,6 +215,8 @@ type Governance struct {
 	currentParams *params.GovParamSet // equals to currentSet
 	initialParams *params.GovParamSet // equals to initial ChainConfig
 
+	chainConfig *params.ChainConfig // for the hardfork blocks, nil if not initialized with a ChainConfig
+
 	TxPool txPool
 
 	blockChain blockChain
@@ -430,6 +444,7 @@ func NewGovernance(dbm database.DBManager) *Governance {
 		GovernanceTallies:        NewGovernanceTallies(),
 		GovernanceVotes:          NewGovernanceVotes(),
 		idxCacheLock:             new(sync.RWMutex),
+		timelockCache:            newGovernanceCache(),
 	}
 }
 
@@ -437,6 +452,7 @@ func NewGovernance(dbm database.DBManager) *Governance {
 // If any items are not stored in DB, it stores governance items of the genesis block to DB.
 func NewGovernanceInitialize(chainConfig *params.ChainConfig, dbm database.DBManager) *Governance {
 	ret := NewGovernance(dbm)
+	ret.chainConfig = chainConfig
 	// nil is for testing or simple function usage
 	if dbm != nil {
 		ret.ReadGovernanceState()
@@ -463,6 +479,14 @@ func (g *Governance) updateGovernanceParams() {
 	if v, ok := g.Params().Get(params.ConstTxGasHumanReadable); ok {
 		params.TxGasHumanReadable = v.(uint64)
 	}
+
+	// The round change timeout schedule must be the same among validators
+	if v, ok := g.Params().Get(params.TimeoutMultiplier); ok {
+		atomic.StoreUint64(&istanbul.DefaultConfig.TimeoutMultiplier, v.(uint64))
+	}
+	if v, ok := g.Params().Get(params.MaxTimeout); ok {
+		atomic.StoreUint64(&istanbul.DefaultConfig.MaxTimeout, v.(uint64))
+	}
 }
 
 func (g *Governance) SetNodeAddress(addr common.Address) {
@@ -554,7 +578,7 @@ func (g *Governance) ParseVoteValue(gVote *GovernanceVote) (*GovernanceVote, err
 	}
 
 	switch k {
-	case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio, params.Kip82Ratio:
+	case params.GovernanceMode, params.MintingAmount, params.MinimumStake, params.Ratio, params.Kip82Ratio, params.Reconfiguration, params.Timelock:
 		v, ok := gVote.Value.([]uint8)
 		if !ok {
 			return nil, ErrValueTypeMismatch
@@ -588,6 +612,7 @@ func (g *Governance) ParseVoteValue(gVote *GovernanceVote) (*GovernanceVote, err
 		}
 	case params.Epoch, params.CommitteeSize, params.UnitPrice, params.DeriveShaImpl, params.StakeUpdateInterval,
 		params.ProposerRefreshInterval, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
+		params.TimeoutMultiplier, params.MaxTimeout,
 		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
 		v, ok := gVote.Value.([]uint8)
 		if !ok {
@@ -622,11 +647,12 @@ func (gov *Governance) updateChangeSet(vote GovernanceVote) bool {
 	case params.GoverningNode, params.GovParamContract:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(common.Address))
 		return true
-	case params.GovernanceMode, params.Ratio, params.Kip82Ratio:
+	case params.GovernanceMode, params.Ratio, params.Kip82Ratio, params.Timelock:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(string))
 		return true
 	case params.Epoch, params.StakeUpdateInterval, params.ProposerRefreshInterval, params.CommitteeSize,
 		params.UnitPrice, params.DeriveShaImpl, params.ConstTxGasHumanReadable, params.Policy, params.Timeout,
+		params.TimeoutMultiplier, params.MaxTimeout,
 		params.LowerBoundBaseFee, params.UpperBoundBaseFee, params.GasTarget, params.MaxBlockGasUsedForBaseFee, params.BaseFeeDenominator:
 		gov.changeSet.SetValue(GovernanceKeyMap[vote.Key], vote.Value.(uint64))
 		return true
@@ -869,6 +895,10 @@ func (gov *Governance) WriteGovernanceForNextEpoch(number uint64, governance []b
 
 	// Store updated governance information if exist
 	if number%epoch == 0 {
+		// The changes scheduled at this block by a rewound or reorganized header are not used anymore
+		if len(governance) == 0 {
+			gov.handleTimelock(number, nil)
+		}
 		if len(governance) > 0 {
 			tempData := []byte("")
 			tempItems := make(map[string]interface{})
@@ -883,9 +913,14 @@ func (gov *Governance) WriteGovernanceForNextEpoch(number uint64, governance []b
 
 			}
 			tempItems = adjustDecodedSet(tempItems)
+
+			// An approved timelock is stored apart from the governance items
+			timelockKey := GovernanceKeyMapReverse[params.Timelock]
+			gov.handleTimelock(number, tempItems[timelockKey])
+			delete(tempItems, timelockKey)
 			tempSet.Import(tempItems)
 
-			_, govItems, err := gov.ReadGovernance(number)
+			govNum, govItems, err := gov.ReadGovernance(number)
 			if err != nil {
 				logger.Error("Failed to read governance", "number", number, "err", err)
 				return
@@ -893,6 +928,10 @@ func (gov *Governance) WriteGovernanceForNextEpoch(number uint64, governance []b
 			govSet := NewGovernanceSet()
 			govSet.Import(govItems)
 
+			// Merge the changes scheduled before the new items take effect.
+			// The votes of this epoch are newer, so they override the scheduled changes.
+			govSet.Merge(gov.scheduledItems(number, governanceEffectiveBlock(govNum, epoch), number+epoch-1))
+
 			// Store new governance items for next epoch to governance database
 			if err := gov.WriteGovernance(number, govSet, tempSet); err != nil {
 				logger.Crit("Failed to store new governance data", "number", number, "err", err)
@@ -1182,8 +1221,17 @@ func (gov *Governance) CurrentSetCopy() map[string]interface{} {
 	return gov.currentSet.Items()
 }
 
+// PendingChanges returns the changes to be written in the next epoch block,
+// and the scheduled changes not activated yet under "governance.scheduledchanges".
 func (gov *Governance) PendingChanges() map[string]interface{} {
-	return gov.changeSet.Items()
+	items := gov.changeSet.Items()
+	if gov.blockChain != nil {
+		head := gov.blockChain.CurrentHeader().Number.Uint64()
+		if pending := gov.pendingScheduledChanges(head); len(pending) > 0 {
+			items[scheduledChangesKey] = pending
+		}
+	}
+	return items
 }
 
 func (gov *Governance) Votes() []GovernanceVote {
@@ -1230,11 +1278,14 @@ func (gov *Governance) ParamsAt(num uint64) (*params.GovParamSet, error) {
 
 	// Should be equivalent to Governance.ReadGovernance(), but without in-memory caches.
 	// Not using in-memory caches to make it stateless, hence less error-prone.
-	_, strMap, err := gov.db.ReadGovernanceAtNumber(num, epoch)
+	idx, strMap, err := gov.db.ReadGovernanceAtNumber(num, epoch)
 	if err != nil {
 		logger.Error("ReadGovernanceAtNumber failed", "num", num, "err", err)
 		return nil, err
 	}
+	for k, v := range gov.scheduledItems(num, governanceEffectiveBlock(idx, epoch), num) {
+		strMap[k] = v
+	}
 	pset, err := params.NewGovParamSetStrMap(strMap)
 	if err != nil {
 		logger.Error("NewGovParamSetStrMap failed", "num", num, "err", err)
@@ -1245,6 +1296,16 @@ func (gov *Governance) ParamsAt(num uint64) (*params.GovParamSet, error) {
 
 func (gov *Governance) UpdateParams() error {
 	strMap := gov.currentSet.Items()
+
+	// Apply the scheduled changes activated until the upcoming block
+	if gov.blockChain != nil {
+		idx, _ := gov.actualGovernanceBlock.Load().(uint64)
+		from := governanceEffectiveBlock(idx, gov.epochWithFallback())
+		head := gov.blockChain.CurrentHeader().Number.Uint64()
+		for k, v := range gov.scheduledItems(head+1, from, head+1) {
+			strMap[k] = v
+		}
+	}
 	pset, err := params.NewGovParamSetStrMap(strMap)
 	if err != nil {
 		return err
@@ -33,6 +33,8 @@ Following keys can be handled as of 7/20/2019.
   - "governance.unitprice"        : To change the unitprice of Klaytn (Unit price is same as gasprice in Ethereum)
   - "governance.addvalidator"     : To add new node as a council node
   - "governance.removevalidator"  : To remove a node from the governance council
+  - "governance.reconfiguration"  : To add or remove council nodes and resize the committee at a specified block
+  - "governance.timelock"         : To schedule parameter changes at a specified block, or to cancel them before the block, from the timelock hardfork
   - "istanbul.epoch"              : To change Epoch, the period to gather votes
   - "istanbul.committeesize"      : To change the size of the committee
   - "reward.mintingamount"        : To change the amount of block generation reward
@@ -63,6 +65,10 @@ Governance related functions and variables are defined in the files listed below
   - api.go        : console APIs to get governance information and to cast a vote
   - interface.go  : Abstract interfaces to various underlying implementations
   - mixed.go      : Wrapper for multiple engine implementations
+  - reconfiguration.go : scheduled reconfiguration of the council and the committee size
+  - param_history.go : history of a parameter merged from header and contract governance
+  - proposal.go   : offline validation of proposed votes, used by the validate-votes command
+  - timelock.go   : parameter changes scheduled at a specified block and their cancellation
 
 */
 package governance
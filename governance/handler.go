@@ -70,6 +70,9 @@ var GovernanceItems = map[int]check{
 	params.CommitteeSize:             {uint64T, checkCommitteeSize, nil},
 	params.ConstTxGasHumanReadable:   {uint64T, checkUint64andBool, updateTxGasHumanReadable},
 	params.Timeout:                   {uint64T, checkUint64andBool, nil},
+	params.TimeoutMultiplier:         {uint64T, checkTimeoutMultiplier, updateTimeoutMultiplier},
+	params.MaxTimeout:                {uint64T, checkUint64andBool, updateMaxTimeout},
+	params.Reconfiguration:           {stringT, checkReconfiguration, nil},
 }
 
 func updateTxGasHumanReadable(g *Governance, k string, v interface{}) {
@@ -77,6 +80,16 @@ func updateTxGasHumanReadable(g *Governance, k string, v interface{}) {
 	logger.Info("TxGasHumanReadable changed", "New value", params.TxGasHumanReadable)
 }
 
+func updateTimeoutMultiplier(g *Governance, k string, v interface{}) {
+	atomic.StoreUint64(&istanbul.DefaultConfig.TimeoutMultiplier, v.(uint64))
+	logger.Info("Round change timeout multiplier changed", "New value", v)
+}
+
+func updateMaxTimeout(g *Governance, k string, v interface{}) {
+	atomic.StoreUint64(&istanbul.DefaultConfig.MaxTimeout, v.(uint64))
+	logger.Info("Round change max timeout changed", "New value", v)
+}
+
 // AddVote adds a vote to the voteMap
 func (g *Governance) AddVote(key string, val interface{}) bool {
 	key = g.getKey(key)
@@ -143,6 +156,10 @@ func (g *Governance) adjustValueType(key string, val interface{}) interface{} {
 			}
 			return nodeAddresses
 		}
+	} else if k == params.Reconfiguration {
+		return canonicalReconfiguration(v)
+	} else if k == params.Timelock {
+		return canonicalTimelock(v)
 	} else {
 		// If a string text come as uppercase, make it into lowercase
 		return strings.ToLower(v)
@@ -245,6 +262,12 @@ func checkCommitteeSize(k string, v interface{}) bool {
 	return true
 }
 
+// checkTimeoutMultiplier checks the multiplier is given in percent and does not shrink the timeout
+func checkTimeoutMultiplier(k string, v interface{}) bool {
+	m, ok := v.(uint64)
+	return ok && m >= 100
+}
+
 func checkRewardMinimumStake(k string, v interface{}) bool {
 	if !checkBigInt(k, v) {
 		return false
@@ -378,6 +401,50 @@ func (gov *Governance) HandleGovernanceVote(valset istanbul.ValidatorSet, votes
 					return valset, votes, tally
 				}
 			}
+		case params.Reconfiguration:
+			v, ok := gVote.Value.(string)
+			if !ok {
+				logger.Warn("Invalid value Type", "number", header.Number, "Validator", gVote.Validator, "key", gVote.Key, "value", gVote.Value)
+				return valset, votes, tally
+			}
+			// A reconfiguration is not voted before the hardfork
+			if gov.chainConfig == nil || !gov.chainConfig.IsReconfigurationForkEnabled(header.Number) {
+				if writable && proposer == self {
+					logger.Warn("A reconfiguration vote before the hardfork has been proposed. It is being removed without further handling", "number", header.Number, "value", gVote.Value)
+					gov.removeDuplicatedVote(gVote, header.Number.Uint64())
+				}
+				return valset, votes, tally
+			}
+			// A reconfiguration should be scheduled at a future block and change the current council
+			if r, err := ParseReconfiguration(v); err == nil && (r.Block <= header.Number.Uint64() || !r.IsMeaningful(valset)) {
+				if writable && proposer == self {
+					logger.Warn("A meaningless vote has been proposed. It is being removed without further handling", "key", gVote.Key, "value", gVote.Value)
+					gov.removeDuplicatedVote(gVote, header.Number.Uint64())
+				}
+				return valset, votes, tally
+			}
+		case params.Timelock:
+			v, ok := gVote.Value.(string)
+			if !ok {
+				logger.Warn("Invalid value Type", "number", header.Number, "Validator", gVote.Validator, "key", gVote.Key, "value", gVote.Value)
+				return valset, votes, tally
+			}
+			// A timelock is not voted before the hardfork
+			if !gov.isTimelockEnabled(header.Number.Uint64()) {
+				if writable && proposer == self {
+					logger.Warn("A timelock vote before the hardfork has been proposed. It is being removed without further handling", "number", header.Number, "value", gVote.Value)
+					gov.removeDuplicatedVote(gVote, header.Number.Uint64())
+				}
+				return valset, votes, tally
+			}
+			// A timelock should be scheduled at a future block
+			if c, err := ParseTimelockChange(v); err == nil && c.Block <= header.Number.Uint64() {
+				if writable && proposer == self {
+					logger.Warn("A meaningless vote has been proposed. It is being removed without further handling", "key", gVote.Key, "value", gVote.Value)
+					gov.removeDuplicatedVote(gVote, header.Number.Uint64())
+				}
+				return valset, votes, tally
+			}
 		}
 
 		number := header.Number.Uint64()
@@ -517,6 +584,8 @@ func (gov *Governance) addNewVote(valset istanbul.ValidatorSet, votes []Governan
 						votes = gov.removeVotesFromRemovedNode(votes, target)
 					}
 				}
+			case params.Reconfiguration:
+				// An approved reconfiguration is scheduled by the consensus engine with TakeApprovedReconfigurations.
 			case params.Timeout:
 				timeout := gVote.Value.(uint64)
 				atomic.StoreUint64(&istanbul.DefaultConfig.Timeout, timeout)
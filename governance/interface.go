@@ -31,6 +31,12 @@ type Engine interface {
 	ReaderEngine
 	HeaderGov() HeaderEngine
 	ContractGov() ReaderEngine
+
+	// Returns the changes of a parameter which took effect in (from, to]
+	ParamHistory(key string, from, to uint64) (*ParamHistory, error)
+
+	// Returns the pending and the applied changes of the current params
+	ParamUpdates() *ParamUpdates
 }
 
 type ReaderEngine interface {
@@ -77,6 +83,9 @@ type HeaderEngine interface {
 		valset istanbul.ValidatorSet, votes []GovernanceVote, tally []GovernanceTallyItem,
 		header *types.Header, proposer common.Address, self common.Address, writable bool) (
 		istanbul.ValidatorSet, []GovernanceVote, []GovernanceTallyItem)
+	TakeApprovedReconfigurations(
+		valset istanbul.ValidatorSet, votes []GovernanceVote, tally []GovernanceTallyItem) (
+		[]*Reconfiguration, []GovernanceVote, []GovernanceTallyItem)
 
 	// Get internal fields
 	GetVoteMapCopy() map[string]VoteStatus
@@ -109,6 +118,7 @@ type blockChain interface {
 	CurrentHeader() *types.Header
 	GetHeaderByNumber(val uint64) *types.Header
 	GetBlockByNumber(num uint64) *types.Block
+	GetReceiptsByBlockHash(blockHash common.Hash) types.Receipts
 	StateAt(root common.Hash) (*state.StateDB, error)
 	Config() *params.ChainConfig
 }
@@ -18,6 +18,8 @@ package governance
 
 import (
 	"math/big"
+	"sort"
+	"sync"
 
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
@@ -63,6 +65,30 @@ type MixedEngine struct {
 	// for param update
 	txpool     txPool
 	blockchain blockChain
+
+	// for the record of param updates
+	updatesLock    sync.RWMutex
+	appliedChanges []*AppliedChange // the latest last, at most maxAppliedChanges
+	updatedBlock   uint64           // the upcoming block when the params were last updated with the blockchain
+}
+
+// maxAppliedChanges is the maximum number of the applied changes kept by MixedEngine.
+const maxAppliedChanges = 128
+
+// AppliedChange is a change of a parameter applied to the current params,
+// by a header governance vote, a contract governance transaction or a timelock.
+type AppliedChange struct {
+	Block     uint64      `json:"block"` // the upcoming block when the change was applied
+	Key       string      `json:"key"`
+	Old       interface{} `json:"old"`
+	New       interface{} `json:"new"`
+	Scheduled bool        `json:"scheduled"` // whether the change was scheduled by a timelock
+}
+
+// ParamUpdates is the pending and the applied changes of the current params.
+type ParamUpdates struct {
+	Pending []*ScheduledChange `json:"pending"` // the changes scheduled by timelock and not activated yet
+	Applied []*AppliedChange   `json:"applied"` // the recent changes applied, the latest last
 }
 
 // newMixedEngine instantiate a new MixedEngine struct.
@@ -171,7 +197,11 @@ func (e *MixedEngine) UpdateParams() error {
 	headerParams := e.headerGov.Params()
 
 	newParams := e.assembleParams(headerParams, contractParams)
-	e.handleParamUpdate(e.currentParams, newParams)
+	if e.blockchain != nil {
+		e.handleParamUpdate(e.currentParams, newParams, num.Uint64()+1)
+	} else {
+		e.handleParamUpdate(e.currentParams, newParams, 0)
+	}
 
 	e.currentParams = newParams
 
@@ -188,10 +218,16 @@ func (e *MixedEngine) assembleParams(headerParams, contractParams *params.GovPar
 	return p
 }
 
-func (e *MixedEngine) handleParamUpdate(old, new *params.GovParamSet) {
+// handleParamUpdate applies the changed params to the ChainConfig, and records the changes
+// to be used from the upcoming block `number`. The changes are not recorded if number is 0,
+// or if the params are updated with the blockchain for the first time.
+func (e *MixedEngine) handleParamUpdate(old, new *params.GovParamSet, number uint64) {
+	var applied []*AppliedChange
+
 	// NOTE: key set must be the same, which is guaranteed at NewMixedEngine
 	for k, oldval := range old.IntMap() {
 		if newval := new.MustGet(k); oldval != newval {
+			applied = append(applied, &AppliedChange{Block: number, Key: GovernanceKeyMapReverse[k], Old: oldval, New: newval})
 			switch k {
 			// config.Istanbul
 			case params.Epoch:
@@ -248,6 +284,48 @@ func (e *MixedEngine) handleParamUpdate(old, new *params.GovParamSet) {
 			}
 		}
 	}
+	e.recordAppliedChanges(applied, number)
+}
+
+// recordAppliedChanges records the changes applied since the last update.
+func (e *MixedEngine) recordAppliedChanges(applied []*AppliedChange, number uint64) {
+	if number == 0 {
+		return
+	}
+	e.updatesLock.Lock()
+	defer e.updatesLock.Unlock()
+
+	if e.updatedBlock != 0 && len(applied) > 0 {
+		// The changes activated since the last update are the scheduled ones
+		scheduled := e.headerGov.scheduledItems(number, e.updatedBlock+1, number)
+		sort.Slice(applied, func(i, j int) bool { return applied[i].Key < applied[j].Key })
+		for _, c := range applied {
+			_, c.Scheduled = scheduled[c.Key]
+			logger.Info("Governance parameter is updated", "block", number, "key", c.Key, "old", c.Old, "new", c.New, "scheduled", c.Scheduled)
+		}
+		e.appliedChanges = append(e.appliedChanges, applied...)
+		if len(e.appliedChanges) > maxAppliedChanges {
+			e.appliedChanges = append([]*AppliedChange(nil), e.appliedChanges[len(e.appliedChanges)-maxAppliedChanges:]...)
+		}
+	}
+	e.updatedBlock = number
+}
+
+// ParamUpdates returns the changes scheduled by timelock and not activated in the current params yet,
+// and the changes applied to the current params since the node started.
+func (e *MixedEngine) ParamUpdates() *ParamUpdates {
+	updates := &ParamUpdates{Pending: []*ScheduledChange{}, Applied: []*AppliedChange{}}
+	if e.blockchain != nil {
+		// The current params are used from the upcoming block
+		if pending := e.headerGov.pendingScheduledChanges(e.blockchain.CurrentHeader().Number.Uint64() + 1); pending != nil {
+			updates.Pending = pending
+		}
+	}
+
+	e.updatesLock.RLock()
+	defer e.updatesLock.RUnlock()
+	updates.Applied = append(updates.Applied, e.appliedChanges...)
+	return updates
 }
 
 func (e *MixedEngine) HeaderGov() HeaderEngine {
@@ -316,6 +394,14 @@ func (e *MixedEngine) HandleGovernanceVote(
 	return e.headerGov.HandleGovernanceVote(valset, votes, tally, header, proposer, self, writable)
 }
 
+func (e *MixedEngine) TakeApprovedReconfigurations(
+	valset istanbul.ValidatorSet, votes []GovernanceVote, tally []GovernanceTallyItem,
+) (
+	[]*Reconfiguration, []GovernanceVote, []GovernanceTallyItem,
+) {
+	return e.headerGov.TakeApprovedReconfigurations(valset, votes, tally)
+}
+
 func (e *MixedEngine) GetVoteMapCopy() map[string]VoteStatus {
 	return e.headerGov.GetVoteMapCopy()
 }
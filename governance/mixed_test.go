@@ -253,3 +253,38 @@ func TestMixedEngine_ParamsAt(t *testing.T) {
 			i, headerBlock, contractBlock)
 	}
 }
+
+// TestMixedEngine_ParamUpdates tests if the pending and the applied changes are exposed by ParamUpdates()
+func TestMixedEngine_ParamUpdates(t *testing.T) {
+	config := getTestConfig()
+	config.Istanbul.Epoch = 10
+	config.UnitPrice = 25
+	config.TimelockCompatibleBlock = common.Big0
+	e, _, sim, _ := newTestMixedEngine(t, config)
+	for sim.BlockChain().CurrentHeader().Number.Uint64() < 10 {
+		sim.Commit()
+	}
+
+	// The first update with the blockchain is not recorded
+	require.Nil(t, e.UpdateParams())
+	assert.Empty(t, e.ParamUpdates().Applied)
+
+	scheduled := &ScheduledChange{Block: 15, Changes: map[string]interface{}{"governance.unitprice": uint64(40)}, ApprovedAt: 10}
+	e.headerGov.writeScheduledChanges(10, []*ScheduledChange{scheduled}, true)
+	require.Nil(t, e.UpdateParams())
+	updates := e.ParamUpdates()
+	assert.Equal(t, []*ScheduledChange{scheduled}, updates.Pending)
+	assert.Empty(t, updates.Applied)
+
+	for sim.BlockChain().CurrentHeader().Number.Uint64() < 14 {
+		sim.Commit()
+	}
+	require.Nil(t, e.UpdateParams())
+	assert.Equal(t, uint64(40), e.Params().UnitPrice())
+
+	updates = e.ParamUpdates()
+	assert.Empty(t, updates.Pending)
+	assert.Equal(t, []*AppliedChange{
+		{Block: 15, Key: "governance.unitprice", Old: uint64(25), New: uint64(40), Scheduled: true},
+	}, updates.Applied)
+}
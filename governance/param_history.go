@@ -0,0 +1,370 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	govcontract "github.com/klaytn/klaytn/contracts/gov"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+)
+
+const (
+	ParamChangeSourceHeader   = "header"
+	ParamChangeSourceContract = "contract"
+
+	// maxGovParamTxSearchBlocks limits the blocks searched backward from an activation block
+	// to find the GovParam transaction scheduling it.
+	maxGovParamTxSearchBlocks = 10000
+	// maxParamVoteSearchBlocks limits the blocks searched backward from a governance block
+	// to find the votes for a header governance change.
+	maxParamVoteSearchBlocks = 10000
+	// maxParamHistoryChanges is the maximum number of changes returned at once by ParamHistory.
+	maxParamHistoryChanges = 10
+)
+
+var (
+	errUnknownParamKey      = errors.New("unknown governance parameter")
+	errInvalidHistoryRange  = errors.New("fromBlock should not be greater than toBlock")
+	errHistoryRangeTooHigh  = errors.New("toBlock should not be greater than the current block")
+	errParamHistoryNotReady = errors.New("blockchain is not set")
+)
+
+// ParamHistory is the list of changes of a governance parameter
+// that took effect in the blocks (FromBlock, ToBlock].
+// If Truncated, ToBlock is lowered to the last change returned, and the rest can be read from ToBlock.
+type ParamHistory struct {
+	Key          string         `json:"key"`
+	FromBlock    uint64         `json:"fromBlock"`
+	ToBlock      uint64         `json:"toBlock"`
+	InitialValue interface{}    `json:"initialValue"` // the value at FromBlock
+	Changes      []*ParamChange `json:"changes"`
+	Truncated    bool           `json:"truncated,omitempty"`
+}
+
+// ParamChange is a change of a governance parameter which took effect at Block.
+// The header governance fields are set if Source is "header",
+// and the contract governance fields are set if Source is "contract".
+type ParamChange struct {
+	Block         uint64      `json:"block"`
+	Value         interface{} `json:"value"`
+	PreviousValue interface{} `json:"previousValue"`
+	Source        string      `json:"source"`
+
+	// Header governance: the change is written in the header of GovernanceBlock,
+	// tallied from the votes cast in the previous epoch.
+	// If the epoch is longer than maxParamVoteSearchBlocks, only the votes since VoteSearchFrom are listed.
+	GovernanceBlock *uint64      `json:"governanceBlock,omitempty"`
+	VotingBlock     *uint64      `json:"votingBlock,omitempty"` // the last vote for the new value
+	Votes           []*ParamVote `json:"votes,omitempty"`
+	Tally           int          `json:"tally,omitempty"` // the number of distinct voters
+	VoteSearchFrom  *uint64      `json:"voteSearchFrom,omitempty"`
+
+	// Contract governance: the change is scheduled by a GovParam transaction,
+	// which is set if found within maxGovParamTxSearchBlocks blocks before the activation.
+	Contract *common.Address `json:"contract,omitempty"`
+	TxHash   *common.Hash    `json:"txHash,omitempty"`
+	TxBlock  *uint64         `json:"txBlock,omitempty"`
+}
+
+// ParamVote is a header vote for the new value of a ParamChange.
+type ParamVote struct {
+	Block     uint64         `json:"block"`
+	Validator common.Address `json:"validator"`
+}
+
+// ParamHistory returns the changes of the parameter `key` which took effect in (from, to].
+// The values are the ones returned by ParamsAt, so the changes from both engines
+// are merged in the same priority as assembleParams.
+// At most maxParamHistoryChanges changes are returned, and the history is truncated after them.
+func (e *MixedEngine) ParamHistory(key string, from, to uint64) (*ParamHistory, error) {
+	k, ok := GovernanceKeyMap[key]
+	if !ok {
+		return nil, errUnknownParamKey
+	}
+	if _, ok := e.Params().Get(k); !ok {
+		return nil, errUnknownParamKey
+	}
+	if from > to {
+		return nil, errInvalidHistoryRange
+	}
+	chain := e.headerGov.BlockChain()
+	if chain == nil {
+		return nil, errParamHistoryNotReady
+	}
+	if to > chain.CurrentHeader().Number.Uint64() {
+		return nil, errHistoryRangeTooHigh
+	}
+
+	prev, err := e.ParamsAt(from)
+	if err != nil {
+		return nil, err
+	}
+	history := &ParamHistory{
+		Key:          key,
+		FromBlock:    from,
+		ToBlock:      to,
+		InitialValue: paramValue(prev, k),
+		Changes:      []*ParamChange{},
+	}
+
+	headerBlocks := e.headerChangeBlocks(from, to)
+	contractBlocks, contractAddr, err := e.contractChangeBlocks(key, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, num := range mergeBlocks(headerBlocks, contractBlocks) {
+		pset, err := e.ParamsAt(num)
+		if err != nil {
+			return nil, err
+		}
+		prevValue, value := paramValue(prev, k), paramValue(pset, k)
+		prev = pset
+		if fmt.Sprint(prevValue) == fmt.Sprint(value) {
+			continue
+		}
+		if len(history.Changes) == maxParamHistoryChanges {
+			history.ToBlock = history.Changes[len(history.Changes)-1].Block
+			history.Truncated = true
+			break
+		}
+
+		change := &ParamChange{
+			Block:         num,
+			Value:         value,
+			PreviousValue: prevValue,
+		}
+		if _, ok := contractBlocks[num]; ok && e.isContractChange(k, num, headerBlocks) {
+			change.Source = ParamChangeSourceContract
+			e.fillContractChange(change, key, contractAddr)
+		} else {
+			change.Source = ParamChangeSourceHeader
+			e.fillHeaderChange(change, key, k, headerBlocks[num])
+		}
+		history.Changes = append(history.Changes, change)
+	}
+	return history, nil
+}
+
+// headerChangeBlocks returns the blocks in (from, to] where a header governance change takes effect,
+// mapped to the block carrying the change. The change written at the epoch block E is used from E+epoch.
+func (e *MixedEngine) headerChangeBlocks(from, to uint64) map[uint64]uint64 {
+	epoch := e.headerGov.epochWithFallback()
+	idxs, _ := e.db.ReadRecentGovernanceIdx(0)
+
+	blocks := make(map[uint64]uint64)
+	for _, idx := range idxs {
+		if idx == 0 {
+			continue
+		}
+		if num := idx + epoch; from < num && num <= to {
+			blocks[num] = idx
+		}
+	}
+	// The scheduled changes take effect at the timelock block
+	for _, sc := range e.headerGov.ScheduledChangesAt(to) {
+		if from < sc.Block && sc.Block <= to && e.headerGov.isTimelockEnabled(sc.Block) {
+			blocks[sc.Block] = sc.ApprovedAt
+		}
+	}
+	return blocks
+}
+
+// contractChangeBlocks returns the activation blocks in (from, to] of the GovParam checkpoints of `key`,
+// read from the contract effective at `to`.
+func (e *MixedEngine) contractChangeBlocks(key string, from, to uint64) (map[uint64]bool, common.Address, error) {
+	blocks := make(map[uint64]bool)
+	if !e.config.IsKoreForkEnabled(new(big.Int).SetUint64(to)) {
+		return blocks, common.Address{}, nil
+	}
+	addr, err := e.contractGov.contractAddrAt(to)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if common.EmptyAddress(addr) {
+		return blocks, addr, nil
+	}
+
+	caller := &contractCaller{chain: e.headerGov.BlockChain(), contractAddr: addr}
+	checkpoints, err := caller.getCheckpoints(key)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	for _, cp := range checkpoints {
+		if cp.Activation == nil || !cp.Activation.IsUint64() {
+			continue
+		}
+		if num := cp.Activation.Uint64(); from < num && num <= to {
+			blocks[num] = true
+		}
+	}
+	return blocks, addr, nil
+}
+
+// isContractChange returns true if the change at num comes from the contract.
+// If both engines change the parameter at num, the contract wins as in assembleParams,
+// unless the contract no longer has the parameter.
+func (e *MixedEngine) isContractChange(k int, num uint64, headerBlocks map[uint64]uint64) bool {
+	if _, ok := headerBlocks[num]; !ok {
+		return true
+	}
+	pset, err := e.contractGov.ParamsAt(num)
+	if err != nil {
+		return false
+	}
+	_, ok := pset.Get(k)
+	return ok
+}
+
+// fillHeaderChange fills the votes for change.Value cast in the epoch before govBlock,
+// searching at most maxParamVoteSearchBlocks blocks.
+func (e *MixedEngine) fillHeaderChange(change *ParamChange, key string, k int, govBlock uint64) {
+	change.GovernanceBlock = &govBlock
+
+	chain := e.headerGov.BlockChain()
+	epoch := e.headerGov.epochWithFallback()
+	start := uint64(0)
+	if govBlock > epoch {
+		start = govBlock - epoch
+	}
+	if govBlock-start > maxParamVoteSearchBlocks {
+		start = govBlock - maxParamVoteSearchBlocks
+		change.VoteSearchFrom = &start
+	}
+
+	voters := make(map[common.Address]bool)
+	for num := start; num < govBlock; num++ {
+		header := chain.GetHeaderByNumber(num)
+		if header == nil || len(header.Vote) == 0 {
+			continue
+		}
+		vote := new(GovernanceVote)
+		if err := rlp.DecodeBytes(header.Vote, vote); err != nil {
+			continue
+		}
+		value, ok := e.voteValueFor(vote, key, change.Block)
+		if !ok || !isVoteFor(k, value, change.Value) {
+			continue
+		}
+
+		change.Votes = append(change.Votes, &ParamVote{Block: num, Validator: vote.Validator})
+		voters[vote.Validator] = true
+		votingBlock := num
+		change.VotingBlock = &votingBlock
+	}
+	change.Tally = len(voters)
+}
+
+// voteValueFor returns the value of `key` voted directly, or by a timelock scheduled at `block`.
+func (e *MixedEngine) voteValueFor(vote *GovernanceVote, key string, block uint64) (interface{}, bool) {
+	if vote.Key != key && vote.Key != GovernanceKeyMapReverse[params.Timelock] {
+		return nil, false
+	}
+	vote, err := e.headerGov.ParseVoteValue(vote)
+	if err != nil {
+		return nil, false
+	}
+	if vote.Key == key {
+		return vote.Value, true
+	}
+	c, err := ParseTimelockChange(vote.Value.(string))
+	if err != nil || c.Block != block {
+		return nil, false
+	}
+	value, ok := c.Changes[key]
+	return value, ok
+}
+
+// isVoteFor returns true if a vote value is the same as the parameter value after type conversion.
+func isVoteFor(k int, voteValue, value interface{}) bool {
+	pset, err := params.NewGovParamSetIntMap(map[int]interface{}{k: voteValue})
+	if err != nil {
+		return false
+	}
+	return fmt.Sprint(paramValue(pset, k)) == fmt.Sprint(value)
+}
+
+// fillContractChange finds the SetParam transaction scheduling the change,
+// searching the blocks before the activation block.
+func (e *MixedEngine) fillContractChange(change *ParamChange, key string, addr common.Address) {
+	change.Contract = &addr
+
+	chain := e.headerGov.BlockChain()
+	event := govParamAbi.Events["SetParam"]
+
+	for num := change.Block; num > 0 && change.Block-num < maxGovParamTxSearchBlocks; {
+		num--
+		header := chain.GetHeaderByNumber(num)
+		if header == nil {
+			continue
+		}
+		if !types.BloomLookup(header.Bloom, addr) || !types.BloomLookup(header.Bloom, event.ID) {
+			continue
+		}
+		for _, receipt := range chain.GetReceiptsByBlockHash(header.Hash()) {
+			for _, log := range receipt.Logs {
+				if log.Address != addr || len(log.Topics) == 0 || log.Topics[0] != event.ID {
+					continue
+				}
+				ev := new(govcontract.GovParamSetParam)
+				if err := govParamAbi.Unpack(ev, "SetParam", log.Data); err != nil {
+					continue
+				}
+				if ev.Name != key || ev.Activation == nil || ev.Activation.Uint64() != change.Block {
+					continue
+				}
+				txHash, txBlock := receipt.TxHash, num
+				change.TxHash = &txHash
+				change.TxBlock = &txBlock
+				return
+			}
+		}
+	}
+}
+
+// paramValue returns the value of k in pset, or nil if pset does not have it.
+func paramValue(pset *params.GovParamSet, k int) interface{} {
+	if v, ok := pset.Get(k); ok {
+		return v
+	}
+	return nil
+}
+
+// mergeBlocks returns the union of the block numbers in ascending order.
+func mergeBlocks(headerBlocks map[uint64]uint64, contractBlocks map[uint64]bool) []uint64 {
+	set := make(map[uint64]bool)
+	for num := range headerBlocks {
+		set[num] = true
+	}
+	for num := range contractBlocks {
+		set[num] = true
+	}
+
+	blocks := make([]uint64, 0, len(set))
+	for num := range set {
+		blocks = append(blocks, num)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	return blocks
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMixedEngine_ParamHistory tests if ParamHistory() lists the changes from both engines.
+//
+// valueA is set in ChainConfig
+// valueB is set in DB at headerBlock, effective from headerBlock + epoch
+// valueC is set in GovParam contract, effective from activation
+func TestMixedEngine_ParamHistory(t *testing.T) {
+	var (
+		name        = "kip71.gastarget"
+		valueA      = uint64(0xa)
+		valueB      = uint64(0xbb)
+		valueC      = uint64(0xcccccc)
+		valueCBytes = []byte{0xcc, 0xcc, 0xcc}
+		epoch       = uint64(10)
+		headerBlock = uint64(10)
+		activation  = uint64(25)
+	)
+
+	config := getTestConfig()
+	config.Istanbul.Epoch = epoch
+	config.Governance.KIP71.GasTarget = valueA
+	e, owner, sim, contract := newTestMixedEngine(t, config)
+
+	items := e.Params().StrMap()
+	items[name] = valueB
+	gset := NewGovernanceSet()
+	gset.Import(items)
+	require.Nil(t, e.headerGov.WriteGovernance(headerBlock, NewGovernanceSet(), gset))
+
+	tx, err := contract.SetParam(owner, name, true, valueCBytes, new(big.Int).SetUint64(activation))
+	require.Nil(t, err)
+	sim.Commit()
+	txBlock := sim.BlockChain().CurrentHeader().Number.Uint64()
+
+	for sim.BlockChain().CurrentHeader().Number.Uint64() < 30 {
+		sim.Commit()
+	}
+
+	history, err := e.ParamHistory(name, 0, 30)
+	require.Nil(t, err)
+	assert.Equal(t, valueA, history.InitialValue)
+	require.Equal(t, 2, len(history.Changes))
+
+	headerChange := history.Changes[0]
+	assert.Equal(t, headerBlock+epoch, headerChange.Block)
+	assert.Equal(t, ParamChangeSourceHeader, headerChange.Source)
+	assert.Equal(t, valueA, headerChange.PreviousValue)
+	assert.Equal(t, valueB, headerChange.Value)
+	require.NotNil(t, headerChange.GovernanceBlock)
+	assert.Equal(t, headerBlock, *headerChange.GovernanceBlock)
+	assert.Nil(t, headerChange.TxHash)
+
+	contractChange := history.Changes[1]
+	assert.Equal(t, activation, contractChange.Block)
+	assert.Equal(t, ParamChangeSourceContract, contractChange.Source)
+	assert.Equal(t, valueB, contractChange.PreviousValue)
+	assert.Equal(t, valueC, contractChange.Value)
+	require.NotNil(t, contractChange.TxHash)
+	assert.Equal(t, tx.Hash(), *contractChange.TxHash)
+	assert.Equal(t, txBlock, *contractChange.TxBlock)
+	assert.Equal(t, config.Governance.GovParamContract, *contractChange.Contract)
+
+	// (from, to] excludes the change at from
+	history, err = e.ParamHistory(name, headerBlock+epoch, 30)
+	require.Nil(t, err)
+	assert.Equal(t, valueB, history.InitialValue)
+	assert.Equal(t, 1, len(history.Changes))
+
+	// the parameter is not changed by the other votes
+	history, err = e.ParamHistory("governance.unitprice", 0, 30)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(history.Changes))
+
+	_, err = e.ParamHistory("governance.addvalidator", 0, 30)
+	assert.Equal(t, errUnknownParamKey, err)
+	_, err = e.ParamHistory(name, 30, 0)
+	assert.Equal(t, errInvalidHistoryRange, err)
+	_, err = e.ParamHistory(name, 0, 31)
+	assert.Equal(t, errHistoryRangeTooHigh, err)
+}
+
+func TestIsVoteFor(t *testing.T) {
+	assert.True(t, isVoteFor(params.GasTarget, uint64(100), uint64(100)))
+	assert.False(t, isVoteFor(params.GasTarget, uint64(100), uint64(101)))
+	assert.True(t, isVoteFor(params.Ratio, "34/54/12", "34/54/12"))
+	assert.False(t, isVoteFor(params.Ratio, "invalid", "34/54/12"))
+}
+
+// TestMixedEngine_ParamHistoryTruncated tests if ParamHistory() returns at most maxParamHistoryChanges changes at once.
+func TestMixedEngine_ParamHistoryTruncated(t *testing.T) {
+	var (
+		name  = "kip71.gastarget"
+		epoch = uint64(10)
+		last  = uint64(130)
+	)
+
+	config := getTestConfig()
+	config.Istanbul.Epoch = epoch
+	e, _, sim, _ := newTestMixedEngine(t, config)
+
+	// The changes written at 10, 20, ..., 110 take effect at 20, 30, ..., 120
+	for num := epoch; num <= epoch*(maxParamHistoryChanges+1); num += epoch {
+		items := e.Params().StrMap()
+		items[name] = num
+		gset := NewGovernanceSet()
+		gset.Import(items)
+		require.Nil(t, e.headerGov.WriteGovernance(num, NewGovernanceSet(), gset))
+	}
+	for sim.BlockChain().CurrentHeader().Number.Uint64() < last {
+		sim.Commit()
+	}
+
+	history, err := e.ParamHistory(name, 0, last)
+	require.Nil(t, err)
+	assert.True(t, history.Truncated)
+	assert.Equal(t, maxParamHistoryChanges, len(history.Changes))
+	assert.Equal(t, epoch*(maxParamHistoryChanges+1), history.ToBlock)
+
+	history, err = e.ParamHistory(name, history.ToBlock, last)
+	require.Nil(t, err)
+	assert.False(t, history.Truncated)
+	require.Equal(t, 1, len(history.Changes))
+	assert.Equal(t, epoch*(maxParamHistoryChanges+2), history.Changes[0].Block)
+}
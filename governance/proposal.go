@@ -0,0 +1,122 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+)
+
+var (
+	errEmptyProposal   = errors.New("no vote is proposed")
+	errForbiddenKey    = errors.New("the key cannot be voted")
+	errDuplicatedVotes = errors.New("the key is voted more than once")
+)
+
+// ProposalVote is a proposed vote to be validated offline.
+// Value is given as governance.vote receives it from the console,
+// i.e., a number is a float64 and an address is a string.
+type ProposalVote struct {
+	Key   string
+	Value interface{}
+}
+
+// ProposalResult is the outcome of the proposed votes if they are approved.
+type ProposalResult struct {
+	// Changes are the validated votes in canonical types.
+	Changes map[string]interface{} `json:"changes"`
+
+	// ValidatorChanges are the votes changing the council, applied as soon as they are approved
+	// rather than at the next epoch.
+	ValidatorChanges map[string]interface{} `json:"validatorChanges,omitempty"`
+
+	// Params are the parameters effective from EffectiveBlock.
+	Params map[string]interface{} `json:"params"`
+
+	VoteBlock       uint64 `json:"voteBlock"`       // the block carrying the votes
+	GovernanceBlock uint64 `json:"governanceBlock"` // the epoch block carrying the tallied changes
+	EffectiveBlock  uint64 `json:"effectiveBlock"`  // the first block using the changes
+}
+
+// ValidateProposal validates the votes with the same rules and type conversion as governance.vote
+// against the parameters of `config`, and returns the parameters after the votes cast at voteBlock
+// are approved. It does not need a running node.
+func ValidateProposal(config *params.ChainConfig, votes []ProposalVote, voteBlock uint64) (*ProposalResult, error) {
+	if len(votes) == 0 {
+		return nil, errEmptyProposal
+	}
+
+	e := NewMixedEngineNoInit(config, database.NewMemoryDBManager())
+	base := e.assembleParams(params.NewGovParamSet(), params.NewGovParamSet())
+
+	result := &ProposalResult{
+		Changes:          make(map[string]interface{}),
+		ValidatorChanges: make(map[string]interface{}),
+		VoteBlock:        voteBlock,
+	}
+	changes := make(map[int]interface{})
+	for _, v := range votes {
+		key := e.headerGov.getKey(v.Key)
+		if _, ok := GovernanceForbiddenKeyMap[key]; ok {
+			return nil, fmt.Errorf("%s: %v", key, errForbiddenKey)
+		}
+		if _, ok := result.Changes[key]; ok {
+			return nil, fmt.Errorf("%s: %v", key, errDuplicatedVotes)
+		}
+		vote, ok := e.headerGov.ValidateVote(&GovernanceVote{Key: key, Value: v.Value})
+		if !ok {
+			return nil, fmt.Errorf("%s: %v", key, errInvalidKeyValue)
+		}
+
+		result.Changes[key] = vote.Value
+		switch k := GovernanceKeyMap[key]; k {
+		case params.AddValidator, params.RemoveValidator, params.Reconfiguration:
+			result.ValidatorChanges[key] = vote.Value
+		default:
+			// Some items such as param.txgashumanreadable are not a part of GovParamSet.
+			if _, err := params.NewGovParamSetIntMap(map[int]interface{}{k: vote.Value}); err == nil {
+				changes[k] = vote.Value
+			}
+		}
+	}
+
+	update, err := params.NewGovParamSetIntMap(changes)
+	if err != nil {
+		return nil, err
+	}
+	pset := params.NewGovParamSetMerged(base, update)
+	if pset.LowerBoundBaseFee() > pset.UpperBoundBaseFee() {
+		if _, ok := changes[params.LowerBoundBaseFee]; ok {
+			return nil, errInvalidLowerBound
+		}
+		return nil, errInvalidUpperBound
+	}
+	result.Params = pset.StrMap()
+
+	// The votes cast in an epoch are tallied at the next epoch block,
+	// and the changes are used from the epoch after that. See ReadGovernanceAtNumber.
+	epoch := params.DefaultEpoch
+	if v, ok := base.Get(params.Epoch); ok {
+		epoch = v.(uint64)
+	}
+	result.GovernanceBlock = (voteBlock/epoch + 1) * epoch
+	result.EffectiveBlock = result.GovernanceBlock + epoch
+	return result, nil
+}
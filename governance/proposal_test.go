@@ -0,0 +1,83 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProposal(t *testing.T) {
+	config := getTestConfig()
+	config.Istanbul.Epoch = 30
+	config.Governance.KIP71.UpperBoundBaseFee = 750e9
+
+	// the values are given as the console gives them
+	result, err := ValidateProposal(config, []ProposalVote{
+		{"KIP71.GasTarget", float64(40000000)},
+		{"reward.ratio", "50/20/30"},
+		{"governance.addvalidator", "0x0000000000000000000000000000000000000001"},
+		{"param.txgashumanreadable", float64(1000)},
+	}, 100)
+	require.Nil(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"kip71.gastarget":          uint64(40000000),
+		"reward.ratio":             "50/20/30",
+		"governance.addvalidator":  common.HexToAddress("0x1"),
+		"param.txgashumanreadable": uint64(1000),
+	}, result.Changes)
+	assert.Equal(t, map[string]interface{}{
+		"governance.addvalidator": common.HexToAddress("0x1"),
+	}, result.ValidatorChanges)
+	assert.Equal(t, uint64(40000000), result.Params["kip71.gastarget"])
+	assert.Equal(t, "50/20/30", result.Params["reward.ratio"])
+	assert.Equal(t, config.UnitPrice, result.Params["governance.unitprice"])
+	assert.Nil(t, result.Params["param.txgashumanreadable"])
+	assert.Equal(t, uint64(120), result.GovernanceBlock)
+	assert.Equal(t, uint64(150), result.EffectiveBlock)
+
+	// the votes of an epoch block are tallied at the next epoch block
+	result, err = ValidateProposal(config, []ProposalVote{{"kip71.gastarget", float64(1)}}, 120)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(150), result.GovernanceBlock)
+	assert.Equal(t, uint64(180), result.EffectiveBlock)
+
+	testcases := []struct {
+		votes    []ProposalVote
+		expected string
+	}{
+		{nil, errEmptyProposal.Error()},
+		{[]ProposalVote{{"reward.ratio", "50/20/20"}}, "reward.ratio: " + errInvalidKeyValue.Error()},
+		{[]ProposalVote{{"kip71.gastarget", float64(1.5)}}, "kip71.gastarget: " + errInvalidKeyValue.Error()},
+		{[]ProposalVote{{"istanbul.committeesize", float64(0)}}, "istanbul.committeesize: " + errInvalidKeyValue.Error()},
+		{[]ProposalVote{{"unknown.key", float64(1)}}, "unknown.key: " + errInvalidKeyValue.Error()},
+		{[]ProposalVote{{"istanbul.policy", float64(1)}}, "istanbul.policy: " + errForbiddenKey.Error()},
+		{[]ProposalVote{{"kip71.gastarget", float64(1)}, {"kip71.gastarget", float64(2)}}, "kip71.gastarget: " + errDuplicatedVotes.Error()},
+		{[]ProposalVote{{"kip71.lowerboundbasefee", float64(750e9 + 1)}}, errInvalidLowerBound.Error()},
+		{[]ProposalVote{{"kip71.upperboundbasefee", float64(1)}}, errInvalidUpperBound.Error()},
+	}
+	for i, tc := range testcases {
+		_, err := ValidateProposal(config, tc.votes, 100)
+		if assert.Error(t, err, "testcases[%d] failed", i) {
+			assert.Equal(t, tc.expected, err.Error(), "testcases[%d] failed", i)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+)
+
+var (
+	errEmptyReconfiguration       = errors.New("reconfiguration changes nothing")
+	errZeroReconfigurationBlock   = errors.New("reconfiguration block should be positive")
+	errDuplicatedReconfiguration  = errors.New("an address is added or removed more than once")
+	errUnknownReconfigurationItem = errors.New("unknown field in reconfiguration")
+)
+
+// Reconfiguration is a change of the council and the committee size scheduled at Block.
+// Block is the first block committed by the reconfigured validators.
+// It is voted with "governance.reconfiguration" as a JSON string, e.g.,
+// {"block":1000,"committeesize":7,"add":["0x..."],"remove":["0x..."]}.
+type Reconfiguration struct {
+	Block         uint64           `json:"block"`
+	CommitteeSize uint64           `json:"committeesize,omitempty"` // 0 keeps the committee size
+	Add           []common.Address `json:"add,omitempty"`
+	Remove        []common.Address `json:"remove,omitempty"`
+}
+
+// ParseReconfiguration parses and checks a reconfiguration vote value.
+func ParseReconfiguration(s string) (*Reconfiguration, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+
+	r := new(Reconfiguration)
+	if err := dec.Decode(r); err != nil {
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return nil, errUnknownReconfigurationItem
+		}
+		return nil, err
+	}
+	if r.Block == 0 {
+		return nil, errZeroReconfigurationBlock
+	}
+	if r.CommitteeSize == 0 && len(r.Add) == 0 && len(r.Remove) == 0 {
+		return nil, errEmptyReconfiguration
+	}
+	seen := make(map[common.Address]bool)
+	for _, addr := range append(append([]common.Address{}, r.Add...), r.Remove...) {
+		if seen[addr] {
+			return nil, errDuplicatedReconfiguration
+		}
+		seen[addr] = true
+	}
+	return r, nil
+}
+
+// String returns the canonical vote value of the reconfiguration.
+// Addresses are sorted and every letter is lowercase, so that the same reconfiguration is tallied together.
+func (r *Reconfiguration) String() string {
+	c := r.Copy()
+	sortAddresses(c.Add)
+	sortAddresses(c.Remove)
+	b, _ := json.Marshal(c)
+	return strings.ToLower(string(b))
+}
+
+// Copy returns a deep copy of the reconfiguration.
+func (r *Reconfiguration) Copy() *Reconfiguration {
+	return &Reconfiguration{
+		Block:         r.Block,
+		CommitteeSize: r.CommitteeSize,
+		Add:           append([]common.Address(nil), r.Add...),
+		Remove:        append([]common.Address(nil), r.Remove...),
+	}
+}
+
+// IsMeaningful reports whether every address to add is not in the council and every address to remove is.
+func (r *Reconfiguration) IsMeaningful(valset istanbul.ValidatorSet) bool {
+	inCouncil := func(addr common.Address) bool {
+		if _, v := valset.GetByAddress(addr); v != nil {
+			return true
+		}
+		_, v := valset.GetDemotedByAddress(addr)
+		return v != nil
+	}
+	for _, addr := range r.Add {
+		if inCouncil(addr) {
+			return false
+		}
+	}
+	for _, addr := range r.Remove {
+		if !inCouncil(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortAddresses(addrs []common.Address) {
+	sort.Slice(addrs, func(i, j int) bool {
+		return strings.Compare(addrs[i].Hex(), addrs[j].Hex()) < 0
+	})
+}
+
+func checkReconfiguration(k string, v interface{}) bool {
+	_, err := ParseReconfiguration(v.(string))
+	return err == nil
+}
+
+// canonicalReconfiguration returns the canonical form of a reconfiguration vote value, or v itself if invalid.
+func canonicalReconfiguration(v string) string {
+	r, err := ParseReconfiguration(v)
+	if err != nil {
+		return v
+	}
+	return r.String()
+}
+
+// TakeApprovedReconfigurations removes the approved reconfigurations from the votes and the tally, and returns them.
+// A reconfiguration is approved in the same way as other votes: by any vote in the "none" governance mode,
+// by the vote of the governing node in the "single" mode, and by more than half of the voting power in the "ballot" mode.
+func (gov *Governance) TakeApprovedReconfigurations(valset istanbul.ValidatorSet, votes []GovernanceVote, tally []GovernanceTallyItem) ([]*Reconfiguration, []GovernanceVote, []GovernanceTallyItem) {
+	key := GovernanceKeyMapReverse[params.Reconfiguration]
+	governanceMode := gov.Params().GovernanceModeInt()
+	governingNode := gov.Params().GoverningNode()
+
+	var approved []*Reconfiguration
+	approvedValues := make(map[string]bool)
+	for _, item := range tally {
+		if item.Key != key {
+			continue
+		}
+		value, ok := item.Value.(string)
+		if !ok {
+			continue
+		}
+		isApproved := governanceMode == params.GovernanceMode_Ballot && item.Votes > valset.TotalVotingPower()/2
+		for _, vote := range votes {
+			if vote.Key == key && vote.Value == value && gov.isGovernanceModeSingleOrNone(governanceMode, governingNode, vote.Validator) {
+				isApproved = true
+			}
+		}
+		if !isApproved {
+			continue
+		}
+		if r, err := ParseReconfiguration(value); err == nil {
+			approved = append(approved, r)
+			approvedValues[value] = true
+		}
+	}
+	if len(approved) == 0 {
+		return nil, votes, tally
+	}
+
+	remainingVotes := make([]GovernanceVote, 0, len(votes))
+	for _, vote := range votes {
+		if value, ok := vote.Value.(string); ok && vote.Key == key && approvedValues[value] {
+			continue
+		}
+		remainingVotes = append(remainingVotes, vote)
+	}
+	remainingTally := make([]GovernanceTallyItem, 0, len(tally))
+	for _, item := range tally {
+		if value, ok := item.Value.(string); ok && item.Key == key && approvedValues[value] {
+			continue
+		}
+		remainingTally = append(remainingTally, item)
+	}
+	return approved, remainingVotes, remainingTally
+}
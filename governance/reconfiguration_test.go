@@ -0,0 +1,119 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/consensus/istanbul/validator"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReconfiguration(t *testing.T) {
+	validators := getTestValidators()
+
+	r, err := ParseReconfiguration(fmt.Sprintf(`{"block":100,"committeesize":7,"add":["%s","%s"]}`, validators[1].Hex(), validators[0].Hex()))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), r.Block)
+	assert.Equal(t, uint64(7), r.CommitteeSize)
+	assert.Equal(t, 2, len(r.Add))
+
+	// the same reconfiguration in another order or case has the same canonical value
+	same, err := ParseReconfiguration(fmt.Sprintf(`{"add":["%s","%s"],"CommitteeSize":7,"block":100}`, validators[0].Hex(), validators[1].Hex()))
+	assert.NoError(t, err)
+	assert.Equal(t, r.String(), same.String())
+
+	testcases := []struct {
+		value string
+		err   error
+	}{
+		{`{"committeesize":7}`, errZeroReconfigurationBlock},
+		{`{"block":100}`, errEmptyReconfiguration},
+		{fmt.Sprintf(`{"block":100,"add":["%s"],"remove":["%s"]}`, validators[0].Hex(), validators[0].Hex()), errDuplicatedReconfiguration},
+		{`{"block":100,"committeesize":7,"epoch":10}`, errUnknownReconfigurationItem},
+	}
+	for _, tc := range testcases {
+		_, err := ParseReconfiguration(tc.value)
+		assert.Equal(t, tc.err, err, tc.value)
+	}
+	_, err = ParseReconfiguration("100/7")
+	assert.Error(t, err)
+}
+
+func TestGovernance_TakeApprovedReconfigurations(t *testing.T) {
+	validators := getTestValidators()
+	demotedValidators := getTestDemotedValidators()
+
+	var valSet istanbul.ValidatorSet
+	valSet = validator.NewWeightedCouncil(validators, demotedValidators, getTestRewards(), getTestVotingPowers(len(validators)), nil, istanbul.WeightedRandom, 21, 0, 0, nil)
+
+	config := getTestConfig()
+	config.Governance.GovernanceMode = GovernanceModeBallot
+	config.ReconfigurationCompatibleBlock = big.NewInt(2)
+	dbm := database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	gov := NewGovernanceInitialize(config, dbm)
+	self := validators[len(validators)-1]
+	gov.nodeAddress.Store(self)
+
+	votes := make([]GovernanceVote, 0)
+	tally := make([]GovernanceTallyItem, 0)
+	header := &types.Header{Number: big.NewInt(1), BlockScore: common.Big1}
+	vote := func(voter common.Address, value string) {
+		assert.True(t, gov.AddVote("governance.reconfiguration", value))
+		header.Vote = gov.GetEncodedVote(voter, header.Number.Uint64())
+		valSet, votes, tally = gov.HandleGovernanceVote(valSet, votes, tally, header, voter, self, true)
+	}
+
+	// a reconfiguration before the hardfork is not tallied
+	vote(validators[0], `{"block":100,"committeesize":3}`)
+	assert.Empty(t, tally)
+	header.Number = big.NewInt(2)
+
+	// removing a non-member is meaningless, so it is not tallied
+	vote(validators[0], fmt.Sprintf(`{"block":100,"remove":["%s"]}`, getTestRewards()[0].Hex()))
+	assert.Empty(t, tally)
+
+	// a reconfiguration scheduled at a past block is not tallied
+	vote(validators[0], `{"block":1,"committeesize":3}`)
+	assert.Empty(t, tally)
+
+	// more than half of the voting power approves the reconfiguration
+	value := fmt.Sprintf(`{"block":100,"committeesize":3,"remove":["%s"]}`, demotedValidators[0].Hex())
+	for i := 0; i < 2; i++ {
+		vote(validators[i], value)
+		approved, _, _ := gov.TakeApprovedReconfigurations(valSet, votes, tally)
+		assert.Empty(t, approved)
+	}
+	vote(validators[2], value)
+	approved, remainingVotes, remainingTally := gov.TakeApprovedReconfigurations(valSet, votes, tally)
+	if assert.Equal(t, 1, len(approved)) {
+		assert.Equal(t, uint64(100), approved[0].Block)
+		assert.Equal(t, []common.Address{demotedValidators[0]}, approved[0].Remove)
+	}
+	assert.Empty(t, remainingVotes)
+	assert.Empty(t, remainingTally)
+
+	// the council is not changed until the consensus engine applies it
+	_, demoted := valSet.GetDemotedByAddress(demotedValidators[0])
+	assert.NotNil(t, demoted)
+}
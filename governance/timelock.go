@@ -0,0 +1,409 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+var (
+	errEmptyTimelock          = errors.New("timelock changes nothing")
+	errZeroTimelockBlock      = errors.New("timelock block should be positive")
+	errCancelTimelockChanges  = errors.New("timelock cancellation should not have changes")
+	errInvalidTimelockItem    = errors.New("the key cannot be scheduled by timelock")
+	errInvalidTimelockValue   = errors.New("invalid value in timelock")
+	errUnknownTimelockField   = errors.New("unknown field in timelock")
+	errDuplicatedTimelockItem = errors.New("the key is changed more than once in timelock")
+	errTimelockKeysNotCancel  = errors.New("timelock keys should be given only with a cancellation")
+	errPastTimelock           = errors.New("timelock for a past block is ignored")
+	errNoScheduledChanges     = errors.New("no scheduled governance change to cancel")
+	errTimelockNotEnabled     = errors.New("timelock before the timelock hardfork is ignored")
+)
+
+// scheduledChangesKey is the key of the scheduled changes in PendingChanges.
+const scheduledChangesKey = "governance.scheduledchanges"
+
+// timelockItems are the parameters which can be scheduled by timelock.
+// They are read with ParamsAt at every block, so a change takes effect exactly at the scheduled block.
+// The parameters applied only at epoch blocks, such as the committee size, cannot be scheduled.
+// Timelock is enabled from ChainConfig.TimelockCompatibleBlock.
+var timelockItems = map[int]bool{
+	params.UnitPrice:                 true,
+	params.LowerBoundBaseFee:         true,
+	params.UpperBoundBaseFee:         true,
+	params.GasTarget:                 true,
+	params.MaxBlockGasUsedForBaseFee: true,
+	params.BaseFeeDenominator:        true,
+	params.MintingAmount:             true,
+	params.Ratio:                     true,
+	params.Kip82Ratio:                true,
+}
+
+func init() {
+	// registered here since checkTimelock refers to GovernanceItems to check the changes
+	GovernanceItems[params.Timelock] = check{stringT, checkTimelock, nil}
+}
+
+// TimelockChange is a change of parameters scheduled at Block, the first block using the changes.
+// It is voted with "governance.timelock" as a JSON string, e.g.,
+// {"block":1000,"changes":{"kip71.gastarget":40000000}}.
+// The changes scheduled at a block are cancelled by voting {"block":1000,"cancel":true} before the block,
+// or only some of them by listing their keys, e.g., {"block":1000,"cancel":true,"keys":["kip71.gastarget"]}.
+type TimelockChange struct {
+	Block   uint64                 `json:"block"`
+	Changes map[string]interface{} `json:"changes,omitempty"`
+	Cancel  bool                   `json:"cancel,omitempty"`
+	Keys    []string               `json:"keys,omitempty"`
+}
+
+// ScheduledChange is a TimelockChange approved at the epoch block ApprovedAt.
+type ScheduledChange struct {
+	Block      uint64                 `json:"block"`
+	Changes    map[string]interface{} `json:"changes"`
+	ApprovedAt uint64                 `json:"approvedAt"`
+}
+
+// ParseTimelockChange parses and checks a timelock vote value.
+// The values of the changes are converted to the canonical types as governance.vote does.
+func ParseTimelockChange(s string) (*TimelockChange, error) {
+	var raw struct {
+		Block   uint64                 `json:"block"`
+		Changes map[string]interface{} `json:"changes"`
+		Cancel  bool                   `json:"cancel"`
+		Keys    []string               `json:"keys"`
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return nil, errUnknownTimelockField
+		}
+		return nil, err
+	}
+	if raw.Block == 0 {
+		return nil, errZeroTimelockBlock
+	}
+	if raw.Cancel {
+		if len(raw.Changes) > 0 {
+			return nil, errCancelTimelockChanges
+		}
+		keys, err := parseTimelockKeys(raw.Keys)
+		if err != nil {
+			return nil, err
+		}
+		return &TimelockChange{Block: raw.Block, Cancel: true, Keys: keys}, nil
+	}
+	if len(raw.Keys) > 0 {
+		return nil, errTimelockKeysNotCancel
+	}
+	if len(raw.Changes) == 0 {
+		return nil, errEmptyTimelock
+	}
+	changes, err := parseTimelockChanges(raw.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return &TimelockChange{Block: raw.Block, Changes: changes}, nil
+}
+
+// parseTimelockChanges converts the JSON decoded values into the canonical types and validates them.
+// Only the parameters in timelockItems can be scheduled.
+func parseTimelockChanges(raw map[string]interface{}) (map[string]interface{}, error) {
+	changes := make(map[string]interface{})
+	for name, v := range raw {
+		key, k, err := timelockKey(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := changes[key]; ok {
+			return nil, errDuplicatedTimelockItem
+		}
+		item := GovernanceItems[k]
+		value, ok := timelockValue(item.t, v)
+		if !ok || !checkValueType(value, item.t) || !item.validator(key, value) {
+			return nil, errInvalidTimelockValue
+		}
+		changes[key] = value
+	}
+	return changes, nil
+}
+
+// parseTimelockKeys returns the canonical keys of a cancellation in ascending order.
+func parseTimelockKeys(names []string) ([]string, error) {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		key, _, err := timelockKey(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[key] {
+			return nil, errDuplicatedTimelockItem
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// timelockKey returns the canonical form of a key which can be scheduled by timelock.
+func timelockKey(name string) (string, int, error) {
+	key := strings.Trim(strings.ToLower(name), " ")
+	k, ok := GovernanceKeyMap[key]
+	if !ok || !timelockItems[k] {
+		return "", 0, errInvalidTimelockItem
+	}
+	return key, k, nil
+}
+
+// timelockValue converts a JSON decoded value into the type t.
+func timelockValue(t reflect.Type, v interface{}) (interface{}, bool) {
+	switch t {
+	case uint64T:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, false
+		}
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+		return u, err == nil
+	case stringT:
+		s, ok := v.(string)
+		return strings.ToLower(s), ok
+	case addressT:
+		s, ok := v.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return nil, false
+		}
+		return common.HexToAddress(s), true
+	default:
+		return v, true
+	}
+}
+
+// String returns the canonical vote value of the timelock change.
+func (c *TimelockChange) String() string {
+	b, _ := json.Marshal(c)
+	return string(b)
+}
+
+func checkTimelock(k string, v interface{}) bool {
+	_, err := ParseTimelockChange(v.(string))
+	return err == nil
+}
+
+// canonicalTimelock returns the canonical form of a timelock vote value, or v itself if invalid.
+func canonicalTimelock(v string) string {
+	c, err := ParseTimelockChange(v)
+	if err != nil {
+		return v
+	}
+	return c.String()
+}
+
+// ScheduledChangesAt returns the changes approved until the block `num` in the order of activation,
+// except the cancelled ones. The changes are stored at every epoch block approving a timelock,
+// so the ones of a block rewound or reorganized are not used.
+func (gov *Governance) ScheduledChangesAt(num uint64) []*ScheduledChange {
+	if gov.db == nil {
+		return nil
+	}
+	version, b, err := gov.db.ReadGovernanceTimelocks(num)
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+	if cached, ok := gov.timelockCache.Get(common.CacheKeyUint64(version)); ok {
+		return cached.([]*ScheduledChange)
+	}
+
+	var raw []struct {
+		Block      uint64                 `json:"block"`
+		Changes    map[string]interface{} `json:"changes"`
+		ApprovedAt uint64                 `json:"approvedAt"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		logger.Error("Failed to decode scheduled governance changes", "number", version, "err", err)
+		return nil
+	}
+
+	scheduled := make([]*ScheduledChange, 0, len(raw))
+	for _, r := range raw {
+		changes, err := parseTimelockChanges(r.Changes)
+		if err != nil {
+			logger.Error("Invalid scheduled governance change", "block", r.Block, "err", err)
+			continue
+		}
+		scheduled = append(scheduled, &ScheduledChange{Block: r.Block, Changes: changes, ApprovedAt: r.ApprovedAt})
+	}
+	gov.timelockCache.Add(common.CacheKeyUint64(version), scheduled)
+	return scheduled
+}
+
+// writeScheduledChanges stores the scheduled changes as of the epoch block `number`.
+// If they are the same as the ones before the block, the changes stored at the block are deleted instead.
+func (gov *Governance) writeScheduledChanges(number uint64, scheduled []*ScheduledChange, changed bool) {
+	if !changed {
+		if err := gov.db.DeleteGovernanceTimelocks(number); err != nil {
+			logger.Error("Failed to delete scheduled governance changes", "number", number, "err", err)
+		}
+		return
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		if scheduled[i].Block != scheduled[j].Block {
+			return scheduled[i].Block < scheduled[j].Block
+		}
+		return scheduled[i].ApprovedAt < scheduled[j].ApprovedAt
+	})
+	b, err := json.Marshal(scheduled)
+	if err != nil {
+		logger.Error("Failed to encode scheduled governance changes", "err", err)
+		return
+	}
+	if err := gov.db.WriteGovernanceTimelocks(number, b); err != nil {
+		logger.Error("Failed to write scheduled governance changes", "err", err)
+		return
+	}
+	gov.timelockCache.Add(common.CacheKeyUint64(number), scheduled)
+}
+
+// handleTimelock schedules or cancels the changes of an approved timelock vote
+// carried by the header of the epoch block `number`. The value is nil if no timelock is approved at the block.
+// A timelock for a past block is ignored, so a cancellation never affects the processed blocks.
+func (gov *Governance) handleTimelock(number uint64, value interface{}) {
+	if gov.db == nil || number == 0 {
+		return
+	}
+	scheduled := gov.ScheduledChangesAt(number - 1)
+	if value == nil {
+		gov.writeScheduledChanges(number, scheduled, false)
+		return
+	}
+
+	next, err := applyTimelock(number, value, scheduled)
+	if err == nil && !gov.isTimelockEnabled(number) {
+		err = errTimelockNotEnabled
+	}
+	if err != nil {
+		logger.Warn("Invalid timelock", "number", number, "value", value, "err", err)
+		gov.writeScheduledChanges(number, scheduled, false)
+		return
+	}
+	logger.Info("Scheduled governance changes are updated", "number", number, "timelock", value)
+	gov.writeScheduledChanges(number, next, true)
+}
+
+// applyTimelock returns the scheduled changes after the timelock approved at `number`.
+// The given scheduled changes are not modified.
+func applyTimelock(number uint64, value interface{}, scheduled []*ScheduledChange) ([]*ScheduledChange, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errInvalidTimelockValue
+	}
+	c, err := ParseTimelockChange(s)
+	if err != nil {
+		return nil, err
+	}
+	if c.Block <= number {
+		return nil, errPastTimelock
+	}
+	if !c.Cancel {
+		return append(append([]*ScheduledChange{}, scheduled...), &ScheduledChange{Block: c.Block, Changes: c.Changes, ApprovedAt: number}), nil
+	}
+
+	cancelled := false
+	next := make([]*ScheduledChange, 0, len(scheduled))
+	for _, sc := range scheduled {
+		if sc.Block != c.Block {
+			next = append(next, sc)
+			continue
+		}
+		if len(c.Keys) == 0 {
+			cancelled = true
+			continue
+		}
+		// Only the given keys are cancelled, and the schedule is dropped if nothing remains
+		changes := make(map[string]interface{})
+		for k, v := range sc.Changes {
+			changes[k] = v
+		}
+		for _, k := range c.Keys {
+			if _, ok := changes[k]; ok {
+				delete(changes, k)
+				cancelled = true
+			}
+		}
+		if len(changes) > 0 {
+			next = append(next, &ScheduledChange{Block: sc.Block, Changes: changes, ApprovedAt: sc.ApprovedAt})
+		}
+	}
+	if !cancelled {
+		return nil, errNoScheduledChanges
+	}
+	return next, nil
+}
+
+// scheduledItems returns the changes scheduled as of the block `at` and activated in [from, to],
+// merged in the order of activation. The changes before the timelock hardfork are not activated.
+func (gov *Governance) scheduledItems(at, from, to uint64) map[string]interface{} {
+	items := make(map[string]interface{})
+	for _, sc := range gov.ScheduledChangesAt(at) {
+		if from <= sc.Block && sc.Block <= to && gov.isTimelockEnabled(sc.Block) {
+			for k, v := range sc.Changes {
+				items[k] = v
+			}
+		}
+	}
+	return items
+}
+
+// isTimelockEnabled returns whether timelock is enabled at the block.
+func (gov *Governance) isTimelockEnabled(number uint64) bool {
+	return gov.chainConfig != nil && gov.chainConfig.IsTimelockForkEnabled(new(big.Int).SetUint64(number))
+}
+
+// governanceEffectiveBlock returns the first block using the governance items stored at idx.
+// The scheduled changes activated before the block are already merged into the items.
+func governanceEffectiveBlock(idx, epoch uint64) uint64 {
+	if idx == 0 {
+		return 0
+	}
+	return idx + epoch
+}
+
+// pendingScheduledChanges returns the scheduled changes activated after the block `number`.
+func (gov *Governance) pendingScheduledChanges(number uint64) []*ScheduledChange {
+	var pending []*ScheduledChange
+	for _, sc := range gov.ScheduledChangesAt(number) {
+		if sc.Block > number {
+			pending = append(pending, sc)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimelockChange(t *testing.T) {
+	c, err := ParseTimelockChange(`{"block":100,"changes":{"KIP71.GasTarget":40000000,"reward.ratio":"50/20/30"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), c.Block)
+	assert.Equal(t, map[string]interface{}{
+		"kip71.gastarget": uint64(40000000),
+		"reward.ratio":    "50/20/30",
+	}, c.Changes)
+
+	// the same timelock in another order or case has the same canonical value
+	same, err := ParseTimelockChange(`{"changes":{"reward.ratio":"50/20/30","kip71.gastarget":40000000},"block":100}`)
+	assert.NoError(t, err)
+	assert.Equal(t, c.String(), same.String())
+
+	c, err = ParseTimelockChange(`{"block":100,"cancel":true}`)
+	assert.NoError(t, err)
+	assert.True(t, c.Cancel)
+
+	c, err = ParseTimelockChange(`{"block":100,"cancel":true,"keys":["Reward.Ratio","kip71.gastarget"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kip71.gastarget", "reward.ratio"}, c.Keys)
+
+	testcases := []struct {
+		value string
+		err   error
+	}{
+		{`{"changes":{"kip71.gastarget":1}}`, errZeroTimelockBlock},
+		{`{"block":100}`, errEmptyTimelock},
+		{`{"block":100,"cancel":true,"changes":{"kip71.gastarget":1}}`, errCancelTimelockChanges},
+		{`{"block":100,"changes":{"istanbul.epoch":10}}`, errInvalidTimelockItem},
+		{`{"block":100,"changes":{"istanbul.policy":0}}`, errInvalidTimelockItem},
+		{`{"block":100,"changes":{"istanbul.committeesize":7}}`, errInvalidTimelockItem},
+		{`{"block":100,"changes":{"governance.governancemode":"single"}}`, errInvalidTimelockItem},
+		{`{"block":100,"changes":{"kip71.gastarget":1},"keys":["kip71.gastarget"]}`, errTimelockKeysNotCancel},
+		{`{"block":100,"cancel":true,"keys":["istanbul.committeesize"]}`, errInvalidTimelockItem},
+		{`{"block":100,"cancel":true,"keys":["kip71.gastarget","KIP71.GasTarget"]}`, errDuplicatedTimelockItem},
+		{`{"block":100,"changes":{"governance.addvalidator":"0x0000000000000000000000000000000000000001"}}`, errInvalidTimelockItem},
+		{`{"block":100,"changes":{"kip71.gastarget":1.5}}`, errInvalidTimelockValue},
+		{`{"block":100,"changes":{"reward.ratio":"50/20/20"}}`, errInvalidTimelockValue},
+		{`{"block":100,"changes":{"kip71.gastarget":1,"KIP71.GasTarget":2}}`, errDuplicatedTimelockItem},
+		{`{"block":100,"changes":{"kip71.gastarget":1},"epoch":10}`, errUnknownTimelockField},
+	}
+	for _, tc := range testcases {
+		_, err := ParseTimelockChange(tc.value)
+		assert.Equal(t, tc.err, err, tc.value)
+	}
+}
+
+func TestGovernance_Timelock(t *testing.T) {
+	var (
+		epoch     = uint64(30)
+		unitPrice = uint64(25000)
+	)
+	config := getTestConfig()
+	config.Istanbul.Epoch = epoch
+	config.UnitPrice = unitPrice
+	config.TimelockCompatibleBlock = big.NewInt(30)
+	dbm := database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	gov := NewGovernanceInitialize(config, dbm)
+
+	headerGovernance := func(items map[string]interface{}) []byte {
+		j, err := json.Marshal(items)
+		require.NoError(t, err)
+		b, err := rlp.EncodeToBytes(j)
+		require.NoError(t, err)
+		return b
+	}
+	unitPriceAt := func(num uint64) uint64 {
+		pset, err := gov.ParamsAt(num)
+		require.NoError(t, err)
+		return pset.UnitPrice()
+	}
+
+	// Approved at 30, the changes are scheduled at 100 and 200
+	gov.WriteGovernanceForNextEpoch(30, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":100,"changes":{"governance.unitprice":40}}`,
+	}))
+	gov.WriteGovernanceForNextEpoch(60, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":200,"changes":{"governance.unitprice":50}}`,
+	}))
+	require.Equal(t, 2, len(gov.ScheduledChangesAt(60)))
+	require.Equal(t, 1, len(gov.ScheduledChangesAt(59)))
+
+	// A timelock for a past block is ignored
+	gov.WriteGovernanceForNextEpoch(90, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":90,"changes":{"governance.unitprice":1}}`,
+	}))
+	require.Equal(t, 2, len(gov.ScheduledChangesAt(90)))
+
+	assert.Equal(t, unitPrice, unitPriceAt(99))
+	assert.Equal(t, uint64(40), unitPriceAt(100))
+	assert.Equal(t, uint64(50), unitPriceAt(200))
+
+	// The changes at 200 are cancelled before the activation
+	gov.WriteGovernanceForNextEpoch(120, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":200,"cancel":true}`,
+	}))
+	require.Equal(t, 1, len(gov.ScheduledChangesAt(120)))
+	assert.Equal(t, uint64(40), unitPriceAt(200))
+
+	// The cancellation is stored at 120, so it is dropped if the block is processed again without it
+	require.Equal(t, 2, len(gov.ScheduledChangesAt(119)))
+	gov.WriteGovernanceForNextEpoch(120, nil)
+	assert.Equal(t, uint64(50), unitPriceAt(200))
+	gov.WriteGovernanceForNextEpoch(120, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":200,"cancel":true}`,
+	}))
+	assert.Equal(t, uint64(40), unitPriceAt(200))
+
+	// The activated changes are folded into the stored items,
+	// and the newer votes override the scheduled changes.
+	gov.WriteGovernanceForNextEpoch(150, headerGovernance(map[string]interface{}{
+		"governance.unitprice": 60,
+	}))
+	_, items, err := dbm.ReadGovernanceAtNumber(180, epoch)
+	require.NoError(t, err)
+	assert.Equal(t, float64(60), items["governance.unitprice"])
+	assert.Equal(t, uint64(40), unitPriceAt(179))
+	assert.Equal(t, uint64(60), unitPriceAt(180))
+
+	// Only the changes not activated yet are pending
+	gov.WriteGovernanceForNextEpoch(180, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":300,"changes":{"governance.unitprice":70}}`,
+	}))
+	pending := gov.pendingScheduledChanges(180)
+	require.Equal(t, 1, len(pending))
+	assert.Equal(t, uint64(300), pending[0].Block)
+	assert.Equal(t, uint64(180), pending[0].ApprovedAt)
+
+	// A timelock approved before the hardfork is ignored
+	config.TimelockCompatibleBlock = big.NewInt(31)
+	dbm = database.NewDBManager(&database.DBConfig{DBType: database.MemoryDB})
+	gov = NewGovernanceInitialize(config, dbm)
+	gov.WriteGovernanceForNextEpoch(30, headerGovernance(map[string]interface{}{
+		"governance.timelock": `{"block":100,"changes":{"governance.unitprice":40}}`,
+	}))
+	assert.Empty(t, gov.ScheduledChangesAt(30))
+	assert.Equal(t, unitPrice, unitPriceAt(100))
+}
+
+func TestApplyTimelock(t *testing.T) {
+	scheduled := []*ScheduledChange{
+		{Block: 100, Changes: map[string]interface{}{"governance.unitprice": uint64(40), "kip71.gastarget": uint64(1)}, ApprovedAt: 30},
+		{Block: 200, Changes: map[string]interface{}{"governance.unitprice": uint64(50)}, ApprovedAt: 60},
+	}
+
+	// Only the given key is cancelled, and the given changes are not modified
+	next, err := applyTimelock(90, `{"block":100,"cancel":true,"keys":["governance.unitprice"]}`, scheduled)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(next))
+	assert.Equal(t, map[string]interface{}{"kip71.gastarget": uint64(1)}, next[0].Changes)
+	assert.Equal(t, 2, len(scheduled[0].Changes))
+
+	// A schedule without a remaining key is dropped
+	next, err = applyTimelock(90, `{"block":200,"cancel":true,"keys":["governance.unitprice"]}`, scheduled)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(next))
+	assert.Equal(t, uint64(100), next[0].Block)
+
+	_, err = applyTimelock(90, `{"block":200,"cancel":true,"keys":["kip71.gastarget"]}`, scheduled)
+	assert.Equal(t, errNoScheduledChanges, err)
+	_, err = applyTimelock(100, `{"block":100,"cancel":true}`, scheduled)
+	assert.Equal(t, errPastTimelock, err)
+}
@@ -50,6 +50,12 @@ var (
 	ErrLengthTooLong                        = errors.New("length too long")
 	ErrNestedCompositeType                  = errors.New("nested composite type")
 	ErrLegacyTransactionMustBeWithLegacyKey = errors.New("a legacy transaction must be with a legacy account key")
+	ErrSessionKeyNoExpiry                   = errors.New("session key has no expiry")
+	ErrSessionKeyExpired                    = errors.New("session key expired")
+	ErrSessionKeyTargetNotAllowed           = errors.New("the recipient is not allowed by the session key")
+	ErrSessionKeyMethodNotAllowed           = errors.New("the method is not allowed by the session key")
+	ErrSessionKeyValueLimitExceeded         = errors.New("value exceeds the limit of the session key")
+	ErrSessionKeyNotRoleKey                 = errors.New("session key should be a role key other than the account update key")
 
 	ErrDeprecated   = errors.New("deprecated feature")
 	ErrNotSupported = errors.New("not supported")
@@ -126,6 +126,11 @@ const (
 	KAS
 	FORK
 	NodeCnGasPrice
+	ConsensusIstanbulSigner
+	CMDKSIGNER
+	AccountsFeePayer
+	CMDKFEEPAYER
+	AccountsExternal
 
 	// ModuleNameLen should be placed at the end of the list.
 	ModuleNameLen
@@ -203,4 +208,9 @@ var moduleNames = [ModuleNameLen]string{
 	"kas",
 	"fork",
 	"node/cn/gasprice",
+	"consensus/istanbul/signer",
+	"cmd/ksigner",
+	"accounts/feepayer",
+	"cmd/kfeepayer",
+	"accounts/external",
 }
@@ -24,6 +24,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"io"
 	"net"
@@ -33,6 +34,7 @@ import (
 	"github.com/klaytn/klaytn/log"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -40,6 +42,7 @@ var logger = log.NewModuleLogger(log.NetworksGRPC)
 
 type Listener struct {
 	Addr       string
+	TLSConfig  *tls.Config // If set, the listener only accepts TLS connections (e.g. mutually-authenticated)
 	handler    *rpc.Server
 	grpcServer *grpc.Server
 }
@@ -258,7 +261,11 @@ func (gs *Listener) Start() {
 		// TODO-Klaytn-gRPC Need to handle err
 		logger.Error("failed to listen", "err", err)
 	}
-	gs.grpcServer = grpc.NewServer()
+	var opts []grpc.ServerOption
+	if gs.TLSConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(gs.TLSConfig)))
+	}
+	gs.grpcServer = grpc.NewServer(opts...)
 
 	RegisterKlaytnNodeServer(gs.grpcServer, &klaytnServer{handler: gs.handler})
 
@@ -27,17 +27,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 	"strings"
 	"time"
 
+	klaytnapi "github.com/klaytn/klaytn/api"
 	"github.com/klaytn/klaytn/blockchain"
 	"github.com/klaytn/klaytn/blockchain/state"
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/common/hexutil"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/node/cn/gasprice"
 	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/reward"
 	"github.com/klaytn/klaytn/rlp"
 	"github.com/klaytn/klaytn/storage/statedb"
 	"github.com/klaytn/klaytn/work"
@@ -59,6 +63,122 @@ func (api *PublicKlayAPI) Rewardbase() (common.Address, error) {
 	return api.cn.Rewardbase()
 }
 
+// GetTotalSupply returns the total supply of KLAY at the given block and its breakdown.
+// It is available after the total supply is backfilled by admin_backfillTotalSupply.
+func (api *PublicKlayAPI) GetTotalSupply(blockNumber rpc.BlockNumber) (*reward.TotalSupply, error) {
+	return api.cn.supplyManager.GetTotalSupply(api.cn.blockNumber(blockNumber))
+}
+
+// GetRewardsByAddress returns the rewards given to the address in the blocks [fromBlock, toBlock],
+// split by proposer, stakers, KGF and KIR. It is available if the node runs with --rewardindexing.
+func (api *PublicKlayAPI) GetRewardsByAddress(address common.Address, fromBlock, toBlock rpc.BlockNumber) (*reward.AddressRewards, error) {
+	if api.cn.rewardIndexer == nil {
+		return nil, errRewardIndexingDisabled
+	}
+	return reward.ReadRewardsByAddress(api.cn.chainDB, address, api.cn.blockNumber(fromBlock), api.cn.blockNumber(toBlock))
+}
+
+// GetRewardsRange returns the reward distribution summed up over the blocks [fromBlock, toBlock].
+// The range is limited to reward.MaxRewardRangeBlocks blocks.
+// It is available if the node runs with --rewardindexing.
+func (api *PublicKlayAPI) GetRewardsRange(fromBlock, toBlock rpc.BlockNumber) (*reward.RangeRewards, error) {
+	if api.cn.rewardIndexer == nil {
+		return nil, errRewardIndexingDisabled
+	}
+	return reward.ReadRewardsRange(api.cn.chainDB, api.cn.blockNumber(fromBlock), api.cn.blockNumber(toBlock))
+}
+
+// FeeHistoryDetailedResult is the result of klay_feeHistoryDetailed.
+type FeeHistoryDetailedResult struct {
+	OldestBlock  *hexutil.Big                `json:"oldestBlock"`
+	BaseFee      []*hexutil.Big              `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64                   `json:"gasUsedRatio"`
+	Details      []*gasprice.BlockFeeDetails `json:"details"`
+}
+
+// FeeHistoryDetailed returns the fee statistics of the specified range of blocks broken down by
+// the transaction families, i.e., legacy, dynamicFee, feeDelegated and feeDelegatedWithRatio,
+// separating the fees paid by the senders and the fee payers.
+func (api *PublicKlayAPI) FeeHistoryDetailed(ctx context.Context, blockCount klaytnapi.DecimalOrHex, lastBlock rpc.BlockNumber) (*FeeHistoryDetailedResult, error) {
+	oldest, details, baseFee, gasUsedRatio, err := api.cn.APIBackend.gpo.FeeHistoryDetailed(ctx, int(blockCount), lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	result := &FeeHistoryDetailedResult{
+		OldestBlock:  (*hexutil.Big)(oldest),
+		GasUsedRatio: gasUsedRatio,
+		Details:      details,
+	}
+	if baseFee != nil {
+		result.BaseFee = make([]*hexutil.Big, len(baseFee))
+		for i, v := range baseFee {
+			result.BaseFee[i] = (*hexutil.Big)(v)
+		}
+	}
+	return result, nil
+}
+
+// PredictBaseFee projects the base fees of the blockCount blocks following the latest block
+// under the scenario given by args, using the KIP-71 parameters scheduled by the governance.
+// The gas used by the blocks is given by args.GasUsed, or estimated from the txpool if it is empty.
+func (api *PublicKlayAPI) PredictBaseFee(blockCount klaytnapi.DecimalOrHex, args *BaseFeePredictionArgs) (*BaseFeePrediction, error) {
+	if blockCount < 1 || blockCount > maxBaseFeePredictionBlocks {
+		return nil, errInvalidBaseFeePredictionCount
+	}
+	if args == nil {
+		args = new(BaseFeePredictionArgs)
+	}
+	if len(args.GasUsed) != 0 && len(args.GasUsed) != int(blockCount) {
+		return nil, errGasUsedLengthMismatch
+	}
+
+	head := api.cn.blockchain.CurrentHeader()
+	gov := api.cn.governance
+
+	var (
+		pendingParams *params.GovParamSet
+		pendingFrom   uint64
+		err           error
+	)
+	if args.PendingGovernance {
+		if pendingParams, pendingFrom, err = pendingKIP71Changes(gov, head.Number.Uint64()); err != nil {
+			return nil, err
+		}
+	}
+	kip71At := newKIP71At(gov, pendingParams, pendingFrom)
+
+	var gasUsedAt func(i int, baseFee *big.Int, kip71 *params.KIP71Config) uint64
+	if len(args.GasUsed) != 0 {
+		gasUsedAt = func(i int, _ *big.Int, _ *params.KIP71Config) uint64 { return uint64(args.GasUsed[i]) }
+	} else {
+		pending, _ := api.cn.txPool.Content()
+		gasUsedAt = newPoolDemand(pending).gasUsed
+	}
+
+	baseFees, gasUsed, err := predictBaseFees(api.cn.chainConfig, head, int(blockCount), kip71At, gasUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	result := &BaseFeePrediction{
+		OldestBlock: (*hexutil.Big)(new(big.Int).Add(head.Number, common.Big1)),
+		BaseFee:     make([]*hexutil.Big, len(baseFees)),
+		GasUsed:     make([]hexutil.Uint64, len(gasUsed)),
+	}
+	for i := range baseFees {
+		result.BaseFee[i] = (*hexutil.Big)(baseFees[i])
+		result.GasUsed[i] = hexutil.Uint64(gasUsed[i])
+	}
+	return result, nil
+}
+
+// blockNumber returns the number of the given block, regarding the pending block as the latest one.
+func (s *CN) blockNumber(blockNumber rpc.BlockNumber) uint64 {
+	if blockNumber == rpc.LatestBlockNumber || blockNumber == rpc.PendingBlockNumber {
+		return s.blockchain.CurrentHeader().Number.Uint64()
+	}
+	return blockNumber.Uint64()
+}
+
 // PrivateAdminAPI is the collection of CN full node-related APIs
 // exposed over the private admin endpoint.
 type PrivateAdminAPI struct {
@@ -197,6 +317,13 @@ func (api *PrivateAdminAPI) StateMigrationStatus() map[string]interface{} {
 	}
 }
 
+// BackfillTotalSupply starts to accumulate the total supply from the genesis block in the background.
+// If it was interrupted, it resumes from the last stored checkpoint.
+// The "backfill-total-supply" command does the same while the node is stopped.
+func (api *PrivateAdminAPI) BackfillTotalSupply() error {
+	return api.cn.supplyManager.Backfill()
+}
+
 func (api *PrivateAdminAPI) SaveTrieNodeCacheToDisk() error {
 	return api.cn.BlockChain().SaveTrieNodeCacheToDisk()
 }
@@ -299,6 +426,12 @@ func (api *PublicDebugAPI) DumpBlock(ctx context.Context, blockNrOrHash rpc.Bloc
 	return stateDb.RawDump(), nil
 }
 
+// CheckTotalSupply compares the accumulated total supply at the given block with
+// the sum of all balances in the state. It iterates the whole state trie.
+func (api *PublicDebugAPI) CheckTotalSupply(blockNumber rpc.BlockNumber) (*reward.SupplyCheckResult, error) {
+	return api.cn.supplyManager.CheckTotalSupply(api.cn.blockNumber(blockNumber))
+}
+
 type Trie struct {
 	Type   string `json:"type"`
 	Hash   string `json:"hash"`
@@ -21,6 +21,7 @@
 package cn
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"math/big"
@@ -41,11 +42,13 @@ import (
 	"github.com/klaytn/klaytn/consensus"
 	"github.com/klaytn/klaytn/consensus/istanbul"
 	istanbulBackend "github.com/klaytn/klaytn/consensus/istanbul/backend"
+	istanbulSigner "github.com/klaytn/klaytn/consensus/istanbul/signer"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/event"
 	"github.com/klaytn/klaytn/governance"
 	"github.com/klaytn/klaytn/networks/p2p"
+	"github.com/klaytn/klaytn/networks/p2p/discover"
 	"github.com/klaytn/klaytn/networks/rpc"
 	"github.com/klaytn/klaytn/node"
 	"github.com/klaytn/klaytn/node/cn/filters"
@@ -58,7 +61,10 @@ import (
 	"github.com/klaytn/klaytn/work"
 )
 
-var errCNLightSync = errors.New("can't run cn.CN in light sync mode")
+var (
+	errCNLightSync            = errors.New("can't run cn.CN in light sync mode")
+	errRewardIndexingDisabled = errors.New("reward indexing is disabled; restart with --rewardindexing")
+)
 
 //go:generate mockgen -destination=node/cn/mocks/lesserver_mock.go -package=mocks github.com/klaytn/klaytn/node/cn LesServer
 type LesServer interface {
@@ -88,6 +94,7 @@ type Miner interface {
 type BackendProtocolManager interface {
 	Downloader() ProtocolManagerDownloader
 	SetWsEndPoint(wsep string)
+	SetValidatorProof(proof []byte)
 	GetSubProtocols() []p2p.Protocol
 	ProtocolVersion() int
 	ReBroadcastTxs(transactions types.Transactions)
@@ -137,6 +144,9 @@ type CN struct {
 	components []interface{}
 
 	governance governance.Engine
+
+	supplyManager *reward.SupplyManager
+	rewardIndexer *reward.RewardIndexer // nil if reward indexing is disabled
 }
 
 func (s *CN) AddLesServer(ls LesServer) {
@@ -216,6 +226,11 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	setEngineType(chainConfig)
 
+	// Fail early if the reward policy selected in the genesis is not available
+	if _, err := reward.NewRewardPolicy(chainConfig); err != nil {
+		return nil, err
+	}
+
 	// load governance state
 	chainConfig.SetDefaults()
 	// latest values will be applied to chainConfig after NewMixedEngine call
@@ -240,9 +255,10 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		governance:        governance,
 	}
 
-	// istanbul BFT. Derive and set node's address using nodekey
+	// istanbul BFT. Set node's address to the validator address of the consensus engine
+	nodeAddr := validatorAddress(ctx, cn.engine)
 	if cn.chainConfig.Istanbul != nil {
-		governance.SetNodeAddress(crypto.PubkeyToAddress(ctx.NodeKey().PublicKey))
+		governance.SetNodeAddress(nodeAddr)
 	}
 
 	logger.Info("Initialising Klaytn protocol", "versions", cn.engine.Protocol().Versions, "network", config.NetworkId)
@@ -316,6 +332,20 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 
 	cn.protocolManager.SetWsEndPoint(config.WsEndpoint)
 
+	// The peers find a validator by the address of its nodekey, unless it proves its validator address.
+	if nodeKey := ctx.NodeKey(); nodeAddr != crypto.PubkeyToAddress(nodeKey.PublicKey) {
+		signer, ok := cn.engine.(interface{ SignNodeID([]byte) ([]byte, error) })
+		if !ok {
+			return nil, fmt.Errorf("consensus engine cannot prove the validator address %s", nodeAddr.String())
+		}
+		nodeID := discover.PubkeyID(&nodeKey.PublicKey)
+		proof, err := signer.SignNodeID(nodeID[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign the node ID with the validator key: %v", err)
+		}
+		cn.protocolManager.SetValidatorProof(proof)
+	}
+
 	if err := cn.setRewardWallet(); err != nil {
 		logger.Error("Error happened while setting the reward wallet", "err", err)
 	}
@@ -324,6 +354,10 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		// NewStakingManager is called with proper non-nil parameters
 		reward.NewStakingManager(cn.blockchain, governance, cn.chainDB)
 	}
+	cn.supplyManager = reward.NewSupplyManager(cn.blockchain, governance, cn.chainDB)
+	if config.RewardIndexing {
+		cn.rewardIndexer = reward.NewRewardIndexer(cn.blockchain, governance, cn.chainDB)
+	}
 
 	// set worker
 	if config.WorkerDisable {
@@ -336,7 +370,7 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 		}
 	} else {
 		// TODO-Klaytn improve to handle drop transaction on network traffic in PN and EN
-		cn.miner = work.New(cn, cn.chainConfig, cn.EventMux(), cn.engine, ctx.NodeType(), crypto.PubkeyToAddress(ctx.NodeKey().PublicKey), cn.config.TxResendUseLegacy)
+		cn.miner = work.New(cn, cn.chainConfig, cn.EventMux(), cn.engine, ctx.NodeType(), nodeAddr, cn.config.TxResendUseLegacy)
 	}
 
 	// istanbul BFT
@@ -350,7 +384,7 @@ func New(ctx *node.ServiceContext, config *Config) (*CN, error) {
 	//         So let's override gpoParams.Default with config.GasPrice
 	gpoParams.Default = config.GasPrice
 
-	cn.APIBackend.gpo = gasprice.NewOracle(cn.APIBackend, gpoParams, cn.txPool)
+	cn.APIBackend.gpo = gasprice.NewOracle(cn.APIBackend, gpoParams, cn.txPool, cn.governance)
 	//@TODO Klaytn add core component
 	cn.addComponent(cn.blockchain)
 	cn.addComponent(cn.txPool)
@@ -472,7 +506,34 @@ func CreateConsensusEngine(ctx *node.ServiceContext, config *Config, chainConfig
 	if chainConfig.Governance == nil {
 		chainConfig.Governance = params.GetDefaultGovernanceConfig()
 	}
-	return istanbulBackend.New(config.Rewardbase, &config.Istanbul, ctx.NodeKey(), db, gov, nodetype)
+	if config.Istanbul.RemoteSigner == "" {
+		return istanbulBackend.New(config.Rewardbase, &config.Istanbul, ctx.NodeKey(), db, gov, nodetype)
+	}
+
+	var tlsConfig *tls.Config
+	if config.Istanbul.RemoteSignerTLSCert != "" {
+		var err error
+		tlsConfig, err = istanbulSigner.NewClientTLSConfig(config.Istanbul.RemoteSignerTLSCert, config.Istanbul.RemoteSignerTLSKey, config.Istanbul.RemoteSignerTLSCA)
+		if err != nil {
+			logger.Crit("Failed to load the remote signer TLS configuration", "err", err)
+		}
+	}
+	remoteSigner, err := istanbulSigner.NewRemoteSigner(config.Istanbul.RemoteSigner, tlsConfig)
+	if err != nil {
+		logger.Crit("Failed to connect to the remote signer", "endpoint", config.Istanbul.RemoteSigner, "err", err)
+	}
+	// The validator key is kept by the remote signer only, and the nodekey is the p2p identity of the node.
+	logger.Info("Signing with the remote signer", "validator", remoteSigner.Address(), "endpoint", config.Istanbul.RemoteSigner)
+	return istanbulBackend.NewWithSigner(config.Rewardbase, &config.Istanbul, remoteSigner, db, gov, nodetype)
+}
+
+// validatorAddress returns the address of the key signing consensus messages and blocks.
+// It is the address of the remote signer if configured, or the address of the nodekey otherwise.
+func validatorAddress(ctx *node.ServiceContext, engine consensus.Engine) common.Address {
+	if signer, ok := engine.(interface{ Address() common.Address }); ok {
+		return signer.Address()
+	}
+	return crypto.PubkeyToAddress(ctx.NodeKey().PublicKey)
 }
 
 // APIs returns the collection of RPC services the ethereum package offers.
@@ -678,6 +739,10 @@ func (s *CN) Start(srvr p2p.Server) error {
 	}
 
 	reward.StakingManagerSubscribe()
+	s.supplyManager.Start()
+	if s.rewardIndexer != nil {
+		s.rewardIndexer.Start()
+	}
 
 	return nil
 }
@@ -696,7 +761,15 @@ func (s *CN) Stop() error {
 	close(s.closeBloomHandler)
 	s.txPool.Stop()
 	s.miner.Stop()
+	// Release the remote signer after the miner stops signing blocks
+	if closer, ok := s.engine.(interface{ Close() }); ok {
+		closer.Close()
+	}
 	reward.StakingManagerUnsubscribe()
+	s.supplyManager.Stop()
+	if s.rewardIndexer != nil {
+		s.rewardIndexer.Stop()
+	}
 	s.blockchain.Stop()
 	s.chainDB.Close()
 	s.eventMux.Stop()
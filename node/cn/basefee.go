@@ -0,0 +1,179 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/common/hexutil"
+	"github.com/klaytn/klaytn/consensus/misc"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+)
+
+// maxBaseFeePredictionBlocks is the max number of blocks whose base fees are predicted at once.
+const maxBaseFeePredictionBlocks = 1024
+
+var (
+	errInvalidBaseFeePredictionCount = errors.New("block count should be between 1 and 1024")
+	errGasUsedLengthMismatch         = errors.New("the length of gasUsed should be the same as the block count")
+)
+
+// kip71Params is the governance parameters used for the base fee calculation.
+var kip71Params = []int{
+	params.LowerBoundBaseFee,
+	params.UpperBoundBaseFee,
+	params.GasTarget,
+	params.MaxBlockGasUsedForBaseFee,
+	params.BaseFeeDenominator,
+}
+
+// BaseFeePredictionArgs is the scenario of the base fee prediction.
+type BaseFeePredictionArgs struct {
+	// GasUsed is the gas used by each of the upcoming blocks.
+	// If it is empty, the demand of the pending transactions in the txpool is used.
+	GasUsed []hexutil.Uint64 `json:"gasUsed,omitempty"`
+	// PendingGovernance applies the pending changes of the KIP-71 parameters from the block they
+	// would take effect. See pendingKIP71Changes for which changes are regarded as pending.
+	PendingGovernance bool `json:"pendingGovernance,omitempty"`
+}
+
+// BaseFeePrediction is the base fees projected for the upcoming blocks.
+// The base fee of the first block is exact unless the KIP-71 parameters are changed unexpectedly,
+// and the rest depend on the gas used by the blocks assumed by the scenario.
+type BaseFeePrediction struct {
+	OldestBlock *hexutil.Big     `json:"oldestBlock"`
+	BaseFee     []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsed     []hexutil.Uint64 `json:"gasUsed"`
+}
+
+// predictBaseFees projects the base fees of the count blocks following parent by the KIP-71 formula.
+// kip71At returns the KIP-71 parameters used to calculate the base fee of the block (see newKIP71At).
+// gasUsedAt returns the gas used by the i-th block whose base fee and KIP-71 parameters are given.
+func predictBaseFees(config *params.ChainConfig, parent *types.Header, count int,
+	kip71At func(num uint64) (*params.KIP71Config, error),
+	gasUsedAt func(i int, baseFee *big.Int, kip71 *params.KIP71Config) uint64,
+) ([]*big.Int, []uint64, error) {
+	var (
+		baseFees = make([]*big.Int, count)
+		gasUsed  = make([]uint64, count)
+	)
+	for i := 0; i < count; i++ {
+		num := new(big.Int).Add(parent.Number, common.Big1)
+		kip71, err := kip71At(num.Uint64())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		header := &types.Header{Number: num}
+		if config.IsMagmaForkEnabled(num) {
+			header.BaseFee = misc.NextMagmaBlockBaseFee(parent, kip71)
+			baseFees[i] = header.BaseFee
+		} else {
+			baseFees[i] = new(big.Int).SetUint64(params.ZeroBaseFee)
+		}
+		header.GasUsed = gasUsedAt(i, baseFees[i], kip71)
+		gasUsed[i] = header.GasUsed
+		parent = header
+	}
+	return baseFees, gasUsed, nil
+}
+
+// newKIP71At returns the function resolving the KIP-71 parameters used to calculate the base fee
+// of a block. As the consensus engine verifies the base fee of a block with the parameters at its
+// parent, the parameters at num-1 are returned for the block num. If pending is not nil, it is
+// applied to the parameters at pendingFrom and later.
+func newKIP71At(gov governance.Engine, pending *params.GovParamSet, pendingFrom uint64) func(num uint64) (*params.KIP71Config, error) {
+	return func(num uint64) (*params.KIP71Config, error) {
+		if num > 0 {
+			num--
+		}
+		pset, err := gov.ParamsAt(num)
+		if err != nil {
+			return nil, err
+		}
+		if pending != nil && num >= pendingFrom {
+			pset = params.NewGovParamSetMerged(pset, pending)
+		}
+		return pset.ToKIP71Config(), nil
+	}
+}
+
+// poolDemand estimates the gas used by the upcoming blocks from the pending transactions.
+// A block includes the transactions affording its base fee in the descending order of their
+// gas fee caps, up to kip71.maxblockgasusedforbasefee. The gas limits of the transactions are
+// regarded as their gas used, so the estimation is an upper bound.
+type poolDemand struct {
+	txs types.Transactions
+}
+
+func newPoolDemand(pending map[common.Address]types.Transactions) *poolDemand {
+	var txs types.Transactions
+	for _, list := range pending {
+		txs = append(txs, list...)
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].GasFeeCap().Cmp(txs[j].GasFeeCap()) > 0
+	})
+	return &poolDemand{txs: txs}
+}
+
+// gasUsed removes the transactions included in the block from the demand and returns their gas.
+func (d *poolDemand) gasUsed(_ int, baseFee *big.Int, kip71 *params.KIP71Config) uint64 {
+	var (
+		used      uint64
+		remaining types.Transactions
+	)
+	for _, tx := range d.txs {
+		if tx.GasFeeCap().Cmp(baseFee) < 0 || used+tx.Gas() > kip71.MaxBlockGasUsedForBaseFee {
+			remaining = append(remaining, tx)
+			continue
+		}
+		used += tx.Gas()
+	}
+	d.txs = remaining
+	return used
+}
+
+// pendingKIP71Changes returns the KIP-71 parameters among the pending governance changes of gov,
+// and the block from which they would be effective if there is any.
+// The pending changes are the ones approved by the majority of the votes in the headers of the
+// current epoch, which are written at the next epoch block. The votes not approved yet, including
+// the ones cast by this node with governance_vote, are not regarded as pending. The changes
+// scheduled by the timelock are already applied by gov.ParamsAt.
+func pendingKIP71Changes(gov governance.Engine, head uint64) (*params.GovParamSet, uint64, error) {
+	items := make(map[string]interface{})
+	pending := gov.PendingChanges()
+	for _, key := range kip71Params {
+		name := governance.GovernanceKeyMapReverse[key]
+		if v, ok := pending[name]; ok {
+			items[name] = v
+		}
+	}
+	pset, err := params.NewGovParamSetStrMap(items)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The pending changes are written at the next epoch block, and are effective after an epoch.
+	epoch := gov.Params().Epoch()
+	return pset, head - head%epoch + 2*epoch, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package cn
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	istanbulBackend "github.com/klaytn/klaytn/consensus/istanbul/backend"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/governance"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configChain is the chain providing only the chain config to the consensus engine.
+type configChain struct {
+	consensus.ChainReader
+	config *params.ChainConfig
+}
+
+func (c *configChain) Config() *params.ChainConfig { return c.config }
+
+func TestPredictBaseFees(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		db     = database.NewMemoryDBManager()
+	)
+	config := params.TestChainConfig.Copy()
+	config.MagmaCompatibleBlock = big.NewInt(18)
+	config.Istanbul = &params.IstanbulConfig{Epoch: 10, ProposerPolicy: uint64(istanbul.WeightedRandom), SubGroupSize: 1}
+	config.Governance = params.GetDefaultGovernanceConfig()
+	config.Governance.KIP71.LowerBoundBaseFee = 25 * params.Ston
+	config.Governance.KIP71.UpperBoundBaseFee = 750 * params.Ston
+	config.Governance.KIP71.GasTarget = 30000000
+	config.Governance.KIP71.MaxBlockGasUsedForBaseFee = 60000000
+	config.Governance.KIP71.BaseFeeDenominator = 20
+	gov := governance.NewMixedEngine(config, db)
+
+	// The gas target is doubled by the governance written at block 10, which is effective from block 20
+	delta := governance.NewGovernanceSet()
+	delta.SetValue(params.GasTarget, uint64(60000000))
+	headerGov := gov.HeaderGov().(*governance.Governance)
+	require.Nil(t, headerGov.WriteGovernance(10, governance.GetGovernanceItemsFromChainConfig(config), delta))
+
+	parent := &types.Header{Number: big.NewInt(17), Time: common.Big0}
+	gasUsed := []uint64{40000000, 40000000, 40000000, 40000000, 70000000}
+	baseFees, used, err := predictBaseFees(config, parent, len(gasUsed), newKIP71At(gov, nil, 0),
+		func(i int, _ *big.Int, _ *params.KIP71Config) uint64 { return gasUsed[i] })
+	require.Nil(t, err)
+	assert.Equal(t, gasUsed, used)
+
+	// The lower bound at the Magma hardfork
+	assert.Equal(t, new(big.Int).SetUint64(25*params.Ston), baseFees[0])
+	// 40M gas increases the base fee of block 20 with the original gas target,
+	// but decreases the base fee of block 21 with the doubled one
+	assert.True(t, baseFees[2].Cmp(baseFees[1]) > 0)
+	assert.True(t, baseFees[3].Cmp(baseFees[2]) < 0)
+
+	// The consensus engine accepts the predicted base fees. Since the headers are not sealed,
+	// the verification fails right after the base fee is checked.
+	engine := istanbulBackend.New(addr, istanbul.DefaultConfig, key, db, gov, common.CONSENSUSNODE)
+	headers := []*types.Header{parent}
+	for i := range gasUsed {
+		headers = append(headers, &types.Header{
+			Number:  new(big.Int).Add(parent.Number, big.NewInt(int64(i+1))),
+			BaseFee: baseFees[i],
+			GasUsed: gasUsed[i],
+			Time:    common.Big0,
+		})
+	}
+	abort, results := engine.VerifyHeaders(&configChain{config: config}, headers, make([]bool, len(headers)))
+	defer close(abort)
+	for _, header := range headers {
+		assert.EqualError(t, <-results, "invalid extra data format", "block %d", header.Number.Uint64())
+	}
+}
+
+func TestPoolDemand(t *testing.T) {
+	newTx := func(gas uint64, gasPrice int64) *types.Transaction {
+		return types.NewTransaction(0, common.Address{}, big.NewInt(0), gas, big.NewInt(gasPrice), nil)
+	}
+	pending := map[common.Address]types.Transactions{
+		common.HexToAddress("0x1"): {newTx(300, 50), newTx(300, 20)},
+		common.HexToAddress("0x2"): {newTx(500, 40), newTx(400, 30)},
+	}
+	demand := newPoolDemand(pending)
+	kip71 := &params.KIP71Config{MaxBlockGasUsedForBaseFee: 1000}
+
+	// The transactions with the higher gas fee caps are included first
+	assert.Equal(t, uint64(800), demand.gasUsed(0, big.NewInt(25), kip71))
+	// The remaining one is not affordable
+	assert.Equal(t, uint64(400), demand.gasUsed(1, big.NewInt(25), kip71))
+	assert.Equal(t, uint64(0), demand.gasUsed(2, big.NewInt(25), kip71))
+	assert.Equal(t, uint64(300), demand.gasUsed(3, big.NewInt(20), kip71))
+}
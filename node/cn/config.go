@@ -121,6 +121,7 @@ type Config struct {
 	TrieBlockInterval    uint
 	TriesInMemory        uint64
 	SenderTxHashIndexing bool
+	RewardIndexing       bool
 	ParallelDBWrite      bool
 	TrieNodeCacheConfig  statedb.TrieNodeCacheConfig
 	SnapshotCacheSize    int
@@ -40,6 +40,7 @@ var (
 	logger               = log.NewModuleLogger(log.NodeCnGasPrice)
 	errInvalidPercentile = errors.New("invalid reward percentile")
 	errRequestBeyondHead = errors.New("request beyond head block")
+	errMissingBlock      = errors.New("block or receipts are missing")
 )
 
 const (
@@ -53,8 +54,9 @@ type blockFees struct {
 	// set by the caller
 	blockNumber uint64
 	header      *types.Header
-	block       *types.Block // only set if reward percentiles are requested
+	block       *types.Block // only set if reward percentiles or details are requested
 	receipts    types.Receipts
+	detailed    bool // whether the fee details are requested
 	// filled by processBlock
 	results processedFees
 	err     error
@@ -65,6 +67,7 @@ type processedFees struct {
 	reward               []*big.Int
 	baseFee, nextBaseFee *big.Int
 	gasUsedRatio         float64
+	details              *BlockFeeDetails // only set if details are requested
 }
 
 // txGasAndReward is sorted in ascending order based on reward
@@ -94,15 +97,28 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 	if bf.results.baseFee = bf.header.BaseFee; bf.results.baseFee == nil {
 		bf.results.baseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
 	}
+	// The base fee of the next block is calculated with the KIP-71 parameters at this block.
+	kip71, err := oracle.kip71At(bf.blockNumber)
+	if err != nil {
+		bf.err = err
+		return
+	}
 	// TODO-Klaytn: If we implement baseFee feature like Ethereum does, we should calculate nextBaseFee from parent block header.
 	if chainconfig.IsMagmaForkEnabled(big.NewInt(int64(bf.blockNumber + 1))) {
-		bf.results.nextBaseFee = misc.NextMagmaBlockBaseFee(bf.header, chainconfig.Governance.KIP71)
+		bf.results.nextBaseFee = misc.NextMagmaBlockBaseFee(bf.header, kip71)
 	} else {
 		bf.results.nextBaseFee = new(big.Int).SetUint64(params.ZeroBaseFee)
 	}
 
 	// There is no GasLimit in Klaytn, so it is enough to use pre-defined constant in api package as now.
 	bf.results.gasUsedRatio = float64(bf.header.GasUsed) / float64(params.UpperGasLimit)
+	if bf.detailed {
+		if bf.block == nil || (bf.receipts == nil && len(bf.block.Transactions()) != 0) {
+			bf.err = fmt.Errorf("%w: block %d", errMissingBlock, bf.blockNumber)
+			return
+		}
+		bf.results.details = calcBlockFeeDetails(chainconfig, kip71, bf.block, bf.receipts)
+	}
 	if len(percentiles) == 0 {
 		// rewards were not requested, return null
 		return
@@ -145,6 +161,21 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 	}
 }
 
+// kip71At returns the KIP-71 parameters at the given block.
+func (oracle *Oracle) kip71At(num uint64) (*params.KIP71Config, error) {
+	if oracle.gov == nil {
+		if config := oracle.backend.ChainConfig(); config.Governance != nil {
+			return config.Governance.KIP71, nil
+		}
+		return nil, nil
+	}
+	pset, err := oracle.gov.ParamsAt(num)
+	if err != nil {
+		return nil, err
+	}
+	return pset.ToKIP71Config(), nil
+}
+
 // resolveBlockRange resolves the specified block range to absolute block numbers while also
 // enforcing backend specific limitations.
 // Pending block does not exist in Klaytn, so there is no logic to look up pending blocks.
@@ -180,6 +211,44 @@ func (oracle *Oracle) resolveBlockRange(ctx context.Context, lastBlock rpc.Block
 	return uint64(lastBlock), blocks, nil
 }
 
+// processBlocks retrieves and processes the blocks from oldestBlock to lastBlock in parallel.
+// The blocks are sent to the returned channel in no particular order, even if they could not be retrieved.
+func (oracle *Oracle) processBlocks(ctx context.Context, oldestBlock, lastBlock uint64, rewardPercentiles []float64, detailed bool) <-chan *blockFees {
+	var (
+		blocks  = int(lastBlock + 1 - oldestBlock)
+		next    = oldestBlock
+		results = make(chan *blockFees, blocks)
+	)
+	for i := 0; i < maxBlockFetchers && i < blocks; i++ {
+		go func() {
+			for {
+				// Retrieve the next block number to fetch with this goroutine
+				blockNumber := atomic.AddUint64(&next, 1) - 1
+				if blockNumber > lastBlock {
+					return
+				}
+
+				fees := &blockFees{blockNumber: blockNumber, detailed: detailed}
+				if len(rewardPercentiles) != 0 || detailed {
+					fees.block, fees.err = oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
+					if fees.block != nil && fees.err == nil {
+						fees.receipts = oracle.backend.GetBlockReceipts(ctx, fees.block.Hash())
+						fees.header = fees.block.Header()
+					}
+				} else {
+					fees.header, fees.err = oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
+				}
+				if fees.header != nil && fees.err == nil {
+					oracle.processBlock(fees, rewardPercentiles)
+				}
+				// send to results even if empty to guarantee that blocks items are sent in total
+				results <- fees
+			}
+		}()
+	}
+	return results
+}
+
 // FeeHistory returns data relevant for fee estimation based on the specified range of blocks.
 // The range can be specified either with absolute block numbers or ending with the latest
 // or pending block. Backends may or may not support gathering data from the pending block
@@ -223,38 +292,8 @@ func (oracle *Oracle) FeeHistory(
 		return common.Big0, nil, nil, nil, err
 	}
 	oldestBlock := lastBlock + 1 - uint64(blocks)
+	results := oracle.processBlocks(ctx, oldestBlock, lastBlock, rewardPercentiles, false)
 
-	var (
-		next    = oldestBlock
-		results = make(chan *blockFees, blocks)
-	)
-	for i := 0; i < maxBlockFetchers && i < blocks; i++ {
-		go func() {
-			for {
-				// Retrieve the next block number to fetch with this goroutine
-				blockNumber := atomic.AddUint64(&next, 1) - 1
-				if blockNumber > lastBlock {
-					return
-				}
-
-				fees := &blockFees{blockNumber: blockNumber}
-				if len(rewardPercentiles) != 0 {
-					fees.block, fees.err = oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
-					if fees.block != nil && fees.err == nil {
-						fees.receipts = oracle.backend.GetBlockReceipts(ctx, fees.block.Hash())
-						fees.header = fees.block.Header()
-					}
-				} else {
-					fees.header, fees.err = oracle.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
-				}
-				if fees.header != nil && fees.err == nil {
-					oracle.processBlock(fees, rewardPercentiles)
-				}
-				// send to results even if empty to guarantee that blocks items are sent in total
-				results <- fees
-			}
-		}()
-	}
 	var (
 		reward       = make([][]*big.Int, blocks)
 		baseFee      = make([]*big.Int, blocks+1)
@@ -0,0 +1,155 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+)
+
+// Transaction families distinguished by FeeHistoryDetailed.
+const (
+	// TxFamilyLegacy is the transactions paying a fixed gas price by the sender,
+	// i.e., the legacy and access list transactions and the basic Klaytn transactions.
+	TxFamilyLegacy = "legacy"
+	// TxFamilyDynamicFee is the Ethereum dynamic fee transactions.
+	TxFamilyDynamicFee = "dynamicFee"
+	// TxFamilyFeeDelegated is the Klaytn fee delegated transactions whose fee is paid by the fee payer.
+	TxFamilyFeeDelegated = "feeDelegated"
+	// TxFamilyFeeDelegatedWithRatio is the Klaytn partial fee delegated transactions
+	// whose fee is split between the sender and the fee payer.
+	TxFamilyFeeDelegatedWithRatio = "feeDelegatedWithRatio"
+)
+
+// TxFamilies is the list of all the transaction families.
+var TxFamilies = []string{TxFamilyLegacy, TxFamilyDynamicFee, TxFamilyFeeDelegated, TxFamilyFeeDelegatedWithRatio}
+
+// TxFeeStats is the fee statistics of the transactions of a family in a block.
+type TxFeeStats struct {
+	TxCount     int      `json:"txCount"`
+	GasUsed     uint64   `json:"gasUsed"`
+	SenderFee   *big.Int `json:"senderFee"`   // the fee paid by the senders
+	FeePayerFee *big.Int `json:"feePayerFee"` // the fee paid by the fee payers
+}
+
+func newTxFeeStats() *TxFeeStats {
+	return &TxFeeStats{SenderFee: new(big.Int), FeePayerFee: new(big.Int)}
+}
+
+// BlockFeeDetails is the fee statistics of a block broken down by the transaction families.
+type BlockFeeDetails struct {
+	// BaseFeeUtilization is gasUsed/kip71.gastarget with the KIP-71 parameters at the block,
+	// which drives the next base fee. It is 0 before the Magma hardfork.
+	BaseFeeUtilization float64                `json:"baseFeeUtilization"`
+	TxFees             map[string]*TxFeeStats `json:"txFees"`      // TxFeeStats of each family in TxFamilies
+	SenderFee          *big.Int               `json:"senderFee"`   // the fee paid by all the senders
+	FeePayerFee        *big.Int               `json:"feePayerFee"` // the fee paid by all the fee payers
+}
+
+// txFamily returns the family of the transaction type.
+func txFamily(txType types.TxType) string {
+	switch {
+	case txType == types.TxTypeEthereumDynamicFee:
+		return TxFamilyDynamicFee
+	case txType.IsFeeDelegatedWithRatioTransaction():
+		return TxFamilyFeeDelegatedWithRatio
+	case txType.IsFeeDelegatedTransaction():
+		return TxFamilyFeeDelegated
+	default:
+		return TxFamilyLegacy
+	}
+}
+
+// calcBlockFeeDetails computes the fee statistics of the block from the gas used by its transactions.
+// kip71 is the KIP-71 parameters at the block.
+func calcBlockFeeDetails(config *params.ChainConfig, kip71 *params.KIP71Config, block *types.Block, receipts types.Receipts) *BlockFeeDetails {
+	header := block.Header()
+	details := &BlockFeeDetails{
+		TxFees:      make(map[string]*TxFeeStats, len(TxFamilies)),
+		SenderFee:   new(big.Int),
+		FeePayerFee: new(big.Int),
+	}
+	for _, family := range TxFamilies {
+		details.TxFees[family] = newTxFeeStats()
+	}
+	if config.IsMagmaForkEnabled(header.Number) && kip71 != nil && kip71.GasTarget != 0 {
+		details.BaseFeeUtilization = float64(header.GasUsed) / float64(kip71.GasTarget)
+	}
+
+	for i, tx := range block.Transactions() {
+		gasUsed := receipts[i].GasUsed
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), tx.EffectiveGasPrice(header))
+
+		senderFee, feePayerFee := fee, new(big.Int)
+		if tx.IsFeeDelegatedTransaction() {
+			if feeRatio, ok := tx.FeeRatio(); ok {
+				feePayerFee, senderFee = types.CalcFeeWithRatio(feeRatio, fee)
+			} else {
+				senderFee, feePayerFee = new(big.Int), fee
+			}
+		}
+
+		stats := details.TxFees[txFamily(tx.Type())]
+		stats.TxCount++
+		stats.GasUsed += gasUsed
+		stats.SenderFee.Add(stats.SenderFee, senderFee)
+		stats.FeePayerFee.Add(stats.FeePayerFee, feePayerFee)
+		details.SenderFee.Add(details.SenderFee, senderFee)
+		details.FeePayerFee.Add(details.FeePayerFee, feePayerFee)
+	}
+	return details
+}
+
+// FeeHistoryDetailed returns the fee statistics of the specified range of blocks broken down by the
+// transaction families in TxFamilies, along with the base fees and the gas used ratios as FeeHistory does.
+// The fees are separated into the ones paid by the senders and the ones paid by the fee payers.
+// The range is resolved in the same way as FeeHistory, and is limited by the block history.
+func (oracle *Oracle) FeeHistoryDetailed(ctx context.Context, blocks int, unresolvedLastBlock rpc.BlockNumber) (*big.Int, []*BlockFeeDetails, []*big.Int, []float64, error) {
+	if blocks < 1 {
+		return common.Big0, nil, nil, nil, nil
+	}
+	if blocks > oracle.maxBlockHistory {
+		logger.Warn("Sanitizing fee history length", "requested", blocks, "truncated", oracle.maxBlockHistory)
+		blocks = oracle.maxBlockHistory
+	}
+	lastBlock, blocks, err := oracle.resolveBlockRange(ctx, unresolvedLastBlock, blocks)
+	if err != nil || blocks == 0 {
+		return common.Big0, nil, nil, nil, err
+	}
+	oldestBlock := lastBlock + 1 - uint64(blocks)
+	results := oracle.processBlocks(ctx, oldestBlock, lastBlock, nil, true)
+
+	var (
+		details      = make([]*BlockFeeDetails, blocks)
+		baseFee      = make([]*big.Int, blocks+1)
+		gasUsedRatio = make([]float64, blocks)
+	)
+	for n := blocks; n > 0; n-- {
+		fees := <-results
+		if fees.err != nil {
+			return common.Big0, nil, nil, nil, fees.err
+		}
+		i := int(fees.blockNumber - oldestBlock)
+		details[i], baseFee[i], baseFee[i+1], gasUsedRatio[i] = fees.results.details, fees.results.baseFee, fees.results.nextBaseFee, fees.results.gasUsedRatio
+	}
+	return new(big.Int).SetUint64(oldestBlock), details, baseFee, gasUsedRatio, nil
+}
@@ -23,9 +23,15 @@ package gasprice
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/networks/rpc"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFeeHistory(t *testing.T) {
@@ -57,7 +63,7 @@ func TestFeeHistory(t *testing.T) {
 			MaxBlockHistory:  c.maxBlock,
 		}
 		backend := newTestBackend(t)
-		oracle := NewOracle(backend, config, nil)
+		oracle := NewOracle(backend, config, nil, nil)
 
 		first, reward, baseFee, ratio, err := oracle.FeeHistory(context.Background(), c.count, c.last, c.percent)
 
@@ -87,3 +93,93 @@ func TestFeeHistory(t *testing.T) {
 		}
 	}
 }
+
+func TestFeeHistoryDetailed(t *testing.T) {
+	backend := newTestBackend(t)
+	oracle := NewOracle(backend, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 5}, nil, nil)
+
+	first, details, baseFee, ratio, err := oracle.FeeHistoryDetailed(context.Background(), 10, 30)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(26), first.Uint64())
+	assert.Equal(t, 5, len(details))
+	assert.Equal(t, 6, len(baseFee))
+	assert.Equal(t, 5, len(ratio))
+
+	// Each test block has a legacy transaction paid by the sender
+	for i, d := range details {
+		block := backend.chain.GetBlockByNumber(first.Uint64() + uint64(i))
+		receipts := backend.chain.GetReceiptsByBlockHash(block.Hash())
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipts[0].GasUsed), block.Transactions()[0].GasPrice())
+
+		legacy := d.TxFees[TxFamilyLegacy]
+		assert.Equal(t, 1, legacy.TxCount)
+		assert.Equal(t, receipts[0].GasUsed, legacy.GasUsed)
+		assert.Equal(t, fee, legacy.SenderFee)
+		assert.Equal(t, 0, legacy.FeePayerFee.Sign())
+		assert.Equal(t, fee, d.SenderFee)
+		assert.Equal(t, 0, d.FeePayerFee.Sign())
+		assert.Equal(t, 0, d.TxFees[TxFamilyFeeDelegated].TxCount)
+	}
+
+	_, _, _, _, err = oracle.FeeHistoryDetailed(context.Background(), 10, 40)
+	assert.True(t, errors.Is(err, errRequestBeyondHead))
+}
+
+func TestCalcBlockFeeDetails(t *testing.T) {
+	var (
+		to       = common.HexToAddress("0x1")
+		from     = common.HexToAddress("0x2")
+		feePayer = common.HexToAddress("0x3")
+		gasPrice = big.NewInt(25 * params.Ston)
+	)
+	newTx := func(txType types.TxType, values map[types.TxValueKeyType]interface{}) *types.Transaction {
+		values[types.TxValueKeyNonce] = uint64(0)
+		values[types.TxValueKeyTo] = to
+		values[types.TxValueKeyAmount] = big.NewInt(1)
+		values[types.TxValueKeyGasLimit] = uint64(100000)
+		values[types.TxValueKeyGasPrice] = gasPrice
+		values[types.TxValueKeyFrom] = from
+		tx, err := types.NewTransactionWithMap(txType, values)
+		require.Nil(t, err)
+		return tx
+	}
+	txs := types.Transactions{
+		newTx(types.TxTypeValueTransfer, map[types.TxValueKeyType]interface{}{}),
+		newTx(types.TxTypeFeeDelegatedValueTransfer, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyFeePayer: feePayer,
+		}),
+		newTx(types.TxTypeFeeDelegatedValueTransferWithRatio, map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyFeePayer:           feePayer,
+			types.TxValueKeyFeeRatioOfFeePayer: types.FeeRatio(30),
+		}),
+	}
+	receipts := types.Receipts{{GasUsed: 21000}, {GasUsed: 31000}, {GasUsed: 41000}}
+	header := &types.Header{Number: big.NewInt(1), GasUsed: 93000}
+	block := types.NewBlockWithHeader(header).WithBody(txs)
+
+	details := calcBlockFeeDetails(params.TestChainConfig, &params.KIP71Config{GasTarget: 31000}, block, receipts)
+	fee := func(gasUsed int64) *big.Int { return new(big.Int).Mul(big.NewInt(gasUsed), gasPrice) }
+
+	assert.Equal(t, 1, details.TxFees[TxFamilyLegacy].TxCount)
+	assert.Equal(t, fee(21000), details.TxFees[TxFamilyLegacy].SenderFee)
+
+	assert.Equal(t, 1, details.TxFees[TxFamilyFeeDelegated].TxCount)
+	assert.Equal(t, 0, details.TxFees[TxFamilyFeeDelegated].SenderFee.Sign())
+	assert.Equal(t, fee(31000), details.TxFees[TxFamilyFeeDelegated].FeePayerFee)
+
+	withRatio := details.TxFees[TxFamilyFeeDelegatedWithRatio]
+	assert.Equal(t, uint64(41000), withRatio.GasUsed)
+	assert.Equal(t, fee(41000*30/100), withRatio.FeePayerFee)
+	assert.Equal(t, fee(41000*70/100), withRatio.SenderFee)
+
+	assert.Equal(t, 0, details.TxFees[TxFamilyDynamicFee].TxCount)
+	assert.Equal(t, new(big.Int).Add(fee(21000), fee(41000*70/100)), details.SenderFee)
+	assert.Equal(t, new(big.Int).Add(fee(31000), fee(41000*30/100)), details.FeePayerFee)
+
+	// The utilization is measured by the given KIP-71 parameters after the Magma hardfork
+	assert.Equal(t, float64(0), details.BaseFeeUtilization)
+	magmaConfig := params.TestChainConfig.Copy()
+	magmaConfig.MagmaCompatibleBlock = common.Big0
+	details = calcBlockFeeDetails(magmaConfig, &params.KIP71Config{GasTarget: 31000}, block, receipts)
+	assert.Equal(t, float64(3), details.BaseFeeUtilization)
+}
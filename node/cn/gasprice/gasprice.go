@@ -55,6 +55,11 @@ type TxPool interface {
 	GasPrice() *big.Int
 }
 
+// GovernanceParams provides the governance parameters at each block.
+type GovernanceParams interface {
+	ParamsAt(num uint64) (*params.GovParamSet, error)
+}
+
 // Oracle recommends gas prices based on the content of recent
 // blocks. Suitable for both light and full clients.
 type Oracle struct {
@@ -64,6 +69,7 @@ type Oracle struct {
 	cacheLock sync.RWMutex
 	fetchLock sync.Mutex
 	txPool    TxPool
+	gov       GovernanceParams // if nil, the KIP-71 parameters in the chain config are used
 
 	checkBlocks, maxEmpty, maxBlocks  int
 	percentile                        int
@@ -71,7 +77,7 @@ type Oracle struct {
 }
 
 // NewOracle returns a new oracle.
-func NewOracle(backend OracleBackend, params Config, txPool TxPool) *Oracle {
+func NewOracle(backend OracleBackend, params Config, txPool TxPool, gov GovernanceParams) *Oracle {
 	blocks := params.Blocks
 	if blocks < 1 {
 		blocks = 1
@@ -93,6 +99,7 @@ func NewOracle(backend OracleBackend, params Config, txPool TxPool) *Oracle {
 		maxHeaderHistory: params.MaxHeaderHistory,
 		maxBlockHistory:  params.MaxBlockHistory,
 		txPool:           txPool,
+		gov:              gov,
 	}
 }
 
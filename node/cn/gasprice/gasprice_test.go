@@ -112,7 +112,7 @@ func TestGasPrice_NewOracle(t *testing.T) {
 	defer mockCtrl.Finish()
 	mockBackend := mock_api.NewMockBackend(mockCtrl)
 	params := Config{}
-	oracle := NewOracle(mockBackend, params, nil)
+	oracle := NewOracle(mockBackend, params, nil, nil)
 
 	assert.Nil(t, oracle.lastPrice)
 	assert.Equal(t, 1, oracle.checkBlocks)
@@ -121,7 +121,7 @@ func TestGasPrice_NewOracle(t *testing.T) {
 	assert.Equal(t, 0, oracle.percentile)
 
 	params = Config{Blocks: 2}
-	oracle = NewOracle(mockBackend, params, nil)
+	oracle = NewOracle(mockBackend, params, nil, nil)
 
 	assert.Nil(t, oracle.lastPrice)
 	assert.Equal(t, 2, oracle.checkBlocks)
@@ -130,7 +130,7 @@ func TestGasPrice_NewOracle(t *testing.T) {
 	assert.Equal(t, 0, oracle.percentile)
 
 	params = Config{Percentile: -1}
-	oracle = NewOracle(mockBackend, params, nil)
+	oracle = NewOracle(mockBackend, params, nil, nil)
 
 	assert.Nil(t, oracle.lastPrice)
 	assert.Equal(t, 1, oracle.checkBlocks)
@@ -139,7 +139,7 @@ func TestGasPrice_NewOracle(t *testing.T) {
 	assert.Equal(t, 0, oracle.percentile)
 
 	params = Config{Percentile: 101}
-	oracle = NewOracle(mockBackend, params, nil)
+	oracle = NewOracle(mockBackend, params, nil, nil)
 
 	assert.Nil(t, oracle.lastPrice)
 	assert.Equal(t, 1, oracle.checkBlocks)
@@ -148,7 +148,7 @@ func TestGasPrice_NewOracle(t *testing.T) {
 	assert.Equal(t, 100, oracle.percentile)
 
 	params = Config{Percentile: 101, Default: big.NewInt(123)}
-	oracle = NewOracle(mockBackend, params, nil)
+	oracle = NewOracle(mockBackend, params, nil, nil)
 
 	assert.Equal(t, big.NewInt(123), oracle.lastPrice)
 	assert.Equal(t, 1, oracle.checkBlocks)
@@ -166,7 +166,7 @@ func TestGasPrice_SuggestPrice(t *testing.T) {
 	chainConfig := testBackend.ChainConfig()
 	chainConfig.UnitPrice = 0
 	txPoolWith0 := blockchain.NewTxPool(blockchain.DefaultTxPoolConfig, chainConfig, testBackend.chain)
-	oracle := NewOracle(mockBackend, params, txPoolWith0)
+	oracle := NewOracle(mockBackend, params, txPoolWith0, nil)
 
 	currentBlock := testBackend.CurrentBlock()
 	mockBackend.EXPECT().ChainConfig().Return(chainConfig).Times(2)
@@ -179,7 +179,7 @@ func TestGasPrice_SuggestPrice(t *testing.T) {
 	params = Config{Default: big.NewInt(123)}
 	chainConfig.UnitPrice = 25
 	txPoolWith25 := blockchain.NewTxPool(blockchain.DefaultTxPoolConfig, chainConfig, testBackend.chain)
-	oracle = NewOracle(mockBackend, params, txPoolWith25)
+	oracle = NewOracle(mockBackend, params, txPoolWith25, nil)
 
 	price, err = oracle.SuggestPrice(nil)
 	assert.Equal(t, big.NewInt(25), price)
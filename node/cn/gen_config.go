@@ -47,6 +47,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TrieBlockInterval       uint
 		TriesInMemory           uint64
 		SenderTxHashIndexing    bool
+		RewardIndexing          bool
 		ParallelDBWrite         bool
 		TrieNodeCacheConfig     statedb.TrieNodeCacheConfig
 		ServiceChainSigner      common.Address `toml:",omitempty"`
@@ -97,6 +98,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TrieBlockInterval = c.TrieBlockInterval
 	enc.TriesInMemory = c.TriesInMemory
 	enc.SenderTxHashIndexing = c.SenderTxHashIndexing
+	enc.RewardIndexing = c.RewardIndexing
 	enc.ParallelDBWrite = c.ParallelDBWrite
 	enc.TrieNodeCacheConfig = c.TrieNodeCacheConfig
 	enc.ServiceChainSigner = c.ServiceChainSigner
@@ -151,6 +153,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TrieBlockInterval       *uint
 		TriesInMemory           *uint64
 		SenderTxHashIndexing    *bool
+		RewardIndexing          *bool
 		ParallelDBWrite         *bool
 		TrieNodeCacheConfig     *statedb.TrieNodeCacheConfig
 		ServiceChainSigner      *common.Address `toml:",omitempty"`
@@ -256,6 +259,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SenderTxHashIndexing != nil {
 		c.SenderTxHashIndexing = *dec.SenderTxHashIndexing
 	}
+	if dec.RewardIndexing != nil {
+		c.RewardIndexing = *dec.RewardIndexing
+	}
 	if dec.ParallelDBWrite != nil {
 		c.ParallelDBWrite = *dec.ParallelDBWrite
 	}
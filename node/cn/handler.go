@@ -133,6 +133,9 @@ type ProtocolManager struct {
 
 	wsendpoint string
 
+	// validatorProof is sent in the handshake if the validator key is kept apart from the nodekey
+	validatorProof []byte
+
 	nodetype          common.ConnType
 	txResendUseLegacy bool
 
@@ -505,7 +508,7 @@ func (pm *ProtocolManager) handle(p Peer) error {
 		td      = pm.blockchain.GetTd(hash, number)
 	)
 
-	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash(), pm.validatorProof); err != nil {
 		p.GetP2PPeer().Log().Debug("Klaytn peer handshake failed", "err", err)
 		return err
 	}
@@ -1595,6 +1598,12 @@ func (pm *ProtocolManager) Downloader() ProtocolManagerDownloader {
 	return pm.downloader
 }
 
+// SetValidatorProof sets the signature of the node ID made by the validator key, which is sent
+// in the handshake so that the peers find the node by the validator address.
+func (pm *ProtocolManager) SetValidatorProof(proof []byte) {
+	pm.validatorProof = proof
+}
+
 func (pm *ProtocolManager) SetWsEndPoint(wsep string) {
 	pm.wsendpoint = wsep
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
 	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/istanbul"
 	"github.com/klaytn/klaytn/crypto"
 	"github.com/klaytn/klaytn/datasync/downloader"
 	"github.com/klaytn/klaytn/networks/p2p"
@@ -180,7 +181,8 @@ type Peer interface {
 
 	// Handshake executes the Klaytn protocol handshake, negotiating version number,
 	// network IDs, difficulties, head, and genesis blocks and returning error.
-	Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error
+	// The validator proof is sent if the validator key is kept apart from the nodekey.
+	Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash, validatorProof []byte) error
 
 	// ConnType returns the conntype of the peer.
 	ConnType() common.ConnType
@@ -630,8 +632,9 @@ func (p *basePeer) RequestStakingInfo(hashes []common.Hash) error {
 }
 
 // Handshake executes the Klaytn protocol handshake, negotiating version number,
-// network IDs, difficulties, head and genesis blocks.
-func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash) error {
+// network IDs, difficulties, head and genesis blocks. If the peer gives a validator proof,
+// the address of the peer is set to the validator address recovered from the proof.
+func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.Hash, genesis common.Hash, validatorProof []byte) error {
 	// Send out own handshake in a new thread
 	errc := make(chan error, 2)
 	var status statusData // safe to read after two values have been received from errc
@@ -644,6 +647,7 @@ func (p *basePeer) Handshake(network uint64, chainID, td *big.Int, head common.H
 			CurrentBlock:    head,
 			GenesisBlock:    genesis,
 			ChainID:         chainID,
+			ValidatorProof:  validatorProof,
 		})
 	}()
 	go func() {
@@ -692,6 +696,14 @@ func (p *basePeer) readStatus(network uint64, status *statusData, genesis common
 	if int(status.ProtocolVersion) != p.version {
 		return errResp(ErrProtocolVersionMismatch, "%d (!= %d)", status.ProtocolVersion, p.version)
 	}
+	if len(status.ValidatorProof) > 0 {
+		id := p.GetP2PPeerID()
+		addr, err := istanbul.GetSignatureAddress(istanbul.NodeIDData(id[:]), status.ValidatorProof)
+		if err != nil {
+			return errResp(ErrInvalidValidatorProof, "%v", err)
+		}
+		p.SetAddr(addr)
+	}
 	return nil
 }
 
@@ -1108,7 +1120,7 @@ func (p *multiChannelPeer) Handle(pm *ProtocolManager) error {
 		td      = pm.blockchain.GetTd(hash, number)
 	)
 
-	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkId, pm.getChainID(), td, hash, genesis.Hash(), pm.validatorProof); err != nil {
 		p.GetP2PPeer().Log().Debug("Klaytn peer handshake failed", "err", err)
 		return err
 	}
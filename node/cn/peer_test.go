@@ -29,6 +29,7 @@ import (
 
 	"github.com/klaytn/klaytn/blockchain/types"
 	"github.com/klaytn/klaytn/common"
+	istanbulBackend "github.com/klaytn/klaytn/consensus/istanbul/backend"
 	"github.com/klaytn/klaytn/networks/p2p"
 	"github.com/stretchr/testify/assert"
 )
@@ -170,6 +171,39 @@ func TestBasePeer_GetAndSetAddr(t *testing.T) {
 	assert.Equal(t, addrs[1], basePeer.GetAddr())
 }
 
+func TestBasePeer_HandshakeValidatorProof(t *testing.T) {
+	validatorKey, _ := crypto.GenerateKey()
+	validator := crypto.PubkeyToAddress(validatorKey.PublicKey)
+	proof, err := istanbulBackend.NewLocalSigner(validatorKey).SignNodeID(nodeids[0][:])
+	assert.NoError(t, err)
+
+	handshake := func(remoteProof []byte) (Peer, error) {
+		pipe1, pipe2 := p2p.MsgPipe()
+		local, remote := newPeer(version, p2pPeers[0], pipe1), newPeer(version, p2pPeers[1], pipe2)
+		local.SetAddr(addrs[0])
+
+		chainID, td := big.NewInt(1001), big.NewInt(1)
+		errc := make(chan error, 1)
+		go func() { errc <- remote.Handshake(1, chainID, td, hash1, hash1, remoteProof) }()
+		err := local.Handshake(1, chainID, td, hash1, hash1, nil)
+		<-errc
+		return local, err
+	}
+
+	// The peer is found by the address of its nodekey without a proof
+	peer, err := handshake(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, addrs[0], peer.GetAddr())
+
+	// The peer is found by the validator address with a proof
+	peer, err = handshake(proof)
+	assert.NoError(t, err)
+	assert.Equal(t, validator, peer.GetAddr())
+
+	_, err = handshake(proof[:64])
+	assert.Error(t, err)
+}
+
 func TestBasePeer_GetVersion(t *testing.T) {
 	basePeer, _, _ := newBasePeer()
 	assert.Equal(t, version, basePeer.GetVersion())
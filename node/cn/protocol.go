@@ -105,6 +105,7 @@ const (
 	ErrUnexpectedTxType
 	ErrFailedToGetStateDB
 	ErrUnsupportedEnginePolicy
+	ErrInvalidValidatorProof
 )
 
 func (e errCode) String() string {
@@ -126,6 +127,7 @@ var errorToString = map[int]string{
 	ErrUnexpectedTxType:        "Unexpected tx type",
 	ErrFailedToGetStateDB:      "Failed to get stateDB",
 	ErrUnsupportedEnginePolicy: "Unsupported engine or policy",
+	ErrInvalidValidatorProof:   "Invalid validator proof",
 }
 
 //go:generate mockgen -destination=node/cn/mocks/downloader_mock.go -package=mocks github.com/klaytn/klaytn/node/cn ProtocolManagerDownloader
@@ -168,6 +170,10 @@ type statusData struct {
 	CurrentBlock    common.Hash
 	GenesisBlock    common.Hash
 	ChainID         *big.Int // ChainID to sign a transaction.
+
+	// ValidatorProof is the signature of istanbul.NodeIDData of the sender's node ID made by
+	// its validator key. It is given only if the validator key is kept apart from the nodekey.
+	ValidatorProof []byte `rlp:"optional"`
 }
 
 // newBlockHashesData is the network packet for the block announcements.
@@ -153,6 +153,18 @@ func (mr *MockBackendProtocolManagerMockRecorder) SetTmpStop(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncStop", reflect.TypeOf((*MockBackendProtocolManager)(nil).SetSyncStop), arg0)
 }
 
+// SetValidatorProof mocks base method.
+func (m *MockBackendProtocolManager) SetValidatorProof(arg0 []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetValidatorProof", arg0)
+}
+
+// SetValidatorProof indicates an expected call of SetValidatorProof.
+func (mr *MockBackendProtocolManagerMockRecorder) SetValidatorProof(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValidatorProof", reflect.TypeOf((*MockBackendProtocolManager)(nil).SetValidatorProof), arg0)
+}
+
 // SetWsEndPoint mocks base method.
 func (m *MockBackendProtocolManager) SetWsEndPoint(arg0 string) {
 	m.ctrl.T.Helper()
@@ -55,6 +55,7 @@ type Node struct {
 	config   *Config
 	accman   *accounts.Manager
 
+	accmanClosed      bool // Whether accman is closed by Stop, to be created again by Start
 	ephemeralKeystore string
 	instanceDirLock   flock.Releaser
 
@@ -179,6 +180,13 @@ func (n *Node) Start() error {
 	if err := n.openDataDir(); err != nil {
 		return err
 	}
+	if n.accmanClosed {
+		am, ephemeralKeystore, err := makeAccountManager(n.config)
+		if err != nil {
+			return err
+		}
+		n.accman, n.ephemeralKeystore, n.accmanClosed = am, ephemeralKeystore, false
+	}
 
 	n.serverConfig = n.config.P2P
 	n.serverConfig.PrivateKey = n.config.NodeKey()
@@ -626,6 +634,10 @@ func (n *Node) Stop() error {
 	// unblock n.Wait
 	close(n.stop)
 
+	// Close the account manager and its backends, such as the connection to the external signer.
+	accmanErr := n.accman.Close()
+	n.accmanClosed = true
+
 	// Remove the keystore if it was created ephemerally.
 	var keystoreErr error
 	if n.ephemeralKeystore != "" {
@@ -635,6 +647,9 @@ func (n *Node) Stop() error {
 	if len(failure.Services) > 0 {
 		return failure
 	}
+	if accmanErr != nil {
+		return accmanErr
+	}
 	if keystoreErr != nil {
 		return keystoreErr
 	}
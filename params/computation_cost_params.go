@@ -143,6 +143,7 @@ const (
 	FeePayerComputationCost             = 10
 	ValidateSenderPerSigComputationCost = 180000
 	ValidateSenderBaseComputationCost   = 10000
+	P256VerifyComputationCost           = 150000
 
 	// computation costs for opcode added at istanbulCompatible Protocol Upgrade
 	ChainIDComputationCost      = 120
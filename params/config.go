@@ -177,6 +177,28 @@ type ChainConfig struct {
 	MagmaCompatibleBlock     *big.Int `json:"magmaCompatibleBlock,omitempty"`     // MagmaCompatible switch block (nil = no fork, 0 already on Magma)
 	KoreCompatibleBlock      *big.Int `json:"koreCompatibleBlock,omitempty"`      // KoreCompatible switch block (nil = no fork, 0 already on Kore)
 
+	// RoundTimeoutCompatibleBlock switch block (nil = no fork, 0 already on exponential round change timeout)
+	RoundTimeoutCompatibleBlock *big.Int `json:"roundTimeoutCompatibleBlock,omitempty"`
+
+	// P256CompatibleBlock switch block (nil = no fork, 0 already on P-256 account keys and the P-256 precompile)
+	P256CompatibleBlock *big.Int `json:"p256CompatibleBlock,omitempty"`
+
+	// BatchTxCompatibleBlock switch block (nil = no fork, 0 already on batch transactions)
+	BatchTxCompatibleBlock *big.Int `json:"batchTxCompatibleBlock,omitempty"`
+
+	// SessionKeyCompatibleBlock switch block (nil = no fork, 0 already on session account keys)
+	SessionKeyCompatibleBlock *big.Int `json:"sessionKeyCompatibleBlock,omitempty"`
+
+	// CustomTxTypeCompatibleBlock switch block (nil = no fork, 0 already on custom tx types).
+	// It is for service chains only, enabling the tx types registered by types.RegisterTxType.
+	CustomTxTypeCompatibleBlock *big.Int `json:"customTxTypeCompatibleBlock,omitempty"`
+
+	// ReconfigurationCompatibleBlock switch block (nil = no fork, 0 already on scheduled reconfiguration votes)
+	ReconfigurationCompatibleBlock *big.Int `json:"reconfigurationCompatibleBlock,omitempty"`
+
+	// TimelockCompatibleBlock switch block (nil = no fork, 0 already on timelocked governance changes)
+	TimelockCompatibleBlock *big.Int `json:"timelockCompatibleBlock,omitempty"`
+
 	// Various consensus engines
 	Gxhash   *GxhashConfig   `json:"gxhash,omitempty"` // (deprecated) not supported engine
 	Clique   *CliqueConfig   `json:"clique,omitempty"`
@@ -185,6 +207,9 @@ type ChainConfig struct {
 	UnitPrice     uint64            `json:"unitPrice"`
 	DeriveShaImpl int               `json:"deriveShaImpl"`
 	Governance    *GovernanceConfig `json:"governance"`
+
+	// RewardPolicy selects how the block rewards are distributed (nil = the Klaytn reward policy)
+	RewardPolicy *RewardPolicyConfig `json:"rewardPolicy,omitempty"`
 }
 
 // GovernanceConfig stores governance information for a network
@@ -212,6 +237,25 @@ type RewardConfig struct {
 	MinimumStake           *big.Int `json:"minimumStake"`           // Minimum amount of peb to join CCO
 }
 
+// RewardPolicyConfig selects the reward distribution policy for private networks.
+// Unlike RewardConfig, it is fixed in the genesis and cannot be changed by governance.
+type RewardPolicyConfig struct {
+	Name     string         `json:"name"`               // the name of the policy registered in the reward package
+	Treasury common.Address `json:"treasury,omitempty"` // the recipient of the tx fees in the "treasury" policy
+}
+
+// Equal reports whether the two configs select the same policy. A nil config equals the one without a name.
+func (c *RewardPolicyConfig) Equal(other *RewardPolicyConfig) bool {
+	var a, b RewardPolicyConfig
+	if c != nil {
+		a = *c
+	}
+	if other != nil {
+		b = *other
+	}
+	return a == b
+}
+
 // Magma governance parameters
 type KIP71Config struct {
 	LowerBoundBaseFee         uint64 `json:"lowerboundbasefee"`         // Minimum base fee for dynamic gas price
@@ -326,6 +370,41 @@ func (c *ChainConfig) IsKoreForkEnabled(num *big.Int) bool {
 	return isForked(c.KoreCompatibleBlock, num)
 }
 
+// IsRoundTimeoutForkEnabled returns whether num is either equal to the round timeout block or greater.
+func (c *ChainConfig) IsRoundTimeoutForkEnabled(num *big.Int) bool {
+	return isForked(c.RoundTimeoutCompatibleBlock, num)
+}
+
+// IsP256ForkEnabled returns whether num is either equal to the P-256 block or greater.
+func (c *ChainConfig) IsP256ForkEnabled(num *big.Int) bool {
+	return isForked(c.P256CompatibleBlock, num)
+}
+
+// IsBatchTxForkEnabled returns whether num is either equal to the batch tx block or greater.
+func (c *ChainConfig) IsBatchTxForkEnabled(num *big.Int) bool {
+	return isForked(c.BatchTxCompatibleBlock, num)
+}
+
+// IsSessionKeyForkEnabled returns whether num is either equal to the session key block or greater.
+func (c *ChainConfig) IsSessionKeyForkEnabled(num *big.Int) bool {
+	return isForked(c.SessionKeyCompatibleBlock, num)
+}
+
+// IsCustomTxTypeForkEnabled returns whether num is either equal to the custom tx type block or greater.
+func (c *ChainConfig) IsCustomTxTypeForkEnabled(num *big.Int) bool {
+	return isForked(c.CustomTxTypeCompatibleBlock, num)
+}
+
+// IsReconfigurationForkEnabled returns whether num is either equal to the reconfiguration block or greater.
+func (c *ChainConfig) IsReconfigurationForkEnabled(num *big.Int) bool {
+	return isForked(c.ReconfigurationCompatibleBlock, num)
+}
+
+// IsTimelockForkEnabled returns whether num is either equal to the timelock block or greater.
+func (c *ChainConfig) IsTimelockForkEnabled(num *big.Int) bool {
+	return isForked(c.TimelockCompatibleBlock, num)
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64) *ConfigCompatError {
@@ -359,6 +438,13 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		{name: "ethTxTypeBlock", block: c.EthTxTypeCompatibleBlock},
 		{name: "magmaBlock", block: c.MagmaCompatibleBlock},
 		{name: "koreBlock", block: c.KoreCompatibleBlock},
+		{name: "roundTimeoutBlock", block: c.RoundTimeoutCompatibleBlock, optional: true},
+		{name: "p256Block", block: c.P256CompatibleBlock, optional: true},
+		{name: "batchTxBlock", block: c.BatchTxCompatibleBlock, optional: true},
+		{name: "sessionKeyBlock", block: c.SessionKeyCompatibleBlock, optional: true},
+		{name: "customTxTypeBlock", block: c.CustomTxTypeCompatibleBlock, optional: true},
+		{name: "reconfigurationBlock", block: c.ReconfigurationCompatibleBlock, optional: true},
+		{name: "timelockBlock", block: c.TimelockCompatibleBlock, optional: true},
 	} {
 		if lastFork.name != "" {
 			// Next one must be higher number
@@ -397,6 +483,31 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.KoreCompatibleBlock, newcfg.KoreCompatibleBlock, head) {
 		return newCompatError("Kore Block", c.KoreCompatibleBlock, newcfg.KoreCompatibleBlock)
 	}
+	if isForkIncompatible(c.RoundTimeoutCompatibleBlock, newcfg.RoundTimeoutCompatibleBlock, head) {
+		return newCompatError("RoundTimeout Block", c.RoundTimeoutCompatibleBlock, newcfg.RoundTimeoutCompatibleBlock)
+	}
+	if isForkIncompatible(c.P256CompatibleBlock, newcfg.P256CompatibleBlock, head) {
+		return newCompatError("P256 Block", c.P256CompatibleBlock, newcfg.P256CompatibleBlock)
+	}
+	if isForkIncompatible(c.BatchTxCompatibleBlock, newcfg.BatchTxCompatibleBlock, head) {
+		return newCompatError("BatchTx Block", c.BatchTxCompatibleBlock, newcfg.BatchTxCompatibleBlock)
+	}
+	if isForkIncompatible(c.SessionKeyCompatibleBlock, newcfg.SessionKeyCompatibleBlock, head) {
+		return newCompatError("SessionKey Block", c.SessionKeyCompatibleBlock, newcfg.SessionKeyCompatibleBlock)
+	}
+	if isForkIncompatible(c.CustomTxTypeCompatibleBlock, newcfg.CustomTxTypeCompatibleBlock, head) {
+		return newCompatError("CustomTxType Block", c.CustomTxTypeCompatibleBlock, newcfg.CustomTxTypeCompatibleBlock)
+	}
+	if isForkIncompatible(c.ReconfigurationCompatibleBlock, newcfg.ReconfigurationCompatibleBlock, head) {
+		return newCompatError("Reconfiguration Block", c.ReconfigurationCompatibleBlock, newcfg.ReconfigurationCompatibleBlock)
+	}
+	if isForkIncompatible(c.TimelockCompatibleBlock, newcfg.TimelockCompatibleBlock, head) {
+		return newCompatError("Timelock Block", c.TimelockCompatibleBlock, newcfg.TimelockCompatibleBlock)
+	}
+	// The reward policy is applied from the genesis, so the blocks after it must be rewound on change.
+	if head.Sign() > 0 && !c.RewardPolicy.Equal(newcfg.RewardPolicy) {
+		return newCompatError("RewardPolicy", common.Big0, common.Big0)
+	}
 	return nil
 }
 
@@ -509,11 +620,17 @@ func (err *ConfigCompatError) Error() string {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainID    *big.Int
-	IsIstanbul bool
-	IsLondon   bool
-	IsMagma    bool
-	IsKore     bool
+	ChainID           *big.Int
+	IsIstanbul        bool
+	IsLondon          bool
+	IsMagma           bool
+	IsKore            bool
+	IsP256            bool
+	IsBatchTx         bool
+	IsSessionKey      bool
+	IsCustomTxType    bool
+	IsReconfiguration bool
+	IsTimelock        bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -523,11 +640,17 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 		chainID = new(big.Int)
 	}
 	return Rules{
-		ChainID:    new(big.Int).Set(chainID),
-		IsIstanbul: c.IsIstanbulForkEnabled(num),
-		IsLondon:   c.IsLondonForkEnabled(num),
-		IsMagma:    c.IsMagmaForkEnabled(num),
-		IsKore:     c.IsKoreForkEnabled(num),
+		ChainID:           new(big.Int).Set(chainID),
+		IsIstanbul:        c.IsIstanbulForkEnabled(num),
+		IsLondon:          c.IsLondonForkEnabled(num),
+		IsMagma:           c.IsMagmaForkEnabled(num),
+		IsKore:            c.IsKoreForkEnabled(num),
+		IsP256:            c.IsP256ForkEnabled(num),
+		IsBatchTx:         c.IsBatchTxForkEnabled(num),
+		IsSessionKey:      c.IsSessionKeyForkEnabled(num),
+		IsCustomTxType:    c.IsCustomTxTypeForkEnabled(num),
+		IsReconfiguration: c.IsReconfigurationForkEnabled(num),
+		IsTimelock:        c.IsTimelockForkEnabled(num),
 	}
 }
 
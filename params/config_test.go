@@ -20,12 +20,65 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/klaytn/klaytn/common"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestChainConfig_CheckConfigForkOrder(t *testing.T) {
 	assert.Nil(t, BaobabChainConfig.CheckConfigForkOrder())
 	assert.Nil(t, CypressChainConfig.CheckConfigForkOrder())
+
+	// The optional forks may be skipped, but should not precede the former ones
+	config := &ChainConfig{
+		IstanbulCompatibleBlock:  common.Big0,
+		LondonCompatibleBlock:    common.Big0,
+		EthTxTypeCompatibleBlock: common.Big0,
+		MagmaCompatibleBlock:     common.Big0,
+		KoreCompatibleBlock:      common.Big0,
+	}
+	config.CustomTxTypeCompatibleBlock = big.NewInt(10)
+	assert.Nil(t, config.CheckConfigForkOrder())
+	config.SessionKeyCompatibleBlock = big.NewInt(20)
+	assert.Error(t, config.CheckConfigForkOrder())
+	config.SessionKeyCompatibleBlock = big.NewInt(10)
+	assert.Nil(t, config.CheckConfigForkOrder())
+	config.ReconfigurationCompatibleBlock = big.NewInt(5)
+	assert.Error(t, config.CheckConfigForkOrder())
+	config.ReconfigurationCompatibleBlock = big.NewInt(10)
+	assert.Nil(t, config.CheckConfigForkOrder())
+	config.TimelockCompatibleBlock = big.NewInt(5)
+	assert.Error(t, config.CheckConfigForkOrder())
+	config.TimelockCompatibleBlock = big.NewInt(10)
+	assert.Nil(t, config.CheckConfigForkOrder())
+}
+
+func TestChainConfig_CheckCompatibleRewardPolicy(t *testing.T) {
+	treasury := &RewardPolicyConfig{Name: "treasury", Treasury: common.HexToAddress("0x1")}
+	testcases := []struct {
+		stored, new *RewardPolicyConfig
+		compatible  bool
+	}{
+		{nil, nil, true},
+		{nil, &RewardPolicyConfig{}, true},
+		{treasury, &RewardPolicyConfig{Name: "treasury", Treasury: common.HexToAddress("0x1")}, true},
+		{nil, treasury, false},
+		{treasury, nil, false},
+		{treasury, &RewardPolicyConfig{Name: "treasury", Treasury: common.HexToAddress("0x2")}, false},
+	}
+	for i, tc := range testcases {
+		stored, new := &ChainConfig{RewardPolicy: tc.stored}, &ChainConfig{RewardPolicy: tc.new}
+
+		// Nothing to rewind at the genesis
+		assert.Nil(t, stored.CheckCompatible(new, 0), i)
+
+		err := stored.CheckCompatible(new, 100)
+		if tc.compatible {
+			assert.Nil(t, err, i)
+		} else if assert.NotNil(t, err, i) {
+			assert.Equal(t, "RewardPolicy", err.What, i)
+			assert.Equal(t, uint64(0), err.RewindTo, i)
+		}
+	}
 }
 
 func TestChainConfig_Copy(t *testing.T) {
@@ -65,6 +65,10 @@ const (
 	GovParamContract
 	Kip82Ratio
 	DeriveShaImpl
+	TimeoutMultiplier
+	MaxTimeout
+	Reconfiguration
+	Timelock
 )
 
 const (
@@ -136,8 +140,13 @@ func IsProposerUpdateInterval(blockNum uint64) (bool, uint64) {
 
 // CalcProposerBlockNumber returns number of block where list of proposers is updated for block blockNum
 func CalcProposerBlockNumber(blockNum uint64) uint64 {
+	return CalcProposerBlockNumberWithInterval(blockNum, ProposerUpdateInterval())
+}
+
+// CalcProposerBlockNumberWithInterval is CalcProposerBlockNumber with the given proposer update interval.
+func CalcProposerBlockNumberWithInterval(blockNum uint64, proposerInterval uint64) uint64 {
 	var number uint64
-	if isInterval, proposerInterval := IsProposerUpdateInterval(blockNum); isInterval {
+	if (blockNum % proposerInterval) == 0 {
 		number = blockNum - proposerInterval
 	} else {
 		number = blockNum - (blockNum % proposerInterval)
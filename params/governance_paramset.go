@@ -16,6 +16,7 @@ import (
 var (
 	errUnknownGovParamKey  = errors.New("Unknown governance param key")
 	errUnknownGovParamName = errors.New("Unknown governance param name")
+	errHeaderOnlyGovParam  = errors.New("Governance param can only be changed by header votes")
 	errBadGovParamValue    = errors.New("Malformed governance param value")
 )
 
@@ -241,6 +242,9 @@ var govParamTypes = map[int]*govParamType{
 	BaseFeeDenominator:        govParamTypeUint64,
 	GovParamContract:          govParamTypeAddress,
 	DeriveShaImpl:             govParamTypeUint64,
+	Timeout:                   govParamTypeUint64,
+	TimeoutMultiplier:         govParamTypeUint64,
+	MaxTimeout:                govParamTypeUint64,
 }
 
 var govParamNames = map[string]int{
@@ -265,6 +269,17 @@ var govParamNames = map[string]int{
 	"kip71.maxblockgasusedforbasefee": MaxBlockGasUsedForBaseFee,
 	"kip71.basefeedenominator":        BaseFeeDenominator,
 	"governance.deriveshaimpl":        DeriveShaImpl,
+	"istanbul.timeout":                Timeout,
+	"istanbul.timeoutmultiplier":      TimeoutMultiplier,
+	"istanbul.maxtimeout":             MaxTimeout,
+}
+
+// headerOnlyGovParams can be changed only by the header votes of validators,
+// because the round change timeout schedule must be agreed on by all validators.
+var headerOnlyGovParams = map[int]bool{
+	Timeout:           true,
+	TimeoutMultiplier: true,
+	MaxTimeout:        true,
 }
 
 var govParamNamesReverse = map[int]string{}
@@ -340,6 +355,9 @@ func NewGovParamSetBytesMap(items map[string][]byte) (*GovParamSet, error) {
 		if !ok {
 			return nil, errUnknownGovParamName
 		}
+		if headerOnlyGovParams[key] {
+			return nil, errHeaderOnlyGovParam
+		}
 		err := p.setBytes(key, value)
 		if err != nil {
 			return nil, err
@@ -630,6 +648,14 @@ func (p *GovParamSet) Timeout() uint64 {
 	return p.MustGet(Timeout).(uint64)
 }
 
+func (p *GovParamSet) TimeoutMultiplier() uint64 {
+	return p.MustGet(TimeoutMultiplier).(uint64)
+}
+
+func (p *GovParamSet) MaxTimeout() uint64 {
+	return p.MustGet(MaxTimeout).(uint64)
+}
+
 func (p *GovParamSet) LowerBoundBaseFee() uint64 {
 	return p.MustGet(LowerBoundBaseFee).(uint64)
 }
@@ -226,6 +226,21 @@ func TestGovParamSet_New(t *testing.T) {
 		"istanbul.epoch": {1, 1, 2, 3, 4, 5, 6, 7, 8},
 	})
 	assert.NotNil(t, err)
+
+	// The round change timeout params are set by header votes, but not by the governance contract
+	p, err = NewGovParamSetStrMap(map[string]interface{}{
+		"istanbul.timeout":           uint64(10000),
+		"istanbul.timeoutmultiplier": uint64(200),
+		"istanbul.maxtimeout":        uint64(60000),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(200), p.TimeoutMultiplier())
+	for _, name := range []string{"istanbul.timeout", "istanbul.timeoutmultiplier", "istanbul.maxtimeout"} {
+		_, err = NewGovParamSetBytesMap(map[string][]byte{
+			name: {0x12, 0x34},
+		})
+		assert.Equal(t, errHeaderOnlyGovParam, err, name)
+	}
 }
 
 func TestGovParamSet_Merged(t *testing.T) {
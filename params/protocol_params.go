@@ -128,6 +128,7 @@ const (
 	VMLogPerByteGas                  uint64 = 20     // Per-byte price for a VMLOG operation
 	FeePayerGas                      uint64 = 300    // Gas needed for calculating the fee payer of the transaction in a smart contract.
 	ValidateSenderGas                uint64 = 5000   // Gas needed for validating the signature of a message.
+	P256VerifyGas                    uint64 = 3450   // Gas needed for verifying a P-256 signature.
 
 	// The Refund Quotient is the cap on how much of the used gas can be refunded. Before EIP-3529,
 	// up to half the consumed gas could be refunded. Redefined as 1/5th in EIP-3529
@@ -155,6 +156,11 @@ const (
 	TxValidationGasDefault      uint64 = 0
 	TxAccountCreationGasPerKey  uint64 = 20000 // WARNING: With integer overflow in mind before changing this value.
 	TxValidationGasPerKey       uint64 = 15000 // WARNING: With integer overflow in mind before changing this value.
+	TxValidationGasP256         uint64 = 5000  // Additional gas for each P-256 signature over a secp256k1 one. WARNING: With integer overflow in mind before changing this value.
+
+	TxAccountCreationGasSessionKey      uint64 = 10000 // Additional gas for the expiry and the value limit of a session key.
+	TxAccountCreationGasPerSessionScope uint64 = 2000  // Per target or method selector of a session key.
+	TxValidationGasSessionKey           uint64 = 1000  // Additional gas for checking the expiry and the scope of a session key.
 
 	// Fee for new tx types
 	// TODO-Klaytn: Need to fix values
@@ -163,6 +169,8 @@ const (
 	TxGasFeeDelegated          uint64 = 10000
 	TxGasFeeDelegatedWithRatio uint64 = 15000
 	TxGasCancel                uint64 = 21000
+	TxGasBatch                 uint64 = 21000
+	TxGasBatchCall             uint64 = 9000 // Per call of a batch transaction
 
 	// Network Id
 	UnusedNetworkId              uint64 = 0
@@ -176,6 +184,11 @@ const (
 
 	TxDataGas uint64 = 100
 
+	MaxBatchCalls = 32 // Maximum number of calls in a batch transaction
+
+	MaxSessionKeyTargets   = 16 // Maximum number of targets of a session key
+	MaxSessionKeySelectors = 16 // Maximum number of method selectors of a session key
+
 	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP 2930 access list
 	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP 2930 access list
 
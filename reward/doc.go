@@ -78,5 +78,35 @@ Last, distribute reward to each address (proposer, PoC, KIR).
  related struct
  - RewardDistributor
  - rewardConfigCache
+
+
+Accounting Total Supply
+
+The total supply of KLAY at a block is derived as follows.
+
+	TotalSupply = Genesis + TotalMinted - TotalBurntFee - BurntByAddress
+
+Genesis is the sum of the balances allocated in the genesis block.
+TotalMinted and TotalBurntFee are accumulated from the block rewards and the fees burnt by the Magma and Kore rules.
+BurntByAddress is the sum of the balances of the well-known burn addresses such as 0x0 and 0x...dEaD.
+The accumulation starts by admin_backfillTotalSupply, or offline by the backfill-total-supply command of kcn and ken,
+and is stored every 128 blocks, after which klay_getTotalSupply is served.
+debug_checkTotalSupply compares the accumulated supply with the sum of all balances in the state.
+
+ related struct
+ - SupplyManager
+
+
+Indexing Rewards
+
+If a node runs with --rewardindexing, the reward distribution of every new block is stored in the database,
+split by the roles of each reward address: proposer, stakers, KGF and KIR.
+klay_getRewardsByAddress sums up the rewards of an address over a range of blocks,
+klay_getRewardsRange sums up the whole distribution over a range of blocks,
+and the export-rewards command writes the rewards of an address to a CSV file.
+
+ related struct
+ - RewardIndexer
+ - RewardBreakdown
 */
 package reward
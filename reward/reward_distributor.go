@@ -155,20 +155,10 @@ func IsRewardSimple(pset *params.GovParamSet) bool {
 
 // GetBlockReward returns the actual reward amounts paid in this block
 // Used in klay_getReward RPC API
-func GetBlockReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
-	var spec *RewardSpec
-	var err error
-
-	if IsRewardSimple(pset) {
-		spec, err = CalcDeferredRewardSimple(header, rules, pset)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		spec, err = CalcDeferredReward(header, rules, pset)
-		if err != nil {
-			return nil, err
-		}
+func GetBlockReward(policy RewardPolicy, header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
+	spec, err := calcRewardSpec(policy, header, rules, pset)
+	if err != nil {
+		return nil, err
 	}
 
 	// Compensate the difference between CalcDeferredReward() and actual payment.
@@ -184,6 +174,28 @@ func GetBlockReward(header *types.Header, rules params.Rules, pset *params.GovPa
 	return spec, nil
 }
 
+// GetBlockIssuance returns the amount of KLAY issued and burnt by the given block, which changes the total supply.
+// The issued amount is the minted amount, plus the tx fee distributed without being charged before Kore
+// (see CalcDeferredRewardSimple). The burnt amount is the tx fee burnt by the Magma and Kore rules.
+func GetBlockIssuance(policy RewardPolicy, header *types.Header, rules params.Rules, pset *params.GovParamSet) (*big.Int, *big.Int, error) {
+	// The same reward as the one distributed in the consensus engine
+	spec, err := calcRewardSpec(policy, header, rules, pset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// distributed - charged = issued - burnt
+	issued := new(big.Int).Set(spec.BurntFee)
+	for _, amount := range spec.Rewards {
+		issued.Add(issued, amount)
+	}
+	if pset.DeferredTxFee() {
+		// The fee is charged from senders, but not given to the proposer during tx execution
+		issued.Sub(issued, GetTotalTxFee(header, rules, pset))
+	}
+	return issued, new(big.Int).Set(spec.BurntFee), nil
+}
+
 // CalcDeferredRewardSimple distributes rewards to proposer after optional fee burning
 // this behaves similar to the previous MintKLAY
 // MintKLAY has been superseded because we need to split reward distribution
@@ -381,7 +381,7 @@ func TestRewardDistributor_GetBlockReward(t *testing.T) {
 		pset, err := params.NewGovParamSetChainConfig(config)
 		require.Nil(t, err)
 
-		spec, err := GetBlockReward(header, rules, pset)
+		spec, err := GetBlockReward(DefaultRewardPolicy, header, rules, pset)
 		require.Nil(t, err, "testcases[%d] failed", i)
 		assert.Equal(t, tc.expected, spec, "testcases[%d] failed", i)
 	}
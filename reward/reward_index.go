@@ -0,0 +1,422 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	chainEventChanSize = 255
+	// MaxRewardRangeBlocks is the maximum number of blocks whose rewards are summed up at once by ReadRewardsRange.
+	MaxRewardRangeBlocks = 10000
+)
+
+var (
+	errInvalidRewardRange = errors.New("invalid block range")
+	errNoStakingInfo      = errors.New("staking info is not available to find the KGF and KIR addresses")
+)
+
+// rewardIndexDB is an interface for the database storing the reward history.
+type rewardIndexDB interface {
+	ReadBlockRewardHistory(blockNum uint64) ([]byte, error)
+	ReadAddressRewardHistory(addr common.Address, from, to uint64) ([]uint64, [][]byte, error)
+	WriteRewardHistory(blockNum uint64, blockHistory []byte, addressHistory map[common.Address][]byte) error
+	DeleteAddressRewardHistory(blockNum uint64, addrs []common.Address) error
+}
+
+// rewardIndexChain is an interface for blockchain.Blockchain used in RewardIndexer.
+type rewardIndexChain interface {
+	SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription
+	Config() *params.ChainConfig
+}
+
+// RewardBreakdown is the reward given to an address, split by the roles of the address.
+type RewardBreakdown struct {
+	Proposer *big.Int `json:"proposer"` // the reward as the block proposer, including the tx fee paid during tx execution
+	Stakers  *big.Int `json:"stakers"`  // the reward as a staker
+	Kgf      *big.Int `json:"kgf"`      // the reward as KGF
+	Kir      *big.Int `json:"kir"`      // the reward as KIR
+}
+
+func newRewardBreakdown() *RewardBreakdown {
+	return &RewardBreakdown{
+		Proposer: big.NewInt(0),
+		Stakers:  big.NewInt(0),
+		Kgf:      big.NewInt(0),
+		Kir:      big.NewInt(0),
+	}
+}
+
+// Total returns the sum of the rewards of all roles.
+func (b *RewardBreakdown) Total() *big.Int {
+	total := new(big.Int).Add(b.Proposer, b.Stakers)
+	total.Add(total, b.Kgf)
+	return total.Add(total, b.Kir)
+}
+
+func (b *RewardBreakdown) add(o *RewardBreakdown) {
+	b.Proposer.Add(b.Proposer, o.Proposer)
+	b.Stakers.Add(b.Stakers, o.Stakers)
+	b.Kgf.Add(b.Kgf, o.Kgf)
+	b.Kir.Add(b.Kir, o.Kir)
+}
+
+// blockRewardHistory is the reward distribution of a block stored in the reward index.
+type blockRewardHistory struct {
+	Minted   *big.Int                            `json:"minted"`
+	TotalFee *big.Int                            `json:"totalFee"`
+	BurntFee *big.Int                            `json:"burntFee"`
+	Rewards  map[common.Address]*RewardBreakdown `json:"rewards"`
+}
+
+// RangeRewards is the reward distribution summed up over the blocks [From, To].
+type RangeRewards struct {
+	From     uint64                              `json:"from"`
+	To       uint64                              `json:"to"`
+	Minted   *big.Int                            `json:"minted"`
+	TotalFee *big.Int                            `json:"totalFee"`
+	BurntFee *big.Int                            `json:"burntFee"`
+	Proposer *big.Int                            `json:"proposer"`
+	Stakers  *big.Int                            `json:"stakers"`
+	Kgf      *big.Int                            `json:"kgf"`
+	Kir      *big.Int                            `json:"kir"`
+	Rewards  map[common.Address]*RewardBreakdown `json:"rewards"`
+}
+
+// AddressRewards is the reward given to an address over the blocks [From, To].
+type AddressRewards struct {
+	Address  common.Address `json:"address"`
+	From     uint64         `json:"from"`
+	To       uint64         `json:"to"`
+	Blocks   uint64         `json:"blocks"` // the number of blocks rewarding the address
+	Total    *big.Int       `json:"total"`
+	Proposer *big.Int       `json:"proposer"`
+	Stakers  *big.Int       `json:"stakers"`
+	Kgf      *big.Int       `json:"kgf"`
+	Kir      *big.Int       `json:"kir"`
+}
+
+// GetRewardBreakdown returns the reward given to each address by the given block, split by roles.
+// Unlike GetBlockReward, the tx fee paid to the proposer during tx execution is counted as the proposer reward.
+func GetRewardBreakdown(policy RewardPolicy, header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, map[common.Address]*RewardBreakdown, error) {
+	spec, err := calcRewardSpec(policy, header, rules, pset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	breakdowns, err := policy.Breakdown(spec, header, pset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If not DeferredTxFee, the tx fee has been paid to the proposer during tx execution.
+	if !pset.DeferredTxFee() {
+		if _, ok := breakdowns[header.Rewardbase]; !ok {
+			breakdowns[header.Rewardbase] = newRewardBreakdown()
+		}
+		proposer := breakdowns[header.Rewardbase].Proposer
+		proposer.Add(proposer, GetTotalTxFee(header, rules, pset))
+	}
+	return spec, breakdowns, nil
+}
+
+// breakdownRewards splits spec.Rewards by the roles. The KGF and KIR portions are given to kgfAddr and kirAddr
+// if not nil, the proposer portion is given to the rewardbase, and the rest is regarded as the staking reward.
+func breakdownRewards(spec *RewardSpec, header *types.Header, kgfAddr, kirAddr *common.Address) map[common.Address]*RewardBreakdown {
+	breakdowns := make(map[common.Address]*RewardBreakdown)
+	for addr, amount := range spec.Rewards {
+		b := newRewardBreakdown()
+		rest := new(big.Int).Set(amount)
+		if kgfAddr != nil && addr == *kgfAddr {
+			b.Kgf.Set(spec.Kgf)
+			rest.Sub(rest, spec.Kgf)
+		}
+		if kirAddr != nil && addr == *kirAddr {
+			b.Kir.Set(spec.Kir)
+			rest.Sub(rest, spec.Kir)
+		}
+		if addr == header.Rewardbase {
+			b.Proposer.Set(spec.Proposer)
+			rest.Sub(rest, spec.Proposer)
+		}
+		// The rest is the staking reward
+		b.Stakers.Set(rest)
+		breakdowns[addr] = b
+	}
+	return breakdowns
+}
+
+// RewardIndexer stores the reward distribution of every new block in the database,
+// so that the rewards can be summed up per address over a range of blocks.
+type RewardIndexer struct {
+	chain rewardIndexChain
+	gh    governanceHelper
+	db    rewardIndexDB
+
+	// The reward policy is fixed in the chain config, so it is resolved once at the first use
+	policy     RewardPolicy
+	policyErr  error
+	policyOnce sync.Once
+
+	chainEventCh  chan blockchain.ChainEvent
+	chainEventSub event.Subscription
+	wg            sync.WaitGroup
+}
+
+// NewRewardIndexer creates a RewardIndexer.
+func NewRewardIndexer(chain rewardIndexChain, gh governanceHelper, db rewardIndexDB) *RewardIndexer {
+	return &RewardIndexer{
+		chain:        chain,
+		gh:           gh,
+		db:           db,
+		chainEventCh: make(chan blockchain.ChainEvent, chainEventChanSize),
+	}
+}
+
+// Start starts to index new blocks.
+func (ri *RewardIndexer) Start() {
+	ri.chainEventSub = ri.chain.SubscribeChainEvent(ri.chainEventCh)
+
+	ri.wg.Add(1)
+	go ri.loop()
+}
+
+// Stop stops indexing new blocks.
+func (ri *RewardIndexer) Stop() {
+	if ri.chainEventSub != nil {
+		ri.chainEventSub.Unsubscribe()
+	}
+	ri.wg.Wait()
+}
+
+func (ri *RewardIndexer) loop() {
+	defer ri.wg.Done()
+
+	for {
+		select {
+		case ev := <-ri.chainEventCh:
+			if err := ri.IndexBlock(ev.Block.Header()); err != nil {
+				logger.Error("Failed to index the block reward", "number", ev.Block.NumberU64(), "err", err)
+			}
+		case <-ri.chainEventSub.Err():
+			return
+		}
+	}
+}
+
+// IndexBlock stores the reward distribution of the given block.
+// The rewards of the block previously indexed at the same number are replaced.
+func (ri *RewardIndexer) IndexBlock(header *types.Header) error {
+	number := header.Number.Uint64()
+	pset, err := ri.gh.ParamsAt(number)
+	if err != nil {
+		return err
+	}
+	ri.policyOnce.Do(func() {
+		ri.policy, ri.policyErr = NewRewardPolicy(ri.chain.Config())
+	})
+	if ri.policyErr != nil {
+		return ri.policyErr
+	}
+	spec, breakdowns, err := GetRewardBreakdown(ri.policy, header, ri.chain.Config().Rules(header.Number), pset)
+	if err != nil {
+		return err
+	}
+
+	history := &blockRewardHistory{
+		Minted:   spec.Minted,
+		TotalFee: GetTotalTxFee(header, ri.chain.Config().Rules(header.Number), pset),
+		BurntFee: spec.BurntFee,
+		Rewards:  breakdowns,
+	}
+	blockData, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	addressData := make(map[common.Address][]byte, len(breakdowns))
+	for addr, b := range breakdowns {
+		if addressData[addr], err = json.Marshal(b); err != nil {
+			return err
+		}
+	}
+
+	// Remove the rewards of the addresses not rewarded anymore by a chain reorganization
+	if old, err := readBlockRewardHistory(ri.db, number); err == nil {
+		var stale []common.Address
+		for addr := range old.Rewards {
+			if _, ok := breakdowns[addr]; !ok {
+				stale = append(stale, addr)
+			}
+		}
+		if len(stale) > 0 {
+			if err := ri.db.DeleteAddressRewardHistory(number, stale); err != nil {
+				return err
+			}
+		}
+	}
+	return ri.db.WriteRewardHistory(number, blockData, addressData)
+}
+
+func readBlockRewardHistory(db rewardIndexDB, number uint64) (*blockRewardHistory, error) {
+	data, err := db.ReadBlockRewardHistory(number)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("rewards of block %d are not indexed", number)
+	}
+	history := new(blockRewardHistory)
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// ReadRewardsRange sums up the reward distribution of the blocks [from, to] stored in the reward index.
+// Every block in the range should be indexed.
+func ReadRewardsRange(db rewardIndexDB, from, to uint64) (*RangeRewards, error) {
+	if from > to {
+		return nil, errInvalidRewardRange
+	}
+	if to-from >= MaxRewardRangeBlocks {
+		return nil, fmt.Errorf("block range should be less than %d", MaxRewardRangeBlocks)
+	}
+
+	result := &RangeRewards{
+		From:     from,
+		To:       to,
+		Minted:   big.NewInt(0),
+		TotalFee: big.NewInt(0),
+		BurntFee: big.NewInt(0),
+		Proposer: big.NewInt(0),
+		Stakers:  big.NewInt(0),
+		Kgf:      big.NewInt(0),
+		Kir:      big.NewInt(0),
+		Rewards:  make(map[common.Address]*RewardBreakdown),
+	}
+	for number := from; number <= to; number++ {
+		history, err := readBlockRewardHistory(db, number)
+		if err != nil {
+			return nil, err
+		}
+		result.Minted.Add(result.Minted, history.Minted)
+		result.TotalFee.Add(result.TotalFee, history.TotalFee)
+		result.BurntFee.Add(result.BurntFee, history.BurntFee)
+		for addr, b := range history.Rewards {
+			result.Proposer.Add(result.Proposer, b.Proposer)
+			result.Stakers.Add(result.Stakers, b.Stakers)
+			result.Kgf.Add(result.Kgf, b.Kgf)
+			result.Kir.Add(result.Kir, b.Kir)
+
+			if _, ok := result.Rewards[addr]; !ok {
+				result.Rewards[addr] = newRewardBreakdown()
+			}
+			result.Rewards[addr].add(b)
+		}
+	}
+	return result, nil
+}
+
+// ReadRewardsByAddress sums up the rewards given to the address in the blocks [from, to] stored in the reward index.
+// The first and the last blocks of the range should be indexed.
+func ReadRewardsByAddress(db rewardIndexDB, addr common.Address, from, to uint64) (*AddressRewards, error) {
+	nums, breakdowns, err := readAddressRewardHistory(db, addr, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	total := newRewardBreakdown()
+	for _, b := range breakdowns {
+		total.add(b)
+	}
+	return &AddressRewards{
+		Address:  addr,
+		From:     from,
+		To:       to,
+		Blocks:   uint64(len(nums)),
+		Total:    total.Total(),
+		Proposer: total.Proposer,
+		Stakers:  total.Stakers,
+		Kgf:      total.Kgf,
+		Kir:      total.Kir,
+	}, nil
+}
+
+// ExportRewardsByAddress writes the rewards given to the address in the blocks [from, to] to w in CSV,
+// one row per block rewarding the address.
+func ExportRewardsByAddress(w io.Writer, db rewardIndexDB, addr common.Address, from, to uint64) error {
+	nums, breakdowns, err := readAddressRewardHistory(db, addr, from, to)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"block", "proposer", "stakers", "kgf", "kir", "total"}); err != nil {
+		return err
+	}
+	for i, b := range breakdowns {
+		record := []string{
+			strconv.FormatUint(nums[i], 10),
+			b.Proposer.String(),
+			b.Stakers.String(),
+			b.Kgf.String(),
+			b.Kir.String(),
+			b.Total().String(),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readAddressRewardHistory(db rewardIndexDB, addr common.Address, from, to uint64) ([]uint64, []*RewardBreakdown, error) {
+	if from > to {
+		return nil, nil, errInvalidRewardRange
+	}
+	// The blocks not rewarding the address are not stored, so only the both ends are checked.
+	for _, number := range []uint64{from, to} {
+		if _, err := readBlockRewardHistory(db, number); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	nums, data, err := db.ReadAddressRewardHistory(addr, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	breakdowns := make([]*RewardBreakdown, len(data))
+	for i := range data {
+		breakdowns[i] = new(RewardBreakdown)
+		if err := json.Unmarshal(data[i], breakdowns[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nums, breakdowns, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRewardIndexChain struct {
+	config *params.ChainConfig
+	feed   event.Feed
+}
+
+func (c *testRewardIndexChain) SubscribeChainEvent(ch chan<- blockchain.ChainEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func (c *testRewardIndexChain) Config() *params.ChainConfig { return c.config }
+
+func TestGetRewardBreakdown(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	stakingInfo := genStakingInfo(5, nil, map[int]uint64{
+		0: minStaking + 4,
+		1: minStaking + 3,
+	})
+	SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	var (
+		staker0 = intToAddress(rewardBaseAddr)
+		staker1 = intToAddress(rewardBaseAddr + 1)
+		zero    = big.NewInt(0)
+	)
+	breakdown := func(proposer, stakers, kgf, kir *big.Int) *RewardBreakdown {
+		return &RewardBreakdown{Proposer: proposer, Stakers: stakers, Kgf: kgf, Kir: kir}
+	}
+
+	testcases := []struct {
+		rewardbase    common.Address
+		deferredTxFee bool
+		expected      map[common.Address]*RewardBreakdown
+	}{
+		{
+			rewardbase:    proposerAddr,
+			deferredTxFee: true,
+			expected: map[common.Address]*RewardBreakdown{
+				proposerAddr: breakdown(new(big.Int).SetUint64(0.6528e18+1), zero, zero, zero),
+				kgfAddr:      breakdown(zero, zero, new(big.Int).SetUint64(5.184e18), zero),
+				kirAddr:      breakdown(zero, zero, zero, new(big.Int).SetUint64(1.152e18)),
+				staker0:      breakdown(zero, new(big.Int).SetUint64(1492114285714285714), zero, zero),
+				staker1:      breakdown(zero, new(big.Int).SetUint64(1119085714285714285), zero, zero),
+			},
+		},
+		{
+			// the tx fee paid during tx execution is counted as the proposer reward
+			rewardbase:    proposerAddr,
+			deferredTxFee: false,
+			expected: map[common.Address]*RewardBreakdown{
+				proposerAddr: breakdown(new(big.Int).SetUint64(0.6528e18+1+1000), zero, zero, zero),
+				kgfAddr:      breakdown(zero, zero, new(big.Int).SetUint64(5.184e18), zero),
+				kirAddr:      breakdown(zero, zero, zero, new(big.Int).SetUint64(1.152e18)),
+				staker0:      breakdown(zero, new(big.Int).SetUint64(1492114285714285714), zero, zero),
+				staker1:      breakdown(zero, new(big.Int).SetUint64(1119085714285714285), zero, zero),
+			},
+		},
+		{
+			// the proposer is also a staker
+			rewardbase:    staker0,
+			deferredTxFee: true,
+			expected: map[common.Address]*RewardBreakdown{
+				staker0: breakdown(new(big.Int).SetUint64(0.6528e18+1), new(big.Int).SetUint64(1492114285714285714), zero, zero),
+				kgfAddr: breakdown(zero, zero, new(big.Int).SetUint64(5.184e18), zero),
+				kirAddr: breakdown(zero, zero, zero, new(big.Int).SetUint64(1.152e18)),
+				staker1: breakdown(zero, new(big.Int).SetUint64(1119085714285714285), zero, zero),
+			},
+		},
+	}
+
+	for i, tc := range testcases {
+		config := getTestConfig()
+		if !tc.deferredTxFee {
+			config = noDeferred(config)
+		}
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		header := &types.Header{
+			Number:     big.NewInt(1),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: tc.rewardbase,
+		}
+		_, breakdowns, err := GetRewardBreakdown(DefaultRewardPolicy, header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, len(tc.expected), len(breakdowns), "testcases[%d] failed", i)
+		for addr, expected := range tc.expected {
+			if assert.NotNil(t, breakdowns[addr], "testcases[%d] failed", i) {
+				assert.Equal(t, breakdownString(expected), breakdownString(breakdowns[addr]), "testcases[%d] failed: %s", i, addr.Hex())
+			}
+		}
+	}
+
+	// without the staking info, the KGF and KIR addresses are unknown
+	SetTestStakingManager(nil)
+	pset, err := params.NewGovParamSetChainConfig(getTestConfig())
+	require.Nil(t, err)
+	header := &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(1), Rewardbase: proposerAddr}
+	_, _, err = GetRewardBreakdown(DefaultRewardPolicy, header, getTestConfig().Rules(header.Number), pset)
+	assert.Equal(t, errNoStakingInfo, err)
+}
+
+// breakdownString formats b to compare big.Ints by their values.
+func breakdownString(b *RewardBreakdown) string {
+	return fmt.Sprintf("proposer=%v stakers=%v kgf=%v kir=%v", b.Proposer, b.Stakers, b.Kgf, b.Kir)
+}
+
+func TestRewardIndexer(t *testing.T) {
+	config := roundrobin(getTestConfig())
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	var (
+		addrA   = intToAddress(1)
+		addrB   = intToAddress(2)
+		db      = database.NewMemoryDBManager()
+		indexer = NewRewardIndexer(&testRewardIndexChain{config: config}, &testGovernance{pset}, db)
+		// every block rewards its proposer with minted + 500, burning the other half of the fee
+		blockReward = new(big.Int).Add(minted, big.NewInt(500))
+	)
+	index := func(number int64, rewardbase common.Address) {
+		header := &types.Header{
+			Number:     big.NewInt(number),
+			GasUsed:    1000,
+			BaseFee:    big.NewInt(1),
+			Rewardbase: rewardbase,
+		}
+		require.Nil(t, indexer.IndexBlock(header))
+	}
+	times := func(x *big.Int, n int64) *big.Int {
+		return new(big.Int).Mul(x, big.NewInt(n))
+	}
+
+	index(1, addrA)
+	index(2, addrB)
+	index(3, addrA)
+
+	rewards, err := ReadRewardsByAddress(db, addrA, 1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(2), rewards.Blocks)
+	assert.Equal(t, times(blockReward, 2), rewards.Total)
+	assert.Equal(t, times(blockReward, 2), rewards.Proposer)
+	assert.Equal(t, big.NewInt(0), rewards.Stakers)
+
+	rangeRewards, err := ReadRewardsRange(db, 1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, times(minted, 3), rangeRewards.Minted)
+	assert.Equal(t, big.NewInt(3000), rangeRewards.TotalFee)
+	assert.Equal(t, big.NewInt(1500), rangeRewards.BurntFee)
+	assert.Equal(t, times(blockReward, 3), rangeRewards.Proposer)
+	assert.Equal(t, times(blockReward, 2), rangeRewards.Rewards[addrA].Proposer)
+	assert.Equal(t, blockReward, rangeRewards.Rewards[addrB].Proposer)
+
+	// the range should be indexed
+	_, err = ReadRewardsRange(db, 1, 4)
+	assert.Error(t, err)
+	_, err = ReadRewardsByAddress(db, addrA, 0, 3)
+	assert.Error(t, err)
+	_, err = ReadRewardsByAddress(db, addrA, 3, 1)
+	assert.Equal(t, errInvalidRewardRange, err)
+
+	// block 3 is replaced by a chain reorganization
+	index(3, addrB)
+	rewards, err = ReadRewardsByAddress(db, addrA, 1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), rewards.Blocks)
+	assert.Equal(t, blockReward, rewards.Total)
+
+	var buf bytes.Buffer
+	require.Nil(t, ExportRewardsByAddress(&buf, db, addrB, 1, 3))
+	assert.Equal(t, fmt.Sprintf("block,proposer,stakers,kgf,kir,total\n2,%v,0,0,0,%v\n3,%v,0,0,0,%v\n",
+		blockReward, blockReward, blockReward, blockReward), buf.String())
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/params"
+)
+
+const (
+	DefaultRewardPolicyName  = "default"
+	TreasuryRewardPolicyName = "treasury"
+)
+
+var (
+	errRewardPolicyRegistered = errors.New("reward policy is already registered")
+	errEmptyTreasury          = errors.New("treasury address is not set")
+)
+
+// RewardPolicy determines the rewards distributed at the end of each block.
+// The policy of a network is selected by ChainConfig.RewardPolicy in the genesis,
+// so every node of the network should have the same set of policies registered.
+type RewardPolicy interface {
+	// CalcDeferredReward calculates the rewards when the proposers are selected
+	// by the staking amounts, i.e., the proposer policy is istanbul.WeightedRandom.
+	CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error)
+
+	// CalcDeferredRewardSimple calculates the rewards under the other proposer policies,
+	// where the staking information is not available.
+	CalcDeferredRewardSimple(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error)
+
+	// Breakdown splits the rewards of spec, calculated by the policy for the block, by the roles of the recipients.
+	// The tx fee paid to the proposer during tx execution is not included.
+	Breakdown(spec *RewardSpec, header *types.Header, pset *params.GovParamSet) (map[common.Address]*RewardBreakdown, error)
+}
+
+// RewardPolicyFactory creates a RewardPolicy from the genesis configuration.
+type RewardPolicyFactory func(config *params.RewardPolicyConfig) (RewardPolicy, error)
+
+var (
+	rewardPoliciesLock sync.RWMutex
+	rewardPolicies     = map[string]RewardPolicyFactory{
+		DefaultRewardPolicyName:  func(*params.RewardPolicyConfig) (RewardPolicy, error) { return DefaultRewardPolicy, nil },
+		TreasuryRewardPolicyName: newTreasuryRewardPolicy,
+	}
+)
+
+// RegisterRewardPolicy registers a reward policy that can be selected by its name in the genesis.
+// It should be called before the blockchain is created, e.g., in an init function.
+func RegisterRewardPolicy(name string, factory RewardPolicyFactory) error {
+	rewardPoliciesLock.Lock()
+	defer rewardPoliciesLock.Unlock()
+
+	if _, ok := rewardPolicies[name]; ok {
+		return errRewardPolicyRegistered
+	}
+	rewardPolicies[name] = factory
+	return nil
+}
+
+// NewRewardPolicy returns the reward policy selected by the chain config.
+// The default policy is returned if no policy is selected.
+func NewRewardPolicy(config *params.ChainConfig) (RewardPolicy, error) {
+	if config == nil || config.RewardPolicy == nil || config.RewardPolicy.Name == "" {
+		return DefaultRewardPolicy, nil
+	}
+
+	rewardPoliciesLock.RLock()
+	factory, ok := rewardPolicies[config.RewardPolicy.Name]
+	rewardPoliciesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown reward policy: %s", config.RewardPolicy.Name)
+	}
+	return factory(config.RewardPolicy)
+}
+
+// calcRewardSpec calculates the rewards distributed by the consensus engine at the end of the block.
+func calcRewardSpec(policy RewardPolicy, header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
+	if IsRewardSimple(pset) {
+		return policy.CalcDeferredRewardSimple(header, rules, pset)
+	}
+	return policy.CalcDeferredReward(header, rules, pset)
+}
+
+// DefaultRewardPolicy distributes the rewards to the proposer, the stakers, KGF and KIR
+// by reward.ratio and reward.kip82ratio, burning the tx fee by the Magma and Kore rules.
+var DefaultRewardPolicy RewardPolicy = defaultRewardPolicy{}
+
+type defaultRewardPolicy struct{}
+
+func (defaultRewardPolicy) CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
+	return CalcDeferredReward(header, rules, pset)
+}
+
+func (defaultRewardPolicy) CalcDeferredRewardSimple(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
+	return CalcDeferredRewardSimple(header, rules, pset)
+}
+
+// Breakdown finds the KGF and KIR addresses in the staking info, where the portions are paid.
+func (defaultRewardPolicy) Breakdown(spec *RewardSpec, header *types.Header, pset *params.GovParamSet) (map[common.Address]*RewardBreakdown, error) {
+	if IsRewardSimple(pset) {
+		return breakdownRewards(spec, header, nil, nil), nil
+	}
+	// Without the staking info, the KGF and KIR portions would be mistaken for the staking reward.
+	stakingInfo := GetStakingInfo(header.Number.Uint64())
+	if stakingInfo == nil {
+		return nil, errNoStakingInfo
+	}
+	return breakdownRewards(spec, header, &stakingInfo.PoCAddr, &stakingInfo.KIRAddr), nil
+}
+
+// TreasuryRewardPolicy gives the minted amount to the proposer, and all the tx fee to the treasury
+// without burning, regardless of the proposer policy. Setting reward.mintingamount to 0 stops minting.
+// If reward.deferredtxfee is false, the tx fee is paid to the proposer during tx execution as usual.
+type TreasuryRewardPolicy struct {
+	treasury common.Address
+}
+
+func newTreasuryRewardPolicy(config *params.RewardPolicyConfig) (RewardPolicy, error) {
+	if common.EmptyAddress(config.Treasury) {
+		return nil, errEmptyTreasury
+	}
+	return &TreasuryRewardPolicy{treasury: config.Treasury}, nil
+}
+
+// Treasury returns the recipient of the tx fees.
+func (p *TreasuryRewardPolicy) Treasury() common.Address {
+	return p.treasury
+}
+
+func (p *TreasuryRewardPolicy) CalcDeferredReward(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
+	minted := new(big.Int).Set(pset.MintingAmountBig())
+
+	totalFee := big.NewInt(0)
+	if pset.DeferredTxFee() {
+		totalFee = GetTotalTxFee(header, rules, pset)
+	}
+
+	spec := &RewardSpec{
+		Minted:   minted,
+		TotalFee: totalFee,
+		BurntFee: big.NewInt(0),
+		Proposer: new(big.Int).Set(minted),
+		Stakers:  big.NewInt(0),
+		Kgf:      new(big.Int).Set(totalFee),
+		Kir:      big.NewInt(0),
+		Rewards:  make(map[common.Address]*big.Int),
+	}
+	incrementRewardsMap(spec.Rewards, header.Rewardbase, spec.Proposer)
+	incrementRewardsMap(spec.Rewards, p.treasury, spec.Kgf)
+	return spec, nil
+}
+
+func (p *TreasuryRewardPolicy) CalcDeferredRewardSimple(header *types.Header, rules params.Rules, pset *params.GovParamSet) (*RewardSpec, error) {
+	return p.CalcDeferredReward(header, rules, pset)
+}
+
+// Breakdown counts the tx fee given to the treasury as the KGF portion.
+func (p *TreasuryRewardPolicy) Breakdown(spec *RewardSpec, header *types.Header, pset *params.GovParamSet) (map[common.Address]*RewardBreakdown, error) {
+	return breakdownRewards(spec, header, &p.treasury, nil), nil
+}
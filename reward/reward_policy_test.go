@@ -0,0 +1,118 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRewardPolicy(t *testing.T) {
+	config := getTestConfig()
+	policy, err := NewRewardPolicy(config)
+	require.Nil(t, err)
+	assert.Equal(t, DefaultRewardPolicy, policy)
+
+	config.RewardPolicy = &params.RewardPolicyConfig{Name: TreasuryRewardPolicyName, Treasury: kgfAddr}
+	policy, err = NewRewardPolicy(config)
+	require.Nil(t, err)
+	assert.Equal(t, kgfAddr, policy.(*TreasuryRewardPolicy).Treasury())
+
+	config.RewardPolicy = &params.RewardPolicyConfig{Name: TreasuryRewardPolicyName}
+	_, err = NewRewardPolicy(config)
+	assert.Equal(t, errEmptyTreasury, err)
+
+	config.RewardPolicy = &params.RewardPolicyConfig{Name: "unknown"}
+	_, err = NewRewardPolicy(config)
+	assert.Error(t, err)
+
+	assert.Equal(t, errRewardPolicyRegistered, RegisterRewardPolicy(DefaultRewardPolicyName, nil))
+}
+
+func TestTreasuryRewardPolicy(t *testing.T) {
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasUsed:    1000,
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+	}
+	fee := big.NewInt(1000)
+
+	testcases := []struct {
+		policy        istanbul.ProposerPolicy
+		deferredTxFee bool
+		mintingAmount *big.Int
+		expected      map[common.Address]*big.Int // rewards given by GetBlockReward
+	}{
+		{istanbul.WeightedRandom, true, minted, map[common.Address]*big.Int{
+			proposerAddr: minted,
+			kgfAddr:      fee,
+		}},
+		{istanbul.RoundRobin, true, minted, map[common.Address]*big.Int{
+			proposerAddr: minted,
+			kgfAddr:      fee,
+		}},
+		// The tx fee is paid to the proposer during tx execution
+		{istanbul.WeightedRandom, false, minted, map[common.Address]*big.Int{
+			proposerAddr: new(big.Int).Add(minted, fee),
+			kgfAddr:      big.NewInt(0),
+		}},
+		// No minting
+		{istanbul.WeightedRandom, true, big.NewInt(0), map[common.Address]*big.Int{
+			proposerAddr: big.NewInt(0),
+			kgfAddr:      fee,
+		}},
+	}
+
+	for i, tc := range testcases {
+		config := getTestConfig()
+		config.Governance.Reward.DeferredTxFee = tc.deferredTxFee
+		config.Governance.Reward.MintingAmount = tc.mintingAmount
+		config.Istanbul.ProposerPolicy = uint64(tc.policy)
+		config.RewardPolicy = &params.RewardPolicyConfig{Name: TreasuryRewardPolicyName, Treasury: kgfAddr}
+		rules := config.Rules(header.Number)
+
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+		policy, err := NewRewardPolicy(config)
+		require.Nil(t, err)
+
+		spec, err := GetBlockReward(policy, header, rules, pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.expected, spec.Rewards, "testcases[%d] failed", i)
+		assert.Equal(t, big.NewInt(0), spec.BurntFee, "testcases[%d] failed", i)
+
+		// Nothing is burnt, so only the minted amount is issued
+		issued, burnt, err := GetBlockIssuance(policy, header, rules, pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.mintingAmount.String(), issued.String(), "testcases[%d] failed", i)
+		assert.Equal(t, big.NewInt(0), burnt, "testcases[%d] failed", i)
+
+		// The treasury gets the fee as KGF
+		_, breakdowns, err := GetRewardBreakdown(policy, header, rules, pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, tc.expected[kgfAddr], breakdowns[kgfAddr].Kgf, "testcases[%d] failed", i)
+		assert.Equal(t, tc.expected[proposerAddr], breakdowns[proposerAddr].Proposer, "testcases[%d] failed", i)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+var errInconsistentStakingInfo = errors.New("the numbers of council addresses and staking amounts are different")
+
+// stakingInfoSnapshotDB is an interface for the database to export or import all the staking information.
+type stakingInfoSnapshotDB interface {
+	stakingInfoDB
+	ReadAllStakingInfo() ([]uint64, [][]byte, error)
+}
+
+// StakingInfoMismatch is a difference between a stored StakingInfo and
+// the one rebuilt from the AddressBook contract at its block.
+type StakingInfoMismatch struct {
+	BlockNum uint64      `json:"blockNum"`
+	Field    string      `json:"field"`
+	Stored   interface{} `json:"stored,omitempty"`
+	Expected interface{} `json:"expected,omitempty"`
+	Error    string      `json:"error,omitempty"` // the reason if the entry could not be verified
+}
+
+func (m *StakingInfoMismatch) String() string {
+	if m.Error != "" {
+		return fmt.Sprintf("block %d: %s: %s", m.BlockNum, m.Field, m.Error)
+	}
+	return fmt.Sprintf("block %d: %s: stored %v, expected %v", m.BlockNum, m.Field, m.Stored, m.Expected)
+}
+
+// ExportStakingInfos writes all the stored staking information to w in JSON, one StakingInfo per line
+// in the order of block numbers. It returns the number of exported entries.
+func ExportStakingInfos(w io.Writer, db stakingInfoSnapshotDB) (int, error) {
+	nums, data, err := db.ReadAllStakingInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	enc := json.NewEncoder(w)
+	for i := range data {
+		stakingInfo, err := decodeStakingInfo(data[i])
+		if err != nil {
+			return i, fmt.Errorf("invalid staking info at block %d: %v", nums[i], err)
+		}
+		if err := enc.Encode(stakingInfo); err != nil {
+			return i, err
+		}
+	}
+	return len(data), nil
+}
+
+// ImportStakingInfos reads the staking information written by ExportStakingInfos from r and stores them to db.
+// The staking information already stored is kept unless overwrite is true.
+// It returns the numbers of imported and skipped entries.
+func ImportStakingInfos(r io.Reader, db stakingInfoDB, overwrite bool) (int, int, error) {
+	imported, skipped := 0, 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), math.MaxInt32)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		stakingInfo, err := decodeStakingInfo(scanner.Bytes())
+		if err != nil {
+			return imported, skipped, fmt.Errorf("invalid staking info at line %d: %v", line, err)
+		}
+		if !overwrite {
+			if _, err := db.ReadStakingInfo(stakingInfo.BlockNum); err == nil {
+				skipped++
+				continue
+			}
+		}
+
+		b, err := json.Marshal(stakingInfo)
+		if err != nil {
+			return imported, skipped, err
+		}
+		if err := db.WriteStakingInfo(stakingInfo.BlockNum, b); err != nil {
+			return imported, skipped, err
+		}
+		imported++
+	}
+	return imported, skipped, scanner.Err()
+}
+
+func decodeStakingInfo(b []byte) (*StakingInfo, error) {
+	stakingInfo := new(StakingInfo)
+	if err := json.Unmarshal(b, stakingInfo); err != nil {
+		return nil, err
+	}
+	n := len(stakingInfo.CouncilNodeAddrs)
+	if len(stakingInfo.CouncilStakingAddrs) != n || len(stakingInfo.CouncilRewardAddrs) != n || len(stakingInfo.CouncilStakingAmounts) != n {
+		return nil, errInconsistentStakingInfo
+	}
+	return stakingInfo, nil
+}
+
+// VerifyStakingInfos compares all the stored staking information with the AddressBook contract state
+// at their blocks, and returns the differences. The entries whose state is not available are reported with Error.
+// The Gini coefficient is reported as missing if it is used but not stored,
+// although it is filled in when the staking information is loaded.
+func VerifyStakingInfos(db stakingInfoSnapshotDB, bc blockChain, gh governanceHelper) ([]*StakingInfoMismatch, error) {
+	nums, data, err := db.ReadAllStakingInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	ac := newAddressBookConnector(bc, gh)
+	mismatches := make([]*StakingInfoMismatch, 0)
+	for i, num := range nums {
+		stored, err := decodeStakingInfo(data[i])
+		if err != nil {
+			mismatches = append(mismatches, &StakingInfoMismatch{BlockNum: num, Field: "stakingInfo", Error: err.Error()})
+			continue
+		}
+		if stored.BlockNum != num {
+			mismatches = append(mismatches, &StakingInfoMismatch{BlockNum: num, Field: "BlockNum", Stored: stored.BlockNum, Expected: num})
+		}
+
+		expected, err := ac.getStakingInfoFromAddressBook(num)
+		if err != nil {
+			mismatches = append(mismatches, &StakingInfoMismatch{BlockNum: num, Field: "addressBook", Error: err.Error()})
+			continue
+		}
+		if expected.UseGini {
+			pset, err := gh.ParamsAt(num)
+			if err != nil {
+				mismatches = append(mismatches, &StakingInfoMismatch{BlockNum: num, Field: "Gini", Error: err.Error()})
+				continue
+			}
+			if c := expected.GetConsolidatedStakingInfo(); c != nil {
+				expected.Gini = c.CalcGiniCoefficientMinStake(pset.MinimumStakeBig().Uint64())
+			}
+		}
+		mismatches = append(mismatches, compareStakingInfo(stored, expected)...)
+	}
+	return mismatches, nil
+}
+
+// compareStakingInfo returns the fields of stored different from expected.
+func compareStakingInfo(stored, expected *StakingInfo) []*StakingInfoMismatch {
+	var mismatches []*StakingInfoMismatch
+	add := func(field string, s, e interface{}) {
+		mismatches = append(mismatches, &StakingInfoMismatch{BlockNum: expected.BlockNum, Field: field, Stored: s, Expected: e})
+	}
+
+	fields := []struct {
+		name     string
+		stored   interface{}
+		expected interface{}
+	}{
+		{"CouncilNodeAddrs", stored.CouncilNodeAddrs, expected.CouncilNodeAddrs},
+		{"CouncilStakingAddrs", stored.CouncilStakingAddrs, expected.CouncilStakingAddrs},
+		{"CouncilRewardAddrs", stored.CouncilRewardAddrs, expected.CouncilRewardAddrs},
+		{"CouncilStakingAmounts", stored.CouncilStakingAmounts, expected.CouncilStakingAmounts},
+		{"KIRAddr", stored.KIRAddr, expected.KIRAddr},
+		{"PoCAddr", stored.PoCAddr, expected.PoCAddr},
+		{"UseGini", stored.UseGini, expected.UseGini},
+	}
+	for _, f := range fields {
+		// nil and empty slices are the same
+		if reflect.ValueOf(f.stored).Kind() == reflect.Slice &&
+			reflect.ValueOf(f.stored).Len() == 0 && reflect.ValueOf(f.expected).Len() == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(f.stored, f.expected) {
+			add(f.name, f.stored, f.expected)
+		}
+	}
+
+	if expected.UseGini {
+		if stored.Gini < 0 {
+			mismatches = append(mismatches, &StakingInfoMismatch{
+				BlockNum: expected.BlockNum, Field: "Gini", Stored: stored.Gini, Expected: expected.Gini,
+				Error: "missing Gini coefficient",
+			})
+		} else if math.Abs(stored.Gini-expected.Gini) > 1e-9 {
+			add("Gini", stored.Gini, expected.Gini)
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakingInfoSnapshot_ExportImport(t *testing.T) {
+	src := database.NewMemoryDBManager()
+	var stored []*StakingInfo
+	for i, testcase := range stakingInfoTestCases {
+		stakingInfo := *testcase.stakingInfo
+		stakingInfo.BlockNum = uint64(86400 * (len(stakingInfoTestCases) - i))
+		b, err := json.Marshal(&stakingInfo)
+		require.Nil(t, err)
+		require.Nil(t, src.WriteStakingInfo(stakingInfo.BlockNum, b))
+		stored = append([]*StakingInfo{&stakingInfo}, stored...)
+	}
+
+	var buf bytes.Buffer
+	n, err := ExportStakingInfos(&buf, src)
+	require.Nil(t, err)
+	assert.Equal(t, len(stored), n)
+
+	// An entry already stored is kept
+	dst := database.NewMemoryDBManager()
+	kept := []byte(`{"BlockNum":86400}`)
+	require.Nil(t, dst.WriteStakingInfo(86400, kept))
+
+	imported, skipped, err := ImportStakingInfos(bytes.NewReader(buf.Bytes()), dst, false)
+	require.Nil(t, err)
+	assert.Equal(t, len(stored)-1, imported)
+	assert.Equal(t, 1, skipped)
+	b, err := dst.ReadStakingInfo(86400)
+	require.Nil(t, err)
+	assert.Equal(t, kept, b)
+
+	imported, skipped, err = ImportStakingInfos(bytes.NewReader(buf.Bytes()), dst, true)
+	require.Nil(t, err)
+	assert.Equal(t, len(stored), imported)
+	assert.Equal(t, 0, skipped)
+
+	nums, data, err := dst.ReadAllStakingInfo()
+	require.Nil(t, err)
+	require.Equal(t, len(stored), len(nums))
+	for i := range nums {
+		stakingInfo, err := decodeStakingInfo(data[i])
+		require.Nil(t, err)
+		assert.Equal(t, stored[i], stakingInfo)
+	}
+
+	// An inconsistent entry is rejected
+	_, _, err = ImportStakingInfos(strings.NewReader(`{"BlockNum":86400,"CouncilNodeAddrs":["0x0000000000000000000000000000000000000001"]}`), dst, true)
+	assert.Error(t, err)
+}
+
+func TestCompareStakingInfo(t *testing.T) {
+	expected := *stakingInfoTestCases[len(stakingInfoTestCases)-1].stakingInfo
+	expected.UseGini = true
+	expected.Gini = 0.5
+
+	// The Gini coefficient is not stored in DB
+	stored := expected
+	stored.Gini = DefaultGiniCoefficient
+	mismatches := compareStakingInfo(&stored, &expected)
+	require.Equal(t, 1, len(mismatches))
+	assert.Equal(t, "Gini", mismatches[0].Field)
+	assert.NotEmpty(t, mismatches[0].Error)
+
+	stored = expected
+	stored.KIRAddr = common.HexToAddress("0x1")
+	stored.CouncilStakingAmounts = append([]uint64{}, expected.CouncilStakingAmounts...)
+	stored.CouncilStakingAmounts[0]++
+	mismatches = compareStakingInfo(&stored, &expected)
+	require.Equal(t, 2, len(mismatches))
+	assert.Equal(t, "CouncilStakingAmounts", mismatches[0].Field)
+	assert.Equal(t, "KIRAddr", mismatches[1].Field)
+
+	// nil and empty slices are the same
+	empty := newEmptyStakingInfo(0)
+	noCouncil := *empty
+	noCouncil.CouncilNodeAddrs = nil
+	assert.Empty(t, compareStakingInfo(&noCouncil, empty))
+}
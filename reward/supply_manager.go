@@ -0,0 +1,454 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/event"
+)
+
+const (
+	// supplyCheckpointInterval is the interval of blocks whose accumulated supply is stored in the database.
+	supplyCheckpointInterval = 128
+	// maxSupplyAccumulation is the maximum number of blocks accumulated on demand after the last checkpoint.
+	maxSupplyAccumulation = 16 * supplyCheckpointInterval
+)
+
+var (
+	// BurnAddresses are the addresses known to hold burnt KLAY, which nobody can spend.
+	BurnAddresses = []common.Address{
+		common.HexToAddress("0x0000000000000000000000000000000000000000"),
+		common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+	}
+
+	errSupplyNotBackfilled   = errors.New("total supply is not backfilled yet")
+	errSupplyBackfillRunning = errors.New("total supply is being backfilled")
+	errSupplyManagerStopped  = errors.New("supply manager is stopped")
+)
+
+// supplyDB is an interface for the database storing the supply checkpoints.
+type supplyDB interface {
+	ReadSupplyCheckpoint(blockNum uint64) ([]byte, error)
+	WriteSupplyCheckpoint(blockNum uint64, checkpoint []byte) error
+	ReadLastSupplyCheckpointNumber() (uint64, bool)
+	WriteLastSupplyCheckpointNumber(blockNum uint64) error
+}
+
+// supplyChain is an interface for blockchain.Blockchain used in SupplyManager.
+type supplyChain interface {
+	blockChain
+
+	CurrentHeader() *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// supplyCheckpoint is the amount of KLAY accumulated from the genesis block to Number.
+type supplyCheckpoint struct {
+	Number        uint64   `json:"number"`
+	Genesis       *big.Int `json:"genesis"`
+	TotalMinted   *big.Int `json:"totalMinted"`
+	TotalBurntFee *big.Int `json:"totalBurntFee"`
+}
+
+func (c *supplyCheckpoint) copy() *supplyCheckpoint {
+	return &supplyCheckpoint{
+		Number:        c.Number,
+		Genesis:       new(big.Int).Set(c.Genesis),
+		TotalMinted:   new(big.Int).Set(c.TotalMinted),
+		TotalBurntFee: new(big.Int).Set(c.TotalBurntFee),
+	}
+}
+
+// TotalSupply is the total supply of KLAY at a block and its breakdown.
+// TotalSupply = Genesis + TotalMinted - TotalBurntFee - BurntByAddress
+type TotalSupply struct {
+	Number         uint64   `json:"number"`
+	TotalSupply    *big.Int `json:"totalSupply"`
+	Genesis        *big.Int `json:"genesis"`        // the sum of the balances allocated in the genesis block
+	TotalMinted    *big.Int `json:"totalMinted"`    // the amount minted by block rewards
+	TotalBurntFee  *big.Int `json:"totalBurntFee"`  // the amount of tx fee burnt by the Magma and Kore rules
+	BurntByAddress *big.Int `json:"burntByAddress"` // the sum of the balances of BurnAddresses
+}
+
+// SupplyCheckResult compares the total supply accumulated by SupplyManager with the one recomputed from the state.
+type SupplyCheckResult struct {
+	Number      uint64   `json:"number"`
+	Accumulated *big.Int `json:"accumulated"` // Genesis + TotalMinted - TotalBurntFee
+	FromState   *big.Int `json:"fromState"`   // the sum of the balances of all accounts
+	Match       bool     `json:"match"`
+}
+
+// SupplyManager incrementally accumulates the KLAY minted and burnt by blocks,
+// and stores the accumulated supply every supplyCheckpointInterval blocks.
+// It starts accumulating after Backfill is called, and keeps up with new blocks since then.
+type SupplyManager struct {
+	chain supplyChain
+	gh    governanceHelper
+	db    supplyDB
+
+	mu   sync.RWMutex
+	last *supplyCheckpoint // the last checkpoint stored in the database, nil if not backfilled
+
+	backfilling   int32
+	wakeCh        chan struct{}
+	chainHeadChan chan blockchain.ChainHeadEvent
+	chainHeadSub  event.Subscription
+	quit          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewSupplyManager creates a SupplyManager, loading the last checkpoint from the database.
+func NewSupplyManager(chain supplyChain, gh governanceHelper, db supplyDB) *SupplyManager {
+	sm := &SupplyManager{
+		chain:         chain,
+		gh:            gh,
+		db:            db,
+		wakeCh:        make(chan struct{}, 1),
+		chainHeadChan: make(chan blockchain.ChainHeadEvent, chainHeadChanSize),
+		quit:          make(chan struct{}),
+	}
+	if number, ok := db.ReadLastSupplyCheckpointNumber(); ok {
+		if c, err := sm.readCheckpoint(number); err == nil {
+			sm.last = c
+		} else {
+			logger.Error("Failed to read the last supply checkpoint", "number", number, "err", err)
+		}
+	}
+	return sm
+}
+
+// Start starts to keep up with new blocks.
+func (sm *SupplyManager) Start() {
+	sm.chainHeadSub = sm.chain.SubscribeChainHeadEvent(sm.chainHeadChan)
+
+	sm.wg.Add(2)
+	go sm.loop()
+	go sm.accumulateLoop()
+	sm.wake()
+}
+
+// Stop stops SupplyManager. An ongoing backfill is resumed from the last checkpoint after restart.
+func (sm *SupplyManager) Stop() {
+	if sm.chainHeadSub != nil {
+		sm.chainHeadSub.Unsubscribe()
+	}
+	close(sm.quit)
+	sm.wg.Wait()
+}
+
+// Backfill starts to accumulate the supply from the genesis block, or from the last checkpoint if exists, in the background.
+func (sm *SupplyManager) Backfill() error {
+	select {
+	case <-sm.quit:
+		return errSupplyManagerStopped
+	default:
+	}
+	if atomic.LoadInt32(&sm.backfilling) == 1 {
+		return errSupplyBackfillRunning
+	}
+	if err := sm.initBackfill(); err != nil {
+		return err
+	}
+
+	sm.wake()
+	return nil
+}
+
+// BackfillTo accumulates the supply up to the last checkpoint at or before the given block in the caller's goroutine,
+// from the genesis block, or from the last checkpoint if exists. It does not need Start, so it is used to backfill offline.
+// It returns the number of the last checkpoint stored.
+func (sm *SupplyManager) BackfillTo(number uint64) (uint64, error) {
+	if err := sm.initBackfill(); err != nil {
+		return 0, err
+	}
+	err := sm.accumulateTo(number)
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.last.Number, err
+}
+
+// initBackfill stores the genesis checkpoint if no checkpoint exists.
+func (sm *SupplyManager) initBackfill() error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.last != nil {
+		return nil
+	}
+	genesis, err := sm.genesisCheckpoint()
+	if err != nil {
+		return err
+	}
+	if err := sm.storeCheckpoint(genesis); err != nil {
+		return err
+	}
+	sm.last = genesis
+	return nil
+}
+
+// GetTotalSupply returns the total supply at the given block.
+func (sm *SupplyManager) GetTotalSupply(number uint64) (*TotalSupply, error) {
+	header := sm.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, fmt.Errorf("block %d does not exist", number)
+	}
+	c, err := sm.checkpointAt(number)
+	if err != nil {
+		return nil, err
+	}
+
+	stateDB, err := sm.chain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	burnt := new(big.Int)
+	for _, addr := range BurnAddresses {
+		burnt.Add(burnt, stateDB.GetBalance(addr))
+	}
+
+	total := new(big.Int).Add(c.Genesis, c.TotalMinted)
+	total.Sub(total, c.TotalBurntFee)
+	total.Sub(total, burnt)
+	return &TotalSupply{
+		Number:         number,
+		TotalSupply:    total,
+		Genesis:        c.Genesis,
+		TotalMinted:    c.TotalMinted,
+		TotalBurntFee:  c.TotalBurntFee,
+		BurntByAddress: burnt,
+	}, nil
+}
+
+// CheckTotalSupply recomputes the total supply at the given block from the balances of all accounts,
+// and compares it with the accumulated one. It iterates the whole state trie, so it takes a long time.
+func (sm *SupplyManager) CheckTotalSupply(number uint64) (*SupplyCheckResult, error) {
+	header := sm.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, fmt.Errorf("block %d does not exist", number)
+	}
+	c, err := sm.checkpointAt(number)
+	if err != nil {
+		return nil, err
+	}
+	stateDB, err := sm.chain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	fromState, err := stateDB.TotalBalance()
+	if err != nil {
+		return nil, err
+	}
+
+	accumulated := new(big.Int).Add(c.Genesis, c.TotalMinted)
+	accumulated.Sub(accumulated, c.TotalBurntFee)
+	return &SupplyCheckResult{
+		Number:      number,
+		Accumulated: accumulated,
+		FromState:   fromState,
+		Match:       accumulated.Cmp(fromState) == 0,
+	}, nil
+}
+
+// checkpointAt returns the accumulated supply at the given block, from the nearest stored checkpoint.
+func (sm *SupplyManager) checkpointAt(number uint64) (*supplyCheckpoint, error) {
+	sm.mu.RLock()
+	last := sm.last
+	sm.mu.RUnlock()
+
+	if last == nil {
+		return nil, errSupplyNotBackfilled
+	}
+	if number > last.Number+maxSupplyAccumulation {
+		return nil, fmt.Errorf("total supply is accumulated up to block %d", last.Number)
+	}
+
+	var c *supplyCheckpoint
+	if base := number - number%supplyCheckpointInterval; base < last.Number {
+		var err error
+		if c, err = sm.readCheckpoint(base); err != nil {
+			return nil, err
+		}
+	} else {
+		c = last.copy()
+	}
+	return c, sm.accumulate(c, number, false)
+}
+
+// accumulate adds the supply changes of the blocks in (c.Number, to] to c.
+// If store is true, the checkpoints in the range are stored in the database.
+func (sm *SupplyManager) accumulate(c *supplyCheckpoint, to uint64, store bool) error {
+	policy, err := NewRewardPolicy(sm.chain.Config())
+	if err != nil {
+		return err
+	}
+	for c.Number < to {
+		select {
+		case <-sm.quit:
+			return errSupplyManagerStopped
+		default:
+		}
+
+		number := c.Number + 1
+		header := sm.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("block %d does not exist", number)
+		}
+		pset, err := sm.gh.ParamsAt(number)
+		if err != nil {
+			return err
+		}
+		minted, burnt, err := GetBlockIssuance(policy, header, sm.chain.Config().Rules(header.Number), pset)
+		if err != nil {
+			return err
+		}
+		c.TotalMinted.Add(c.TotalMinted, minted)
+		c.TotalBurntFee.Add(c.TotalBurntFee, burnt)
+		c.Number = number
+
+		if store && number%supplyCheckpointInterval == 0 {
+			if err := sm.storeCheckpoint(c); err != nil {
+				return err
+			}
+			sm.mu.Lock()
+			sm.last = c.copy()
+			sm.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// catchUp accumulates the supply up to the last checkpoint before the current block.
+func (sm *SupplyManager) catchUp() {
+	head := sm.chain.CurrentHeader().Number.Uint64()
+	if err := sm.accumulateTo(head); err != nil && err != errSupplyManagerStopped {
+		logger.Error("Failed to accumulate the total supply", "err", err)
+	}
+}
+
+// accumulateTo accumulates the supply from the last checkpoint up to the last checkpoint at or before the given block.
+func (sm *SupplyManager) accumulateTo(number uint64) error {
+	sm.mu.RLock()
+	last := sm.last
+	sm.mu.RUnlock()
+	if last == nil {
+		return nil
+	}
+
+	target := number - number%supplyCheckpointInterval
+	if target <= last.Number {
+		return nil
+	}
+
+	atomic.StoreInt32(&sm.backfilling, 1)
+	defer atomic.StoreInt32(&sm.backfilling, 0)
+
+	if target-last.Number > supplyCheckpointInterval {
+		logger.Info("Start to accumulate the total supply", "from", last.Number, "to", target)
+	}
+	return sm.accumulate(last.copy(), target, true)
+}
+
+func (sm *SupplyManager) wake() {
+	select {
+	case sm.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// loop wakes up accumulateLoop on new blocks. Accumulation is done in another goroutine not to block the chain head feed.
+func (sm *SupplyManager) loop() {
+	defer sm.wg.Done()
+
+	for {
+		select {
+		case <-sm.chainHeadChan:
+			sm.wake()
+		case <-sm.chainHeadSub.Err():
+			return
+		case <-sm.quit:
+			return
+		}
+	}
+}
+
+func (sm *SupplyManager) accumulateLoop() {
+	defer sm.wg.Done()
+
+	for {
+		select {
+		case <-sm.wakeCh:
+			sm.catchUp()
+		case <-sm.quit:
+			return
+		}
+	}
+}
+
+// genesisCheckpoint returns the checkpoint of the genesis block, which is the sum of the genesis allocation.
+func (sm *SupplyManager) genesisCheckpoint() (*supplyCheckpoint, error) {
+	genesis := sm.chain.GetHeaderByNumber(0)
+	if genesis == nil {
+		return nil, errors.New("genesis block does not exist")
+	}
+	stateDB, err := sm.chain.StateAt(genesis.Root)
+	if err != nil {
+		return nil, err
+	}
+	alloc, err := stateDB.TotalBalance()
+	if err != nil {
+		return nil, err
+	}
+	return &supplyCheckpoint{
+		Number:        0,
+		Genesis:       alloc,
+		TotalMinted:   new(big.Int),
+		TotalBurntFee: new(big.Int),
+	}, nil
+}
+
+func (sm *SupplyManager) readCheckpoint(number uint64) (*supplyCheckpoint, error) {
+	data, err := sm.db.ReadSupplyCheckpoint(number)
+	if err != nil {
+		return nil, err
+	}
+	c := new(supplyCheckpoint)
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (sm *SupplyManager) storeCheckpoint(c *supplyCheckpoint) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := sm.db.WriteSupplyCheckpoint(c.Number, data); err != nil {
+		return err
+	}
+	return sm.db.WriteLastSupplyCheckpointNumber(c.Number)
+}
@@ -0,0 +1,238 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package reward
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain"
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/consensus"
+	"github.com/klaytn/klaytn/consensus/istanbul"
+	"github.com/klaytn/klaytn/event"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/storage/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSupplyChain is a chain of head+1 blocks sharing the same state,
+// each of which mints `minted` and spends 1000 tx fee.
+type testSupplyChain struct {
+	config  *params.ChainConfig
+	stateDB state.Database
+	root    common.Hash
+	head    uint64
+	feed    event.Feed
+}
+
+func newTestSupplyChain(t *testing.T, config *params.ChainConfig, alloc map[common.Address]*big.Int, head uint64) *testSupplyChain {
+	db := state.NewDatabase(database.NewMemoryDBManager())
+	statedb, err := state.New(common.Hash{}, db, nil)
+	require.Nil(t, err)
+	for addr, balance := range alloc {
+		statedb.AddBalance(addr, balance)
+	}
+	root, err := statedb.Commit(false)
+	require.Nil(t, err)
+	require.Nil(t, db.TrieDB().Commit(root, false, 0))
+
+	return &testSupplyChain{config: config, stateDB: db, root: root, head: head}
+}
+
+func (c *testSupplyChain) SubscribeChainHeadEvent(ch chan<- blockchain.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func (c *testSupplyChain) GetBlockByNumber(number uint64) *types.Block {
+	if header := c.GetHeaderByNumber(number); header != nil {
+		return types.NewBlockWithHeader(header)
+	}
+	return nil
+}
+
+func (c *testSupplyChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return state.New(root, c.stateDB, nil)
+}
+
+func (c *testSupplyChain) Config() *params.ChainConfig                 { return c.config }
+func (c *testSupplyChain) Engine() consensus.Engine                    { return nil }
+func (c *testSupplyChain) GetHeader(common.Hash, uint64) *types.Header { return nil }
+func (c *testSupplyChain) CurrentHeader() *types.Header                { return c.GetHeaderByNumber(c.head) }
+
+func (c *testSupplyChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number > c.head {
+		return nil
+	}
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Root:       c.root,
+		GasUsed:    1000,
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+	}
+}
+
+func TestGetBlockIssuance(t *testing.T) {
+	oldStakingManager := GetStakingManager()
+	defer SetTestStakingManager(oldStakingManager)
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasUsed:    1000,
+		BaseFee:    big.NewInt(1),
+		Rewardbase: proposerAddr,
+	}
+	stakingInfo := genStakingInfo(5, nil, map[int]uint64{
+		0: minStaking + 4,
+		1: minStaking + 3,
+	})
+	SetTestStakingManagerWithStakingInfoCache(stakingInfo)
+
+	testcases := []struct {
+		policy        istanbul.ProposerPolicy
+		deferredTxFee bool
+		expectedBurnt uint64
+	}{
+		{istanbul.RoundRobin, true, 500},
+		{istanbul.RoundRobin, false, 0},
+		{istanbul.WeightedRandom, true, 1000},
+		{istanbul.WeightedRandom, false, 0},
+	}
+
+	for i, tc := range testcases {
+		config := getTestConfig()
+		if !tc.deferredTxFee {
+			config = noDeferred(config)
+		}
+		config.Istanbul.ProposerPolicy = uint64(tc.policy)
+
+		pset, err := params.NewGovParamSetChainConfig(config)
+		require.Nil(t, err)
+
+		// The tx fee is either burnt or moved between accounts, so only the minted amount is issued.
+		issued, burnt, err := GetBlockIssuance(DefaultRewardPolicy, header, config.Rules(header.Number), pset)
+		require.Nil(t, err, "testcases[%d] failed", i)
+		assert.Equal(t, minted, issued, "testcases[%d] failed", i)
+		assert.Equal(t, new(big.Int).SetUint64(tc.expectedBurnt), burnt, "testcases[%d] failed", i)
+	}
+}
+
+func TestSupplyManager(t *testing.T) {
+	config := roundrobin(getTestConfig())
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	var (
+		deadAddr = BurnAddresses[1]
+		alloc    = map[common.Address]*big.Int{
+			intToAddress(1): big.NewInt(1e18),
+			intToAddress(2): big.NewInt(2e18),
+			deadAddr:        big.NewInt(3),
+		}
+		genesis = big.NewInt(3e18 + 3)
+		chain   = newTestSupplyChain(t, config, alloc, 300)
+		db      = database.NewMemoryDBManager()
+		sm      = NewSupplyManager(chain, &testGovernance{pset}, db)
+	)
+
+	_, err = sm.GetTotalSupply(100)
+	assert.Equal(t, errSupplyNotBackfilled, err)
+
+	// Backfill stores the genesis checkpoint, and the accumulation is done up to the last checkpoint.
+	require.Nil(t, sm.Backfill())
+	sm.catchUp()
+	number, ok := db.ReadLastSupplyCheckpointNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(256), number)
+
+	for _, num := range []uint64{0, 100, 256, 300} {
+		totalMinted := new(big.Int).Mul(minted, new(big.Int).SetUint64(num))
+		totalBurntFee := new(big.Int).SetUint64(500 * num)
+		expected := new(big.Int).Add(genesis, totalMinted)
+		expected.Sub(expected, totalBurntFee)
+		expected.Sub(expected, big.NewInt(3))
+
+		supply, err := sm.GetTotalSupply(num)
+		require.Nil(t, err, "block %d", num)
+		assert.Equal(t, &TotalSupply{
+			Number:         num,
+			TotalSupply:    expected,
+			Genesis:        genesis,
+			TotalMinted:    totalMinted,
+			TotalBurntFee:  totalBurntFee,
+			BurntByAddress: big.NewInt(3),
+		}, supply, "block %d", num)
+	}
+
+	_, err = sm.GetTotalSupply(301)
+	assert.Error(t, err)
+
+	// The last checkpoint is loaded on restart
+	restarted := NewSupplyManager(chain, &testGovernance{pset}, db)
+	assert.Equal(t, uint64(256), restarted.last.Number)
+
+	// The genesis state has all the supply, but the test chain never changes its state.
+	result, err := restarted.CheckTotalSupply(0)
+	require.Nil(t, err)
+	assert.True(t, result.Match)
+	assert.Equal(t, genesis, result.FromState)
+
+	result, err = restarted.CheckTotalSupply(1)
+	require.Nil(t, err)
+	assert.False(t, result.Match)
+
+	restarted.Stop()
+	assert.Equal(t, errSupplyManagerStopped, restarted.Backfill())
+}
+
+func TestSupplyManager_BackfillTo(t *testing.T) {
+	config := roundrobin(getTestConfig())
+	pset, err := params.NewGovParamSetChainConfig(config)
+	require.Nil(t, err)
+
+	var (
+		chain = newTestSupplyChain(t, config, map[common.Address]*big.Int{intToAddress(1): big.NewInt(1e18)}, 300)
+		db    = database.NewMemoryDBManager()
+		sm    = NewSupplyManager(chain, &testGovernance{pset}, db)
+	)
+
+	// The accumulation is done in the caller's goroutine without Start
+	number, err := sm.BackfillTo(200)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(128), number)
+
+	// It resumes from the last checkpoint
+	number, err = sm.BackfillTo(300)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(256), number)
+	stored, ok := db.ReadLastSupplyCheckpointNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(256), stored)
+
+	supply, err := sm.GetTotalSupply(300)
+	require.Nil(t, err)
+	assert.Equal(t, new(big.Int).Mul(minted, big.NewInt(300)), supply.TotalMinted)
+
+	// The blocks after the head do not exist
+	number, err = sm.BackfillTo(600)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(256), number)
+}
@@ -277,11 +277,27 @@ type DBManager interface {
 	ReadGovernanceAtNumber(num uint64, epoch uint64) (uint64, map[string]interface{}, error)
 	WriteGovernanceState(b []byte) error
 	ReadGovernanceState() ([]byte, error)
+	WriteGovernanceTimelocks(num uint64, b []byte) error
+	ReadGovernanceTimelocks(num uint64) (uint64, []byte, error)
+	DeleteGovernanceTimelocks(num uint64) error
 	// TODO-Klaytn implement governance DB deletion methods.
 
 	// StakingInfo related functions
 	ReadStakingInfo(blockNum uint64) ([]byte, error)
 	WriteStakingInfo(blockNum uint64, stakingInfo []byte) error
+	ReadAllStakingInfo() ([]uint64, [][]byte, error)
+
+	// Supply checkpoint related functions
+	ReadSupplyCheckpoint(blockNum uint64) ([]byte, error)
+	WriteSupplyCheckpoint(blockNum uint64, checkpoint []byte) error
+	ReadLastSupplyCheckpointNumber() (uint64, bool)
+	WriteLastSupplyCheckpointNumber(blockNum uint64) error
+
+	// Reward history related functions
+	ReadBlockRewardHistory(blockNum uint64) ([]byte, error)
+	ReadAddressRewardHistory(addr common.Address, from, to uint64) ([]uint64, [][]byte, error)
+	WriteRewardHistory(blockNum uint64, blockHistory []byte, addressHistory map[common.Address][]byte) error
+	DeleteAddressRewardHistory(blockNum uint64, addrs []common.Address) error
 
 	// DB migration related function
 	StartDBMigration(DBManager) error
@@ -2640,6 +2656,78 @@ func (dbm *databaseManager) ReadGovernanceState() ([]byte, error) {
 	return db.Get(governanceStateKey)
 }
 
+// WriteGovernanceTimelocks stores the encoded governance changes scheduled at explicit blocks,
+// as of the block `num`. The changes stored at the same block are overwritten.
+func (dbm *databaseManager) WriteGovernanceTimelocks(num uint64, b []byte) error {
+	db := dbm.getDatabase(MiscDB)
+	idxs, err := dbm.readGovernanceTimelocksIdx()
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(idxs), func(i int) bool { return idxs[i] >= num })
+	if i == len(idxs) || idxs[i] != num {
+		idxs = append(idxs[:i], append([]uint64{num}, idxs[i:]...)...)
+		if err := dbm.writeGovernanceTimelocksIdx(idxs); err != nil {
+			return err
+		}
+	}
+	return db.Put(makeKey(governanceTimelocksPrefix, num), b)
+}
+
+// ReadGovernanceTimelocks returns the block number and the encoded governance changes scheduled at explicit blocks,
+// stored at the latest block not after `num`. If nothing is stored, it returns nil.
+func (dbm *databaseManager) ReadGovernanceTimelocks(num uint64) (uint64, []byte, error) {
+	db := dbm.getDatabase(MiscDB)
+	idxs, err := dbm.readGovernanceTimelocksIdx()
+	if err != nil {
+		return 0, nil, err
+	}
+	i := sort.Search(len(idxs), func(i int) bool { return idxs[i] > num })
+	if i == 0 {
+		return 0, nil, nil
+	}
+	b, err := db.Get(makeKey(governanceTimelocksPrefix, idxs[i-1]))
+	return idxs[i-1], b, err
+}
+
+// DeleteGovernanceTimelocks deletes the governance changes stored at the block `num`.
+func (dbm *databaseManager) DeleteGovernanceTimelocks(num uint64) error {
+	db := dbm.getDatabase(MiscDB)
+	idxs, err := dbm.readGovernanceTimelocksIdx()
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(idxs), func(i int) bool { return idxs[i] >= num })
+	if i == len(idxs) || idxs[i] != num {
+		return nil
+	}
+	if err := dbm.writeGovernanceTimelocksIdx(append(idxs[:i], idxs[i+1:]...)); err != nil {
+		return err
+	}
+	return db.Delete(makeKey(governanceTimelocksPrefix, num))
+}
+
+// readGovernanceTimelocksIdx returns the block numbers storing the governance changes in ascending order.
+func (dbm *databaseManager) readGovernanceTimelocksIdx() ([]uint64, error) {
+	db := dbm.getDatabase(MiscDB)
+	idxs := make([]uint64, 0)
+	if data, err := db.Get(governanceTimelocksIdxKey); err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, &idxs); err != nil {
+			return nil, err
+		}
+	}
+	return idxs, nil
+}
+
+func (dbm *databaseManager) writeGovernanceTimelocksIdx(idxs []uint64) error {
+	db := dbm.getDatabase(MiscDB)
+	data, err := json.Marshal(idxs)
+	if err != nil {
+		return err
+	}
+	return db.Put(governanceTimelocksIdxKey, data)
+}
+
 func (dbm *databaseManager) WriteChainDataFetcherCheckpoint(checkpoint uint64) error {
 	db := dbm.getDatabase(MiscDB)
 	return db.Put(chaindatafetcherCheckpointKey, common.Int64ToByteBigEndian(checkpoint))
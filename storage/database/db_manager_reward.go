@@ -0,0 +1,85 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/binary"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// ReadBlockRewardHistory reads the reward distribution of the given block from database.
+// The reward history is stored in MiscDB.
+// blockHistory should be type blockRewardHistory defined in reward/reward_index.go
+func (dbm *databaseManager) ReadBlockRewardHistory(blockNum uint64) ([]byte, error) {
+	db := dbm.getDatabase(MiscDB)
+	return db.Get(blockRewardHistoryKey(blockNum))
+}
+
+// ReadAddressRewardHistory reads the rewards given to the address in the blocks [from, to] from database.
+// It returns the numbers of the blocks rewarding the address in ascending order and the corresponding rewards.
+func (dbm *databaseManager) ReadAddressRewardHistory(addr common.Address, from, to uint64) ([]uint64, [][]byte, error) {
+	db := dbm.getDatabase(MiscDB)
+	prefix := addressRewardHistoryKeyPrefix(addr)
+
+	it := db.NewIterator(prefix, common.Int64ToByteBigEndian(from))
+	defer it.Release()
+
+	var (
+		nums    []uint64
+		rewards [][]byte
+	)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		num := binary.BigEndian.Uint64(key[len(prefix):])
+		if num > to {
+			break
+		}
+		nums = append(nums, num)
+		rewards = append(rewards, common.CopyBytes(it.Value()))
+	}
+	return nums, rewards, it.Error()
+}
+
+// WriteRewardHistory writes the reward distribution of the given block and the reward of each address to database at once.
+func (dbm *databaseManager) WriteRewardHistory(blockNum uint64, blockHistory []byte, addressHistory map[common.Address][]byte) error {
+	batch := dbm.getDatabase(MiscDB).NewBatch()
+	if err := batch.Put(blockRewardHistoryKey(blockNum), blockHistory); err != nil {
+		return err
+	}
+	for addr, history := range addressHistory {
+		if err := batch.Put(addressRewardHistoryKey(addr, blockNum), history); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// DeleteAddressRewardHistory deletes the rewards given to the addresses in the given block,
+// which are left by a block replaced by a chain reorganization.
+func (dbm *databaseManager) DeleteAddressRewardHistory(blockNum uint64, addrs []common.Address) error {
+	batch := dbm.getDatabase(MiscDB).NewBatch()
+	for _, addr := range addrs {
+		if err := batch.Delete(addressRewardHistoryKey(addr, blockNum)); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/klaytn/klaytn/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseManager_RewardHistory(t *testing.T) {
+	for _, dbm := range dbManagers {
+		if dbm.GetDBConfig().DBType == BadgerDB {
+			continue // badgerDB doesn't support NewIterator
+		}
+		addr1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+		addr2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+		// addr1 is rewarded in 255, 256 and 257, and addr2 only in 256.
+		for _, num := range []uint64{255, 256, 257} {
+			rewards := map[common.Address][]byte{addr1: {byte(num)}}
+			if num == 256 {
+				rewards[addr2] = []byte("addr2")
+			}
+			assert.NoError(t, dbm.WriteRewardHistory(num, []byte("block"), rewards))
+		}
+
+		block, err := dbm.ReadBlockRewardHistory(256)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("block"), block)
+
+		nums, rewards, err := dbm.ReadAddressRewardHistory(addr1, 256, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{256, 257}, nums)
+		assert.Equal(t, [][]byte{{0}, {1}}, rewards)
+
+		nums, _, err = dbm.ReadAddressRewardHistory(addr1, 0, 255)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{255}, nums)
+
+		nums, rewards, err = dbm.ReadAddressRewardHistory(addr2, 0, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{256}, nums)
+		assert.Equal(t, [][]byte{[]byte("addr2")}, rewards)
+
+		assert.NoError(t, dbm.DeleteAddressRewardHistory(256, []common.Address{addr2}))
+		nums, _, err = dbm.ReadAddressRewardHistory(addr2, 0, 1000)
+		assert.NoError(t, err)
+		assert.Empty(t, nums)
+	}
+}
@@ -16,6 +16,13 @@
 
 package database
 
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/klaytn/klaytn/common"
+)
+
 // ReadStakingInfo reads staking information from database. It returns
 // (StakingInfo, nil) if it succeeds to read and (nil, error) if it fails.
 // StakingInfo is stored in MiscDB.
@@ -46,3 +53,37 @@ func (dbm *databaseManager) WriteStakingInfo(blockNum uint64, stakingInfo []byte
 	key := makeKey(stakingInfoPrefix, blockNum)
 	return db.Put(key, stakingInfo)
 }
+
+// ReadAllStakingInfo reads all the staking information stored in database.
+// It returns the block numbers in ascending order and the corresponding staking information.
+func (dbm *databaseManager) ReadAllStakingInfo() ([]uint64, [][]byte, error) {
+	db := dbm.getDatabase(MiscDB)
+
+	it := db.NewIterator(stakingInfoPrefix, nil)
+	defer it.Release()
+
+	stored := make(map[uint64][]byte)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(stakingInfoPrefix)+8 {
+			continue
+		}
+		stored[binary.LittleEndian.Uint64(key[len(stakingInfoPrefix):])] = common.CopyBytes(it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	// The block numbers are encoded in little endian, so the keys are not in the order of the numbers
+	nums := make([]uint64, 0, len(stored))
+	for num := range stored {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	stakingInfos := make([][]byte, len(nums))
+	for i, num := range nums {
+		stakingInfos[i] = stored[num]
+	}
+	return nums, stakingInfos, nil
+}
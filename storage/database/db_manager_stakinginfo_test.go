@@ -19,6 +19,8 @@ package database
 import (
 	"bytes"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDatabaseManager_StakingInfo(t *testing.T) {
@@ -40,3 +42,32 @@ func TestDatabaseManager_StakingInfo(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDatabaseManager_ReadAllStakingInfo(t *testing.T) {
+	for _, dbm := range dbManagers {
+		if dbm.GetDBConfig().DBType == BadgerDB {
+			continue // badgerDB doesn't support NewIterator
+		}
+		// 256 and 86400 are not in order when encoded in little endian
+		for _, num := range []uint64{86400, 256, 172800} {
+			if err := dbm.WriteStakingInfo(num, []byte{byte(num >> 8)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		nums, stakingInfos, err := dbm.ReadAllStakingInfo()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Other tests may write staking information to the same database
+		var found []uint64
+		for i, num := range nums {
+			if num == 256 || num == 86400 || num == 172800 {
+				assert.Equal(t, []byte{byte(num >> 8)}, stakingInfos[i])
+				found = append(found, num)
+			}
+		}
+		assert.Equal(t, []uint64{256, 86400, 172800}, found)
+	}
+}
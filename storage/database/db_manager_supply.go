@@ -0,0 +1,54 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"encoding/binary"
+
+	"github.com/klaytn/klaytn/common"
+)
+
+// ReadSupplyCheckpoint reads the accumulated supply at the given block from database.
+// The supply checkpoint is stored in MiscDB.
+// supplyCheckpoint should be type supplyCheckpoint defined in reward/supply_manager.go
+func (dbm *databaseManager) ReadSupplyCheckpoint(blockNum uint64) ([]byte, error) {
+	db := dbm.getDatabase(MiscDB)
+	return db.Get(makeKey(supplyCheckpointPrefix, blockNum))
+}
+
+// WriteSupplyCheckpoint writes the accumulated supply at the given block to database.
+func (dbm *databaseManager) WriteSupplyCheckpoint(blockNum uint64, checkpoint []byte) error {
+	db := dbm.getDatabase(MiscDB)
+	return db.Put(makeKey(supplyCheckpointPrefix, blockNum), checkpoint)
+}
+
+// ReadLastSupplyCheckpointNumber returns the number of the last block whose supply checkpoint is stored.
+// It returns false if no supply checkpoint is stored.
+func (dbm *databaseManager) ReadLastSupplyCheckpointNumber() (uint64, bool) {
+	db := dbm.getDatabase(MiscDB)
+	data, err := db.Get(lastSupplyCheckpointNumberKey)
+	if err != nil || len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// WriteLastSupplyCheckpointNumber writes the number of the last block whose supply checkpoint is stored.
+func (dbm *databaseManager) WriteLastSupplyCheckpointNumber(blockNum uint64) error {
+	db := dbm.getDatabase(MiscDB)
+	return db.Put(lastSupplyCheckpointNumberKey, common.Int64ToByteBigEndian(blockNum))
+}
@@ -116,12 +116,21 @@ var (
 	governancePrefix     = []byte("governance")
 	governanceHistoryKey = []byte("governanceIdxHistory")
 	governanceStateKey   = []byte("governanceState")
+	// the keys should not be the governancePrefix followed by 8 bytes
+	governanceTimelocksPrefix = []byte("governanceTimelocks")
+	governanceTimelocksIdxKey = []byte("governanceTimelocksIdx")
 
 	databaseDirPrefix  = []byte("databaseDirectory")
 	migrationStatusKey = []byte("migrationStatus")
 
 	stakingInfoPrefix = []byte("stakingInfo")
 
+	supplyCheckpointPrefix        = []byte("supplyCheckpoint")
+	lastSupplyCheckpointNumberKey = []byte("lastSupplyCheckpointNumber")
+
+	blockRewardHistoryPrefix   = []byte("blockRewardHistory")
+	addressRewardHistoryPrefix = []byte("addressRewardHistory")
+
 	chaindatafetcherCheckpointKey = []byte("chaindatafetcherCheckpoint")
 )
 
@@ -253,6 +262,20 @@ func BloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// blockRewardHistoryKey = blockRewardHistoryPrefix + num (uint64 big endian)
+func blockRewardHistoryKey(num uint64) []byte {
+	return append(blockRewardHistoryPrefix, common.Int64ToByteBigEndian(num)...)
+}
+
+// addressRewardHistoryKey = addressRewardHistoryPrefix + address + num (uint64 big endian)
+func addressRewardHistoryKey(addr common.Address, num uint64) []byte {
+	return append(addressRewardHistoryKeyPrefix(addr), common.Int64ToByteBigEndian(num)...)
+}
+
+func addressRewardHistoryKeyPrefix(addr common.Address) []byte {
+	return append(append([]byte{}, addressRewardHistoryPrefix...), addr.Bytes()...)
+}
+
 func makeKey(prefix []byte, num uint64) []byte {
 	byteKey := common.Int64ToByteLittleEndian(num)
 	return append(prefix, byteKey...)
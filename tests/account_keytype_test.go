@@ -506,6 +506,9 @@ func TestDefaultTxsWithDefaultAccountKey(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1867,6 +1870,9 @@ func TestRoleBasedKeySendTx(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		if i.IsLegacyTransaction() || i.IsEthTypedTransaction() {
 			continue // accounts with role-based key cannot send the legacy tx and ethereum typed tx.
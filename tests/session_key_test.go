@@ -0,0 +1,126 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/types/accountkey"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/crypto"
+	"github.com/klaytn/klaytn/kerrors"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionAccountKey tests transactions signed by a session key used as RoleTransaction of a role-based key.
+// 1. The transactions in the scope of the session key are executed.
+// 2. The transactions out of the scope, or after the expiry, are rejected.
+// 3. The session key cannot update the account.
+func TestSessionAccountKey(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlTrace)
+
+	bcdata, err := NewBCData(6, 4)
+	require.NoError(t, err)
+	defer bcdata.Shutdown()
+	bcdata.bc.Config().IstanbulCompatibleBlock = big.NewInt(0)
+	bcdata.bc.Config().SessionKeyCompatibleBlock = big.NewInt(0)
+
+	signer := types.LatestSignerForChainID(bcdata.bc.Config().ChainID)
+	gasPrice := new(big.Int).SetUint64(bcdata.bc.Config().UnitPrice)
+	blockNumber := bcdata.bc.CurrentBlock().NumberU64()
+	blockTime := bcdata.bc.CurrentBlock().Time().Uint64()
+
+	statedb, err := bcdata.bc.State()
+	require.NoError(t, err)
+
+	sender := *bcdata.addrs[0]
+	ownerKey := bcdata.privKeys[0]
+	sessionKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	target := common.HexToAddress("0x1111")
+	valueLimit := big.NewInt(params.KLAY)
+	installSessionKey := func(expiryTime uint64) {
+		session := accountkey.NewAccountKeySessionWithValues(accountkey.NewAccountKeyPublicWithValue(&sessionKey.PublicKey),
+			blockNumber+100, expiryTime, []common.Address{target}, nil, valueLimit)
+		roleBased := accountkey.NewAccountKeyRoleBasedWithValues(accountkey.AccountKeyRoleBased{
+			session, accountkey.NewAccountKeyPublicWithValue(&ownerKey.PublicKey),
+		})
+		require.NoError(t, statedb.UpdateKey(sender, roleBased, blockNumber))
+	}
+	genTx := func(txType types.TxType, to common.Address, value *big.Int, keys []*ecdsa.PrivateKey) *types.Transaction {
+		values := map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    statedb.GetNonce(sender),
+			types.TxValueKeyFrom:     sender,
+			types.TxValueKeyGasLimit: uint64(1000000),
+			types.TxValueKeyGasPrice: gasPrice,
+		}
+		if txType == types.TxTypeAccountUpdate {
+			values[types.TxValueKeyAccountKey] = accountkey.NewAccountKeyPublicWithValue(&ownerKey.PublicKey)
+		} else {
+			values[types.TxValueKeyTo] = to
+			values[types.TxValueKeyAmount] = value
+		}
+		tx, err := types.NewTransactionWithMap(txType, values)
+		require.NoError(t, err)
+		require.NoError(t, tx.SignWithKeys(signer, keys))
+		return tx
+	}
+
+	installSessionKey(blockTime + 1000)
+
+	// 1. A value transfer in the scope is executed.
+	{
+		receipt, err := applyTransactionWithState(bcdata, statedb, genTx(types.TxTypeValueTransfer, target, valueLimit, []*ecdsa.PrivateKey{sessionKey}))
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		assert.Equal(t, params.TxGasValueTransfer+params.TxValidationGasSessionKey, receipt.GasUsed)
+		assert.Equal(t, valueLimit, statedb.GetBalance(target))
+	}
+
+	// 2. Transactions out of the scope are rejected.
+	{
+		overLimit := new(big.Int).Add(valueLimit, common.Big1)
+		_, err := applyTransactionWithState(bcdata, statedb, genTx(types.TxTypeValueTransfer, target, overLimit, []*ecdsa.PrivateKey{sessionKey}))
+		assert.Equal(t, kerrors.ErrSessionKeyValueLimitExceeded, err)
+
+		_, err = applyTransactionWithState(bcdata, statedb, genTx(types.TxTypeValueTransfer, common.HexToAddress("0x2222"), valueLimit, []*ecdsa.PrivateKey{sessionKey}))
+		assert.Equal(t, kerrors.ErrSessionKeyTargetNotAllowed, err)
+
+		// The next block is generated after the expiry time.
+		installSessionKey(blockTime + 1)
+		_, err = applyTransactionWithState(bcdata, statedb, genTx(types.TxTypeValueTransfer, target, valueLimit, []*ecdsa.PrivateKey{sessionKey}))
+		assert.Equal(t, kerrors.ErrSessionKeyExpired, err)
+	}
+
+	// 3. The account is updated by the key of RoleAccountUpdate, not by the session key.
+	{
+		_, err := applyTransactionWithState(bcdata, statedb, genTx(types.TxTypeAccountUpdate, common.Address{}, nil, []*ecdsa.PrivateKey{sessionKey}))
+		assert.Equal(t, types.ErrInvalidSigSender, err)
+
+		receipt, err := applyTransactionWithState(bcdata, statedb, genTx(types.TxTypeAccountUpdate, common.Address{}, nil, []*ecdsa.PrivateKey{ownerKey}))
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		assert.Equal(t, accountkey.AccountKeyTypePublic, statedb.GetKey(sender).Type())
+	}
+}
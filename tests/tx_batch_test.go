@@ -0,0 +1,189 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/state"
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/blockchain/vm"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBatchTransaction tests the execution of TxTypeBatch and TxTypeFeeDelegatedBatch.
+// 1. The calls of a batch transaction are executed in order.
+// 2. If a call fails, the state changes of all calls are reverted and the receipt has the status of each call.
+// 3. The fee-delegated batch transaction is paid by the fee payer.
+// 4. Invalid batch transactions and batch transactions before the hardfork are rejected.
+func TestBatchTransaction(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlTrace)
+
+	bcdata, err := NewBCData(6, 4)
+	require.NoError(t, err)
+	defer bcdata.Shutdown()
+	bcdata.bc.Config().IstanbulCompatibleBlock = big.NewInt(0)
+	bcdata.bc.Config().BatchTxCompatibleBlock = big.NewInt(0)
+
+	sender := &TestAccountType{Addr: *bcdata.addrs[0], Keys: []*ecdsa.PrivateKey{bcdata.privKeys[0]}}
+	feePayer := &TestAccountType{Addr: *bcdata.addrs[1], Keys: []*ecdsa.PrivateKey{bcdata.privKeys[1]}}
+	signer := types.LatestSignerForChainID(bcdata.bc.Config().ChainID)
+	gasPrice := new(big.Int).SetUint64(bcdata.bc.Config().UnitPrice)
+
+	statedb, err := bcdata.bc.State()
+	require.NoError(t, err)
+
+	genTx := func(txType types.TxType, calls []*types.BatchCall) *types.Transaction {
+		values := map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    statedb.GetNonce(sender.Addr),
+			types.TxValueKeyFrom:     sender.Addr,
+			types.TxValueKeyGasLimit: uint64(1000000),
+			types.TxValueKeyGasPrice: gasPrice,
+			types.TxValueKeyCalls:    calls,
+		}
+		if txType.IsFeeDelegatedTransaction() {
+			values[types.TxValueKeyFeePayer] = feePayer.Addr
+		}
+		tx, err := types.NewTransactionWithMap(txType, values)
+		require.NoError(t, err)
+		require.NoError(t, tx.SignWithKeys(signer, sender.Keys))
+		if txType.IsFeeDelegatedTransaction() {
+			require.NoError(t, tx.SignFeePayerWithKeys(signer, feePayer.Keys))
+		}
+		return tx
+	}
+	apply := func(tx *types.Transaction) (*types.Receipt, error) {
+		return applyTransactionWithState(bcdata, statedb, tx)
+	}
+
+	to1, to2 := common.HexToAddress("0x1111"), common.HexToAddress("0x2222")
+	value := big.NewInt(params.KLAY)
+
+	// 1. All calls succeed.
+	{
+		senderBalance := statedb.GetBalance(sender.Addr)
+		nonce := statedb.GetNonce(sender.Addr)
+		tx := genTx(types.TxTypeBatch, []*types.BatchCall{
+			{To: to1, Value: value, Data: []byte{}},
+			{To: to2, Value: value, Data: []byte{}},
+		})
+		receipt, err := apply(tx)
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		assert.Equal(t, []uint{types.ReceiptStatusSuccessful, types.ReceiptStatusSuccessful}, receipt.CallStatuses)
+		assert.Equal(t, params.TxGasBatch+2*params.TxGasBatchCall, receipt.GasUsed)
+
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+		assert.Equal(t, value, statedb.GetBalance(to1))
+		assert.Equal(t, value, statedb.GetBalance(to2))
+		assert.Equal(t, new(big.Int).Sub(senderBalance, new(big.Int).Add(fee, tx.Value())), statedb.GetBalance(sender.Addr))
+		assert.Equal(t, nonce+1, statedb.GetNonce(sender.Addr))
+	}
+
+	// 2. The second call fails by sending KLAY to a precompiled contract, so the first call is reverted.
+	{
+		senderBalance := statedb.GetBalance(sender.Addr)
+		nonce := statedb.GetNonce(sender.Addr)
+		tx := genTx(types.TxTypeBatch, []*types.BatchCall{
+			{To: to1, Value: value, Data: []byte{}},
+			{To: common.BytesToAddress([]byte{1}), Value: value, Data: []byte{}},
+			{To: to2, Value: value, Data: []byte{}},
+		})
+		receipt, err := apply(tx)
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusErrDefault, receipt.Status)
+		assert.Equal(t, []uint{types.ReceiptStatusSuccessful, types.ReceiptStatusErrDefault, types.ReceiptStatusFailed}, receipt.CallStatuses)
+
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+		assert.Equal(t, value, statedb.GetBalance(to1))
+		assert.Equal(t, value, statedb.GetBalance(to2))
+		assert.Equal(t, new(big.Int).Sub(senderBalance, fee), statedb.GetBalance(sender.Addr))
+		assert.Equal(t, nonce+1, statedb.GetNonce(sender.Addr))
+
+		// The call statuses are kept in both encodings of the receipt.
+		b, err := rlp.EncodeToBytes(receipt)
+		require.NoError(t, err)
+		dec := new(types.Receipt)
+		require.NoError(t, rlp.DecodeBytes(b, dec))
+		assert.Equal(t, receipt.CallStatuses, dec.CallStatuses)
+
+		b, err = rlp.EncodeToBytes((*types.ReceiptForStorage)(receipt))
+		require.NoError(t, err)
+		decStorage := new(types.ReceiptForStorage)
+		require.NoError(t, rlp.DecodeBytes(b, decStorage))
+		assert.Equal(t, receipt.CallStatuses, decStorage.CallStatuses)
+	}
+
+	// 3. The fee payer pays the fee of a fee-delegated batch transaction.
+	{
+		senderBalance := statedb.GetBalance(sender.Addr)
+		feePayerBalance := statedb.GetBalance(feePayer.Addr)
+		tx := genTx(types.TxTypeFeeDelegatedBatch, []*types.BatchCall{
+			{To: to1, Value: value, Data: []byte{}},
+		})
+		receipt, err := apply(tx)
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		assert.Equal(t, []uint{types.ReceiptStatusSuccessful}, receipt.CallStatuses)
+		assert.Equal(t, params.TxGasBatch+params.TxGasFeeDelegated+params.TxGasBatchCall, receipt.GasUsed)
+
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+		assert.Equal(t, new(big.Int).Mul(value, big.NewInt(2)), statedb.GetBalance(to1))
+		assert.Equal(t, new(big.Int).Sub(senderBalance, value), statedb.GetBalance(sender.Addr))
+		assert.Equal(t, new(big.Int).Sub(feePayerBalance, fee), statedb.GetBalance(feePayer.Addr))
+	}
+
+	// 4. Invalid batch transactions are rejected.
+	{
+		_, err := apply(genTx(types.TxTypeBatch, []*types.BatchCall{}))
+		assert.Equal(t, types.ErrBatchCallsEmpty, err)
+
+		calls := make([]*types.BatchCall, params.MaxBatchCalls+1)
+		for i := range calls {
+			calls[i] = &types.BatchCall{To: to1, Value: big.NewInt(0), Data: []byte{}}
+		}
+		_, err = apply(genTx(types.TxTypeBatch, calls))
+		assert.Equal(t, types.ErrBatchCallsTooMany, err)
+
+		bcdata.bc.Config().BatchTxCompatibleBlock = nil
+		_, err = apply(genTx(types.TxTypeBatch, calls[:1]))
+		assert.Equal(t, types.ErrTxTypeNotSupported, err)
+	}
+}
+
+// applyTransactionWithState applies the transaction on the given state as a transaction of the next block.
+// The block is proposed by the third account not to mix the tx fee with the balances of the sender and the fee payer.
+func applyTransactionWithState(bcdata *BCData, statedb *state.StateDB, tx *types.Transaction) (*types.Receipt, error) {
+	parent := bcdata.bc.CurrentBlock()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Extra:      parent.Extra(),
+		Time:       new(big.Int).Add(parent.Time(), common.Big1),
+		BlockScore: big.NewInt(0),
+	}
+	usedGas := uint64(0)
+	receipt, _, _, err := bcdata.bc.ApplyTransaction(bcdata.bc.Config(), bcdata.addrs[2], statedb, header, tx, &usedGas, &vm.Config{})
+	return receipt, err
+}
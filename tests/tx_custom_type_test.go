@@ -0,0 +1,147 @@
+// Copyright 2022 The klaytn Authors
+// This file is part of the klaytn library.
+//
+// The klaytn library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The klaytn library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the klaytn library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/klaytn/klaytn/blockchain/types"
+	"github.com/klaytn/klaytn/common"
+	"github.com/klaytn/klaytn/log"
+	"github.com/klaytn/klaytn/params"
+	"github.com/klaytn/klaytn/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	txTypeTaggedTransfer    = types.TxTypeCustomFirst + 0x08
+	txGasTaggedTransfer     = params.TxGas + 1000
+	taggedTransferTagLength = 4
+)
+
+var errNoRecipient = errors.New("tagged transfer requires a recipient")
+
+// registerTaggedTransfer registers a custom tx type transferring KLAY with a tag of 4 bytes.
+func registerTaggedTransfer(t *testing.T) {
+	err := types.RegisterTxType(txTypeTaggedTransfer, types.CustomTxType{
+		Name: "TxTypeTaggedTransfer",
+		DecodePayload: func(payload []byte) (interface{}, error) {
+			if len(payload) != taggedTransferTagLength {
+				return nil, errors.New("invalid tag length")
+			}
+			return string(payload), nil
+		},
+		IntrinsicGas: func(t *types.TxInternalDataCustom, currentBlockNumber uint64) (uint64, error) {
+			return txGasTaggedTransfer, nil
+		},
+		Validate: func(t *types.TxInternalDataCustom, stateDB types.StateDB, currentBlockNumber uint64) error {
+			if t.GetRecipient() == nil {
+				return errNoRecipient
+			}
+			return nil
+		},
+		Execute: func(t *types.TxInternalDataCustom, sender types.ContractRef, vm types.VM, stateDB types.StateDB, currentBlockNumber uint64, gas uint64, value *big.Int) ([]byte, uint64, error) {
+			return vm.Call(sender, t.Recipient, nil, gas, value)
+		},
+	})
+	if err != types.ErrTxTypeRegistered {
+		require.NoError(t, err)
+	}
+}
+
+// TestCustomTxType tests the execution of a custom tx type registered by a service chain.
+// 1. The custom tx type is decoded and executed by the registered hooks.
+// 2. The Validate hook rejects invalid transactions.
+// 3. The custom tx type is rejected before the custom tx type fork.
+func TestCustomTxType(t *testing.T) {
+	log.EnableLogForTest(log.LvlCrit, log.LvlTrace)
+	registerTaggedTransfer(t)
+
+	bcdata, err := NewBCData(6, 4)
+	require.NoError(t, err)
+	defer bcdata.Shutdown()
+	bcdata.bc.Config().IstanbulCompatibleBlock = big.NewInt(0)
+	bcdata.bc.Config().CustomTxTypeCompatibleBlock = big.NewInt(0)
+
+	sender := &TestAccountType{Addr: *bcdata.addrs[0], Keys: []*ecdsa.PrivateKey{bcdata.privKeys[0]}}
+	signer := types.LatestSignerForChainID(bcdata.bc.Config().ChainID)
+	gasPrice := new(big.Int).SetUint64(bcdata.bc.Config().UnitPrice)
+
+	statedb, err := bcdata.bc.State()
+	require.NoError(t, err)
+
+	genTx := func(to *common.Address, value *big.Int) *types.Transaction {
+		values := map[types.TxValueKeyType]interface{}{
+			types.TxValueKeyNonce:    statedb.GetNonce(sender.Addr),
+			types.TxValueKeyFrom:     sender.Addr,
+			types.TxValueKeyGasLimit: uint64(100000),
+			types.TxValueKeyGasPrice: gasPrice,
+			types.TxValueKeyAmount:   value,
+			types.TxValueKeyData:     []byte("tag1"),
+		}
+		if to != nil {
+			values[types.TxValueKeyTo] = *to
+		}
+		tx, err := types.NewTransactionWithMap(txTypeTaggedTransfer, values)
+		require.NoError(t, err)
+		require.NoError(t, tx.SignWithKeys(signer, sender.Keys))
+
+		// Transactions are received through the network in RLP
+		b, err := rlp.EncodeToBytes(tx)
+		require.NoError(t, err)
+		decoded := new(types.Transaction)
+		require.NoError(t, rlp.DecodeBytes(b, decoded))
+		return decoded
+	}
+
+	to := common.HexToAddress("0x1111")
+	value := big.NewInt(params.KLAY)
+
+	// 1. The custom tx type is executed.
+	{
+		senderBalance := statedb.GetBalance(sender.Addr)
+		nonce := statedb.GetNonce(sender.Addr)
+		tx := genTx(&to, value)
+		receipt, err := applyTransactionWithState(bcdata, statedb, tx)
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		assert.Equal(t, txGasTaggedTransfer, receipt.GasUsed)
+
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+		assert.Equal(t, value, statedb.GetBalance(to))
+		assert.Equal(t, new(big.Int).Sub(senderBalance, new(big.Int).Add(fee, value)), statedb.GetBalance(sender.Addr))
+		assert.Equal(t, nonce+1, statedb.GetNonce(sender.Addr))
+		assert.Equal(t, "TxTypeTaggedTransfer", tx.MakeRPCOutput()["type"])
+	}
+
+	// 2. The Validate hook rejects a transaction without a recipient.
+	{
+		_, err := applyTransactionWithState(bcdata, statedb, genTx(nil, value))
+		assert.Equal(t, errNoRecipient, err)
+	}
+
+	// 3. The custom tx type is rejected before the fork.
+	{
+		bcdata.bc.Config().CustomTxTypeCompatibleBlock = nil
+		_, err := applyTransactionWithState(bcdata, statedb, genTx(&to, value))
+		assert.Equal(t, types.ErrTxTypeNotSupported, err)
+	}
+}
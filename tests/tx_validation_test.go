@@ -106,6 +106,9 @@ func TestValidationPoolInsert(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -235,6 +238,9 @@ func TestValidationPoolInsertMagma(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -361,6 +367,9 @@ func TestValidationBlockTx(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -567,6 +576,9 @@ func TestValidationInvalidSig(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -792,6 +804,9 @@ func TestInvalidBalance(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1185,6 +1200,9 @@ func TestInvalidBalanceBlockTx(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1599,6 +1617,9 @@ func TestValidationTxSizeAfterRLP(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		tx, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1765,6 +1786,9 @@ func TestValidationPoolResetAfterSenderKeyChange(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {
@@ -1910,6 +1934,9 @@ func TestValidationPoolResetAfterFeePayerKeyChange(t *testing.T) {
 		if i == types.TxTypeKlaytnLast {
 			i = types.TxTypeEthereumAccessList
 		}
+		if i.IsBatch() {
+			continue // batch txs need the batch tx hardfork and are tested in tx_batch_test.go.
+		}
 
 		_, err := types.NewTxInternalData(i)
 		if err == nil {